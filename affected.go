@@ -0,0 +1,85 @@
+package goseeder
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// AffectedSeeders returns, in registration order, the names of the seeders
+// whose Paths contain one of the changed files, e.g. the output of
+// "git diff --name-only". Running the result with RunSeedersInOrder re-seeds
+// only what a change touched.
+func (sm *SeederManager) AffectedSeeders(changed []string) []string {
+	seeders, _ := sm.registry()
+	return affectedSeeders(seeders, changed)
+}
+
+// affectedSeeders returns the names of the seeders declaring a path that
+// contains one of the changed files
+func affectedSeeders(seeders []SeederItem, changed []string) []string {
+	names := make([]string, 0)
+	for _, seeder := range seeders {
+		if seeder.Affects(changed...) {
+			names = append(names, seeder.Name)
+		}
+	}
+	return names
+}
+
+// Affects reports whether one of the files is, or is inside, one of the
+// seeder's Paths. Paths are relative to the repository root like the paths
+// git prints: a file, a directory matching everything under it (a trailing
+// "/..." as in Go package patterns is accepted) or a path.Match glob, e.g.
+// "fixtures/users_*.json".
+func (si SeederItem) Affects(files ...string) bool {
+	for _, source := range si.Paths {
+		source = cleanChangedPath(strings.TrimSuffix(source, "/..."))
+		for _, file := range files {
+			if pathContains(source, cleanChangedPath(file)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pathContains reports whether file is source, is inside the directory
+// source or matches the glob source, itself or through a parent directory
+func pathContains(source, file string) bool {
+	if source == "." {
+		return true
+	}
+	for candidate := file; candidate != "." && candidate != "/"; candidate = path.Dir(candidate) {
+		if candidate == source {
+			return true
+		}
+		if IsGlob(source) {
+			if matched, _ := path.Match(source, candidate); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cleanChangedPath converts p to a clean slash-separated path without a
+// leading "./"
+func cleanChangedPath(p string) string {
+	return path.Clean(filepath.ToSlash(strings.TrimSpace(p)))
+}
+
+// ReadChangedFiles reads file paths, one per line, as printed by
+// "git diff --name-only". Blank lines are ignored.
+func ReadChangedFiles(r io.Reader) ([]string, error) {
+	var files []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if file := strings.TrimSpace(scanner.Text()); file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, scanner.Err()
+}
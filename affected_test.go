@@ -0,0 +1,74 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newAffectedManager registers seeders declaring fixture and package paths
+func newAffectedManager() *SeederManager {
+	manager := NewSeederManager()
+	noop := func() error { return nil }
+	manager.RegisterSeeders(
+		SeederItem{Name: "countries", Function: noop, Paths: []string{"fixtures/countries.json"}},
+		SeederItem{Name: "users", Function: noop, Paths: []string{"./internal/seeds/users/...", "fixtures/users_*.json"}},
+		SeederItem{Name: "orders", Function: noop, Paths: []string{"internal/seeds/orders", "fixtures/orders"}},
+		SeederItem{Name: "settings", Function: noop},
+	)
+	return manager
+}
+
+// TestAffectedSeeders tests mapping changed files to the seeders to re-run
+func TestAffectedSeeders(t *testing.T) {
+	manager := newAffectedManager()
+
+	for name, tc := range map[string]struct {
+		changed  []string
+		expected []string
+	}{
+		"Fixture file":              {[]string{"fixtures/countries.json"}, []string{"countries"}},
+		"Package directory":         {[]string{"internal/seeds/users/seed.go"}, []string{"users"}},
+		"Nested file":               {[]string{"fixtures/orders/2024/march.json"}, []string{"orders"}},
+		"Glob":                      {[]string{"fixtures/users_admins.json"}, []string{"users"}},
+		"Registration order":        {[]string{"fixtures/orders/a.json", "fixtures/countries.json"}, []string{"countries", "orders"}},
+		"Directory prefix only":     {[]string{"internal/seeds/orders_archive/seed.go"}, []string{}},
+		"Unrelated files":           {[]string{"README.md", "cmd/api/main.go"}, []string{}},
+		"Leading dot slash":         {[]string{"./fixtures/countries.json"}, []string{"countries"}},
+		"Whitespace of input lines": {[]string{" fixtures/countries.json "}, []string{"countries"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, manager.AffectedSeeders(tc.changed))
+		})
+	}
+}
+
+// TestReadChangedFiles tests reading git diff --name-only output
+func TestReadChangedFiles(t *testing.T) {
+	files, err := ReadChangedFiles(strings.NewReader("fixtures/users.json\n\ninternal/seeds/users/seed.go\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fixtures/users.json", "internal/seeds/users/seed.go"}, files)
+}
+
+// TestCLIAffected tests printing the affected seeders from arguments or stdin
+func TestCLIAffected(t *testing.T) {
+	cli := NewCLI(newAffectedManager())
+	var out bytes.Buffer
+	cli.SetOutput(&out)
+
+	cli.stdin = strings.NewReader("fixtures/orders/a.json\nfixtures/countries.json\n")
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"affected"}))
+	assert.Equal(t, "countries\norders\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"affected", "internal/seeds/users/seed.go", "README.md"}))
+	assert.Equal(t, "users\n", out.String())
+
+	names, err := ReadSeederList(strings.NewReader(out.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"users"}, names, "output is a -from-file list")
+}
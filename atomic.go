@@ -0,0 +1,97 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// atomicRun tracks a run inside one transaction, see RunAllSeedersAtomic
+type atomicRun struct {
+	mu      sync.Mutex
+	applied []string // Seeders recorded in the history and completion store after the commit
+}
+
+// RunAllSeedersAtomic runs all seeders in registration order inside one
+// transaction of the transaction database (see SetTransactionDB), so
+// either every seeder applies or none does, e.g. for deterministic test
+// database setup. Seeders reach the transaction with TxFromContext;
+// WithTransaction seeders join it. History entries and completion markers
+// are only written once the transaction is committed.
+func (sm *SeederManager) RunAllSeedersAtomic() error {
+	return sm.RunAllSeedersAtomicContext(context.Background())
+}
+
+// RunAllSeedersAtomicContext is RunAllSeedersAtomic with a context; a stop
+// or cancellation rolls the whole run back
+func (sm *SeederManager) RunAllSeedersAtomicContext(ctx context.Context) (err error) {
+	if sm.transactionDB == nil {
+		return fmt.Errorf("atomic runs need a database, see SetTransactionDB")
+	}
+	ctx, end, err := sm.beginRun(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
+
+	tx, err := sm.transactionDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logError(ctx, "Failed to roll back the run: %v", rollbackErr)
+			return
+		}
+		logInfo(ctx, "Rolled back the run, no seeder was applied")
+	}()
+
+	atomic := &atomicRun{}
+	ctx = context.WithValue(context.WithValue(ctx, txKey, tx), atomicKey, atomic)
+	logInfo(ctx, "Running all seeders in one transaction...")
+	seeders, _ := sm.registry()
+	startProgress(ctx, len(seeders))
+	for _, seeder := range seeders {
+		if err := stopCause(ctx); err != nil {
+			return err
+		}
+		if err := sm.executeSeeder(ctx, seeder); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the run: %w", err)
+	}
+	committed = true
+	for _, name := range atomic.applied {
+		if err := sm.recordHistory(ctx, name); err != nil {
+			return err
+		}
+		if err := sm.markComplete(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	logInfo(ctx, "All seeders completed successfully!")
+	return nil
+}
+
+// recordApplied writes the history entry and completion marker of a seeder
+// that completed, or defers them to the commit of an atomic run
+func (sm *SeederManager) recordApplied(ctx context.Context, name string) error {
+	if atomic, ok := ctx.Value(atomicKey).(*atomicRun); ok {
+		atomic.mu.Lock()
+		defer atomic.mu.Unlock()
+		atomic.applied = append(atomic.applied, name)
+		return nil
+	}
+	if err := sm.recordHistory(ctx, name); err != nil {
+		return err
+	}
+	return sm.markComplete(ctx, name)
+}
@@ -0,0 +1,70 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newAtomicManager registers seeders inserting through the run transaction,
+// "orders" failing when fail is set
+func newAtomicManager(t *testing.T, fail bool) (*SeederManager, *fakeDB, *MemoryHistoryStore) {
+	db, fake := newFakeDB()
+	history := NewMemoryHistoryStore()
+	manager := NewSeederManager()
+	manager.SetTransactionDB(db)
+	manager.SetHistoryStore(history)
+
+	insert := func(table string) SeederFunc {
+		return func(ctx context.Context) error {
+			tx, ok := TxFromContext(ctx)
+			if !ok {
+				return errors.New("no transaction")
+			}
+			if _, err := tx.ExecContext(ctx, "INSERT INTO "+table+" (id) VALUES (1)"); err != nil {
+				return err
+			}
+			if fail && table == "orders" {
+				return errors.New("duplicate key")
+			}
+			return nil
+		}
+	}
+	manager.RegisterSeederContext("users", insert("users"))
+	manager.RegisterSeederContext("orders", insert("orders"), WithTransaction())
+	return manager, fake, history
+}
+
+// TestRunAllSeedersAtomic tests that all seeders apply in one transaction
+func TestRunAllSeedersAtomic(t *testing.T) {
+	manager, fake, history := newAtomicManager(t, false)
+
+	assert.NoError(t, manager.RunAllSeedersAtomic())
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO users (id) VALUES (1)",
+		"INSERT INTO orders (id) VALUES (1)",
+		"COMMIT",
+	}, fake.Events(), "WithTransaction seeders join the run transaction")
+	applied, _ := history.Applied(context.Background())
+	assert.Len(t, applied, 2)
+}
+
+// TestRunAllSeedersAtomicRollback tests that a failure applies nothing
+func TestRunAllSeedersAtomicRollback(t *testing.T) {
+	manager, fake, history := newAtomicManager(t, true)
+
+	assert.ErrorContains(t, manager.RunAllSeedersAtomic(), "seeder 'orders' failed: duplicate key")
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO users (id) VALUES (1)",
+		"INSERT INTO orders (id) VALUES (1)",
+		"ROLLBACK",
+	}, fake.Events())
+	applied, _ := history.Applied(context.Background())
+	assert.Empty(t, applied, "history is only written after the commit")
+
+	assert.ErrorContains(t, NewSeederManager().RunAllSeedersAtomic(), "atomic runs need a database, see SetTransactionDB")
+}
@@ -0,0 +1,118 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// BenchmarkOptions configures a benchmark run of a single seeder
+type BenchmarkOptions struct {
+	Iterations int                   // Number of times the seeder is executed (defaults to 1)
+	Setup      func() error          // Optional hook run before each iteration, e.g. to create a scratch schema
+	Teardown   func() error          // Optional hook run after each iteration, e.g. to drop the scratch schema
+	CountRows  func() (int64, error) // Optional hook returning the rows written by the last iteration
+}
+
+// BenchmarkResult holds the timing statistics collected by BenchmarkSeeder
+type BenchmarkResult struct {
+	Name          string
+	Iterations    int
+	Durations     []time.Duration
+	Mean          time.Duration
+	StdDev        time.Duration
+	Min           time.Duration
+	Max           time.Duration
+	Rows          int64   // Total rows reported by CountRows across all iterations
+	RowsPerSecond float64 // Zero when CountRows is not set
+}
+
+// BenchmarkSeeder runs a seeder repeatedly and reports duration statistics.
+// Setup and Teardown are excluded from the measured durations. Every
+// iteration runs the seeder, even when the history store records it as
+// applied.
+func (sm *SeederManager) BenchmarkSeeder(name string, options BenchmarkOptions) (*BenchmarkResult, error) {
+	resolved, exists := sm.ResolveSeeder(name)
+	if !exists {
+		return nil, fmt.Errorf("seeder with name '%s' not found%s", name, didYouMean(name, sm.GetRegisteredSeeders()))
+	}
+	name = resolved
+
+	iterations := options.Iterations
+	if iterations <= 0 {
+		iterations = 1
+	}
+
+	result := &BenchmarkResult{
+		Name:       name,
+		Iterations: iterations,
+		Durations:  make([]time.Duration, 0, iterations),
+	}
+
+	ctx := ContextWithForceRun(context.Background())
+	for i := 0; i < iterations; i++ {
+		if options.Setup != nil {
+			if err := options.Setup(); err != nil {
+				return nil, fmt.Errorf("benchmark setup for '%s' failed: %w", name, err)
+			}
+		}
+
+		start := time.Now()
+		err := sm.RunSeederByNameContext(ctx, name)
+		elapsed := time.Since(start)
+		if err != nil {
+			return nil, err
+		}
+		result.Durations = append(result.Durations, elapsed)
+
+		if options.CountRows != nil {
+			rows, err := options.CountRows()
+			if err != nil {
+				return nil, fmt.Errorf("benchmark row count for '%s' failed: %w", name, err)
+			}
+			result.Rows += rows
+		}
+
+		if options.Teardown != nil {
+			if err := options.Teardown(); err != nil {
+				return nil, fmt.Errorf("benchmark teardown for '%s' failed: %w", name, err)
+			}
+		}
+	}
+
+	result.calculate()
+	return result, nil
+}
+
+// calculate fills in the aggregate statistics from the recorded durations
+func (br *BenchmarkResult) calculate() {
+	if len(br.Durations) == 0 {
+		return
+	}
+
+	var total time.Duration
+	br.Min = br.Durations[0]
+	br.Max = br.Durations[0]
+	for _, d := range br.Durations {
+		total += d
+		if d < br.Min {
+			br.Min = d
+		}
+		if d > br.Max {
+			br.Max = d
+		}
+	}
+	br.Mean = total / time.Duration(len(br.Durations))
+
+	var variance float64
+	for _, d := range br.Durations {
+		diff := float64(d - br.Mean)
+		variance += diff * diff
+	}
+	br.StdDev = time.Duration(math.Sqrt(variance / float64(len(br.Durations))))
+
+	if br.Rows > 0 && total > 0 {
+		br.RowsPerSecond = float64(br.Rows) / total.Seconds()
+	}
+}
@@ -0,0 +1,171 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBenchmarkSeeder tests the BenchmarkSeeder method
+func TestBenchmarkSeeder(t *testing.T) {
+	t.Run("Benchmark runs seeder with hooks", func(t *testing.T) {
+		manager := NewSeederManager()
+		calls := []string{}
+
+		manager.RegisterSeeder("users", func() error {
+			calls = append(calls, "run")
+			return nil
+		})
+
+		result, err := manager.BenchmarkSeeder("users", BenchmarkOptions{
+			Iterations: 3,
+			Setup: func() error {
+				calls = append(calls, "setup")
+				return nil
+			},
+			Teardown: func() error {
+				calls = append(calls, "teardown")
+				return nil
+			},
+			CountRows: func() (int64, error) { return 10, nil },
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Iterations)
+		assert.Len(t, result.Durations, 3)
+		assert.Equal(t, int64(30), result.Rows)
+		assert.Greater(t, result.RowsPerSecond, 0.0)
+		assert.Equal(t, []string{
+			"setup", "run", "teardown",
+			"setup", "run", "teardown",
+			"setup", "run", "teardown",
+		}, calls)
+	})
+
+	t.Run("Benchmark defaults to one iteration", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		result, err := manager.BenchmarkSeeder("users", BenchmarkOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Iterations)
+		assert.Zero(t, result.RowsPerSecond)
+	})
+
+	t.Run("Benchmark runs applied seeders every iteration", func(t *testing.T) {
+		manager := NewSeederManager()
+		history := NewMemoryHistoryStore()
+		manager.SetHistoryStore(history)
+		calls := 0
+		manager.RegisterSeeder("users", func() error {
+			calls++
+			return nil
+		})
+
+		result, err := manager.BenchmarkSeeder("users", BenchmarkOptions{Iterations: 5})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, calls)
+		assert.Len(t, result.Durations, 5)
+		applied, err := history.IsApplied(context.Background(), "users")
+		assert.NoError(t, err)
+		assert.True(t, applied)
+	})
+
+	t.Run("Benchmark unknown seeder", func(t *testing.T) {
+		manager := NewSeederManager()
+
+		_, err := manager.BenchmarkSeeder("missing", BenchmarkOptions{Iterations: 2})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("Benchmark stops on seeder error", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("broken", func() error { return errors.New("boom") })
+
+		_, err := manager.BenchmarkSeeder("broken", BenchmarkOptions{Iterations: 2})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "boom")
+	})
+
+	t.Run("Benchmark setup error", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		_, err := manager.BenchmarkSeeder("users", BenchmarkOptions{
+			Setup: func() error { return errors.New("no schema") },
+		})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "setup")
+	})
+}
+
+// TestBenchmarkResultCalculate tests the statistics calculation
+func TestBenchmarkResultCalculate(t *testing.T) {
+	result := &BenchmarkResult{
+		Durations: []time.Duration{2 * time.Second, 4 * time.Second, 4 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second, 7 * time.Second, 9 * time.Second},
+	}
+
+	result.calculate()
+
+	assert.Equal(t, 5*time.Second, result.Mean)
+	assert.Equal(t, 2*time.Second, result.StdDev)
+	assert.Equal(t, 2*time.Second, result.Min)
+	assert.Equal(t, 9*time.Second, result.Max)
+}
+
+// TestCLIBenchCommand tests the bench command
+func TestCLIBenchCommand(t *testing.T) {
+	t.Run("Bench with flag after name", func(t *testing.T) {
+		manager := NewSeederManager()
+		runs := 0
+		manager.RegisterSeeder("users", func() error {
+			runs++
+			return nil
+		})
+
+		cli := NewCLI(manager)
+		err := cli.runCommand(context.Background(), []string{"bench", "users", "-n=4"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, runs)
+	})
+
+	t.Run("Bench without name", func(t *testing.T) {
+		cli := NewCLI(NewSeederManager())
+
+		err := cli.runCommand(context.Background(), []string{"bench"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown command", func(t *testing.T) {
+		cli := NewCLI(NewSeederManager())
+
+		err := cli.runCommand(context.Background(), []string{"explode"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown command")
+	})
+}
+
+// TestParseCommandArgs tests flag parsing around a positional argument
+func TestParseCommandArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	n := fs.Int("n", 1, "")
+
+	name, err := NewCLI(NewSeederManager()).parseCommandArgs(fs, []string{"-n=2", "users", "-n=3"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "users", name)
+	assert.Equal(t, 3, *n)
+}
@@ -0,0 +1,79 @@
+package goseeder
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Prefixes of fixture strings holding binary values, e.g. for bytea or blob
+// columns: {"avatar": "!file ./img/avatar1.png"} or {"key": "!base64 AAEC"}
+const (
+	fileValuePrefix   = "!file "
+	base64ValuePrefix = "!base64 "
+)
+
+// FileBytes is a binary value read from a file when its row is inserted,
+// so a fixture referencing large files does not hold them all in memory
+type FileBytes struct {
+	Path string
+}
+
+// Value implements driver.Valuer
+func (f FileBytes) Value() (driver.Value, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read binary value: %w", err)
+	}
+	return data, nil
+}
+
+// MarshalJSON writes the file reference as it is written in fixtures
+func (f FileBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fileValuePrefix + f.Path)
+}
+
+// resolveBinaryValues replaces "!base64 <data>" column values of records
+// with the decoded bytes and "!file <path>" ones with FileBytes. Relative
+// paths are relative to dir, the directory of the fixture file.
+func resolveBinaryValues(records []Record, dir string) error {
+	for i, record := range records {
+		if err := resolveRecordBinaryValues(record, dir); err != nil {
+			return fmt.Errorf("record %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// resolveRecordBinaryValues resolves the binary values of a single record
+// like resolveBinaryValues
+func resolveRecordBinaryValues(record Record, dir string) error {
+	for column, value := range record {
+		text, ok := value.(string)
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(text, base64ValuePrefix):
+			data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text[len(base64ValuePrefix):]))
+			if err != nil {
+				return fmt.Errorf("column '%s': invalid base64: %w", column, err)
+			}
+			record[column] = data
+		case strings.HasPrefix(text, fileValuePrefix):
+			path := strings.TrimSpace(text[len(fileValuePrefix):])
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("column '%s': %w", column, err)
+			}
+			record[column] = FileBytes{Path: path}
+		}
+	}
+	return nil
+}
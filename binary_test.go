@@ -0,0 +1,62 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBinaryFixtureValues tests loading files and base64 data into binary columns
+func TestBinaryFixtureValues(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "img"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "img", "avatar1.png"), []byte{0x89, 'P', 'N', 'G'}, 0o644))
+	path := filepath.Join(dir, "users.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`[
+		{"id": 1, "avatar": "!file ./img/avatar1.png", "key": "!base64 AAEC"},
+		{"id": 2, "avatar": null, "key": "plain text"}
+	]`), 0o644))
+
+	records, err := ReadFixtureFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, FileBytes{Path: filepath.Join(dir, "img", "avatar1.png")}, records[0]["avatar"])
+	assert.Equal(t, []byte{0, 1, 2}, records[0]["key"])
+	assert.Equal(t, "plain text", records[1]["key"])
+
+	rendered, err := ReadFixtureFileContext(context.Background(), path)
+	assert.NoError(t, err)
+	assert.Equal(t, records, rendered)
+
+	db, fake := newFakeDB()
+	assert.NoError(t, NewSQLFixtureWriter(db, nil).WriteFixture(context.Background(), "users", records))
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO users (avatar, id, key) VALUES ($1, $2, $3), ($4, $5, $6) [[137 80 78 71] 1 [0 1 2] <nil> 2 plain text]",
+		"COMMIT",
+	}, fake.Events())
+
+	encoded, err := json.Marshal(records[0]["avatar"])
+	assert.NoError(t, err)
+	assert.Equal(t, `"!file `+filepath.Join(dir, "img", "avatar1.png")+`"`, string(encoded))
+}
+
+// TestBinaryFixtureValueErrors tests reporting bad binary values with their record
+func TestBinaryFixtureValueErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.json")
+
+	assert.NoError(t, os.WriteFile(path, []byte(`[{"id": 1}, {"id": 2, "avatar": "!file missing.png"}]`), 0o644))
+	_, err := ReadFixtureFile(path)
+	assert.ErrorContains(t, err, path+":2: column 'avatar': stat "+filepath.Join(dir, "missing.png"))
+
+	assert.NoError(t, os.WriteFile(path, []byte(`[{"id": 1, "key": "!base64 not base64"}]`), 0o644))
+	_, err = ReadFixtureFile(path)
+	assert.ErrorContains(t, err, path+":1: column 'key': invalid base64")
+
+	_, err = FileBytes{Path: filepath.Join(dir, "gone.png")}.Value()
+	assert.ErrorContains(t, err, "failed to read binary value")
+}
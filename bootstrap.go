@@ -0,0 +1,173 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Migrator applies schema migrations before seeding. Adapt your migration
+// tool (golang-migrate, goose, atlas, ...) to this interface.
+type Migrator interface {
+	Up(ctx context.Context) error
+}
+
+// MigratorFunc adapts a function to the Migrator interface
+type MigratorFunc func(ctx context.Context) error
+
+// Up calls f(ctx)
+func (f MigratorFunc) Up(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Conventional seeder tags used by the built-in bootstrap profiles
+const (
+	TagCore = "core" // Reference data every environment needs
+	TagDemo = "demo" // Sample data for demos and previews
+)
+
+// BootstrapProfile describes which steps a bootstrap runs
+type BootstrapProfile struct {
+	Name        string
+	Description string
+	SkipMigrate bool     // Don't run the migrator
+	Tags        []string // Seeders carrying any of these tags, in registration order; empty selects all seeders
+	Seeders     []string // Additional seeders to run after the tagged ones
+}
+
+// PreviewProfile creates the schema and seeds core and demo data, producing
+// a ready-to-demo environment from an empty database
+var PreviewProfile = BootstrapProfile{
+	Name:        "preview",
+	Description: "Migrate, then seed core and demo data",
+	Tags:        []string{TagCore, TagDemo},
+}
+
+// CoreProfile creates the schema and seeds core data only
+var CoreProfile = BootstrapProfile{
+	Name:        "core",
+	Description: "Migrate, then seed core data",
+	Tags:        []string{TagCore},
+}
+
+// Bootstrap sequences "migrate up → seed" behind a single call, which is
+// what preview-environment pipelines usually need
+type Bootstrap struct {
+	migrator Migrator
+	manager  Manager
+	profiles map[string]BootstrapProfile
+}
+
+// NewBootstrap creates a bootstrap orchestration with the built-in preview
+// and core profiles. A nil migrator skips the migration step.
+func NewBootstrap(migrator Migrator, manager Manager) *Bootstrap {
+	b := &Bootstrap{
+		migrator: migrator,
+		manager:  manager,
+		profiles: make(map[string]BootstrapProfile),
+	}
+	b.AddProfile(PreviewProfile)
+	b.AddProfile(CoreProfile)
+	return b
+}
+
+// AddProfile registers a profile, replacing any profile with the same name
+func (b *Bootstrap) AddProfile(profile BootstrapProfile) {
+	b.profiles[profile.Name] = profile
+}
+
+// Profile returns the profile registered under name
+func (b *Bootstrap) Profile(name string) (BootstrapProfile, bool) {
+	profile, exists := b.profiles[name]
+	return profile, exists
+}
+
+// Run migrates the schema up and then runs all seeders
+func (b *Bootstrap) Run(ctx context.Context) error {
+	ctx = b.withLogger(ctx)
+	if err := b.migrate(ctx); err != nil {
+		return err
+	}
+
+	if err := b.manager.RunAllSeedersContext(ctx); err != nil {
+		return fmt.Errorf("bootstrap seeding failed: %w", err)
+	}
+
+	logInfo(ctx, "Bootstrap completed successfully!")
+	return nil
+}
+
+// RunProfile runs the steps described by the named profile
+func (b *Bootstrap) RunProfile(ctx context.Context, name string) error {
+	ctx = b.withLogger(ctx)
+	profile, exists := b.profiles[name]
+	if !exists {
+		names := make([]string, 0, len(b.profiles))
+		for profileName := range b.profiles {
+			names = append(names, profileName)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("bootstrap profile '%s' not found%s", name, didYouMean(name, names))
+	}
+
+	logInfo(ctx, "Bootstrapping with profile: %s", profile.Name)
+
+	if !profile.SkipMigrate {
+		if err := b.migrate(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := b.manager.RunSeedersInOrderContext(ctx, b.profileSeeders(profile)); err != nil {
+		return fmt.Errorf("bootstrap seeding failed: %w", err)
+	}
+
+	logInfo(ctx, "Bootstrap profile '%s' completed successfully!", profile.Name)
+	return nil
+}
+
+// migrate runs the migrator if one is configured
+func (b *Bootstrap) migrate(ctx context.Context) error {
+	if b.migrator == nil {
+		logInfo(ctx, "No migrator configured, skipping migrations")
+		return nil
+	}
+
+	logInfo(ctx, "Running migrations...")
+	if err := b.migrator.Up(ctx); err != nil {
+		return fmt.Errorf("bootstrap migration failed: %w", err)
+	}
+	logInfo(ctx, "Migrations completed successfully")
+	return nil
+}
+
+// withLogger returns ctx carrying the logger of a *SeederManager set with
+// WithLogger, so migrations log to it too
+func (b *Bootstrap) withLogger(ctx context.Context) context.Context {
+	if manager, ok := b.manager.(*SeederManager); ok {
+		return manager.withLogger(ctx)
+	}
+	return ctx
+}
+
+// profileSeeders resolves the seeders selected by a profile, without duplicates
+func (b *Bootstrap) profileSeeders(profile BootstrapProfile) []string {
+	var selected []string
+	if len(profile.Tags) == 0 {
+		selected = b.manager.GetRegisteredSeeders()
+	} else {
+		selected = b.manager.GetSeedersByTag(profile.Tags...)
+	}
+
+	seen := make(map[string]bool, len(selected))
+	for _, name := range selected {
+		seen[name] = true
+	}
+	for _, name := range profile.Seeders {
+		if !seen[name] {
+			seen[name] = true
+			selected = append(selected, name)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,166 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBootstrap tests the migrate-then-seed orchestration
+func TestBootstrap(t *testing.T) {
+	t.Run("Migrates before seeding", func(t *testing.T) {
+		manager := NewSeederManager()
+		steps := []string{}
+
+		manager.RegisterSeeder("users", func() error {
+			steps = append(steps, "seed")
+			return nil
+		})
+		migrator := MigratorFunc(func(ctx context.Context) error {
+			steps = append(steps, "migrate")
+			return nil
+		})
+
+		err := NewBootstrap(migrator, manager).Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"migrate", "seed"}, steps)
+	})
+
+	t.Run("Migration failure skips seeding", func(t *testing.T) {
+		manager := NewSeederManager()
+		seeded := false
+
+		manager.RegisterSeeder("users", func() error {
+			seeded = true
+			return nil
+		})
+		migrator := MigratorFunc(func(ctx context.Context) error {
+			return errors.New("dirty schema")
+		})
+
+		err := NewBootstrap(migrator, manager).Run(context.Background())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "migration failed")
+		assert.False(t, seeded)
+	})
+
+	t.Run("Nil migrator only seeds", func(t *testing.T) {
+		manager := NewSeederManager()
+		seeded := false
+		manager.RegisterSeeder("users", func() error {
+			seeded = true
+			return nil
+		})
+
+		err := NewBootstrap(nil, manager).Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.True(t, seeded)
+	})
+
+	t.Run("Seeding failure is reported", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return errors.New("boom") })
+
+		err := NewBootstrap(nil, manager).Run(context.Background())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "seeding failed")
+	})
+}
+
+// TestCLIBootstrapCommand tests the bootstrap command
+func TestCLIBootstrapCommand(t *testing.T) {
+	manager := NewSeederManager()
+	migrated := false
+	manager.RegisterSeeder("users", func() error { return nil })
+
+	cli := NewCLI(manager)
+	cli.SetMigrator(MigratorFunc(func(ctx context.Context) error {
+		migrated = true
+		return nil
+	}))
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"bootstrap"}))
+	assert.True(t, migrated)
+	assert.Error(t, cli.runCommand(context.Background(), []string{"bootstrap", "extra"}))
+}
+
+// TestBootstrapProfiles tests profile-driven bootstraps
+func TestBootstrapProfiles(t *testing.T) {
+	newManager := func(executed *[]string) *SeederManager {
+		manager := NewSeederManager()
+		record := func(name string) func() error {
+			return func() error {
+				*executed = append(*executed, name)
+				return nil
+			}
+		}
+		manager.RegisterSeeders(
+			SeederItem{Name: "countries", Function: record("countries"), Tags: []string{TagCore}},
+			SeederItem{Name: "demo_users", Function: record("demo_users"), Tags: []string{TagDemo}},
+			SeederItem{Name: "load_test", Function: record("load_test")},
+		)
+		return manager
+	}
+
+	t.Run("Preview profile seeds core and demo", func(t *testing.T) {
+		executed := []string{}
+		migrated := false
+		migrator := MigratorFunc(func(ctx context.Context) error {
+			migrated = true
+			return nil
+		})
+
+		err := NewBootstrap(migrator, newManager(&executed)).RunProfile(context.Background(), "preview")
+
+		assert.NoError(t, err)
+		assert.True(t, migrated)
+		assert.Equal(t, []string{"countries", "demo_users"}, executed)
+	})
+
+	t.Run("Custom profile with extra seeders and no migration", func(t *testing.T) {
+		executed := []string{}
+		migrated := false
+		bootstrap := NewBootstrap(MigratorFunc(func(ctx context.Context) error {
+			migrated = true
+			return nil
+		}), newManager(&executed))
+		bootstrap.AddProfile(BootstrapProfile{
+			Name:        "perf",
+			SkipMigrate: true,
+			Tags:        []string{TagCore},
+			Seeders:     []string{"load_test", "countries"},
+		})
+
+		err := bootstrap.RunProfile(context.Background(), "perf")
+
+		assert.NoError(t, err)
+		assert.False(t, migrated)
+		assert.Equal(t, []string{"countries", "load_test"}, executed)
+	})
+
+	t.Run("Unknown profile", func(t *testing.T) {
+		executed := []string{}
+
+		err := NewBootstrap(nil, newManager(&executed)).RunProfile(context.Background(), "missing")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("CLI profile flag", func(t *testing.T) {
+		executed := []string{}
+		cli := NewCLI(newManager(&executed))
+		cli.AddBootstrapProfile(BootstrapProfile{Name: "demo-only", Tags: []string{TagDemo}})
+
+		err := cli.runCommand(context.Background(), []string{"bootstrap", "-profile=demo-only"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"demo_users"}, executed)
+	})
+}
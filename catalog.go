@@ -0,0 +1,126 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// envTagPrefix marks tags restricting a seeder to an environment
+const envTagPrefix = "env:"
+
+// EnvTag returns the tag restricting a seeder to env, e.g. "env:staging".
+// Seeders without any environment tag belong to every environment.
+func EnvTag(env string) string {
+	return envTagPrefix + env
+}
+
+// SeederStatus is the completion status of a seeder, derived from the
+// completion store
+type SeederStatus string
+
+// Seeder statuses reported by ListSeeders
+const (
+	SeederStatusPending  SeederStatus = "pending"
+	SeederStatusComplete SeederStatus = "complete"
+)
+
+// ListOptions filters and paginates ListSeeders. Zero values don't filter.
+type ListOptions struct {
+	Query  string       // Case-insensitive substring of the name
+	Tags   []string     // Seeders carrying at least one of these tags
+	Env    string       // Seeders tagged EnvTag(Env) or without environment tags
+	Status SeederStatus // Requires a completion store, see SeederManager.SetCompletionStore
+	Offset int          // Number of matching seeders to skip
+	Limit  int          // Maximum seeders per page, zero for all
+}
+
+// SeederInfo describes a registered seeder in a catalog listing
+type SeederInfo struct {
+	Name   string       `json:"name"`
+	Tags   []string     `json:"tags,omitempty"`
+	Status SeederStatus `json:"status,omitempty"` // Empty without a completion store
+}
+
+// SeederPage is one page of a catalog listing
+type SeederPage struct {
+	Seeders    []SeederInfo `json:"seeders"`
+	Total      int          `json:"total"`       // Matching seeders across all pages
+	NextOffset int          `json:"next_offset"` // Offset of the next page, zero on the last page
+}
+
+// ListSeeders returns, in registration order, one page of the seeders
+// matching options, for catalog endpoints and dashboards
+func (sm *SeederManager) ListSeeders(ctx context.Context, options ListOptions) (*SeederPage, error) {
+	if options.Offset < 0 || options.Limit < 0 {
+		return nil, fmt.Errorf("offset and limit cannot be negative")
+	}
+	if options.Status != "" && sm.completionStore == nil {
+		return nil, fmt.Errorf("filtering by status requires a completion store, see SeederManager.SetCompletionStore")
+	}
+
+	query := strings.ToLower(options.Query)
+	page := &SeederPage{Seeders: make([]SeederInfo, 0)}
+	seeders, _ := sm.registry()
+	for _, seeder := range seeders {
+		if query != "" && !strings.Contains(strings.ToLower(seeder.Name), query) {
+			continue
+		}
+		if len(options.Tags) > 0 && !seeder.HasAnyTag(options.Tags...) {
+			continue
+		}
+		if options.Env != "" && !inEnv(seeder, options.Env) {
+			continue
+		}
+
+		info := SeederInfo{Name: seeder.Name, Tags: seeder.Tags}
+		if sm.completionStore != nil {
+			status, err := sm.seederStatus(ctx, seeder.Name)
+			if err != nil {
+				return nil, err
+			}
+			info.Status = status
+		}
+		if options.Status != "" && info.Status != options.Status {
+			continue
+		}
+
+		page.Total++
+		if page.Total <= options.Offset || (options.Limit > 0 && len(page.Seeders) >= options.Limit) {
+			continue
+		}
+		page.Seeders = append(page.Seeders, info)
+	}
+
+	if next := options.Offset + len(page.Seeders); next < page.Total && len(page.Seeders) > 0 {
+		page.NextOffset = next
+	}
+	return page, nil
+}
+
+// seederStatus looks up the completion marker of a seeder
+func (sm *SeederManager) seederStatus(ctx context.Context, name string) (SeederStatus, error) {
+	key := sm.completionService + "/" + name
+	done, err := sm.completionStore.IsComplete(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to check completion of '%s': %w", key, err)
+	}
+	if done {
+		return SeederStatusComplete, nil
+	}
+	return SeederStatusPending, nil
+}
+
+// inEnv reports whether seeder belongs to env
+func inEnv(seeder SeederItem, env string) bool {
+	restricted := false
+	for _, tag := range seeder.Tags {
+		if tag == EnvTag(env) {
+			return true
+		}
+		if strings.HasPrefix(tag, envTagPrefix) {
+			restricted = true
+		}
+	}
+	return !restricted
+}
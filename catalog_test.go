@@ -0,0 +1,100 @@
+package goseeder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newCatalogManager returns a manager with a small mixed catalog
+func newCatalogManager() *SeederManager {
+	manager := NewSeederManager()
+	noop := func() error { return nil }
+	manager.RegisterSeeders(
+		SeederItem{Name: "countries", Function: noop, Tags: []string{TagCore}},
+		SeederItem{Name: "users", Function: noop, Tags: []string{TagCore}},
+		SeederItem{Name: "demo_users", Function: noop, Tags: []string{TagDemo, EnvTag("staging")}},
+		SeederItem{Name: "load_orders", Function: noop, Tags: []string{EnvTag("perf")}},
+		SeederItem{Name: "user_roles", Function: noop},
+	)
+	return manager
+}
+
+// pageNames returns the names in a page
+func pageNames(page *SeederPage) []string {
+	names := []string{}
+	for _, info := range page.Seeders {
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+// TestListSeeders tests catalog filtering and pagination
+func TestListSeeders(t *testing.T) {
+	ctx := context.Background()
+	manager := newCatalogManager()
+
+	t.Run("Filters", func(t *testing.T) {
+		cases := map[string]struct {
+			options  ListOptions
+			expected []string
+		}{
+			"All":   {ListOptions{}, []string{"countries", "users", "demo_users", "load_orders", "user_roles"}},
+			"Query": {ListOptions{Query: "USER"}, []string{"users", "demo_users", "user_roles"}},
+			"Tags":  {ListOptions{Tags: []string{TagDemo, EnvTag("perf")}}, []string{"demo_users", "load_orders"}},
+			"Env":   {ListOptions{Env: "staging"}, []string{"countries", "users", "demo_users", "user_roles"}},
+			"Both":  {ListOptions{Query: "user", Tags: []string{TagCore}}, []string{"users"}},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				page, err := manager.ListSeeders(ctx, tc.options)
+
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, pageNames(page))
+				assert.Equal(t, len(tc.expected), page.Total)
+				assert.Zero(t, page.NextOffset)
+			})
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		page, err := manager.ListSeeders(ctx, ListOptions{Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"countries", "users"}, pageNames(page))
+		assert.Equal(t, 5, page.Total)
+		assert.Equal(t, 2, page.NextOffset)
+
+		page, _ = manager.ListSeeders(ctx, ListOptions{Limit: 2, Offset: page.NextOffset})
+		assert.Equal(t, []string{"demo_users", "load_orders"}, pageNames(page))
+		assert.Equal(t, 4, page.NextOffset)
+
+		page, _ = manager.ListSeeders(ctx, ListOptions{Limit: 2, Offset: page.NextOffset})
+		assert.Equal(t, []string{"user_roles"}, pageNames(page))
+		assert.Zero(t, page.NextOffset)
+
+		page, _ = manager.ListSeeders(ctx, ListOptions{Offset: 10})
+		assert.Empty(t, page.Seeders)
+		assert.Equal(t, 5, page.Total)
+
+		_, err = manager.ListSeeders(ctx, ListOptions{Limit: -1})
+		assert.Error(t, err)
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		_, err := manager.ListSeeders(ctx, ListOptions{Status: SeederStatusComplete})
+		assert.ErrorContains(t, err, "SetCompletionStore")
+
+		store := NewMemoryCompletionStore()
+		manager.SetCompletionStore("shop", store)
+		defer manager.SetCompletionStore("", nil)
+		assert.NoError(t, manager.RunSeederByName("users"))
+
+		page, err := manager.ListSeeders(ctx, ListOptions{Status: SeederStatusComplete})
+		assert.NoError(t, err)
+		assert.Equal(t, []SeederInfo{{Name: "users", Tags: []string{TagCore}, Status: SeederStatusComplete}}, page.Seeders)
+
+		page, _ = manager.ListSeeders(ctx, ListOptions{Status: SeederStatusPending, Tags: []string{TagCore}})
+		assert.Equal(t, []string{"countries"}, pageNames(page))
+	})
+}
@@ -0,0 +1,102 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrChaosTransient is returned when chaos mode injects a transient failure
+	ErrChaosTransient = errors.New("chaos: injected transient error")
+
+	// ErrChaosCanceled is the cause of the contexts canceled by chaos mode,
+	// returned when the seeder ignores the cancellation. It wraps
+	// context.Canceled so errors.Is(err, context.Canceled) holds.
+	ErrChaosCanceled = fmt.Errorf("chaos: injected cancellation: %w", context.Canceled)
+)
+
+// ChaosConfig configures fault injection for seeder execution.
+// Chaos mode is intended for tests only: it lets teams verify that their
+// retry, resume and rollback logic copes with failing seeders.
+type ChaosConfig struct {
+	Seed       int64         // RNG seed, the same seed reproduces the same faults
+	ErrorRate  float64       // Probability (0-1) of failing with ErrChaosTransient
+	CancelRate float64       // Probability (0-1) of running the seeder with a context canceled with ErrChaosCanceled
+	DelayRate  float64       // Probability (0-1) of sleeping before the seeder runs
+	MaxDelay   time.Duration // Upper bound for injected delays
+}
+
+// chaosInjector applies a ChaosConfig using a seeded random source
+type chaosInjector struct {
+	config ChaosConfig
+	mu     sync.Mutex
+	rng    *rand.Rand
+}
+
+// EnableChaos turns on fault injection for every seeder run by this manager
+func (sm *SeederManager) EnableChaos(config ChaosConfig) {
+	sm.chaos = &chaosInjector{
+		config: config,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+	}
+	logInfo(sm.withLogger(context.Background()), "Chaos mode enabled (seed %d)", config.Seed)
+}
+
+// DisableChaos turns off fault injection
+func (sm *SeederManager) DisableChaos() {
+	sm.chaos = nil
+}
+
+// wrap returns a function that injects faults before calling function. An
+// injected cancellation cancels the seeder's context, so the seeder sees it
+// as it would a real one, and fails the run even if the seeder ignores it.
+func (ci *chaosInjector) wrap(name string, function SeederFunc) SeederFunc {
+	return func(ctx context.Context) error {
+		delay, err := ci.roll()
+		if delay > 0 {
+			logWarn(ctx, "Chaos: delaying seeder '%s' by %s", name, delay)
+			select {
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			case <-time.After(delay):
+			}
+		}
+		switch {
+		case errors.Is(err, ErrChaosCanceled):
+			logWarn(ctx, "Chaos: canceling the context of seeder '%s'", name)
+			ctx, cancel := context.WithCancelCause(ctx)
+			cancel(ErrChaosCanceled)
+			if err := function(ctx); err != nil {
+				return err
+			}
+			return context.Cause(ctx)
+		case err != nil:
+			logWarn(ctx, "Chaos: injecting failure into seeder '%s': %v", name, err)
+			return err
+		}
+		return function(ctx)
+	}
+}
+
+// roll draws the faults for a single execution
+func (ci *chaosInjector) roll() (time.Duration, error) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+
+	var delay time.Duration
+	if ci.config.MaxDelay > 0 && ci.rng.Float64() < ci.config.DelayRate {
+		delay = time.Duration(ci.rng.Int63n(int64(ci.config.MaxDelay)) + 1)
+	}
+
+	switch {
+	case ci.rng.Float64() < ci.config.CancelRate:
+		return delay, ErrChaosCanceled
+	case ci.rng.Float64() < ci.config.ErrorRate:
+		return delay, ErrChaosTransient
+	}
+	return delay, nil
+}
@@ -0,0 +1,110 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEnableChaos tests fault injection
+func TestEnableChaos(t *testing.T) {
+	t.Run("Always fail with transient error", func(t *testing.T) {
+		manager := NewSeederManager()
+		executed := false
+		manager.RegisterSeeder("users", func() error {
+			executed = true
+			return nil
+		})
+
+		manager.EnableChaos(ChaosConfig{Seed: 1, ErrorRate: 1})
+		err := manager.RunSeederByName("users")
+
+		assert.ErrorIs(t, err, ErrChaosTransient)
+		assert.False(t, executed)
+	})
+
+	t.Run("Injected cancellation wraps context.Canceled", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		manager.EnableChaos(ChaosConfig{Seed: 1, CancelRate: 1})
+		err := manager.RunAllSeeders()
+
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.ErrorIs(t, err, ErrChaosCanceled, "the seeder ignored the cancellation")
+	})
+
+	t.Run("Injected cancellation cancels the seeder's context", func(t *testing.T) {
+		manager := NewSeederManager()
+		var cause error
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			<-ctx.Done()
+			cause = context.Cause(ctx)
+			return ctx.Err()
+		})
+
+		manager.EnableChaos(ChaosConfig{Seed: 1, CancelRate: 1})
+		err := manager.RunSeederByName("users")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, ErrChaosCanceled, cause)
+	})
+
+	t.Run("Delays end with the context", func(t *testing.T) {
+		manager := NewSeederManager()
+		executed := false
+		manager.RegisterSeeder("users", func() error {
+			executed = true
+			return nil
+		})
+
+		manager.EnableChaos(ChaosConfig{Seed: 1, DelayRate: 1, MaxDelay: time.Hour})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := manager.RunSeederByNameContext(ctx, "users")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.False(t, executed)
+	})
+
+	t.Run("Delays are bounded", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		manager.EnableChaos(ChaosConfig{Seed: 1, DelayRate: 1, MaxDelay: time.Millisecond})
+		start := time.Now()
+		err := manager.RunSeederByName("users")
+
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Same seed reproduces the same faults", func(t *testing.T) {
+		outcomes := func() []bool {
+			manager := NewSeederManager()
+			manager.RegisterSeeder("users", func() error { return nil })
+			manager.EnableChaos(ChaosConfig{Seed: 42, ErrorRate: 0.5})
+
+			results := make([]bool, 20)
+			for i := range results {
+				results[i] = manager.RunSeederByName("users") == nil
+			}
+			return results
+		}
+
+		assert.Equal(t, outcomes(), outcomes())
+	})
+
+	t.Run("Disable chaos", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		manager.EnableChaos(ChaosConfig{Seed: 1, ErrorRate: 1})
+		manager.DisableChaos()
+
+		assert.NoError(t, manager.RunSeederByName("users"))
+	})
+}
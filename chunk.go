@@ -0,0 +1,127 @@
+package goseeder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ChunkOptions configures ChunkFixtures. Limits are not detected from the
+// database; set them below your dialect's limits, e.g. MySQL's
+// max_allowed_packet or CockroachDB's transaction size limit.
+type ChunkOptions struct {
+	MaxRows  int // Maximum records per chunk, zero for no limit
+	MaxBytes int // Maximum JSON-encoded size of a chunk, zero for no limit
+
+	// Markers records every written chunk as
+	// "<Key>/<table>/<chunk>/<hash of its records>" so a failed load
+	// resumes after the last written chunk; chunks whose records changed
+	// since are written again. The markers of a write are cleared once all
+	// its chunks are written, so a later load into a fresh database writes
+	// everything. Optional; Key, e.g. the seeder or pack name and version,
+	// is required with it.
+	Markers CompletionStore
+	Key     string
+}
+
+// ChunkFixtures returns a FixtureWriter splitting every write into chunks
+// within the configured limits and passing each chunk to writer separately,
+// so a writer opening one transaction per call stays below the limits
+func ChunkFixtures(writer FixtureWriter, options ChunkOptions) FixtureWriter {
+	return FixtureWriterFunc(func(ctx context.Context, table string, records []Record) error {
+		if options.Markers != nil && options.Key == "" {
+			return fmt.Errorf("chunk markers require ChunkOptions.Key, so writes of different seeders don't share them")
+		}
+		chunks, err := splitRecords(records, options.MaxRows, options.MaxBytes)
+		if err != nil {
+			return err
+		}
+
+		var markers []string
+		offset := 0
+		for i, chunk := range chunks {
+			start := offset
+			offset += len(chunk)
+			var marker string
+			if options.Markers != nil {
+				if marker, err = chunkMarker(options.Key, table, i, chunk); err != nil {
+					return err
+				}
+				markers = append(markers, marker)
+				done, err := options.Markers.IsComplete(ctx, marker)
+				if err != nil {
+					return fmt.Errorf("failed to check chunk marker '%s': %w", marker, err)
+				}
+				if done {
+					logInfo(ctx, "Skipping chunk %d/%d of %s, already written", i+1, len(chunks), table)
+					continue
+				}
+			}
+
+			if err := writer.WriteFixture(sliceFixtureSource(ctx, start, offset), table, chunk); err != nil {
+				return fmt.Errorf("chunk %d/%d of %s failed: %w", i+1, len(chunks), table, err)
+			}
+
+			if options.Markers != nil {
+				if err := options.Markers.MarkComplete(ctx, marker); err != nil {
+					return fmt.Errorf("failed to record chunk marker '%s': %w", marker, err)
+				}
+			}
+		}
+
+		for _, marker := range markers {
+			if err := options.Markers.ClearComplete(ctx, marker); err != nil {
+				return fmt.Errorf("failed to clear chunk marker '%s': %w", marker, err)
+			}
+		}
+		return nil
+	})
+}
+
+// chunkMarker returns the completion marker of the i-th chunk of a write
+// into table, identifying the chunk by its records
+func chunkMarker(key, table string, i int, chunk []Record) (string, error) {
+	encoded, err := json.Marshal(chunk)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash chunk %d of %s: %w", i+1, table, err)
+	}
+	sum := sha256.Sum256(encoded)
+	return fmt.Sprintf("%s/%s/%d/%s", key, table, i, hex.EncodeToString(sum[:8])), nil
+}
+
+// splitRecords splits records into chunks of at most maxRows records and
+// maxBytes JSON-encoded bytes. A single record above maxBytes is an error.
+func splitRecords(records []Record, maxRows, maxBytes int) ([][]Record, error) {
+	var chunks [][]Record
+	var current []Record
+	size := 0
+
+	for _, record := range records {
+		recordSize := 0
+		if maxBytes > 0 {
+			encoded, err := json.Marshal(record)
+			if err != nil {
+				return nil, fmt.Errorf("failed to measure record: %w", err)
+			}
+			recordSize = len(encoded)
+			if recordSize > maxBytes {
+				return nil, fmt.Errorf("record of %d bytes exceeds the chunk limit of %d bytes", recordSize, maxBytes)
+			}
+		}
+
+		full := (maxRows > 0 && len(current) >= maxRows) || (maxBytes > 0 && size+recordSize > maxBytes)
+		if full && len(current) > 0 {
+			chunks = append(chunks, current)
+			current, size = nil, 0
+		}
+		current = append(current, record)
+		size += recordSize
+	}
+
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks, nil
+}
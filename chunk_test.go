@@ -0,0 +1,87 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// numberedRecords returns count records {"n": 0} .. {"n": count-1}
+func numberedRecords(count int) []Record {
+	records := make([]Record, count)
+	for i := range records {
+		records[i] = Record{"n": i}
+	}
+	return records
+}
+
+// TestSplitRecords tests splitting by row count and size
+func TestSplitRecords(t *testing.T) {
+	chunks, err := splitRecords(numberedRecords(5), 2, 0)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 3)
+	assert.Len(t, chunks[2], 1)
+
+	// {"n":0} is 7 bytes, so two records fit in 16 bytes
+	chunks, err = splitRecords(numberedRecords(5), 0, 16)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 3)
+
+	chunks, err = splitRecords(numberedRecords(5), 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 1)
+
+	_, err = splitRecords([]Record{{"bio": strings.Repeat("x", 100)}}, 0, 50)
+	assert.ErrorContains(t, err, "exceeds the chunk limit")
+}
+
+// TestChunkFixtures tests chunked writes and resuming after a failure
+func TestChunkFixtures(t *testing.T) {
+	markers := NewMemoryCompletionStore()
+	var written [][]Record
+	failAt := 2
+	writer := FixtureWriterFunc(func(ctx context.Context, table string, records []Record) error {
+		if len(written) == failAt {
+			failAt = -1
+			return errors.New("transaction too large")
+		}
+		written = append(written, records)
+		return nil
+	})
+	chunked := ChunkFixtures(writer, ChunkOptions{MaxRows: 2, Markers: markers, Key: "pack-1.0.0"})
+
+	err := chunked.WriteFixture(context.Background(), "orders", numberedRecords(7))
+	assert.ErrorContains(t, err, "chunk 3/4 of orders failed")
+	assert.Len(t, written, 2)
+
+	err = chunked.WriteFixture(context.Background(), "orders", numberedRecords(7))
+	assert.NoError(t, err)
+	assert.Len(t, written, 4, "resumes after the last written chunk")
+	assert.Equal(t, []Record{{"n": 6}}, written[3])
+
+	assert.NoError(t, chunked.WriteFixture(context.Background(), "orders", numberedRecords(7)))
+	assert.Len(t, written, 8, "markers are cleared after a complete write")
+
+	failAt = 9
+	assert.Error(t, chunked.WriteFixture(context.Background(), "orders", numberedRecords(7)))
+	edited := numberedRecords(7)
+	edited[0] = Record{"n": 100}
+	assert.NoError(t, chunked.WriteFixture(context.Background(), "orders", edited))
+	assert.Equal(t, []Record{{"n": 100}, {"n": 1}}, written[9], "changed chunks are written again")
+	assert.Len(t, written, 13)
+
+	err = ChunkFixtures(writer, ChunkOptions{MaxRows: 2, Markers: markers}).WriteFixture(context.Background(), "orders", numberedRecords(1))
+	assert.ErrorContains(t, err, "require ChunkOptions.Key")
+
+	var sources []string
+	writer = FixtureWriterFunc(func(ctx context.Context, table string, records []Record) error {
+		sources = append(sources, fixtureSourceRow(ctx, 0))
+		return nil
+	})
+	ctx := withFixtureSource(context.Background(), "orders.json", nil)
+	assert.NoError(t, ChunkFixtures(writer, ChunkOptions{MaxRows: 3}).WriteFixture(ctx, "orders", numberedRecords(7)))
+	assert.Equal(t, []string{"orders.json:1", "orders.json:4", "orders.json:7"}, sources, "chunks keep the rows of their records")
+}
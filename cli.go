@@ -1,97 +1,1697 @@
 package goseeder
 
 import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"flag"
-	"log"
+	"fmt"
+	"io"
+	"maps"
 	"os"
+	"os/signal"
+	"slices"
+	"strconv"
 	"strings"
+	"syscall"
+	"text/template"
+	"time"
 )
 
 // CLI handles command line interface for seeder operations
 type CLI struct {
-	manager *SeederManager
-	appName string // Application name for usage display
+	manager       Manager
+	appName       string           // Application name for usage display
+	benchOptions  BenchmarkOptions // Hooks used by the bench command
+	migrator      Migrator         // Used by the bootstrap command
+	profiles      []BootstrapProfile
+	pruner        Pruner // Used by the prune command
+	copier        Copier // Used by the copy command
+	anonymizer    Anonymizer
+	db            *sql.DB          // Used by the profile and fixture commands
+	placeholder   Placeholder      // Bind parameter style of db
+	stdin         io.Reader        // Read by "load -" and "load" without a file
+	stdout        io.Writer        // Output of Usage and commands, see SetOutput
+	stderr        io.Writer        // Warnings, errors and flag errors
+	foreignKeys   ForeignKeyLister // Used by the order command
+	columns       ColumnLister     // Used by the load and insert commands, nil to insert records as they are
+	values        ValueLister      // Used by the load, insert and fill commands, nil to accept any value
+	usageTemplate *template.Template
+	transforms    map[string]ColumnTransform // Applied by the load and insert commands
+	dsn           string                     // Opened by commands needing a database when db is nil
+	dbFromDSN     bool
+	settings      map[string]string // Config file values, see SetConfigFile
+	logger        Logger            // Log output of commands, nil for the manager's
+
+	checkingConfig bool // Commands stop after parsing their flags, see validateConfig
 }
 
 // NewCLI creates a new CLI instance
-func NewCLI(manager *SeederManager) *CLI {
+func NewCLI(manager Manager) *CLI {
 	return &CLI{
 		manager: manager,
 		appName: "seeder", // Default app name
+		stdin:   os.Stdin,
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
 	}
 }
 
 // NewCLIWithAppName creates a new CLI instance with custom app name
-func NewCLIWithAppName(manager *SeederManager, appName string) *CLI {
+func NewCLIWithAppName(manager Manager, appName string) *CLI {
 	return &CLI{
 		manager: manager,
 		appName: appName,
+		stdin:   os.Stdin,
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
 	}
 }
 
 // Run executes the seeder based on command line arguments
 func (cli *CLI) Run() error {
-	// Parse command line flags
-	seedType := flag.String("type", "", "Type of seeder to run (all, or specific seeder name)")
-	flag.Parse()
+	return cli.RunContext(context.Background())
+}
+
+// RunContext executes the seeder based on command line arguments, stopping
+// the run when ctx is done or the -timeout flag expires. Seeders see the
+// cancellation through their context, so the run ends cleanly instead of
+// being killed halfway through its tracking state.
+func (cli *CLI) RunContext(ctx context.Context) error {
+	// Parse command line flags into a set of their own, so programs defining
+	// flags of the same names on flag.CommandLine keep working
+	fs := cli.newFlagSet(cli.appName)
+	seedType := fs.String("type", "", "Type of seeder to run (all, a seeder name or a glob such as user_*)")
+	match := fs.String("match", "", "Run the seeders whose names match this regular expression, e.g. ^billing_")
+	phase := fs.String("phase", "", "Run the seeders of a deployment phase: pre-app, core or post-app")
+	parallel := fs.Int("parallel", 0, "Run -type=all with this many workers: seeders without dependencies run concurrently (0 runs serially)")
+	fromFile := fs.String("from-file", "", "Run the seeders listed in this file, one per line and in that order (- for stdin)")
+	timeout := fs.Duration("timeout", 0, "Cancel the run after this duration, e.g. 10m (0 means no timeout)")
+	dsn := fs.String("dsn", "", "Database URL for commands that need one, e.g. postgres://localhost/app (default $DATABASE_URL)")
+	showSecrets := fs.Bool("show-secrets", false, "Print the secrets seeders report, e.g. demo passwords, instead of masking them")
+	force := fs.Bool("force", false, "Run seeders the history store records as applied again")
+	fixtureMode := fs.String("fixture-mode", "", "Check fixtures against their table: strict fails on unknown columns and coerced values, lenient skips them with warnings")
+	quiet := fs.Bool("quiet", false, "Log errors only, without progress messages and the run summary")
+	appendRun := fs.Bool("append", false, "Grow the data of the previous run: sequences continue from its run report instead of starting at 1")
+	maxRows := fs.Int64("max-rows", 0, "Fail inserts that would make the run insert more than this many rows in total (0 means no limit)")
+	maxTableRows := fs.Int64("max-table-rows", 0, "Fail inserts that would make the run insert more than this many rows into one table (0 means no limit)")
+	env := fs.String("env", "", "Environment whose fixture overlays are merged, e.g. staging merges users.staging.json into users.json")
+	config := fs.String("config", "", "Config file setting flags, instead of seeder.yaml or .seederrc found from the working directory")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		return asUsageError(err)
+	}
+
+	if err := cli.loadSettings(*config); err != nil {
+		return err
+	}
+	if err := cli.applySettings(fs, "", "type", "match", "phase", "parallel", "from-file", "timeout", "dsn", "show-secrets", "force", "fixture-mode", "quiet", "append", "max-rows", "max-table-rows", "env"); err != nil {
+		return err
+	}
+	if *dsn == "" {
+		*dsn = os.Getenv("DATABASE_URL")
+	}
+	cli.dsn = *dsn
+	defer cli.closeDatabase()
+	if cli.dsn != "" {
+		ctx = ContextWithDSN(ctx, cli.dsn)
+	}
+
+	if *showSecrets {
+		ctx = ContextWithSecretsShown(ctx)
+	}
+	if *force {
+		ctx = ContextWithForceRun(ctx)
+	}
+	if *fixtureMode != "" {
+		mode, err := ParseFixtureMode(*fixtureMode)
+		if err != nil {
+			return usageErrorf("invalid -fixture-mode: %v", err)
+		}
+		ctx = ContextWithFixtureMode(ctx, mode)
+	}
+	if cli.logger != nil {
+		ctx = ContextWithLogger(ctx, cli.logger)
+	}
+	if *quiet {
+		ctx = ContextWithQuiet(ctx)
+	}
+	if *appendRun {
+		ctx = ContextWithAppend(ctx)
+	}
+	if *env != "" {
+		ctx = ContextWithEnvironment(ctx, *env)
+	}
+	if *maxRows < 0 || *maxTableRows < 0 {
+		return usageErrorf("-max-rows and -max-table-rows cannot be negative")
+	}
+	if *maxRows > 0 || *maxTableRows > 0 {
+		ctx = ContextWithQuota(ctx, Quota{MaxRows: *maxRows, MaxTableRows: *maxTableRows})
+	}
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	ctx, release := cli.handleSignals(ctx)
+	defer release()
+
+	// Positional arguments select a command such as "bench"
+	if args := fs.Args(); len(args) > 0 {
+		return cli.runCommand(ctx, args)
+	}
+
+	if *parallel != 0 && (*seedType != "all" || *match != "") {
+		return usageErrorf("-parallel only applies to -type=all")
+	}
+	if *fromFile != "" {
+		if *seedType != "" || *match != "" || *phase != "" {
+			return usageErrorf("-from-file cannot be combined with -type, -match or -phase")
+		}
+		return cli.runFromFile(ctx, *fromFile)
+	}
+	if *phase != "" {
+		if *seedType != "" || *match != "" {
+			return usageErrorf("-phase cannot be combined with -type or -match")
+		}
+		return cli.runPhase(ctx, *phase)
+	}
 
 	// If no type specified, show usage and available seeders
-	if *seedType == "" {
+	if *seedType == "" && *match == "" {
 		cli.Usage()
 		return nil
 	}
 
-	log.Printf("Starting seeder with type: %s", *seedType)
+	if name, ok := strings.CutPrefix(*seedType, "rollback:"); ok {
+		if *match != "" {
+			return usageErrorf("-type=rollback:<name> cannot be combined with -match")
+		}
+		if name == "all" {
+			return cli.runRollback(ctx, []string{"-all"})
+		}
+		return cli.runRollback(ctx, []string{name})
+	}
+
+	if *match != "" || IsGlob(*seedType) {
+		return cli.runSelection(ctx, *seedType, *match)
+	}
+
+	cli.printf("Starting seeder with type: %s", *seedType)
 
 	switch *seedType {
 	case "all":
-		return cli.manager.RunAllSeeders()
+		if *parallel != 0 {
+			if *parallel < 0 {
+				return usageErrorf("-parallel must be positive, got %d", *parallel)
+			}
+			manager, err := managerAs[parallelRunner](cli, "-parallel")
+			if err != nil {
+				return err
+			}
+			return manager.RunAllSeedersParallelContext(ctx, *parallel)
+		}
+		return cli.manager.RunAllSeedersContext(ctx)
 	default:
 		// Check if it's a specific seeder name
 		if cli.manager.IsSeederRegistered(*seedType) {
-			return cli.manager.RunSeederByName(*seedType)
+			return cli.manager.RunSeederByNameContext(ctx, *seedType)
+		}
+		cli.errorf("Available seeders: %v", cli.manager.GetRegisteredSeeders())
+		cli.Usage()
+		return usageErrorf("unknown seeder type: %s%s", *seedType, didYouMean(*seedType, cli.manager.GetRegisteredSeeders()))
+	}
+}
+
+// handleSignals stops the run of ctx on signals: the first SIGINT or SIGTERM
+// lets the current seeder finish and then stops, a second SIGINT cancels
+// the current seeder as well. The returned function stops the handling.
+func (cli *CLI) handleSignals(ctx context.Context) (context.Context, func()) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	ctx, release := cli.watchSignals(ctx, signals)
+	return ctx, func() {
+		signal.Stop(signals)
+		release()
+	}
+}
+
+// watchSignals implements handleSignals for signals received on signals
+func (cli *CLI) watchSignals(ctx context.Context, signals <-chan os.Signal) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	ctx, stop := WithGracefulStop(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		stopping := false
+		for {
+			select {
+			case sig := <-signals:
+				switch {
+				case !stopping:
+					stopping = true
+					cli.errorf("Received %s, stopping after the current seeder (interrupt again to cancel it)", sig)
+					stop(&StopError{Signal: sig})
+				case sig == os.Interrupt:
+					cli.errorf("Received a second %s, canceling the current seeder", sig)
+					cancel(&StopError{Signal: sig, Forced: true})
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ctx, func() {
+		close(done)
+		stop(nil)
+		cancel(nil)
+	}
+}
+
+// runSelection runs, in registration order, the seeders matching the glob
+// given as -type and the regular expression given as -match
+func (cli *CLI) runSelection(ctx context.Context, glob, expr string) error {
+	selected := cli.manager.GetRegisteredSeeders()
+	var err error
+	if glob != "" && glob != "all" {
+		if selected, err = MatchGlob(selected, glob); err != nil {
+			return asUsageError(err)
+		}
+	}
+	if expr != "" {
+		if selected, err = MatchRegexp(selected, expr); err != nil {
+			return asUsageError(err)
+		}
+	}
+	if len(selected) == 0 {
+		return usageErrorf("no seeders match -type=%q -match=%q", glob, expr)
+	}
+	if selected, err = cli.withDependencies(selected); err != nil {
+		return err
+	}
+
+	cli.printf("Running %d selected seeders: %s", len(selected), strings.Join(selected, ", "))
+	return cli.manager.RunSeedersInOrderContext(ctx, selected)
+}
+
+// dependencyExpander is implemented by managers that know the dependencies
+// of their seeders, such as *SeederManager
+type dependencyExpander interface {
+	ExpandDependencies(names []string) ([]string, error)
+}
+
+// Optional parts of a manager beyond Manager, implemented by
+// *SeederManager, that the commands needing them look up with managerAs
+type (
+	parallelRunner interface {
+		RunAllSeedersParallelContext(ctx context.Context, workers int) error
+	}
+	phaseLister interface {
+		GetSeedersByPhase(phase Phase) []string
+	}
+	scenarioRunner interface {
+		GetRegisteredScenarios() []string
+		RunScenario(ctx context.Context, name string, params map[string]string) error
+		TeardownScenario(ctx context.Context, name string) error
+	}
+	rollbacker interface {
+		RollbackSeederContext(ctx context.Context, name string) error
+		RollbackAllContext(ctx context.Context) error
+	}
+	validator interface {
+		Validate() error
+		ValidateContext(ctx context.Context) error
+	}
+	benchmarker interface {
+		BenchmarkSeeder(name string, options BenchmarkOptions) (*BenchmarkResult, error)
+	}
+	converger interface {
+		Converge(ctx context.Context) (*ConvergeResult, error)
+	}
+	affectedLister interface {
+		AffectedSeeders(changed []string) []string
+	}
+	fingerprinter interface {
+		Fingerprint(root string) (string, error)
+	}
+)
+
+// managerAs returns the CLI's manager as the optional interface T needed
+// by a feature, failing when the manager does not implement it
+func managerAs[T any](cli *CLI, feature string) (T, error) {
+	manager, ok := cli.manager.(T)
+	if !ok {
+		return manager, fmt.Errorf("%s requires a manager supporting it, such as *goseeder.SeederManager, got %T", feature, cli.manager)
+	}
+	return manager, nil
+}
+
+// withDependencies adds the seeders the selected ones depend on, in
+// dependency order, when the manager knows them
+func (cli *CLI) withDependencies(selected []string) ([]string, error) {
+	expander, ok := cli.manager.(dependencyExpander)
+	if !ok {
+		return selected, nil
+	}
+	expanded, err := expander.ExpandDependencies(selected)
+	if err != nil {
+		return nil, err
+	}
+	var added []string
+	for _, name := range expanded {
+		if !slices.Contains(selected, name) {
+			added = append(added, name)
+		}
+	}
+	if len(added) > 0 {
+		cli.printf("Including %d seeders the selection depends on: %s", len(added), strings.Join(added, ", "))
+	}
+	return expanded, nil
+}
+
+// SetOutput sets where Usage and commands write, both their output and
+// their errors; use SetErrorOutput afterwards to separate the errors
+func (cli *CLI) SetOutput(w io.Writer) {
+	cli.stdout = w
+	cli.stderr = w
+}
+
+// SetErrorOutput sets where warnings, errors and flag errors are written,
+// os.Stderr by default
+func (cli *CLI) SetErrorOutput(w io.Writer) {
+	cli.stderr = w
+}
+
+// printf writes a line of output
+func (cli *CLI) printf(format string, args ...any) {
+	fmt.Fprintf(cli.stdout, format+"\n", args...)
+}
+
+// errorf writes a line to the error output
+func (cli *CLI) errorf(format string, args ...any) {
+	fmt.Fprintf(cli.stderr, format+"\n", args...)
+}
+
+// newFlagSet returns a flag set for a command that reports flag errors to
+// the error output
+func (cli *CLI) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(cli.stderr)
+	return fs
+}
+
+// runFromFile runs the seeders listed in path, in the listed order, after
+// checking that all of them are registered
+func (cli *CLI) runFromFile(ctx context.Context, path string) error {
+	var names []string
+	var err error
+	if path == "-" {
+		names, err = ReadSeederList(cli.stdin)
+	} else {
+		names, err = ReadSeederListFile(path)
+	}
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return usageErrorf("%s lists no seeders", path)
+	}
+
+	registered := cli.manager.GetRegisteredSeeders()
+	for _, name := range names {
+		if !cli.manager.IsSeederRegistered(name) {
+			return usageErrorf("%s lists unknown seeder '%s'%s", path, name, didYouMean(name, registered))
+		}
+	}
+
+	if names, err = cli.withDependencies(names); err != nil {
+		return err
+	}
+
+	cli.printf("Running %d seeders listed in %s: %s", len(names), path, strings.Join(names, ", "))
+	return cli.manager.RunSeedersInOrderContext(ctx, names)
+}
+
+// runPhase runs the seeders of a deployment phase in registration order
+func (cli *CLI) runPhase(ctx context.Context, name string) error {
+	phase, err := ParsePhase(name)
+	if err != nil {
+		return asUsageError(err)
+	}
+	manager, err := managerAs[phaseLister](cli, "-phase")
+	if err != nil {
+		return err
+	}
+	names := manager.GetSeedersByPhase(phase)
+	if len(names) == 0 {
+		cli.printf("No seeders in phase %s", phase)
+		return nil
+	}
+
+	cli.printf("Running %d seeders of phase %s: %s", len(names), phase, strings.Join(names, ", "))
+	return cli.manager.RunSeedersInOrderContext(ctx, names)
+}
+
+// SetBenchmarkOptions sets the hooks used by the bench command, e.g. to
+// create and drop a scratch schema around every iteration
+func (cli *CLI) SetBenchmarkOptions(options BenchmarkOptions) {
+	cli.benchOptions = options
+}
+
+// SetMigrator sets the migrator run by the bootstrap command before seeding
+func (cli *CLI) SetMigrator(migrator Migrator) {
+	cli.migrator = migrator
+}
+
+// AddBootstrapProfile registers a profile selectable with "bootstrap -profile=<name>"
+func (cli *CLI) AddBootstrapProfile(profile BootstrapProfile) {
+	cli.profiles = append(cli.profiles, profile)
+}
+
+// SetPruner sets the pruner used by the prune command
+func (cli *CLI) SetPruner(pruner Pruner) {
+	cli.pruner = pruner
+}
+
+// SetCopier sets the copier used by the copy command, SQLCopier when nil,
+// and the anonymizer applied to copied rows when "-anonymize" is given
+func (cli *CLI) SetCopier(copier Copier, anonymizer Anonymizer) {
+	cli.copier = copier
+	cli.anonymizer = anonymizer
+}
+
+// SetDB sets the database used by commands that inspect or write data, such
+// as profile and debug-row
+func (cli *CLI) SetDB(db *sql.DB) {
+	cli.db = db
+}
+
+// SetPlaceholder sets the bind parameter style of the database set with
+// SetDB, DollarPlaceholder by default
+func (cli *CLI) SetPlaceholder(placeholder Placeholder) {
+	cli.placeholder = placeholder
+}
+
+// SetForeignKeyLister sets how the order command lists foreign keys,
+// PostgresForeignKeys of the database set with SetDB by default
+func (cli *CLI) SetForeignKeyLister(lister ForeignKeyLister) {
+	cli.foreignKeys = lister
+}
+
+// SetColumnLister makes the load and insert commands omit generated
+// columns and use column defaults instead of NULLs, e.g. with
+// PostgresColumns(db)
+func (cli *CLI) SetColumnLister(lister ColumnLister) {
+	cli.columns = lister
+}
+
+// SetValueLister makes the load and insert commands check values of enum
+// and check-constrained columns before inserting them, and the fill
+// command pick among them, e.g. with PostgresValues(db)
+func (cli *CLI) SetValueLister(lister ValueLister) {
+	cli.values = lister
+}
+
+// SetColumnTransforms makes the load and insert commands rewrite column
+// values before inserting them, see SQLFixtureWriter.Transforms
+func (cli *CLI) SetColumnTransforms(transforms map[string]ColumnTransform) {
+	cli.transforms = transforms
+}
+
+// SetLogger sends the log output of commands and the runs they start to
+// logger, overriding the manager's (see SeederManager.WithLogger)
+func (cli *CLI) SetLogger(logger Logger) {
+	cli.logger = logger
+}
+
+// commands are the positional commands handled by runCommand
+var commands = []string{"bench", "bootstrap", "scenario", "teardown", "prune", "copy", "validate", "profile", "debug-row", "load", "insert", "delete", "fill", "fmt", "order", "affected", "rollback", "converge", "gen-k8s-job", "fingerprint", "dual-write", "verify"}
+
+// runCommand dispatches a positional command
+func (cli *CLI) runCommand(ctx context.Context, args []string) error {
+	switch args[0] {
+	case "bench":
+		return cli.runBench(ctx, args[1:])
+	case "bootstrap":
+		return cli.runBootstrap(ctx, args[1:])
+	case "scenario":
+		return cli.runScenario(ctx, args[1:])
+	case "teardown":
+		return cli.runTeardown(ctx, args[1:])
+	case "prune":
+		return cli.runPrune(ctx, args[1:])
+	case "copy":
+		return cli.runCopy(ctx, args[1:])
+	case "validate":
+		return cli.runValidate(ctx, args[1:])
+	case "verify":
+		return cli.runVerify(ctx, args[1:])
+	case "profile":
+		return cli.runProfile(ctx, args[1:])
+	case "debug-row":
+		return cli.runDebugRow(ctx, args[1:])
+	case "load":
+		return cli.runLoad(ctx, args[1:])
+	case "insert":
+		return cli.runInsert(ctx, args[1:])
+	case "delete":
+		return cli.runDelete(ctx, args[1:])
+	case "fill":
+		return cli.runFill(ctx, args[1:])
+	case "fmt":
+		return cli.runFmt(ctx, args[1:])
+	case "order":
+		return cli.runOrder(ctx, args[1:])
+	case "affected":
+		return cli.runAffected(ctx, args[1:])
+	case "rollback":
+		return cli.runRollback(ctx, args[1:])
+	case "converge":
+		return cli.runConverge(ctx, args[1:])
+	case "gen-k8s-job":
+		return cli.runGenK8sJob(args[1:])
+	case "fingerprint":
+		return cli.runFingerprint(args[1:])
+	case "dual-write":
+		return cli.runDualWrite(ctx, args[1:])
+	default:
+		cli.Usage()
+		return usageErrorf("unknown command: %s%s", args[0], didYouMean(args[0], commands))
+	}
+}
+
+// runBench handles "bench <name> -n=5"
+func (cli *CLI) runBench(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("bench")
+	iterations := fs.Int("n", 5, "Number of iterations")
+	name, err := cli.parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return usageErrorf("bench requires a seeder name")
+	}
+
+	options := cli.benchOptions
+	options.Iterations = *iterations
+
+	manager, err := managerAs[benchmarker](cli, "bench")
+	if err != nil {
+		return err
+	}
+	result, err := manager.BenchmarkSeeder(name, options)
+	if err != nil {
+		return err
+	}
+
+	cli.printf("Benchmark: %s (%d iterations)", result.Name, result.Iterations)
+	cli.printf("  mean:   %s", result.Mean)
+	cli.printf("  stddev: %s", result.StdDev)
+	cli.printf("  min:    %s", result.Min)
+	cli.printf("  max:    %s", result.Max)
+	if result.Rows > 0 {
+		cli.printf("  rows/s: %.2f (%d rows)", result.RowsPerSecond, result.Rows)
+	}
+	return nil
+}
+
+// runBootstrap handles "bootstrap [-profile=<name>]": migrate up, then seed
+func (cli *CLI) runBootstrap(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("bootstrap")
+	profile := fs.String("profile", "", "Bootstrap profile (e.g. preview, core); empty runs all seeders")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+
+	bootstrap := NewBootstrap(cli.migrator, cli.manager)
+	for _, p := range cli.profiles {
+		bootstrap.AddProfile(p)
+	}
+
+	if *profile == "" {
+		return bootstrap.Run(ctx)
+	}
+	return bootstrap.RunProfile(ctx, *profile)
+}
+
+// runScenario handles "scenario <name> [-param key=value ...]"
+func (cli *CLI) runScenario(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("scenario")
+	params := keyValueFlag{}
+	fs.Var(params, "param", "Scenario parameter override as key=value (repeatable)")
+	name, err := cli.parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return usageErrorf("scenario requires a scenario name")
+	}
+
+	manager, err := managerAs[scenarioRunner](cli, "scenario")
+	if err != nil {
+		return err
+	}
+	return manager.RunScenario(ctx, name, params)
+}
+
+// runTeardown handles "teardown <scenario>"
+func (cli *CLI) runTeardown(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("teardown")
+	name, err := cli.parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return usageErrorf("teardown requires exactly one scenario name")
+	}
+	manager, err := managerAs[scenarioRunner](cli, "teardown")
+	if err != nil {
+		return err
+	}
+	return manager.TeardownScenario(ctx, name)
+}
+
+// now returns the time of the manager's clock, see SeederManager.SetClock,
+// or the system time when the manager has no clock
+func (cli *CLI) now() time.Time {
+	if manager, ok := cli.manager.(interface{ now() time.Time }); ok {
+		return manager.now()
+	}
+	return time.Now()
+}
+
+// runPrune handles "prune [-older-than=7d]"
+func (cli *CLI) runPrune(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("prune")
+	olderThan := fs.String("older-than", "", "Also remove data seeded longer ago than this age (e.g. 7d, 12h)")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	if cli.pruner == nil {
+		return fmt.Errorf("prune requires a pruner, see CLI.SetPruner")
+	}
+
+	request := PruneRequest{Now: cli.now()}
+	if *olderThan != "" {
+		age, err := ParseAge(*olderThan)
+		if err != nil {
+			return err
+		}
+		request.OlderThan = age
+	}
+
+	removed, err := cli.pruner.Prune(ctx, request)
+	if err != nil {
+		return fmt.Errorf("prune failed: %w", err)
+	}
+
+	cli.printf("Pruned %d expired rows", removed)
+	return nil
+}
+
+// runCopy handles "copy -from=<dsn> -to=<dsn> -tables=a,b [-anonymize]"
+func (cli *CLI) runCopy(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("copy")
+	from := fs.String("from", "", "DSN of the source environment")
+	to := fs.String("to", "", "DSN of the target environment")
+	tables := fs.String("tables", "", "Comma-separated tables to copy, in order")
+	anonymize := fs.Bool("anonymize", false, "Anonymize copied rows")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	if *from == "" || *to == "" || *tables == "" {
+		return usageErrorf("copy requires -from, -to and -tables")
+	}
+	copier := cli.copier
+	if copier == nil {
+		copier = SQLCopier{}
+	}
+
+	request := CopyRequest{From: *from, To: *to, Tables: splitList(*tables)}
+	if *anonymize {
+		if cli.anonymizer == nil {
+			return fmt.Errorf("-anonymize requires an anonymizer, see CLI.SetCopier")
+		}
+		request.Anonymizer = cli.anonymizer
+	}
+
+	copied, err := copier.Copy(ctx, request)
+	if err != nil {
+		return fmt.Errorf("copy failed: %w", err)
+	}
+
+	cli.printf("Copied %d rows from %d tables", copied, len(request.Tables))
+	return nil
+}
+
+// runValidate handles "validate [-fast]": checks the seed setup without
+// touching data. -fast runs only static checks, e.g. for a pre-commit hook.
+func (cli *CLI) runValidate(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("validate")
+	fast := fs.Bool("fast", false, "Run only static checks (no database needed)")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+
+	manager, err := managerAs[validator](cli, "validate")
+	if err != nil {
+		return err
+	}
+	validate := func() error { return manager.ValidateContext(ctx) }
+	if *fast {
+		validate = manager.Validate
+	}
+	return cli.reportValidation(ctx, validate)
+}
+
+// runVerify handles "verify": the full validation including checks that
+// read databases, such as cross-service relations, for integration
+// environments where every service has been seeded
+func (cli *CLI) runVerify(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("verify")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	manager, err := managerAs[validator](cli, "verify")
+	if err != nil {
+		return err
+	}
+	return cli.reportValidation(ctx, func() error { return manager.ValidateContext(ctx) })
+}
+
+// reportValidation runs validate plus the CLI's own checks and prints every issue
+func (cli *CLI) reportValidation(ctx context.Context, validate func() error) error {
+	result := &ValidationError{}
+	if err := validate(); err != nil {
+		result.addError("manager", err)
+	}
+	cli.validateProfiles(result)
+	cli.validateConfig(ctx, result)
+
+	if err := result.err(); err != nil {
+		for _, issue := range result.Issues {
+			cli.printf("  [%s] %s", issue.Check, issue.Message)
+		}
+		return err
+	}
+
+	cli.printf("Validation passed")
+	return nil
+}
+
+// validateProfiles checks that bootstrap profiles only reference registered seeders
+func (cli *CLI) validateProfiles(result *ValidationError) {
+	for _, profile := range cli.profiles {
+		if profile.Name == "" {
+			result.add("profiles", "bootstrap profile has no name")
+		}
+		for _, name := range profile.Seeders {
+			if !cli.manager.IsSeederRegistered(name) {
+				result.add("profiles", "bootstrap profile '%s' references unknown seeder '%s'%s", profile.Name, name, didYouMean(name, cli.manager.GetRegisteredSeeders()))
+			}
+		}
+	}
+}
+
+// errConfigChecked stops a command once its flags are set from the config
+// file, see validateConfig
+var errConfigChecked = errors.New("config checked")
+
+// validateConfig checks that the config file only sets flags of known
+// commands, to valid values, by parsing the flags of every command it
+// configures without running it. Top-level flags are checked by Run.
+func (cli *CLI) validateConfig(ctx context.Context, result *ValidationError) {
+	configured := make(map[string]bool)
+	for key := range cli.settings {
+		if command, _, nested := strings.Cut(key, "."); nested {
+			configured[command] = true
+		}
+	}
+
+	cli.checkingConfig = true
+	defer func() { cli.checkingConfig = false }()
+	for _, command := range commands {
+		if !configured[command] {
+			continue
+		}
+		delete(configured, command)
+		if err := cli.runCommand(ctx, []string{command}); !errors.Is(err, errConfigChecked) {
+			result.add("config", "%v", err)
+		}
+	}
+	for _, command := range slices.Sorted(maps.Keys(configured)) {
+		result.add("config", "config file sets flags of unknown command %q%s", command, didYouMean(command, commands))
+	}
+}
+
+// runProfile handles "profile -tables=a,b": reports the shape of seeded data
+func (cli *CLI) runProfile(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("profile")
+	tables := fs.String("tables", "", "Comma-separated tables to profile")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	names := splitList(*tables)
+	if len(names) == 0 {
+		return usageErrorf("profile requires -tables")
+	}
+	if err := cli.openDatabase(ctx, "profile"); err != nil {
+		return err
+	}
+
+	for _, table := range names {
+		profile, err := ProfileTable(ctx, cli.db, table)
+		if err != nil {
+			return err
+		}
+
+		cli.printf("Table %s: %d rows", profile.Table, profile.Rows)
+		for _, column := range profile.Columns {
+			if column.Error != "" {
+				cli.printf("  %-20s not profiled: %s", column.Name, column.Error)
+				continue
+			}
+			cli.printf("  %-20s nulls: %5.1f%%  distinct: %-8d min: %v  max: %v",
+				column.Name, column.NullRatio*100, column.Distinct, column.Min, column.Max)
+		}
+	}
+	return nil
+}
+
+// runDebugRow handles "debug-row <file>:<row> [-table=<name>] [-commit]":
+// inserts a single fixture row with verbose output to diagnose a failing
+// load, converted and checked by the same writer as load
+func (cli *CLI) runDebugRow(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("debug-row")
+	table := fs.String("table", "", "Target table, defaults to the fixture file name")
+	commit := fs.Bool("commit", false, "Keep the row instead of rolling the insert back")
+	location, err := cli.parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	separator := strings.LastIndex(location, ":")
+	file, rowText := location[:max(separator, 0)], location[separator+1:]
+	row, convErr := strconv.Atoi(rowText)
+	if separator < 0 || convErr != nil || row < 1 {
+		return usageErrorf("debug-row requires <file>:<row>, e.g. fixtures/users.json:17")
+	}
+	if *table == "" {
+		*table = fixtureTable(file)
+	}
+	if err := cli.openDatabase(ctx, "debug-row"); err != nil {
+		return err
+	}
+
+	records, origins, err := readFixtureFile(ctx, file)
+	if err != nil {
+		return err
+	}
+	var held []int
+	entries := 0
+	for i, origin := range origins {
+		entries = max(entries, origin.entry)
+		if origin.entry == row {
+			held = append(held, i)
+		}
+	}
+	if row > entries {
+		return usageErrorf("%s has %d rows, row %d does not exist", file, entries, row)
+	}
+	if len(held) != 1 {
+		hint := ""
+		if len(held) > 0 && origins[held[0]].file != file {
+			hint = fmt.Sprintf(", e.g. %s", origins[held[0]])
+		}
+		return usageErrorf("row %d of %s expands into %d records, debug-row replays a row holding a single record%s", row, file, len(held), hint)
+	}
+
+	record := records[held[0]]
+	pretty, _ := json.MarshalIndent(record, "  ", "  ")
+	cli.printf("Row %d of %s:\n  %s", row, file, pretty)
+
+	// Convert and check the row like load does, so the replayed SQL is the SQL that failed
+	writer := cli.fixtureWriter()
+	prepared, _, err := writer.prepare(withFixtureSource(ctx, file, origins[held[0]:held[0]+1]), *table, []Record{record})
+	if err != nil {
+		return err
+	}
+	if len(prepared) == 0 {
+		cli.printf("Row %d is skipped by the lenient fixture mode, nothing to insert", row)
+		return nil
+	}
+	placeholder := writer.placeholder()
+	query, queryArgs, err := BuildInsert(*table, prepared, placeholder)
+	if err != nil {
+		return err
+	}
+	cli.printf("SQL:  %s", query)
+	for i, arg := range queryArgs {
+		cli.printf("  %s = %#v (%T)", placeholder(i+1), arg, arg)
+	}
+
+	tx, err := cli.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, query, queryArgs...); err != nil {
+		tx.Rollback()
+		cli.errorf("Insert failed: %v", err)
+		cli.errorf("Error detail (%T): %+v", err, err)
+		return fmt.Errorf("row %d of %s failed: %w", row, file, err)
+	}
+
+	if !*commit {
+		cli.printf("Row %d inserted successfully, rolled back (use -commit to keep it)", row)
+		return tx.Rollback()
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit row %d: %w", row, err)
+	}
+	cli.printf("Row %d inserted and committed", row)
+	return nil
+}
+
+// runLoad handles "load [<file>] [-table=<name>] [-format=ndjson] [-rows=10:20]
+// [-where=key=value]": inserts the selected records of a fixture file, or of
+// stdin when the file is "-" or omitted
+func (cli *CLI) runLoad(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("load")
+	table := fs.String("table", "", "Target table, defaults to the fixture file name")
+	format := fs.String("format", "", "Format of stdin: json or ndjson, detected when empty")
+	var filter FixtureFilter
+	fs.StringVar(&filter.Rows, "rows", "", "1-based inclusive range of rows to load, e.g. 10:20")
+	fs.StringVar(&filter.Where, "where", "", "Only load records matching key=value or key!=value conditions, e.g. country=ID")
+	file, err := cli.parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	fromStdin := file == "" || file == "-"
+	if fromStdin {
+		file = "stdin"
+		if *table == "" {
+			return usageErrorf("loading from stdin requires -table")
+		}
+	}
+	if *table == "" {
+		*table = fixtureTable(file)
+	}
+	if err := cli.openDatabase(ctx, "load"); err != nil {
+		return err
+	}
+
+	var records []Record
+	var origins []recordOrigin
+	if fromStdin {
+		if records, origins, err = readFixture(ctx, cli.stdin, *format, file); err == nil {
+			err = resolveBinaryValues(records, ".")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse stdin: %w", err)
+		}
+	} else if records, origins, err = readFixtureFile(ctx, file); err != nil {
+		return err
+	}
+	selected, selectedOrigins, err := filter.apply(records, origins)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		cli.printf("No records of %s selected, nothing to load", file)
+		return nil
+	}
+
+	if err := cli.fixtureWriter().WriteFixture(withFixtureSource(ctx, file, selectedOrigins), *table, selected); err != nil {
+		return err
+	}
+	cli.printf("Loaded %d of %d records of %s into %s", len(selected), len(records), file, *table)
+	return nil
+}
+
+// fixtureWriter returns the writer of the load and insert commands
+func (cli *CLI) fixtureWriter() *SQLFixtureWriter {
+	writer := NewSQLFixtureWriter(cli.db, cli.placeholder)
+	writer.Columns = cli.columns
+	writer.Values = cli.values
+	writer.Transforms = cli.transforms
+	return writer
+}
+
+// runInsert handles "insert <table> -set column=value ...": inserts a single
+// row after checking its columns against the table
+func (cli *CLI) runInsert(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("insert")
+	values := keyValueFlag{}
+	fs.Var(values, "set", "Column value as column=value (repeatable); the value null inserts NULL")
+	table, err := cli.parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if table == "" || len(values) == 0 {
+		return usageErrorf("insert requires a table and at least one -set column=value")
+	}
+	if err := cli.openDatabase(ctx, "insert"); err != nil {
+		return err
+	}
+
+	record := Record{}
+	for column, value := range values {
+		if value == "null" {
+			record[column] = nil
 		} else {
-			log.Printf("Unknown seeder type: %s", *seedType)
-			log.Printf("Available seeders: %v", cli.manager.GetRegisteredSeeders())
-			cli.Usage()
-			os.Exit(1)
+			record[column] = value
+		}
+	}
+
+	records := []Record{record}
+	if err := CheckColumns(ctx, cli.db, table, records); err != nil {
+		return err
+	}
+	if err := cli.fixtureWriter().WriteFixture(ctx, table, records); err != nil {
+		return err
+	}
+	cli.printf("Inserted 1 row into %s", table)
+	return nil
+}
+
+// runDelete handles "delete <table> -where=<condition> -limit=100 [-dry-run] [-yes]":
+// previews the matching rows, asks for confirmation and deletes them
+func (cli *CLI) runDelete(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("delete")
+	request := DeleteRequest{}
+	fs.StringVar(&request.Where, "where", "", "SQL condition selecting the rows, e.g. \"email LIKE '%@test.com'\"")
+	fs.IntVar(&request.Limit, "limit", 0, "Maximum rows to delete, required; nothing is deleted when more match")
+	dryRun := fs.Bool("dry-run", false, "Only show the preview")
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt, for scripts")
+	table, err := cli.parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	request.Table = table
+	if table == "" {
+		return usageErrorf("delete requires a table")
+	}
+	if strings.TrimSpace(request.Where) == "" {
+		return usageErrorf("delete requires -where, the condition selecting the rows")
+	}
+	if request.Limit <= 0 {
+		return usageErrorf("delete requires a positive -limit, the most rows it may delete, e.g. -limit=100")
+	}
+	if err := cli.openDatabase(ctx, "delete"); err != nil {
+		return err
+	}
+
+	preview, err := PreviewDelete(ctx, cli.db, request)
+	if err != nil {
+		return err
+	}
+	cli.printf("%d rows of %s match %s (limit %d)", preview.Matches, table, request.Where, request.Limit)
+	if len(preview.Sample) > 0 {
+		cli.printf("  %s", strings.Join(preview.Columns, " | "))
+		for _, row := range preview.Sample {
+			values := make([]string, len(row))
+			for i, value := range row {
+				values[i] = fmt.Sprint(value)
+			}
+			cli.printf("  %s", strings.Join(values, " | "))
+		}
+	}
+
+	switch {
+	case *dryRun:
+		cli.printf("Dry run, nothing deleted")
+		return nil
+	case preview.Matches == 0:
+		cli.printf("Nothing to delete")
+		return nil
+	case preview.Matches > int64(request.Limit):
+		return fmt.Errorf("%d rows match, more than the limit of %d; nothing deleted", preview.Matches, request.Limit)
+	}
+
+	if !*yes {
+		cli.printf("Type the table name (%s) to delete %d rows:", table, preview.Matches)
+		answer, _ := bufio.NewReader(cli.stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != table {
+			return fmt.Errorf("delete not confirmed, nothing deleted")
 		}
 	}
 
+	deleted, err := DeleteRows(ctx, cli.db, request)
+	if err != nil {
+		return err
+	}
+	cli.printf("Deleted %d rows from %s", deleted, table)
+	return nil
+}
+
+// runFmt handles "fmt [-key=id] [-check] <files...>": rewrites fixture files
+// in the canonical layout
+func (cli *CLI) runFmt(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("fmt")
+	key := fs.String("key", "", "Sort records by this column")
+	check := fs.Bool("check", false, "Only report unformatted files and fail if there are any (CI)")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usageErrorf("fmt requires at least one fixture file")
+	}
+
+	var unformatted []string
+	for _, file := range fs.Args() {
+		changed, err := FormatFixtureFile(file, *key, *check)
+		if errors.Is(err, ErrTemplatedFixture) {
+			cli.errorf("Skipped %v", err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if changed {
+			unformatted = append(unformatted, file)
+			if !*check {
+				cli.printf("Formatted %s", file)
+			}
+		}
+	}
+
+	if *check && len(unformatted) > 0 {
+		for _, file := range unformatted {
+			cli.printf("Not formatted: %s", file)
+		}
+		return fmt.Errorf("%d fixture files are not formatted, run fmt", len(unformatted))
+	}
+	return nil
+}
+
+// runFill handles "fill <table>=<count>... [-seed=N]": writes random rows
+// valid for the schema of the tables, see SchemaGenerator
+func (cli *CLI) runFill(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("fill")
+	seed := fs.Int64("seed", 1, "Seed of the random values; the same seed fills the same rows")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usageErrorf("fill requires at least one <table>=<count>, e.g. users=100")
+	}
+	counts := make(map[string]int, fs.NArg())
+	for _, arg := range fs.Args() {
+		table, countText, _ := strings.Cut(arg, "=")
+		count, err := strconv.Atoi(countText)
+		if err != nil || count < 1 || table == "" {
+			return usageErrorf("invalid fill argument %q, expected <table>=<count> with a positive count", arg)
+		}
+		counts[table] = count
+	}
+	if err := cli.openDatabase(ctx, "fill"); err != nil {
+		return err
+	}
+
+	generator := &SchemaGenerator{DB: cli.db, Columns: cli.columns, ForeignKeys: cli.foreignKeys, Values: cli.values, Seed: *seed}
+	if generator.Columns == nil {
+		generator.Columns = PostgresColumns(cli.db)
+	}
+	if generator.ForeignKeys == nil {
+		generator.ForeignKeys = PostgresForeignKeys(cli.db)
+	}
+	written, err := generator.Fill(ctx, cli.fixtureWriter(), counts)
+	for _, table := range slices.Sorted(maps.Keys(written)) {
+		cli.printf("Filled %s with %d rows", table, written[table])
+	}
+	return err
+}
+
+// runOrder handles "order <files...> -write=fixtures/order.json"
+func (cli *CLI) runOrder(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("order")
+	write := fs.String("write", "", "Write the order to this manifest instead of printing it")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usageErrorf("order requires at least one fixture file")
+	}
+
+	lister := cli.foreignKeys
+	if lister == nil {
+		if err := cli.openDatabase(ctx, "order"); err != nil {
+			return err
+		}
+		lister = PostgresForeignKeys(cli.db)
+	}
+	keys, err := lister.ForeignKeys(ctx)
+	if err != nil {
+		return err
+	}
+	files, err := OrderFixtureFiles(fs.Args(), keys)
+	if err != nil {
+		return err
+	}
+
+	if *write != "" {
+		if err := WriteFixtureOrder(*write, files); err != nil {
+			return fmt.Errorf("failed to write fixture order: %w", err)
+		}
+		cli.printf("Wrote the order of %d fixture files to %s", len(files), *write)
+		return nil
+	}
+	for i, file := range files {
+		cli.printf("%3d. %s", i+1, file)
+	}
+	return nil
+}
+
+// runAffected handles "affected [files...]": prints the seeders affected by
+// the changed files, read from stdin when none are given
+func (cli *CLI) runAffected(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("affected")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	changed := fs.Args()
+	if len(changed) == 0 || (len(changed) == 1 && changed[0] == "-") {
+		var err error
+		if changed, err = ReadChangedFiles(cli.stdin); err != nil {
+			return fmt.Errorf("failed to read changed files: %w", err)
+		}
+	}
+	manager, err := managerAs[affectedLister](cli, "affected")
+	if err != nil {
+		return err
+	}
+	for _, name := range manager.AffectedSeeders(changed) {
+		cli.printf("%s", name)
+	}
+	return nil
+}
+
+// runConverge handles "converge": runs the seeders not yet complete and
+// prints the result as JSON on the output, logs going to stderr. It fails
+// only when a seeder failed or the run stopped.
+func (cli *CLI) runConverge(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("converge")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+
+	manager, err := managerAs[converger](cli, "converge")
+	if err != nil {
+		return err
+	}
+	result, err := manager.Converge(ctx)
+	if result != nil {
+		encoder := json.NewEncoder(cli.stdout)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(result); encodeErr != nil && err == nil {
+			err = fmt.Errorf("failed to write the converge result: %w", encodeErr)
+		}
+	}
+	return err
+}
+
+// runDualWrite handles "dual-write -secondary=<dsn> -tables=a,b": seeds the
+// primary database (-primary, default -dsn) and the secondary one, then
+// compares the tables. Differences fail with ErrDriftDetected.
+func (cli *CLI) runDualWrite(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("dual-write")
+	primary := fs.String("primary", "", "DSN of the database seeded first (default -dsn)")
+	secondary := fs.String("secondary", "", "DSN of the database compared with the primary one")
+	tables := fs.String("tables", "", "Comma-separated tables to compare")
+	seeders := fs.String("seeders", "", "Comma-separated seeders to run, in order (default all)")
+	examples := fs.Int("examples", defaultDualWriteExamples, "Differing rows listed per table")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	if *primary == "" {
+		*primary = cli.dsn
+	}
+	if *primary == "" || *secondary == "" || *tables == "" {
+		return usageErrorf("dual-write requires -primary (or -dsn), -secondary and -tables")
+	}
+
+	report, err := VerifyDualWrite(ctx, cli.manager, DualWriteOptions{
+		Primary:   *primary,
+		Secondary: *secondary,
+		Tables:    splitList(*tables),
+		Seeders:   splitList(*seeders),
+		Examples:  *examples,
+	})
+	if err != nil {
+		return err
+	}
+	cli.printDualWriteReport(report)
+	if mismatched := report.Mismatched(); len(mismatched) > 0 {
+		return fmt.Errorf("%w: %d of %d tables differ between the databases: %s",
+			ErrDriftDetected, len(mismatched), len(report.Tables), strings.Join(mismatched, ", "))
+	}
+	return nil
+}
+
+// printDualWriteReport prints the comparison of every table
+func (cli *CLI) printDualWriteReport(report *DualWriteReport) {
+	for _, table := range report.Tables {
+		if table.Identical() {
+			cli.printf("%s: identical (%d rows)", table.Table, table.PrimaryRows)
+			continue
+		}
+		cli.printf("%s: differs (%d primary rows, %d secondary rows)", table.Table, table.PrimaryRows, table.SecondaryRows)
+		if len(table.OnlyPrimary) > 0 {
+			cli.printf("  columns only in primary: %s", strings.Join(table.OnlyPrimary, ", "))
+		}
+		if len(table.OnlySecondary) > 0 {
+			cli.printf("  columns only in secondary: %s", strings.Join(table.OnlySecondary, ", "))
+		}
+		if table.Missing > 0 {
+			cli.printf("  %d rows missing from secondary, e.g.:", table.Missing)
+			for _, row := range table.MissingSamples {
+				cli.printf("    - %s", row)
+			}
+		}
+		if table.Extra > 0 {
+			cli.printf("  %d rows only in secondary, e.g.:", table.Extra)
+			for _, row := range table.ExtraSamples {
+				cli.printf("    + %s", row)
+			}
+		}
+	}
+}
+
+// runFingerprint handles "fingerprint [-root=<dir>]": prints the hash of the
+// seed inputs, for use as a cache key
+func (cli *CLI) runFingerprint(args []string) error {
+	fs := cli.newFlagSet("fingerprint")
+	root := fs.String("root", ".", "Directory the seeders' Paths are relative to")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+
+	manager, err := managerAs[fingerprinter](cli, "fingerprint")
+	if err != nil {
+		return err
+	}
+	fingerprint, err := manager.Fingerprint(*root)
+	if err != nil {
+		return err
+	}
+	cli.printf("%s", fingerprint)
+	return nil
+}
+
+// runGenK8sJob handles "gen-k8s-job -image=<image> [-args=a,b]": prints a
+// Kubernetes Job manifest, or a CronJob with -schedule
+func (cli *CLI) runGenK8sJob(args []string) error {
+	fs := cli.newFlagSet("gen-k8s-job")
+	image := fs.String("image", "", "Image running the seeder binary")
+	jobArgs := fs.String("args", "", "Comma-separated seeder arguments, e.g. -type=all,-timeout=10m")
+	name := fs.String("name", defaultK8sJobName, "Job name")
+	namespace := fs.String("namespace", "", "Namespace of the job (omitted when empty)")
+	schedule := fs.String("schedule", "", "Cron schedule, writes a CronJob instead of a Job")
+	backoffLimit := fs.Int("backoff-limit", defaultK8sBackoffLimit, "Retries before the job fails")
+	ttl := fs.Duration("ttl", defaultK8sTTL*time.Second, "How long a finished job is kept (0 keeps it)")
+	dsnSecret := fs.String("dsn-secret", "", "Secret holding the database URL (default <name>-db)")
+	dsnKey := fs.String("dsn-key", defaultK8sDSNKey, "Key of the database URL in the secret")
+	envFrom := fs.String("env-from", "", "Comma-separated secrets exposed as environment variables")
+	if err := cli.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	if *image == "" {
+		return usageErrorf("gen-k8s-job requires -image")
+	}
+
+	options := K8sJobOptions{
+		Name:         *name,
+		Namespace:    *namespace,
+		Image:        *image,
+		Args:         splitList(*jobArgs),
+		Schedule:     *schedule,
+		BackoffLimit: *backoffLimit,
+		TTLSeconds:   int(ttl.Seconds()),
+		DSNSecret:    *dsnSecret,
+		DSNKey:       *dsnKey,
+		EnvFrom:      splitList(*envFrom),
+	}
+	if options.BackoffLimit == 0 {
+		options.BackoffLimit = -1
+	}
+	if options.TTLSeconds == 0 {
+		options.TTLSeconds = -1
+	}
+	manifest, err := GenerateK8sJob(options)
+	if err != nil {
+		return asUsageError(err)
+	}
+	fmt.Fprint(cli.stdout, manifest)
+	return nil
+}
+
+// runRollback handles "rollback <name>" and "rollback -all": undoes seeded
+// data with the seeders' Rollback functions
+func (cli *CLI) runRollback(ctx context.Context, args []string) error {
+	fs := cli.newFlagSet("rollback")
+	all := fs.Bool("all", false, "Roll back every seeder with a rollback, in the reverse of the order they run in")
+	name, err := cli.parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	manager, err := managerAs[rollbacker](cli, "rollback")
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *all && name != "":
+		return usageErrorf("rollback takes either a seeder name or -all")
+	case *all:
+		cli.printf("Rolling back all seeders")
+		return manager.RollbackAllContext(ctx)
+	case name == "":
+		return usageErrorf("rollback requires a seeder name or -all")
+	case !cli.manager.IsSeederRegistered(name):
+		return usageErrorf("unknown seeder: %s%s", name, didYouMean(name, cli.manager.GetRegisteredSeeders()))
+	}
+	cli.printf("Rolling back seeder: %s", name)
+	return manager.RollbackSeederContext(ctx, name)
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// keyValueFlag collects repeated key=value flags
+type keyValueFlag map[string]string
+
+func (kv keyValueFlag) String() string {
+	return fmt.Sprint(map[string]string(kv))
+}
+
+func (kv keyValueFlag) Set(value string) error {
+	key, val, found := strings.Cut(value, "=")
+	if !found || key == "" {
+		return usageErrorf("expected key=value, got %q", value)
+	}
+	kv[key] = val
+	return nil
+}
+
+// parseFlags parses command flags, marking invalid ones as usage errors,
+// and sets the flags not given from the environment and config file
+func (cli *CLI) parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return asUsageError(err)
+	}
+	if err := cli.applySettings(fs, fs.Name()+"."); err != nil {
+		return err
+	}
+	if cli.checkingConfig {
+		return errConfigChecked
+	}
+	return nil
+}
+
+// parseCommandArgs parses flags for a command taking a single positional
+// argument, allowing flags both before and after it
+func (cli *CLI) parseCommandArgs(fs *flag.FlagSet, args []string) (string, error) {
+	if err := cli.parseFlags(fs, args); err != nil {
+		return "", err
+	}
+	if fs.NArg() == 0 {
+		return "", nil
+	}
+
+	positional := fs.Arg(0)
+	if err := cli.parseFlags(fs, fs.Args()[1:]); err != nil {
+		return "", err
+	}
+	if fs.NArg() > 0 {
+		return "", usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	return positional, nil
+}
+
+// UsageData is the data of a usage template, see CLI.SetUsageTemplate
+type UsageData struct {
+	AppName   string
+	Seeders   []string // Registered seeders, in execution order
+	Scenarios []string
+	Default   string // The built-in usage text
+}
+
+// SetUsageTemplate replaces the usage text with tmpl, executed with
+// UsageData, e.g. to add internal links, environment warnings or team
+// contacts around {{.Default}}
+func (cli *CLI) SetUsageTemplate(tmpl *template.Template) {
+	cli.usageTemplate = tmpl
+}
+
+// scenarios returns the manager's scenarios, none when it has no scenarios
+func (cli *CLI) scenarios() []string {
+	if scenarios, ok := cli.manager.(scenarioRunner); ok {
+		return scenarios.GetRegisteredScenarios()
+	}
 	return nil
 }
 
 // Usage prints the usage information for the seeder
 func (cli *CLI) Usage() {
-	log.Println("=" + strings.Repeat("=", 60))
-	log.Printf("DATABASE SEEDER - %s", strings.ToUpper(cli.appName))
-	log.Println("=" + strings.Repeat("=", 60))
-	log.Println("")
-	log.Println("Usage:")
-	log.Printf("  %s -type=all     # Run all seeders", cli.appName)
-	log.Printf("  %s -type=<name>  # Run specific seeder", cli.appName)
-	log.Printf("  %s               # Show this help", cli.appName)
-	log.Println("")
+	text := cli.defaultUsage()
+	if cli.usageTemplate != nil {
+		var b strings.Builder
+		data := UsageData{
+			AppName:   cli.appName,
+			Seeders:   cli.manager.GetRegisteredSeeders(),
+			Scenarios: cli.scenarios(),
+			Default:   text,
+		}
+		if err := cli.usageTemplate.Execute(&b, data); err != nil {
+			cli.errorf("Usage template failed: %v", err)
+		} else {
+			text = b.String()
+		}
+	}
+
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	io.WriteString(cli.stdout, text)
+}
+
+// defaultUsage returns the built-in usage text
+func (cli *CLI) defaultUsage() string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "="+strings.Repeat("=", 60))
+	fmt.Fprintf(&b, "DATABASE SEEDER - %s\n", strings.ToUpper(cli.appName))
+	fmt.Fprintln(&b, "="+strings.Repeat("=", 60))
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "Usage:")
+	fmt.Fprintf(&b, "  %s -type=all                    # Run all seeders\n", cli.appName)
+	fmt.Fprintf(&b, "  %s -type=<name>                 # Run specific seeder\n", cli.appName)
+	fmt.Fprintf(&b, "  %s -type='user_*'               # Run the seeders matching a glob\n", cli.appName)
+	fmt.Fprintf(&b, "  %s -match='^billing_'           # Run the seeders matching a regexp\n", cli.appName)
+	fmt.Fprintf(&b, "  %s -from-file=seeders.txt       # Run the listed seeders, in that order\n", cli.appName)
+	fmt.Fprintf(&b, "  %s -type=all -parallel=8        # Run independent seeders concurrently\n", cli.appName)
+	fmt.Fprintf(&b, "  %s -phase=post-app              # Run the seeders of a deployment phase\n", cli.appName)
+	fmt.Fprintf(&b, "  %s bootstrap                    # Migrate up, then run all seeders\n", cli.appName)
+	fmt.Fprintf(&b, "  %s bootstrap -profile=<profile> # Run a bootstrap profile (e.g. preview)\n", cli.appName)
+	fmt.Fprintf(&b, "  %s scenario <name>              # Run a scenario\n", cli.appName)
+	fmt.Fprintf(&b, "  %s teardown <name>              # Remove the data a scenario created\n", cli.appName)
+	fmt.Fprintf(&b, "  %s prune -older-than=7d         # Remove expired seeded data\n", cli.appName)
+	fmt.Fprintf(&b, "  %s copy -from=<dsn> -to=<dsn>   # Copy -tables=a,b between environments\n", cli.appName)
+	fmt.Fprintf(&b, "  %s validate                     # Check the seed setup without touching data\n", cli.appName)
+	fmt.Fprintf(&b, "  %s validate -fast               # Static checks only (pre-commit hook)\n", cli.appName)
+	fmt.Fprintf(&b, "  %s verify                       # Validate incl. cross-service relations\n", cli.appName)
+	fmt.Fprintf(&b, "  %s profile -tables=a,b          # Report row counts and column statistics\n", cli.appName)
+	fmt.Fprintf(&b, "  %s debug-row <file>:<row>       # Insert a single fixture row verbosely\n", cli.appName)
+	fmt.Fprintf(&b, "  %s load <file> -where=key=value # Load a fixture file, or a slice of it\n", cli.appName)
+	fmt.Fprintf(&b, "  %s load -table=<name> < rows    # Load records piped to stdin\n", cli.appName)
+	fmt.Fprintf(&b, "  %s insert <table> -set col=val  # Insert one row with inline values\n", cli.appName)
+	fmt.Fprintf(&b, "  %s delete <table> -where=<cond> # Delete -limit=N rows after a preview\n", cli.appName)
+	fmt.Fprintf(&b, "  %s fill users=100 orders=500    # Write random rows valid for the schema\n", cli.appName)
+	fmt.Fprintf(&b, "  %s fmt -key=id <files...>       # Canonicalize fixture files\n", cli.appName)
+	fmt.Fprintf(&b, "  %s order -write=<path> <files>  # Order fixture files by foreign keys\n", cli.appName)
+	fmt.Fprintf(&b, "  %s affected < changed-files     # List the seeders the changed files affect\n", cli.appName)
+	fmt.Fprintf(&b, "  %s converge                     # Seed what is missing, print JSON (provisioners)\n", cli.appName)
+	fmt.Fprintf(&b, "  %s rollback <name>              # Undo a seeder's data (-all: every seeder)\n", cli.appName)
+	fmt.Fprintf(&b, "  %s gen-k8s-job -image=<image>   # Print a Kubernetes Job (-schedule: CronJob)\n", cli.appName)
+	fmt.Fprintf(&b, "  %s fingerprint                  # Print a hash of the seed inputs (cache key)\n", cli.appName)
+	fmt.Fprintf(&b, "  %s dual-write -secondary=<dsn>  # Seed two databases, compare -tables=a,b\n", cli.appName)
+	fmt.Fprintf(&b, "  %s bench <name> -n=5            # Benchmark a seeder\n", cli.appName)
+	fmt.Fprintf(&b, "  %s                              # Show this help\n", cli.appName)
+	fmt.Fprintln(&b)
 
 	// Get registered seeders
 	seeders := cli.manager.GetRegisteredSeeders()
 
 	if len(seeders) == 0 {
-		log.Println("No seeders registered yet.")
-		return
+		fmt.Fprintln(&b, "No seeders registered yet.")
+		return b.String()
 	}
 
-	log.Println("Available seeders (in execution order):")
-	log.Println("-" + strings.Repeat("-", 40))
+	fmt.Fprintln(&b, "Available seeders (in execution order):")
+	fmt.Fprintln(&b, "-"+strings.Repeat("-", 40))
 
 	// Show seeders with numbering
 	for i, name := range seeders {
-		log.Printf("  %d. %s", i+1, name)
-		log.Printf("     Command: %s -type=%s", cli.appName, name)
-		log.Println("")
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, name)
+		fmt.Fprintf(&b, "     Command: %s -type=%s\n", cli.appName, name)
+		fmt.Fprintln(&b)
+	}
+
+	if scenarios := cli.scenarios(); len(scenarios) > 0 {
+		fmt.Fprintln(&b, "Available scenarios:")
+		fmt.Fprintln(&b, "-"+strings.Repeat("-", 40))
+		for _, name := range scenarios {
+			fmt.Fprintf(&b, "  %s\n", name)
+			fmt.Fprintf(&b, "     Command: %s scenario %s\n", cli.appName, name)
+			fmt.Fprintln(&b)
+		}
 	}
 
-	log.Println("Quick commands:")
-	log.Printf("  %s -type=all     # Run all seeders", cli.appName)
-	log.Println("=" + strings.Repeat("=", 60))
+	fmt.Fprintln(&b, "Quick commands:")
+	fmt.Fprintf(&b, "  %s -type=all     # Run all seeders\n", cli.appName)
+	fmt.Fprintln(&b, "="+strings.Repeat("=", 60))
+	return b.String()
 }
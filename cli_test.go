@@ -1,8 +1,13 @@
 package goseeder
 
 import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
 	"strings"
 	"testing"
+	"text/template"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -82,6 +87,51 @@ func TestCLIUsage(t *testing.T) {
 	})
 }
 
+// TestCLIUsageTemplate tests overriding the usage text with a template
+func TestCLIUsageTemplate(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("users", func() error { return nil })
+	cli := NewCLIWithAppName(manager, "my-app")
+	var output bytes.Buffer
+	cli.SetOutput(&output)
+
+	cli.SetUsageTemplate(template.Must(template.New("usage").Parse(
+		"STAGING - data is reset nightly\n{{.Default}}Seeders: {{range .Seeders}}{{.}} {{end}}\nHelp: #seed-support\n")))
+	cli.Usage()
+
+	assert.True(t, strings.HasPrefix(output.String(), "STAGING - data is reset nightly\n====="), output.String())
+	assert.Contains(t, output.String(), "my-app -type=all")
+	assert.True(t, strings.HasSuffix(output.String(), "Seeders: users \nHelp: #seed-support\n"), output.String())
+
+	cli.SetUsageTemplate(template.Must(template.New("usage").Parse("{{.Missing}}")))
+	output.Reset()
+	cli.Usage()
+	assert.Contains(t, output.String(), "Usage template failed")
+	assert.Contains(t, output.String(), "my-app -type=all", "falls back to the built-in usage")
+}
+
+// TestCLISetOutput tests capturing command output and errors
+func TestCLISetOutput(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("users", func() error { return nil })
+	cli := NewCLIWithAppName(manager, "my-app")
+	var stdout, stderr bytes.Buffer
+	cli.SetOutput(&stdout)
+	cli.SetErrorOutput(&stderr)
+
+	cli.Usage()
+	assert.True(t, strings.HasPrefix(stdout.String(), "====="), "no log prefix")
+	assert.Contains(t, stdout.String(), "1. users\n     Command: my-app -type=users\n")
+
+	stdout.Reset()
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"validate", "-fast"}))
+	assert.Equal(t, "Validation passed\n", stdout.String())
+
+	assert.Error(t, cli.runCommand(context.Background(), []string{"bench", "-unknown"}))
+	assert.Contains(t, stderr.String(), "flag provided but not defined: -unknown")
+	assert.NotContains(t, stdout.String(), "flag provided")
+}
+
 // TestCLIRun tests the Run method
 func TestCLIRun(t *testing.T) {
 	t.Run("Run with no type flag shows usage", func(t *testing.T) {
@@ -95,6 +145,30 @@ func TestCLIRun(t *testing.T) {
 		})
 	})
 
+	t.Run("Run leaves flag.CommandLine to the program", func(t *testing.T) {
+		args, commandLine := os.Args, flag.CommandLine
+		defer func() { os.Args, flag.CommandLine = args, commandLine }()
+		flag.CommandLine = flag.NewFlagSet("app", flag.ContinueOnError)
+		flag.String("config", "app.yaml", "Config of the program running the seeders")
+		flag.Bool("force", false, "Flag of the program")
+
+		runs := 0
+		manager := NewSeederManager()
+		manager.RegisterSeeder("test", func() error {
+			runs++
+			return nil
+		})
+		cli := NewCLI(manager)
+		cli.SetOutput(&bytes.Buffer{})
+		os.Args = []string{"app", "-type=test"}
+		assert.NotPanics(t, func() {
+			assert.NoError(t, cli.Run())
+			assert.NoError(t, cli.Run(), "a second run defines its flags again")
+		})
+		assert.Equal(t, 2, runs)
+		assert.Equal(t, "app.yaml", flag.Lookup("config").Value.String())
+	})
+
 	t.Run("Run with type=all", func(t *testing.T) {
 		manager := NewSeederManager()
 		manager.RegisterSeeder("test", func() error { return nil })
@@ -205,3 +279,73 @@ func TestCLIWithRealManager(t *testing.T) {
 		})
 	})
 }
+
+// countingManager decorates a SeederManager to count benchmark calls
+type countingManager struct {
+	*SeederManager
+	benchmarks int
+}
+
+func (cm *countingManager) BenchmarkSeeder(name string, options BenchmarkOptions) (*BenchmarkResult, error) {
+	cm.benchmarks++
+	return cm.SeederManager.BenchmarkSeeder(name, options)
+}
+
+// timedManager decorates a Manager, recording the seeders it runs
+type timedManager struct {
+	Manager
+	ran []string
+}
+
+func (tm *timedManager) RunSeederByNameContext(ctx context.Context, name string) error {
+	tm.ran = append(tm.ran, name)
+	return tm.Manager.RunSeederByNameContext(ctx, name)
+}
+
+func (tm *timedManager) RunAllSeedersContext(ctx context.Context) error {
+	tm.ran = append(tm.ran, "all")
+	return tm.Manager.RunAllSeedersContext(ctx)
+}
+
+// coreManager hides everything of a manager but the Manager interface
+type coreManager struct {
+	Manager
+}
+
+// TestCLIWithDecoratedManager tests that the CLI accepts a Manager decorator
+func TestCLIWithDecoratedManager(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("users", func() error { return nil })
+	decorated := &countingManager{SeederManager: manager}
+
+	cli := NewCLI(decorated)
+	err := cli.runCommand(context.Background(), []string{"bench", "users", "-n=2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, decorated.benchmarks)
+
+	t.Run("Decorated Context run methods", func(t *testing.T) {
+		args := os.Args
+		defer func() { os.Args = args }()
+		decorated := &timedManager{Manager: manager}
+		cli := NewCLI(decorated)
+		cli.SetOutput(&bytes.Buffer{})
+		for _, seedType := range []string{"users", "all"} {
+			os.Args = []string{"app", "-type=" + seedType}
+			assert.NoError(t, cli.Run())
+		}
+		assert.Equal(t, []string{"users", "all"}, decorated.ran)
+	})
+
+	t.Run("Commands needing more than Manager", func(t *testing.T) {
+		var stdout bytes.Buffer
+		cli := NewCLI(coreManager{manager})
+		cli.SetOutput(&stdout)
+		for _, args := range [][]string{{"bench", "users"}, {"scenario", "trial"}, {"rollback", "-all"}, {"validate"}, {"converge"}, {"fingerprint"}, {"affected", "a.json"}} {
+			err := cli.runCommand(context.Background(), args)
+			assert.ErrorContains(t, err, args[0]+" requires a manager supporting it, such as *goseeder.SeederManager, got goseeder.coreManager")
+		}
+		cli.Usage()
+		assert.Contains(t, stdout.String(), "1. users")
+	})
+}
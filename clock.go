@@ -0,0 +1,37 @@
+package goseeder
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time of runs: their IDs, start times and durations,
+// retention expiries, history entries and triage bundles. Tests replace
+// the system clock with SetClock, e.g. with a goseedertest.MockClock, to
+// get stable timestamps.
+type Clock interface {
+	Now() time.Time
+}
+
+// SetClock sets the clock of every run, the system clock by default
+func (sm *SeederManager) SetClock(clock Clock) {
+	sm.clock = clock
+}
+
+// now returns the time of the manager's clock
+func (sm *SeederManager) now() time.Time {
+	if sm.clock != nil {
+		return sm.clock.Now()
+	}
+	return time.Now()
+}
+
+// Now returns the time of the clock of the current run, see SetClock, or
+// the system time outside a run with a clock. Seeders stamping rows with it
+// get the same timestamps as the run's history and report.
+func Now(ctx context.Context) time.Time {
+	if clock, ok := ctx.Value(clockKey).(Clock); ok {
+		return clock.Now()
+	}
+	return time.Now()
+}
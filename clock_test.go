@@ -0,0 +1,57 @@
+package goseeder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedClock is a Clock stopped at a time
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// TestSetClock tests the times of runs taken from the manager's clock
+func TestSetClock(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	t.Run("Stamps runs, history and retention with the clock", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.SetClock(fixedClock(at))
+		manager.SetRetention(time.Hour)
+		history := NewMemoryHistoryStore()
+		manager.SetHistoryStore(history)
+
+		var info RunInfo
+		var retention RetentionInfo
+		var now time.Time
+		require.NoError(t, manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			info, _ = RunInfoFromContext(ctx)
+			retention, _ = RetentionFromContext(ctx)
+			now = Now(ctx)
+			return nil
+		}))
+		require.NoError(t, manager.RunAllSeedersContext(context.Background()))
+
+		assert.Equal(t, at, info.StartedAt)
+		assert.Regexp(t, `^20240301T123000-[0-9a-f]{8}$`, info.ID)
+		assert.Equal(t, at.Add(time.Hour), retention.ExpiresAt)
+		assert.Equal(t, at, now)
+		entries, err := history.Applied(context.Background())
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, at, entries[0].AppliedAt)
+		assert.Equal(t, at, manager.LastRunReport().StartedAt)
+		assert.Zero(t, manager.LastRunReport().Duration, "the run duration is measured with the clock too")
+	})
+
+	t.Run("Now falls back to the system time", func(t *testing.T) {
+		before := time.Now()
+		assert.False(t, Now(context.Background()).Before(before))
+	})
+}
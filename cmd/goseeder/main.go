@@ -0,0 +1,43 @@
+// Command goseeder runs seeders declared in a registration file, for
+// projects that don't want to write their own seeder main.
+//
+// The file is read from $GOSEEDER_CONFIG, defaulting to goseeder.json in the
+// working directory. All other arguments are handled by goseeder.CLI:
+//
+//	goseeder -type=all
+//	goseeder bootstrap -profile=core
+//	goseeder -dsn=postgres://localhost/shop -type=all
+//
+// The binary links no database driver: the -dsn URL (or $DATABASE_URL) is
+// handed to command seeders as $DATABASE_URL.
+package main
+
+import (
+	"log"
+	"os"
+
+	"go.risoftinc.com/goseeder"
+)
+
+func main() {
+	path := os.Getenv("GOSEEDER_CONFIG")
+	if path == "" {
+		path = "goseeder.json"
+	}
+
+	file, err := goseeder.LoadRegistrationFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	manager := goseeder.NewSeederManager()
+	if err := file.Register(manager); err != nil {
+		log.Fatal(err)
+	}
+
+	appName := file.AppName
+	if appName == "" {
+		appName = "goseeder"
+	}
+	os.Exit(goseeder.NewCLIWithAppName(manager, appName).Main())
+}
@@ -0,0 +1,148 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ColumnInfo describes how inserts treat a column
+type ColumnInfo struct {
+	Name       string
+	Type       string // Database type, e.g. "int4", "_text" (text[]) or "int4range" in Postgres
+	Nullable   bool
+	HasDefault bool // The database fills the column when an insert omits it, including identity columns
+	Generated  bool // Computed, or an identity GENERATED ALWAYS; inserts must omit it
+	Composite  bool // Of a Postgres composite type
+}
+
+// ColumnLister lists the columns of a table
+type ColumnLister interface {
+	Columns(ctx context.Context, table string) ([]ColumnInfo, error)
+}
+
+// ColumnListerFunc adapts a function to the ColumnLister interface
+type ColumnListerFunc func(ctx context.Context, table string) ([]ColumnInfo, error)
+
+// Columns calls f(ctx, table)
+func (f ColumnListerFunc) Columns(ctx context.Context, table string) ([]ColumnInfo, error) {
+	return f(ctx, table)
+}
+
+// postgresColumnsQuery lists the columns of a table from information_schema
+const postgresColumnsQuery = `SELECT column_name, udt_name, is_nullable = 'YES',
+  column_default IS NOT NULL OR is_identity = 'YES',
+  is_generated = 'ALWAYS' OR identity_generation = 'ALWAYS',
+  EXISTS (SELECT 1 FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace
+    WHERE t.typname = c.udt_name AND n.nspname = c.udt_schema AND t.typtype = 'c')
+FROM information_schema.columns c
+WHERE table_schema = current_schema() AND table_name = $1
+ORDER BY ordinal_position`
+
+// PostgresColumns lists the columns of tables in the current schema of a
+// Postgres or CockroachDB database
+func PostgresColumns(db *sql.DB) ColumnLister {
+	return ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+		return queryColumns(ctx, db, postgresColumnsQuery, table)
+	})
+}
+
+// mysqlColumnsQuery lists the columns of a table from information_schema.
+// EXTRA holds "VIRTUAL GENERATED" or "STORED GENERATED" for generated
+// columns and "auto_increment" for identity columns.
+const mysqlColumnsQuery = `SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE = 'YES',
+  COLUMN_DEFAULT IS NOT NULL OR EXTRA LIKE '%auto_increment%',
+  EXTRA IN ('VIRTUAL GENERATED', 'STORED GENERATED'), FALSE
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?
+ORDER BY ORDINAL_POSITION`
+
+// MySQLColumns lists the columns of tables in the current database of a
+// MySQL or MariaDB server
+func MySQLColumns(db *sql.DB) ColumnLister {
+	return ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+		return queryColumns(ctx, db, mysqlColumnsQuery, table)
+	})
+}
+
+// queryColumns runs a query returning name, type, nullable, has default,
+// generated and composite for every column of table
+func queryColumns(ctx context.Context, db *sql.DB, query, table string) ([]ColumnInfo, error) {
+	rows, err := db.QueryContext(ctx, query, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	for rows.Next() {
+		var column ColumnInfo
+		if err := rows.Scan(&column.Name, &column.Type, &column.Nullable, &column.HasDefault, &column.Generated, &column.Composite); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s not found", table)
+	}
+	return columns, nil
+}
+
+// applyColumnInfo prepares records for insertion into columns: generated
+// columns are removed, NULLs of NOT NULL columns with a default are
+// removed so the database fills in the default, values of columns with a
+// registered Converter are converted, and arrays and objects of Postgres
+// array, range, composite and PostGIS columns are converted, see
+// postgresValue. Records are copied only when they change.
+func applyColumnInfo(records []Record, columns []ColumnInfo) ([]Record, error) {
+	info := make(map[string]ColumnInfo, len(columns))
+	for _, column := range columns {
+		info[column.Name] = column
+	}
+	omit := func(column string, value any) bool {
+		c, ok := info[column]
+		return ok && (c.Generated || (value == nil && c.HasDefault && !c.Nullable))
+	}
+	convert := func(column string, value any) (any, bool, error) {
+		c := info[column]
+		if converter, ok := converterFor(c.Type); ok && value != nil {
+			converted, err := converter(value)
+			if err != nil {
+				return nil, false, fmt.Errorf("column '%s': %w", column, err)
+			}
+			return converted, true, nil
+		}
+		converted := postgresValue(c.Type, c.Composite, value)
+		switch converted.(type) {
+		case PostgresArray, PostgresRange, PostgresComposite, GeoJSON:
+			return converted, true, nil
+		}
+		return value, false, nil
+	}
+
+	result := make([]Record, len(records))
+	for i, record := range records {
+		result[i] = record
+		prepared := make(Record, len(record))
+		changed := false
+		for column, value := range record {
+			if omit(column, value) {
+				changed = true
+				continue
+			}
+			converted, ok, err := convert(column, value)
+			if err != nil {
+				return nil, fmt.Errorf("record %d: %w", i+1, err)
+			}
+			prepared[column] = converted
+			changed = changed || ok
+		}
+		if changed {
+			result[i] = prepared
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestColumnListers tests reading column metadata from information_schema
+func TestColumnListers(t *testing.T) {
+	db, fake := newFakeDB()
+	ctx := context.Background()
+	expected := []ColumnInfo{
+		{Name: "id", Type: "int4", HasDefault: true, Generated: true},
+		{Name: "email", Type: "text"},
+		{Name: "created_at", Type: "timestamptz", HasDefault: true},
+		{Name: "full_name", Type: "text", Nullable: true, Generated: true},
+		{Name: "address", Type: "address", Nullable: true, Composite: true},
+	}
+
+	fake.On(postgresColumnsQuery, []string{"column_name", "udt_name", "nullable", "has_default", "generated", "composite"},
+		[]driver.Value{"id", "int4", false, true, true, false},
+		[]driver.Value{"email", "text", false, false, false, false},
+		[]driver.Value{"created_at", "timestamptz", false, true, false, false},
+		[]driver.Value{"full_name", "text", true, false, true, false},
+		[]driver.Value{"address", "address", true, false, false, true})
+	columns, err := PostgresColumns(db).Columns(ctx, "users")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, columns)
+	assert.Contains(t, fake.Events(), postgresColumnsQuery+" [users]")
+
+	fake.On(mysqlColumnsQuery, []string{"COLUMN_NAME", "DATA_TYPE", "nullable", "has_default", "generated", "composite"},
+		[]driver.Value{[]byte("id"), []byte("int"), int64(0), int64(1), int64(1), int64(0)},
+		[]driver.Value{[]byte("email"), []byte("varchar"), int64(0), int64(0), int64(0), int64(0)})
+	columns, err = MySQLColumns(db).Columns(ctx, "users")
+	assert.NoError(t, err)
+	assert.Equal(t, []ColumnInfo{
+		{Name: "id", Type: "int", HasDefault: true, Generated: true},
+		{Name: "email", Type: "varchar"},
+	}, columns)
+
+	fake.On(postgresColumnsQuery, []string{"column_name", "udt_name", "nullable", "has_default", "generated", "composite"})
+	_, err = PostgresColumns(db).Columns(ctx, "missing")
+	assert.EqualError(t, err, "table missing not found")
+}
+
+// TestSQLFixtureWriterColumns tests omitting generated columns and NULLs of defaulted columns
+func TestSQLFixtureWriterColumns(t *testing.T) {
+	db, fake := newFakeDB()
+	lister := ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+		return []ColumnInfo{
+			{Name: "id", HasDefault: true, Generated: true},
+			{Name: "email"},
+			{Name: "status", HasDefault: true},
+			{Name: "deleted_at", Nullable: true, HasDefault: true},
+			{Name: "full_name", Nullable: true, Generated: true},
+		}, nil
+	})
+	writer := &SQLFixtureWriter{DB: db, Placeholder: QuestionPlaceholder, Columns: lister}
+	records := []Record{
+		{"id": 1, "email": "a@example.com", "status": nil, "deleted_at": nil, "full_name": "Ana"},
+		{"id": 2, "email": "b@example.com", "status": "banned", "deleted_at": nil},
+	}
+
+	assert.NoError(t, writer.WriteFixture(context.Background(), "users", records))
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO users (deleted_at, email) VALUES (?, ?) [<nil> a@example.com]",
+		"INSERT INTO users (deleted_at, email, status) VALUES (?, ?, ?) [<nil> b@example.com banned]",
+		"COMMIT",
+	}, fake.Events(), "explicit NULLs stay for nullable columns")
+	assert.Equal(t, 1, records[0]["id"], "records of the caller are not modified")
+}
+
+// TestCLIInsertWithColumnLister tests that insert uses column defaults for null values
+func TestCLIInsertWithColumnLister(t *testing.T) {
+	db, fake := newFakeDB()
+	fake.On("SELECT * FROM users WHERE 1 = 0", []string{"id", "name", "status"})
+	cli := NewCLI(NewSeederManager())
+	cli.SetDB(db)
+	cli.SetColumnLister(ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+		return []ColumnInfo{{Name: "id"}, {Name: "name"}, {Name: "status", HasDefault: true}}, nil
+	}))
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"insert", "users", "-set", "name=Test", "-set", "status=null"}))
+	assert.Contains(t, fake.Events(), "INSERT INTO users (name) VALUES ($1) [Test]")
+}
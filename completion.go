@@ -0,0 +1,218 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCompletionTimeout is returned by WaitForCompletion when the marker
+// does not appear in time
+var ErrCompletionTimeout = errors.New("timed out waiting for seeder completion")
+
+// completionPollInterval is how often WaitForCompletion checks the store
+var completionPollInterval = time.Second
+
+// CompletionStore records completion markers shared by the services of a
+// fleet, e.g. rows of a table in a shared database. Keys have the form
+// "<service>/<seeder>".
+type CompletionStore interface {
+	MarkComplete(ctx context.Context, key string) error
+	IsComplete(ctx context.Context, key string) (bool, error)
+	// ClearComplete removes the marker key, doing nothing if it is absent
+	ClearComplete(ctx context.Context, key string) error
+}
+
+// SetCompletionStore makes the manager mark every seeder that completes
+// successfully as "<service>/<seeder>" in store, and lets seeders wait for
+// other services' markers with WaitForCompletion
+func (sm *SeederManager) SetCompletionStore(service string, store CompletionStore) {
+	sm.completionService = service
+	sm.completionStore = store
+}
+
+// markComplete records the completion marker of a seeder, if a store is set
+func (sm *SeederManager) markComplete(ctx context.Context, name string) error {
+	if sm.completionStore == nil {
+		return nil
+	}
+	key := sm.completionService + "/" + name
+	if err := sm.completionStore.MarkComplete(ctx, key); err != nil {
+		return fmt.Errorf("failed to mark '%s' complete: %w", key, err)
+	}
+	return nil
+}
+
+// WaitForCompletion blocks until the marker key ("<service>/<seeder>") is
+// present in the run's completion store, timeout elapses or ctx is done.
+// It returns ErrCompletionTimeout on timeout.
+func WaitForCompletion(ctx context.Context, key string, timeout time.Duration) error {
+	store, ok := ctx.Value(completionStoreKey).(CompletionStore)
+	if !ok {
+		return fmt.Errorf("waiting for '%s' requires a completion store, see SeederManager.SetCompletionStore", key)
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(completionPollInterval)
+	defer ticker.Stop()
+
+	logged := false
+	for {
+		done, err := store.IsComplete(ctx, key)
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("failed to check completion of '%s': %w", key, err)
+		}
+		if done {
+			return nil
+		}
+		if !logged {
+			logInfo(ctx, "Waiting for '%s' to complete (timeout %s)", key, timeout)
+			logged = true
+		}
+
+		select {
+		case <-ctx.Done():
+			// A deadline of the caller's context is not our timeout
+			if err := parent.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("%w: '%s' after %s", ErrCompletionTimeout, key, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// MemoryCompletionStore keeps completion markers in memory, for tests and
+// single-process setups
+type MemoryCompletionStore struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+// NewMemoryCompletionStore creates an empty in-memory completion store
+func NewMemoryCompletionStore() *MemoryCompletionStore {
+	return &MemoryCompletionStore{keys: make(map[string]bool)}
+}
+
+// MarkComplete implements CompletionStore
+func (s *MemoryCompletionStore) MarkComplete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = true
+	return nil
+}
+
+// IsComplete implements CompletionStore
+func (s *MemoryCompletionStore) IsComplete(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys[key], nil
+}
+
+// ClearComplete implements CompletionStore
+func (s *MemoryCompletionStore) ClearComplete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+	return nil
+}
+
+// DefaultCompletionTable is the table SQLCompletionStore keeps markers in
+const DefaultCompletionTable = "seeder_completions"
+
+// SQLCompletionStore keeps completion markers in a table of a database
+// shared by the services of a fleet, created on first use:
+//
+//	CREATE TABLE IF NOT EXISTS seeder_completions (
+//		marker VARCHAR(255) PRIMARY KEY,
+//		completed_at TIMESTAMP NOT NULL
+//	)
+type SQLCompletionStore struct {
+	DB          *sql.DB
+	Placeholder Placeholder // Defaults to DollarPlaceholder
+	Table       string      // Defaults to DefaultCompletionTable
+
+	mu      sync.Mutex
+	created bool
+}
+
+// NewSQLCompletionStore creates a completion store writing to db
+func NewSQLCompletionStore(db *sql.DB, placeholder Placeholder) *SQLCompletionStore {
+	return &SQLCompletionStore{DB: db, Placeholder: placeholder}
+}
+
+// table returns the table name, creating the table on first use
+func (s *SQLCompletionStore) table(ctx context.Context) (string, error) {
+	table := s.Table
+	if table == "" {
+		table = DefaultCompletionTable
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.created {
+		query := "CREATE TABLE IF NOT EXISTS " + table + " (marker VARCHAR(255) PRIMARY KEY, completed_at TIMESTAMP NOT NULL)"
+		if _, err := s.DB.ExecContext(ctx, query); err != nil {
+			return "", fmt.Errorf("failed to create table %s: %w", table, err)
+		}
+		s.created = true
+	}
+	return table, nil
+}
+
+// placeholder returns the n-th bind parameter
+func (s *SQLCompletionStore) placeholder(n int) string {
+	if s.Placeholder == nil {
+		return DollarPlaceholder(n)
+	}
+	return s.Placeholder(n)
+}
+
+// MarkComplete implements CompletionStore, replacing an earlier marker in
+// a transaction as upserts differ between databases
+func (s *SQLCompletionStore) MarkComplete(ctx context.Context, key string) error {
+	table, err := s.table(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE marker = "+s.placeholder(1), key); err != nil {
+		return err
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (marker, completed_at) VALUES (%s, %s)", table, s.placeholder(1), s.placeholder(2))
+	if _, err := tx.ExecContext(ctx, insert, key, Now(ctx).UTC()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// IsComplete implements CompletionStore
+func (s *SQLCompletionStore) IsComplete(ctx context.Context, key string) (bool, error) {
+	table, err := s.table(ctx)
+	if err != nil {
+		return false, err
+	}
+	var count int64
+	err = s.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE marker = "+s.placeholder(1), key).Scan(&count)
+	return count > 0, err
+}
+
+// ClearComplete implements CompletionStore
+func (s *SQLCompletionStore) ClearComplete(ctx context.Context, key string) error {
+	table, err := s.table(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.DB.ExecContext(ctx, "DELETE FROM "+table+" WHERE marker = "+s.placeholder(1), key)
+	return err
+}
@@ -0,0 +1,113 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastCompletionPolling shortens the poll interval for a test
+func fastCompletionPolling(t *testing.T) {
+	previous := completionPollInterval
+	completionPollInterval = time.Millisecond
+	t.Cleanup(func() { completionPollInterval = previous })
+}
+
+// TestSetCompletionStore tests that completed seeders are marked
+func TestSetCompletionStore(t *testing.T) {
+	store := NewMemoryCompletionStore()
+	manager := NewSeederManager()
+	manager.SetCompletionStore("catalog", store)
+	manager.RegisterSeeder("currencies", func() error { return nil })
+	manager.RegisterSeeder("broken", func() error { return errors.New("boom") })
+
+	assert.NoError(t, manager.RunSeederByName("currencies"))
+	assert.Error(t, manager.RunSeederByName("broken"))
+
+	done, _ := store.IsComplete(context.Background(), "catalog/currencies")
+	assert.True(t, done)
+	done, _ = store.IsComplete(context.Background(), "catalog/broken")
+	assert.False(t, done)
+}
+
+// TestWaitForCompletion tests waiting for another service's marker
+func TestWaitForCompletion(t *testing.T) {
+	fastCompletionPolling(t)
+
+	t.Run("Waits until marked", func(t *testing.T) {
+		store := NewMemoryCompletionStore()
+		manager := NewSeederManager()
+		manager.SetCompletionStore("orders", store)
+		manager.RegisterSeederContext("orders", func(ctx context.Context) error {
+			return WaitForCompletion(ctx, "catalog/currencies", time.Second)
+		})
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			store.MarkComplete(context.Background(), "catalog/currencies")
+		}()
+
+		assert.NoError(t, manager.RunSeederByName("orders"))
+	})
+
+	t.Run("Times out", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.SetCompletionStore("orders", NewMemoryCompletionStore())
+		manager.RegisterSeederContext("orders", func(ctx context.Context) error {
+			return WaitForCompletion(ctx, "catalog/currencies", 20*time.Millisecond)
+		})
+
+		err := manager.RunSeederByName("orders")
+
+		assert.ErrorIs(t, err, ErrCompletionTimeout)
+		assert.Contains(t, err.Error(), "catalog/currencies")
+	})
+
+	t.Run("Caller's deadline is not a timeout", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), completionStoreKey, NewMemoryCompletionStore())
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		err := WaitForCompletion(ctx, "catalog/currencies", time.Second)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, ErrCompletionTimeout)
+	})
+
+	t.Run("Requires store", func(t *testing.T) {
+		err := WaitForCompletion(context.Background(), "catalog/currencies", time.Second)
+
+		assert.ErrorContains(t, err, "SetCompletionStore")
+	})
+}
+
+// TestSQLCompletionStore tests markers kept in a table
+func TestSQLCompletionStore(t *testing.T) {
+	db, fake := newFakeDB()
+	store := NewSQLCompletionStore(db, QuestionPlaceholder)
+	completedAt := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), clockKey, fixedClock(completedAt))
+
+	fake.On("SELECT COUNT(*) FROM seeder_completions WHERE marker = ?", []string{"count"}, []driver.Value{int64(1)})
+
+	require.NoError(t, store.MarkComplete(ctx, "catalog/currencies"))
+	done, err := store.IsComplete(ctx, "catalog/currencies")
+	require.NoError(t, err)
+	assert.True(t, done)
+	require.NoError(t, store.ClearComplete(ctx, "catalog/currencies"))
+
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS seeder_completions (marker VARCHAR(255) PRIMARY KEY, completed_at TIMESTAMP NOT NULL)",
+		"BEGIN",
+		"DELETE FROM seeder_completions WHERE marker = ? [catalog/currencies]",
+		"INSERT INTO seeder_completions (marker, completed_at) VALUES (?, ?) [catalog/currencies " + completedAt.String() + "]",
+		"COMMIT",
+		"SELECT COUNT(*) FROM seeder_completions WHERE marker = ? [catalog/currencies]",
+		"DELETE FROM seeder_completions WHERE marker = ? [catalog/currencies]",
+	}, fake.Events())
+}
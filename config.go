@@ -0,0 +1,220 @@
+package goseeder
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// configFileNames are the CLI config files FindConfigFile looks for, in order
+var configFileNames = []string{"seeder.yaml", "seeder.yml", ".seederrc"}
+
+// FindConfigFile returns the first CLI config file (seeder.yaml, seeder.yml
+// or .seederrc) found in dir or its parent directories
+func FindConfigFile(dir string) (string, bool) {
+	for {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// LoadConfigFile reads a CLI config file and returns its settings keyed by
+// flag name, with command flags keyed as "command.flag". The file is the
+// YAML subset of "flag: value" lines, with command flags indented under the
+// command name:
+//
+//	timeout: 10m
+//	profile:
+//	  tables: users,orders
+func LoadConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	settings := make(map[string]string)
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := stripConfigComment(scanner.Text())
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		indented := text[0] == ' ' || text[0] == '\t'
+		text = strings.TrimSpace(text)
+		if strings.HasPrefix(text, "- ") {
+			return nil, fmt.Errorf("%s:%d: lists are not supported, use a comma-separated value", path, line)
+		}
+
+		key, value, found := strings.Cut(text, ":")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("%s:%d: expected \"flag: value\", got %q", path, line, text)
+		}
+		value, err := unquoteConfigValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		switch {
+		case indented && section == "":
+			return nil, fmt.Errorf("%s:%d: indented %q is not under a command", path, line, key)
+		case indented:
+			key = section + "." + key
+		case value == "":
+			section = key
+			continue
+		default:
+			section = ""
+		}
+		if _, exists := settings[key]; exists {
+			return nil, fmt.Errorf("%s:%d: %q is set twice", path, line, key)
+		}
+		settings[key] = value
+	}
+	return settings, scanner.Err()
+}
+
+// stripConfigComment removes a "#" comment outside quotes from line
+func stripConfigComment(line string) string {
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquoteConfigValue removes the quotes of a quoted config value
+func unquoteConfigValue(value string) (string, error) {
+	switch {
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		return strconv.Unquote(value)
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	case strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'"):
+		return "", fmt.Errorf("unterminated quote in %s", value)
+	}
+	return value, nil
+}
+
+// SetConfigFile loads the CLI config file at path instead of the one found
+// from the working directory. The -config flag takes precedence.
+func (cli *CLI) SetConfigFile(path string) error {
+	settings, err := LoadConfigFile(path)
+	if err != nil {
+		return err
+	}
+	cli.settings = settings
+	return nil
+}
+
+// loadSettings loads the config file given with -config, or else the one
+// found from the working directory, unless SetConfigFile loaded one
+func (cli *CLI) loadSettings(path string) error {
+	if path == "" {
+		if cli.settings != nil {
+			return nil
+		}
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil
+		}
+		var found bool
+		if path, found = FindConfigFile(wd); !found {
+			return nil
+		}
+	}
+	return cli.SetConfigFile(path)
+}
+
+// applySettings sets the flags of fs not given on the command line from the
+// environment, then from the config file. Flags are named prefix+flag in
+// the config file and SEEDER_<PREFIX_FLAG> in the environment, e.g.
+// "profile.tables" and SEEDER_PROFILE_TABLES. When names is not empty,
+// only those flags are set.
+func (cli *CLI) applySettings(fs *flag.FlagSet, prefix string, names ...string) error {
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || given[f.Name] || (len(names) > 0 && !slices.Contains(names, f.Name)) {
+			return
+		}
+		key := prefix + f.Name
+		env := "SEEDER_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+		source := env
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			source = "config " + key
+			value, ok = cli.settings[key]
+		}
+		if ok {
+			if setErr := fs.Set(f.Name, value); setErr != nil {
+				err = usageErrorf("invalid value %q of %s: %v", value, source, setErr)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return cli.checkSettings(fs, prefix, names)
+}
+
+// checkSettings rejects config file keys under prefix that are no flag of
+// fs, or of a command for top-level keys
+func (cli *CLI) checkSettings(fs *flag.FlagSet, prefix string, names []string) error {
+	var unknown []string
+	for key := range cli.settings {
+		name, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		if command, _, nested := strings.Cut(name, "."); nested && prefix == "" {
+			if !slices.Contains(commands, command) {
+				return usageErrorf("config file sets flags of unknown command %q%s", command, didYouMean(command, commands))
+			}
+			continue
+		}
+		if fs.Lookup(name) == nil || (len(names) > 0 && !slices.Contains(names, name)) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	var known []string
+	fs.VisitAll(func(f *flag.Flag) {
+		if len(names) == 0 || slices.Contains(names, f.Name) {
+			known = append(known, f.Name)
+		}
+	})
+	return usageErrorf("config file sets unknown flag %q%s", prefix+unknown[0], didYouMean(unknown[0], known))
+}
@@ -0,0 +1,113 @@
+package goseeder
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadConfigFile tests parsing flat and per-command settings
+func TestLoadConfigFile(t *testing.T) {
+	path := writeFixture(t, "seeder.yaml", `# Shared flags of our Makefile targets
+timeout: 10m
+type: "user_*"   # quoted because of the glob
+
+profile:
+  tables: users,orders
+delete:
+  where: 'email LIKE ''%#test.com'''
+  limit: 100
+match: ^billing_
+`)
+
+	settings, err := LoadConfigFile(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"timeout":        "10m",
+		"type":           "user_*",
+		"profile.tables": "users,orders",
+		"delete.where":   "email LIKE '%#test.com'",
+		"delete.limit":   "100",
+		"match":          "^billing_",
+	}, settings)
+
+	for content, message := range map[string]string{
+		"tables:\n  - users\n":   "lists are not supported",
+		"  tables: users\n":      "not under a command",
+		"timeout\n":              "expected \"flag: value\"",
+		"type: all\ntype: a\n":   "set twice",
+		"type: \"unterminated\n": "unterminated quote",
+	} {
+		_, err := LoadConfigFile(writeFixture(t, "seeder.yaml", content))
+		assert.ErrorContains(t, err, message, content)
+	}
+}
+
+// TestFindConfigFile tests discovering the config file from a subdirectory
+func TestFindConfigFile(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "shop")
+	assert.NoError(t, os.MkdirAll(nested, 0o755))
+
+	_, found := FindConfigFile(nested)
+	assert.False(t, found)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, ".seederrc"), []byte("timeout: 1m\n"), 0o644))
+	path, found := FindConfigFile(nested)
+	assert.True(t, found)
+	assert.Equal(t, filepath.Join(root, ".seederrc"), path)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(nested, "seeder.yaml"), []byte("timeout: 2m\n"), 0o644))
+	path, _ = FindConfigFile(nested)
+	assert.Equal(t, filepath.Join(nested, "seeder.yaml"), path, "the nearest file wins")
+}
+
+// TestCLISettingsPrecedence tests that flags override the environment,
+// which overrides the config file
+func TestCLISettingsPrecedence(t *testing.T) {
+	cli := NewCLI(NewSeederManager())
+	assert.NoError(t, cli.SetConfigFile(writeFixture(t, "seeder.yaml", "fmt:\n  check: true\n  key: id\n")))
+	unformatted := func() string {
+		return writeFixture(t, "users.json", `[{"id":2},{"id":1}]`)
+	}
+
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"fmt", unformatted()}), "not formatted", "config file")
+
+	t.Setenv("SEEDER_FMT_CHECK", "false")
+	file := unformatted()
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"fmt", file}), "environment")
+	formatted, _ := os.ReadFile(file)
+	assert.Equal(t, "[\n  {\n    \"id\": 1\n  },\n  {\n    \"id\": 2\n  }\n]\n", string(formatted), "key from the config file")
+
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"fmt", "-check", unformatted()}), "not formatted", "command line")
+}
+
+// TestCLIUnknownSettings tests rejecting misspelled config file keys
+func TestCLIUnknownSettings(t *testing.T) {
+	cli := NewCLI(NewSeederManager())
+
+	assert.NoError(t, cli.SetConfigFile(writeFixture(t, "seeder.yaml", "fmt:\n  chek: true\n")))
+	err := cli.runCommand(context.Background(), []string{"fmt", "users.json"})
+	assert.ErrorIs(t, err, ErrUsage)
+	assert.ErrorContains(t, err, `config file sets unknown flag "fmt.chek", did you mean 'check'?`)
+
+	global := flag.NewFlagSet("seeder", flag.ContinueOnError)
+	timeout := global.Duration("timeout", 0, "")
+	global.String("type", "", "")
+
+	assert.NoError(t, cli.SetConfigFile(writeFixture(t, "seeder.yaml", "timeout: 5m\nfmt:\n  key: id\n")))
+	assert.NoError(t, cli.applySettings(global, "", "type", "timeout"))
+	assert.Equal(t, 5*time.Minute, *timeout)
+
+	assert.NoError(t, cli.SetConfigFile(writeFixture(t, "seeder.yaml", "timout: 5m\n")))
+	assert.ErrorContains(t, cli.applySettings(global, "", "type", "timeout"), "did you mean 'timeout'?")
+
+	assert.NoError(t, cli.SetConfigFile(writeFixture(t, "seeder.yaml", "fmtt:\n  key: id\n")))
+	assert.ErrorContains(t, cli.applySettings(global, "", "type", "timeout"), `unknown command "fmtt", did you mean 'fmt'?`)
+}
@@ -0,0 +1,61 @@
+package goseeder
+
+import "context"
+
+// contextKey is the type of the context keys defined by this package
+type contextKey int
+
+const (
+	seederNameKey contextKey = iota
+	dependenciesKey
+	scenarioKey
+	outputsKey
+	runKey
+	retentionKey
+	provenanceColumnKey
+	completionStoreKey
+	seedingKey
+	outboxModeKey
+	stopKey
+	dsnKey
+	passwordHasherKey
+	showSecretsKey
+	featureFlagsKey
+	forceRunKey
+	txKey
+	atomicKey
+	primaryOnlyKey
+	fixtureSourceKey
+	fixtureModeKey
+	loggerKey
+	quietKey
+	appendKey
+	quotaKey
+	clockKey
+	environmentKey
+)
+
+// contextValue is a key/value pair injected into every run context
+type contextValue struct {
+	key   any
+	value any
+}
+
+// WithContextValue makes value available to every seeder through
+// ctx.Value(key), e.g. an S3 client, tenant info or feature flags.
+// A value already present in the context passed to a Run*Context method
+// takes precedence. It returns the manager to allow chaining.
+func (sm *SeederManager) WithContextValue(key, value any) *SeederManager {
+	sm.contextValues = append(sm.contextValues, contextValue{key: key, value: value})
+	return sm
+}
+
+// injectContextValues adds the manager's context values missing from ctx
+func (sm *SeederManager) injectContextValues(ctx context.Context) context.Context {
+	for _, cv := range sm.contextValues {
+		if ctx.Value(cv.key) == nil {
+			ctx = context.WithValue(ctx, cv.key, cv.value)
+		}
+	}
+	return ctx
+}
@@ -0,0 +1,95 @@
+package goseeder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testContextKey string
+
+// TestWithContextValue tests context value injection
+func TestWithContextValue(t *testing.T) {
+	t.Run("Seeder reads injected value", func(t *testing.T) {
+		manager := NewSeederManager().WithContextValue(testContextKey("tenant"), "acme")
+		var tenant any
+
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			tenant = ctx.Value(testContextKey("tenant"))
+			return nil
+		})
+
+		err := manager.RunAllSeeders()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "acme", tenant)
+	})
+
+	t.Run("Run context takes precedence", func(t *testing.T) {
+		manager := NewSeederManager().WithContextValue(testContextKey("tenant"), "acme")
+		var tenant any
+
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			tenant = ctx.Value(testContextKey("tenant"))
+			return nil
+		})
+
+		ctx := context.WithValue(context.Background(), testContextKey("tenant"), "globex")
+		err := manager.RunSeederByNameContext(ctx, "users")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "globex", tenant)
+	})
+
+	t.Run("Values are visible to middleware", func(t *testing.T) {
+		manager := NewSeederManager().WithContextValue(testContextKey("flag"), true)
+		var seen any
+
+		manager.Use(func(next SeederFunc) SeederFunc {
+			return func(ctx context.Context) error {
+				seen = ctx.Value(testContextKey("flag"))
+				return next(ctx)
+			}
+		})
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		assert.NoError(t, manager.RunSeederByName("users"))
+		assert.Equal(t, true, seen)
+	})
+}
+
+// TestRunContextCancellation tests that canceled contexts stop runs between seeders
+func TestRunContextCancellation(t *testing.T) {
+	t.Run("RunAllSeedersContext stops after cancel", func(t *testing.T) {
+		manager := NewSeederManager()
+		ctx, cancel := context.WithCancel(context.Background())
+		executed := []string{}
+
+		manager.RegisterSeeder("first", func() error {
+			executed = append(executed, "first")
+			cancel()
+			return nil
+		})
+		manager.RegisterSeeder("second", func() error {
+			executed = append(executed, "second")
+			return nil
+		})
+
+		err := manager.RunAllSeedersContext(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []string{"first"}, executed)
+	})
+
+	t.Run("RunSeedersInOrderContext with canceled context", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("first", func() error { return nil })
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := manager.RunSeedersInOrderContext(ctx, []string{"first"})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,123 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+)
+
+// Converge statuses of a seeder, see ConvergeSeeder
+const (
+	ConvergeApplied   = "applied"   // The seeder ran successfully
+	ConvergeUnchanged = "unchanged" // Applied by an earlier run, see SetHistoryStore and SetCompletionStore
+	ConvergeSkipped   = "skipped"   // ShouldRun returned false or SkipIf true
+	ConvergeFailed    = "failed"    // The seeder returned an error
+	ConvergePending   = "pending"   // Not run, as an earlier seeder failed or the run stopped
+)
+
+// ConvergeResult is the machine-readable outcome of Converge. Its fields
+// and their order are stable, and it holds no timings, so converging an
+// unchanged database prints the same result except for the run ID.
+type ConvergeResult struct {
+	Status  string           `json:"status"`  // "converged" or "failed"
+	Changed bool             `json:"changed"` // At least one seeder was applied
+	RunID   string           `json:"run_id"`
+	Seeders []ConvergeSeeder `json:"seeders"` // In registration order
+	Error   string           `json:"error,omitempty"`
+}
+
+// ConvergeSeeder is the outcome of one seeder in a converge run
+type ConvergeSeeder struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // One of the Converge* statuses
+	Rows   int64  `json:"rows"`   // Reported by the seeder with ReportRows
+	Error  string `json:"error,omitempty"`
+	Reason string `json:"reason,omitempty"` // Why the seeder was skipped, see SeederItem.SkipIf
+}
+
+// Converge brings the database to its seeded state, for provisioning tools
+// such as a Terraform local-exec step run after the database is created.
+// With a history or completion store (see SetHistoryStore and
+// SetCompletionStore) seeders applied by an earlier run are not run again,
+// so converging twice is a no-op; without one, seeders must be idempotent
+// themselves. The returned error is non-nil
+// only when a seeder failed or the run stopped, and the result is set even
+// then.
+func (sm *SeederManager) Converge(ctx context.Context) (result *ConvergeResult, err error) {
+	ctx, end, err := sm.beginRun(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state := ctx.Value(runKey).(*runState)
+	seeders, _ := sm.registry()
+	unchanged := make(map[string]bool)
+	defer func() {
+		err = end(err)
+		result = convergeResult(state, seeders, unchanged, err)
+	}()
+
+	logInfo(ctx, "Converging seeders...")
+	startProgress(ctx, len(seeders))
+	for _, seeder := range seeders {
+		if err := stopCause(ctx); err != nil {
+			return nil, err
+		}
+		done, err := sm.alreadyApplied(ctx, seeder.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !done && sm.completionStore != nil {
+			done, err = sm.completionStore.IsComplete(ctx, sm.completionService+"/"+seeder.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check completion of '%s': %w", seeder.Name, err)
+			}
+		}
+		if done {
+			logInfo(ctx, "Seeder '%s' is already complete", seeder.Name)
+			unchanged[seeder.Name] = true
+			progressDone(ctx)
+			continue
+		}
+		if err := sm.executeSeeder(ctx, seeder); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// convergeResult builds the result of a converge run from its state
+func convergeResult(state *runState, seeders []SeederItem, unchanged map[string]bool, err error) *ConvergeResult {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	outcomes := make(map[string]SeederReport, len(state.seeders))
+	for _, report := range state.seeders {
+		outcomes[report.Name] = report
+	}
+
+	result := &ConvergeResult{Status: "converged", RunID: state.info.ID, Seeders: make([]ConvergeSeeder, 0, len(seeders))}
+	if err != nil {
+		result.Status = "failed"
+		result.Error = err.Error()
+	}
+	for _, seeder := range seeders {
+		entry := ConvergeSeeder{Name: seeder.Name, Status: ConvergePending}
+		report, ran := outcomes[seeder.Name]
+		switch {
+		case unchanged[seeder.Name]:
+			entry.Status = ConvergeUnchanged
+		case !ran:
+		case report.Skipped:
+			entry.Status = ConvergeSkipped
+			entry.Reason = report.Reason
+		case report.Error != "":
+			entry.Status = ConvergeFailed
+			entry.Error = report.Error
+		default:
+			entry.Status = ConvergeApplied
+			entry.Rows = report.Rows
+			result.Changed = true
+		}
+		result.Seeders = append(result.Seeders, entry)
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConverge tests that converging twice with a completion store only
+// applies seeders once
+func TestConverge(t *testing.T) {
+	runs := map[string]int{}
+	seeder := func(name string) SeederItem {
+		return SeederItem{Name: name, ContextFunction: func(ctx context.Context) error {
+			runs[name]++
+			ReportRows(ctx, 2)
+			return nil
+		}}
+	}
+	manager := NewSeederManager()
+	manager.SetCompletionStore("shop", NewMemoryCompletionStore())
+	manager.RegisterSeeders(
+		seeder("roles"),
+		SeederItem{Name: "beta", Function: func() error { return nil }, ShouldRun: func(ctx context.Context) bool { return false }},
+		seeder("users"),
+	)
+
+	result, err := manager.Converge(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "converged", result.Status)
+	assert.True(t, result.Changed)
+	assert.Equal(t, []ConvergeSeeder{
+		{Name: "roles", Status: ConvergeApplied, Rows: 2},
+		{Name: "beta", Status: ConvergeSkipped},
+		{Name: "users", Status: ConvergeApplied, Rows: 2},
+	}, result.Seeders)
+
+	result, err = manager.Converge(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.Changed, "converging again is a no-op")
+	assert.Equal(t, ConvergeUnchanged, result.Seeders[0].Status)
+	assert.Equal(t, ConvergeSkipped, result.Seeders[1].Status)
+	assert.Equal(t, map[string]int{"roles": 1, "users": 1}, runs)
+}
+
+// TestConvergeFailure tests the result of a failed converge
+func TestConvergeFailure(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("roles", func() error { return nil })
+	manager.RegisterSeeder("users", func() error { return errors.New("duplicate key") })
+	manager.RegisterSeeder("orders", func() error { return nil })
+
+	result, err := manager.Converge(context.Background())
+	assert.ErrorContains(t, err, "seeder 'users' failed: duplicate key")
+	assert.Equal(t, "failed", result.Status)
+	assert.Equal(t, err.Error(), result.Error)
+	assert.Equal(t, []ConvergeSeeder{
+		{Name: "roles", Status: ConvergeApplied},
+		{Name: "users", Status: ConvergeFailed, Error: "duplicate key"},
+		{Name: "orders", Status: ConvergePending},
+	}, result.Seeders)
+}
+
+// TestCLIConverge tests the converge command's JSON output
+func TestCLIConverge(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("roles", func() error { return nil })
+	cli := NewCLI(manager)
+	var out bytes.Buffer
+	cli.SetOutput(&out)
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"converge"}))
+	var result ConvergeResult
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &result), "the output is only the JSON result")
+	assert.Equal(t, "converged", result.Status)
+	assert.Equal(t, []ConvergeSeeder{{Name: "roles", Status: ConvergeApplied}}, result.Seeders)
+
+	manager.RegisterSeeder("broken", func() error { return errors.New("boom") })
+	out.Reset()
+	err := cli.runCommand(context.Background(), []string{"converge"})
+	assert.Equal(t, ExitFailure, ExitCode(err))
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &result), "printed on failure too")
+	assert.Equal(t, "failed", result.Status)
+
+	assert.ErrorIs(t, cli.runCommand(context.Background(), []string{"converge", "extra"}), ErrUsage)
+}
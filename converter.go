@@ -0,0 +1,52 @@
+package goseeder
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Converter turns a fixture or factory value into the value inserted into
+// a column of the type it is registered for, see RegisterConverter
+type Converter func(raw any) (driver.Value, error)
+
+// converters holds the converters registered with RegisterConverter by
+// lowercased column type
+var converters = struct {
+	mu    sync.RWMutex
+	types map[string]Converter
+}{types: make(map[string]Converter)}
+
+// RegisterConverter makes SQLFixtureWriter convert values of columns of
+// columnType, as reported by its Columns lister, e.g. "citext", the name
+// of a Postgres enum or "enum" in MySQL, with converter before inserting
+// them. Types match regardless of case, and converters take precedence over
+// the built-in handling of arrays, ranges and geometries; NULLs are not
+// converted. Like database/sql.Register it is meant to be called from init
+// functions and panics on an empty type, a nil converter or a duplicate
+// type.
+func RegisterConverter(columnType string, converter Converter) {
+	converters.mu.Lock()
+	defer converters.mu.Unlock()
+
+	key := strings.ToLower(columnType)
+	if key == "" {
+		panic("goseeder: RegisterConverter column type cannot be empty")
+	}
+	if converter == nil {
+		panic(fmt.Sprintf("goseeder: RegisterConverter converter for '%s' is nil", columnType))
+	}
+	if _, exists := converters.types[key]; exists {
+		panic(fmt.Sprintf("goseeder: RegisterConverter called twice for column type '%s'", columnType))
+	}
+	converters.types[key] = converter
+}
+
+// converterFor returns the converter registered for columnType
+func converterFor(columnType string) (Converter, bool) {
+	converters.mu.RLock()
+	defer converters.mu.RUnlock()
+	converter, ok := converters.types[strings.ToLower(columnType)]
+	return converter, ok
+}
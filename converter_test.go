@@ -0,0 +1,73 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	RegisterConverter("test_citext", func(raw any) (driver.Value, error) {
+		return strings.ToLower(fmt.Sprint(raw)), nil
+	})
+	RegisterConverter("Test_Status", func(raw any) (driver.Value, error) {
+		status, ok := raw.(string)
+		if !ok || (status != "active" && status != "banned") {
+			return nil, fmt.Errorf("invalid status %v", raw)
+		}
+		return status, nil
+	})
+}
+
+// TestRegisterConverter tests converting values of registered column types
+func TestRegisterConverter(t *testing.T) {
+	db, fake := newFakeDB()
+	lister := ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+		return []ColumnInfo{
+			{Name: "email", Type: "test_citext"},
+			{Name: "status", Type: "test_status", Nullable: true},
+			{Name: "name", Type: "text"},
+		}, nil
+	})
+	writer := &SQLFixtureWriter{DB: db, Placeholder: QuestionPlaceholder, Columns: lister}
+	records := []Record{
+		{"email": "Ana@Example.com", "status": "active", "name": "Ana"},
+		{"email": "BOB@example.com", "status": nil, "name": "Bob"},
+	}
+
+	assert.NoError(t, writer.WriteFixture(context.Background(), "users", records))
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO users (email, name, status) VALUES (?, ?, ?), (?, ?, ?) [ana@example.com Ana active bob@example.com Bob <nil>]",
+		"COMMIT",
+	}, fake.Events(), "NULLs are not converted")
+	assert.Equal(t, "Ana@Example.com", records[0]["email"], "records of the caller are not modified")
+
+	err := writer.WriteFixture(context.Background(), "users", []Record{{"email": "a@example.com", "status": "gone"}})
+	assert.EqualError(t, err, "failed to convert records of users: record 1: column 'status': invalid status gone")
+}
+
+// TestRegisterConverterPanics tests rejecting invalid registrations
+func TestRegisterConverterPanics(t *testing.T) {
+	identity := func(raw any) (driver.Value, error) { return raw, nil }
+	assert.PanicsWithValue(t, "goseeder: RegisterConverter column type cannot be empty", func() {
+		RegisterConverter("", identity)
+	})
+	assert.PanicsWithValue(t, "goseeder: RegisterConverter converter for 'test_nil' is nil", func() {
+		RegisterConverter("test_nil", nil)
+	})
+	assert.PanicsWithValue(t, "goseeder: RegisterConverter called twice for column type 'TEST_CITEXT'", func() {
+		RegisterConverter("TEST_CITEXT", identity)
+	})
+
+	_, ok := converterFor("test_unknown")
+	assert.False(t, ok)
+	converter, ok := converterFor("TEST_STATUS")
+	assert.True(t, ok)
+	_, err := converter(42)
+	assert.EqualError(t, err, "invalid status 42")
+}
@@ -0,0 +1,121 @@
+package goseeder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// CopyRequest describes which tables a Copier must copy from one environment
+// to another, e.g. to make a preview environment look like staging
+type CopyRequest struct {
+	From       string     // DSN of the environment rows are read from
+	To         string     // DSN of the environment rows are written to
+	Tables     []string   // Tables to copy, in order
+	Anonymizer Anonymizer // Applied to every copied row, nil when anonymization is off
+}
+
+// AnonymizeRow applies the request's Anonymizer to row, if any
+func (cr CopyRequest) AnonymizeRow(table string, row map[string]any) {
+	if cr.Anonymizer != nil {
+		cr.Anonymizer(table, row)
+	}
+}
+
+// Copier copies tables between environments and reports how many rows it copied
+type Copier interface {
+	Copy(ctx context.Context, request CopyRequest) (int64, error)
+}
+
+// CopierFunc adapts a function to the Copier interface
+type CopierFunc func(ctx context.Context, request CopyRequest) (int64, error)
+
+// Copy calls f(ctx, request)
+func (f CopierFunc) Copy(ctx context.Context, request CopyRequest) (int64, error) {
+	return f(ctx, request)
+}
+
+// SQLCopier is the Copier the copy command uses by default. It opens both
+// environments with OpenDSN, reads every table with a SQLSource and inserts
+// its rows with a SQLFixtureWriter, table by table in the order requested.
+// Every batch is inserted in its own transaction, so a failed copy keeps
+// the batches written before it.
+type SQLCopier struct {
+	BatchSize int // Rows per insert transaction, default 100
+}
+
+// Copy implements Copier
+func (c SQLCopier) Copy(ctx context.Context, request CopyRequest) (int64, error) {
+	for _, table := range request.Tables {
+		if !identifierPattern.MatchString(table) {
+			return 0, fmt.Errorf("invalid table name %q", table)
+		}
+	}
+	from, _, err := OpenDSN(ctx, request.From)
+	if err != nil {
+		return 0, fmt.Errorf("source: %w", err)
+	}
+	defer from.Close()
+	to, placeholder, err := OpenDSN(ctx, request.To)
+	if err != nil {
+		return 0, fmt.Errorf("target: %w", err)
+	}
+	defer to.Close()
+
+	writer := NewSQLFixtureWriter(to, placeholder)
+	writer.BatchSize = c.BatchSize
+	var copied int64
+	for _, table := range request.Tables {
+		source := NewSQLSource(from, "SELECT * FROM "+table)
+		sink := SinkFunc(func(ctx context.Context, records []Record) error {
+			return writer.WriteFixture(ctx, table, records)
+		})
+		n, err := Transfer(ctx, source, sink, TransferOptions{
+			BatchSize: c.BatchSize,
+			Transform: func(record Record) (Record, bool, error) {
+				request.AnonymizeRow(table, record)
+				return record, true, nil
+			},
+		})
+		copied += n
+		if err != nil {
+			return copied, fmt.Errorf("failed to copy %s: %w", table, err)
+		}
+		logInfo(ctx, "Copied %d rows of %s", n, table)
+	}
+	return copied, nil
+}
+
+// Anonymizer rewrites the sensitive columns of a copied row in place
+type Anonymizer func(table string, row map[string]any)
+
+// AnonymizeColumns returns an Anonymizer replacing column values using the
+// given rules. Rules are keyed by "table.column", or by "column" to apply to
+// every table; a table-qualified rule wins. Nil values are left untouched.
+func AnonymizeColumns(rules map[string]func(value any) any) Anonymizer {
+	return func(table string, row map[string]any) {
+		for column, value := range row {
+			if value == nil {
+				continue
+			}
+			replace, ok := rules[table+"."+column]
+			if !ok {
+				replace, ok = rules[column]
+			}
+			if ok {
+				row[column] = replace(value)
+			}
+		}
+	}
+}
+
+// Pseudonym returns a replacement function mapping every value to prefix
+// followed by a hash of the value. Equal inputs give equal outputs, so
+// unique constraints and joins on the column keep working.
+func Pseudonym(prefix string) func(value any) any {
+	return func(value any) any {
+		sum := sha256.Sum256([]byte(fmt.Sprint(value)))
+		return prefix + hex.EncodeToString(sum[:6])
+	}
+}
@@ -0,0 +1,130 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAnonymizeColumns tests column anonymization rules
+func TestAnonymizeColumns(t *testing.T) {
+	anonymizer := AnonymizeColumns(map[string]func(value any) any{
+		"email":          Pseudonym("user-"),
+		"customers.name": func(any) any { return "Customer" },
+	})
+
+	customer := map[string]any{"name": "Alice", "email": "alice@example.com", "phone": nil}
+	anonymizer("customers", customer)
+
+	assert.Equal(t, "Customer", customer["name"])
+	assert.NotEqual(t, "alice@example.com", customer["email"])
+	assert.Contains(t, customer["email"], "user-")
+	assert.Nil(t, customer["phone"])
+
+	employee := map[string]any{"name": "Bob", "email": "alice@example.com"}
+	anonymizer("employees", employee)
+
+	assert.Equal(t, "Bob", employee["name"], "table-qualified rule only applies to its table")
+	assert.Equal(t, customer["email"], employee["email"], "pseudonyms are deterministic")
+}
+
+// TestCopyRequestAnonymizeRow tests that rows are untouched without an anonymizer
+func TestCopyRequestAnonymizeRow(t *testing.T) {
+	row := map[string]any{"email": "alice@example.com"}
+
+	CopyRequest{}.AnonymizeRow("users", row)
+
+	assert.Equal(t, "alice@example.com", row["email"])
+}
+
+// TestSQLCopier tests copying tables between DSNs
+func TestSQLCopier(t *testing.T) {
+	t.Run("Copies tables in order, anonymized", func(t *testing.T) {
+		fake := withTestScheme(t)
+		fake.On("SELECT * FROM plans", []string{"id"}, []driver.Value{int64(1)})
+		fake.On("SELECT * FROM customers", []string{"email", "id"},
+			[]driver.Value{[]byte("alice@example.com"), int64(1)}, []driver.Value{[]byte("bob@example.com"), int64(2)})
+		anonymizer := AnonymizeColumns(map[string]func(value any) any{"email": func(any) any { return "x" }})
+
+		copied, err := SQLCopier{}.Copy(context.Background(), CopyRequest{
+			From: "seedtest://staging", To: "seedtest://preview", Tables: []string{"plans", "customers"}, Anonymizer: anonymizer,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), copied)
+		var writes []string
+		for _, event := range fake.Events() {
+			if strings.HasPrefix(event, "INSERT") {
+				writes = append(writes, event)
+			}
+		}
+		assert.Equal(t, []string{
+			"INSERT INTO plans (id) VALUES (?) [1]",
+			"INSERT INTO customers (email, id) VALUES (?, ?), (?, ?) [x 1 x 2]",
+		}, writes)
+	})
+
+	t.Run("Rejects invalid tables and DSNs", func(t *testing.T) {
+		withTestScheme(t)
+
+		_, err := SQLCopier{}.Copy(context.Background(), CopyRequest{From: "seedtest://a", To: "seedtest://b", Tables: []string{"users; --"}})
+		assert.ErrorContains(t, err, "invalid table name")
+		_, err = SQLCopier{}.Copy(context.Background(), CopyRequest{From: "seedtest://a", To: "staging", Tables: []string{"users"}})
+		assert.ErrorContains(t, err, "target: DSN must start with a scheme")
+	})
+}
+
+// TestCLICopyCommand tests the copy command
+func TestCLICopyCommand(t *testing.T) {
+	anonymizer := AnonymizeColumns(map[string]func(value any) any{"email": Pseudonym("")})
+
+	t.Run("Passes request to copier", func(t *testing.T) {
+		var request CopyRequest
+		cli := NewCLI(NewSeederManager())
+		cli.SetCopier(CopierFunc(func(ctx context.Context, r CopyRequest) (int64, error) {
+			request = r
+			return 10, nil
+		}), anonymizer)
+
+		err := cli.runCommand(context.Background(), []string{"copy", "-from=staging", "-to=preview", "-tables=plans, customers"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "staging", request.From)
+		assert.Equal(t, "preview", request.To)
+		assert.Equal(t, []string{"plans", "customers"}, request.Tables)
+		assert.Nil(t, request.Anonymizer)
+	})
+
+	t.Run("Anonymize flag sets anonymizer", func(t *testing.T) {
+		var request CopyRequest
+		cli := NewCLI(NewSeederManager())
+		cli.SetCopier(CopierFunc(func(ctx context.Context, r CopyRequest) (int64, error) {
+			request = r
+			return 0, nil
+		}), anonymizer)
+
+		err := cli.runCommand(context.Background(), []string{"copy", "-from=a", "-to=b", "-tables=users", "-anonymize"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, request.Anonymizer)
+	})
+
+	t.Run("Invalid usage", func(t *testing.T) {
+		cli := NewCLI(NewSeederManager())
+
+		err := cli.runCommand(context.Background(), []string{"copy", "-from=a", "-to=b", "-tables=users"})
+		assert.ErrorContains(t, err, "DSN must start with a scheme", "SQLCopier is the default")
+
+		cli.SetCopier(CopierFunc(func(ctx context.Context, r CopyRequest) (int64, error) {
+			return 0, errors.New("connection refused")
+		}), nil)
+		assert.Error(t, cli.runCommand(context.Background(), []string{"copy", "-from=a", "-to=b"}))
+		assert.Error(t, cli.runCommand(context.Background(), []string{"copy", "-from=a", "-to=b", "-tables=users", "-anonymize"}))
+		assert.Error(t, cli.runCommand(context.Background(), []string{"copy", "-from=a", "-to=b", "-tables=users"}))
+	})
+}
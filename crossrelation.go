@@ -0,0 +1,130 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// crossRelationExamples is the number of missing values listed per relation
+const crossRelationExamples = 5
+
+// CrossRelation declares a logical reference spanning databases, e.g.
+// orders.user_id seeded by the order service pointing at users.id seeded by
+// the account service. No foreign key can enforce it, so VerifyCrossRelation
+// checks it after seeding.
+type CrossRelation struct {
+	Name string       // Reported in issues; "<from> -> <to>" when empty
+	From RelationSide // Referencing column, e.g. orders.user_id
+	To   RelationSide // Referenced column, e.g. users.id
+}
+
+// RelationSide is one end of a CrossRelation
+type RelationSide struct {
+	Service string // Label used in issues, e.g. "accounts"
+	DSN     string // Database URL; the run's DSN (-dsn or DATABASE_URL) when empty
+	Table   string
+	Column  string
+}
+
+// String returns "service:table.column", or "table.column" without a service
+func (rs RelationSide) String() string {
+	if rs.Service == "" {
+		return rs.Table + "." + rs.Column
+	}
+	return rs.Service + ":" + rs.Table + "." + rs.Column
+}
+
+// name returns the relation's name, or "<from> -> <to>"
+func (cr CrossRelation) name() string {
+	if cr.Name != "" {
+		return cr.Name
+	}
+	return cr.From.String() + " -> " + cr.To.String()
+}
+
+// AddCrossRelation registers relation as a validator run by ValidateContext,
+// i.e. by the validate and verify commands but not by validate -fast, since
+// it reads both databases
+func (sm *SeederManager) AddCrossRelation(relation CrossRelation) {
+	sm.AddValidator("cross-relation", func(ctx context.Context) error {
+		return VerifyCrossRelation(ctx, relation)
+	})
+}
+
+// VerifyCrossRelation checks that every non-NULL value of the relation's
+// From column exists in its To column. Missing values are returned as a
+// *ValidationError listing a few of them.
+func VerifyCrossRelation(ctx context.Context, relation CrossRelation) error {
+	from, err := readRelationValues(ctx, relation.From)
+	if err != nil {
+		return err
+	}
+	to, err := readRelationValues(ctx, relation.To)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for value := range from {
+		if _, ok := to[value]; !ok {
+			missing = append(missing, value)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	slices.Sort(missing)
+
+	result := &ValidationError{}
+	examples := strings.Join(missing[:min(len(missing), crossRelationExamples)], ", ")
+	if len(missing) > crossRelationExamples {
+		examples += ", ..."
+	}
+	result.add("cross-relation", "%s: %d value(s) without a match: %s", relation.name(), len(missing), examples)
+	return result
+}
+
+// readRelationValues returns the distinct non-NULL values of side's column,
+// normalized like CompareTables so values read from different engines match
+func readRelationValues(ctx context.Context, side RelationSide) (map[string]struct{}, error) {
+	dsn := side.DSN
+	if dsn == "" {
+		dsn = DSNFromContext(ctx)
+	}
+	if dsn == "" {
+		return nil, fmt.Errorf("no database for %s: set its DSN or pass -dsn", side)
+	}
+	db, _, err := OpenDSN(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the database of %s: %w", side, err)
+	}
+	defer db.Close()
+
+	values, err := queryRelationValues(ctx, db, side.Table, side.Column)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", side, err)
+	}
+	return values, nil
+}
+
+// queryRelationValues reads the distinct non-NULL values of table.column
+func queryRelationValues(ctx context.Context, db *sql.DB, table, column string) (map[string]struct{}, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT DISTINCT %s FROM %s WHERE %s IS NOT NULL", column, table, column))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make(map[string]struct{})
+	for rows.Next() {
+		var value any
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values[normalizeComparable(value)] = struct{}{}
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,55 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVerifyCrossRelation tests checking references between databases
+func TestVerifyCrossRelation(t *testing.T) {
+	fake := withTestScheme(t)
+	ctx := ContextWithDSN(context.Background(), "seedtest://localhost/orders")
+	relation := CrossRelation{
+		From: RelationSide{Service: "orders", Table: "orders", Column: "user_id"},
+		To:   RelationSide{Service: "accounts", DSN: "seedtest://localhost/accounts", Table: "users", Column: "id"},
+	}
+
+	fake.On("SELECT DISTINCT user_id FROM orders WHERE user_id IS NOT NULL", []string{"user_id"},
+		[]driver.Value{int64(1)}, []driver.Value{[]byte("2")})
+	fake.On("SELECT DISTINCT id FROM users WHERE id IS NOT NULL", []string{"id"},
+		[]driver.Value{int64(1)}, []driver.Value{int64(2)}, []driver.Value{int64(3)})
+	assert.NoError(t, VerifyCrossRelation(ctx, relation), "integers match the same IDs read as text")
+	assert.Equal(t, []string{"seedtest://localhost/orders", "seedtest://localhost/accounts"}, testDSNDriver.opened)
+
+	fake.On("SELECT DISTINCT user_id FROM orders WHERE user_id IS NOT NULL", []string{"user_id"},
+		[]driver.Value{int64(1)}, []driver.Value{int64(41)}, []driver.Value{int64(42)})
+	err := VerifyCrossRelation(ctx, relation)
+	assert.EqualError(t, err, "validation failed with 1 issue(s): [cross-relation] orders:orders.user_id -> accounts:users.id: 2 value(s) without a match: 41, 42")
+
+	relation.Name = "order owners"
+	err = VerifyCrossRelation(context.Background(), relation)
+	assert.ErrorContains(t, err, "no database for orders:orders.user_id")
+}
+
+// TestCLIVerifyCommand tests that verify runs cross relations, which validate -fast skips
+func TestCLIVerifyCommand(t *testing.T) {
+	fake := withTestScheme(t)
+	manager := NewSeederManager()
+	manager.AddCrossRelation(CrossRelation{
+		From: RelationSide{Table: "orders", Column: "user_id"},
+		To:   RelationSide{Table: "users", Column: "id"},
+	})
+	cli := NewCLI(manager)
+	ctx := ContextWithDSN(context.Background(), "seedtest://localhost/app")
+
+	fake.On("SELECT DISTINCT user_id FROM orders WHERE user_id IS NOT NULL", []string{"user_id"}, []driver.Value{int64(7)})
+	fake.On("SELECT DISTINCT id FROM users WHERE id IS NOT NULL", []string{"id"}, []driver.Value{int64(1)})
+
+	assert.NoError(t, cli.runCommand(ctx, []string{"validate", "-fast"}))
+	assert.ErrorContains(t, cli.runCommand(ctx, []string{"verify"}), "orders.user_id -> users.id: 1 value(s) without a match: 7")
+	assert.ErrorContains(t, cli.runCommand(ctx, []string{"validate"}), "without a match")
+	assert.Error(t, cli.runCommand(ctx, []string{"verify", "extra"}))
+}
@@ -0,0 +1,72 @@
+package goseeder
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// decimalPattern matches the decimal numbers Decimal accepts
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// Decimal is an exact decimal number such as a price, e.g. "19.99", sent
+// to the database as its text so it never passes through float64. Fixture
+// numbers are exact already (see ReadFixture); use Decimal in factories
+// building records in Go. shopspring/decimal's Decimal works as it is.
+type Decimal string
+
+// NewDecimal returns unscaled / 10^scale, e.g. NewDecimal(1999, 2) is 19.99
+func NewDecimal(unscaled int64, scale int) Decimal {
+	if scale <= 0 {
+		return Decimal(fmt.Sprintf("%d%s", unscaled, strings.Repeat("0", -scale)))
+	}
+	sign := ""
+	digits := fmt.Sprint(unscaled)
+	if unscaled < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return Decimal(sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:])
+}
+
+// Value implements driver.Valuer
+func (d Decimal) Value() (driver.Value, error) {
+	if !decimalPattern.MatchString(string(d)) {
+		return nil, fmt.Errorf("invalid decimal %q", string(d))
+	}
+	return string(d), nil
+}
+
+// MarshalJSON writes the decimal as a JSON number, or as a string when
+// its form is not valid JSON, e.g. ".5"
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !decimalPattern.MatchString(string(d)) {
+		return nil, fmt.Errorf("invalid decimal %q", string(d))
+	}
+	if json.Valid([]byte(d)) {
+		return []byte(d), nil
+	}
+	return json.Marshal(string(d))
+}
+
+// exactNumber returns the exact value of a JSON number, a Decimal or a Go
+// number
+func exactNumber(value any) (*big.Rat, bool) {
+	var text string
+	switch v := value.(type) {
+	case json.Number:
+		text = v.String()
+	case Decimal:
+		text = string(v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		text = fmt.Sprint(v)
+	default:
+		return nil, false
+	}
+	return new(big.Rat).SetString(text)
+}
@@ -0,0 +1,84 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewDecimal tests building decimals from an unscaled value and a scale
+func TestNewDecimal(t *testing.T) {
+	assert.Equal(t, Decimal("19.99"), NewDecimal(1999, 2))
+	assert.Equal(t, Decimal("-0.05"), NewDecimal(-5, 2))
+	assert.Equal(t, Decimal("0.007"), NewDecimal(7, 3))
+	assert.Equal(t, Decimal("7"), NewDecimal(7, 0))
+	assert.Equal(t, Decimal("300"), NewDecimal(3, -2))
+}
+
+// TestDecimalValue tests sending and encoding decimals as their text
+func TestDecimalValue(t *testing.T) {
+	value, err := Decimal("12345678901234567.89").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678901234567.89", value)
+
+	_, err = Decimal("12,50").Value()
+	assert.EqualError(t, err, `invalid decimal "12,50"`)
+
+	encoded, err := json.Marshal(Record{"price": NewDecimal(1999, 2), "fee": Decimal(".5")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"price": 19.99, "fee": ".5"}`, string(encoded))
+
+	_, err = json.Marshal(Decimal("abc"))
+	assert.Error(t, err)
+}
+
+// TestDecimalFixtures tests that fixture numbers reach the database exactly
+func TestDecimalFixtures(t *testing.T) {
+	records, err := ReadFixture(strings.NewReader(`[{"id": 9007199254740993, "price": 12345678901234567.89}]`), FormatJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("12345678901234567.89"), records[0]["price"])
+
+	db, fake := newFakeDB()
+	assert.NoError(t, NewSQLFixtureWriter(db, nil).WriteFixture(context.Background(), "products", records))
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO products (id, price) VALUES ($1, $2) [9007199254740993 12345678901234567.89]",
+		"COMMIT",
+	}, fake.Events())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"price": 0.10000000000000000001}]`)
+	}))
+	defer server.Close()
+	var remote []Record
+	assert.NoError(t, NewJSONSource(nil, server.URL).Read(context.Background(), func(r Record) error {
+		remote = append(remote, r)
+		return nil
+	}))
+	assert.Equal(t, []Record{{"price": json.Number("0.10000000000000000001")}}, remote)
+}
+
+// TestSortRecordsExact tests sorting numbers float64 cannot tell apart
+func TestSortRecordsExact(t *testing.T) {
+	records := []Record{
+		{"id": json.Number("9007199254740993")},
+		{"id": json.Number("9007199254740992")},
+		{"id": Decimal("0.5")},
+		{"id": 3},
+	}
+
+	SortRecords(records, "id")
+
+	assert.Equal(t, []Record{
+		{"id": Decimal("0.5")},
+		{"id": 3},
+		{"id": json.Number("9007199254740992")},
+		{"id": json.Number("9007199254740993")},
+	}, records)
+}
@@ -0,0 +1,119 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// deletePreviewRows is the number of matching rows DeletePreview samples
+const deletePreviewRows = 5
+
+// DeleteRequest describes rows to delete from a table. Where is a raw SQL
+// condition, e.g. "email LIKE '%@test.com'"; it must be set, so a whole
+// table is never deleted by accident.
+type DeleteRequest struct {
+	Table string
+	Where string
+	Limit int // Maximum rows to delete; a request matching more deletes nothing
+}
+
+// validate checks the request before it is used in SQL
+func (dr DeleteRequest) validate() error {
+	if !identifierPattern.MatchString(dr.Table) {
+		return fmt.Errorf("invalid table name %q", dr.Table)
+	}
+	if strings.TrimSpace(dr.Where) == "" {
+		return fmt.Errorf("delete requires a where condition")
+	}
+	if dr.Limit <= 0 {
+		return fmt.Errorf("delete requires a positive limit")
+	}
+	return nil
+}
+
+// DeletePreview is what a DeleteRequest would delete
+type DeletePreview struct {
+	Matches int64    // Rows matching the condition
+	Columns []string // Columns of Sample
+	Sample  [][]any  // Up to five matching rows
+}
+
+// PreviewDelete counts and samples the rows request would delete, without
+// deleting anything
+func PreviewDelete(ctx context.Context, db *sql.DB, request DeleteRequest) (*DeletePreview, error) {
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+
+	preview := &DeletePreview{}
+	count := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", request.Table, request.Where)
+	if err := db.QueryRowContext(ctx, count).Scan(&preview.Matches); err != nil {
+		return nil, fmt.Errorf("failed to count matching rows: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s WHERE %s LIMIT %d", request.Table, request.Where, deletePreviewRows))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample matching rows: %w", err)
+	}
+	defer rows.Close()
+	if preview.Columns, err = rows.Columns(); err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		values := make([]any, len(preview.Columns))
+		pointers := make([]any, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		for i := range values {
+			values[i] = displayValue(values[i])
+		}
+		preview.Sample = append(preview.Sample, values)
+	}
+	return preview, rows.Err()
+}
+
+// DeleteRows deletes the rows matching request in a transaction and returns
+// how many it deleted. Nothing is deleted when more than request.Limit rows
+// match, checked both before and after the DELETE.
+func DeleteRows(ctx context.Context, db *sql.DB, request DeleteRequest) (deleted int64, err error) {
+	if err := request.validate(); err != nil {
+		return 0, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var matches int64
+	count := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", request.Table, request.Where)
+	if err := tx.QueryRowContext(ctx, count).Scan(&matches); err != nil {
+		return 0, fmt.Errorf("failed to count matching rows: %w", err)
+	}
+	if matches > int64(request.Limit) {
+		return 0, fmt.Errorf("%d rows match, more than the limit of %d; nothing deleted", matches, request.Limit)
+	}
+
+	result, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s", request.Table, request.Where))
+	if err != nil {
+		return 0, fmt.Errorf("delete failed: %w", err)
+	}
+	if deleted, err = result.RowsAffected(); err != nil {
+		return 0, err
+	}
+	if deleted > int64(request.Limit) {
+		return 0, fmt.Errorf("delete affected %d rows, more than the limit of %d; rolled back", deleted, request.Limit)
+	}
+	return deleted, tx.Commit()
+}
@@ -0,0 +1,126 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptTestUsers scripts the queries of deleting test users
+func scriptTestUsers(fake *fakeDB, matches int64) {
+	fake.On("SELECT COUNT(*) FROM users WHERE email LIKE '%@test.com'", []string{"count"}, []driver.Value{matches})
+	fake.On("SELECT * FROM users WHERE email LIKE '%@test.com' LIMIT 5", []string{"id", "email"},
+		[]driver.Value{int64(1), []byte("a@test.com")})
+}
+
+// testUsers is the request deleting test users
+var testUsers = DeleteRequest{Table: "users", Where: "email LIKE '%@test.com'", Limit: 100}
+
+// TestPreviewDelete tests previewing a delete
+func TestPreviewDelete(t *testing.T) {
+	db, fake := newFakeDB()
+	scriptTestUsers(fake, 1)
+
+	preview, err := PreviewDelete(context.Background(), db, testUsers)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &DeletePreview{Matches: 1, Columns: []string{"id", "email"}, Sample: [][]any{{int64(1), "a@test.com"}}}, preview)
+	assert.NotContains(t, strings.Join(fake.Events(), "\n"), "DELETE")
+
+	for _, invalid := range []DeleteRequest{
+		{Table: "users", Limit: 1},
+		{Table: "users", Where: "1 = 1"},
+		{Table: "users; --", Where: "1 = 1", Limit: 1},
+	} {
+		_, err := PreviewDelete(context.Background(), db, invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// TestDeleteRows tests deleting within the limit
+func TestDeleteRows(t *testing.T) {
+	t.Run("Within limit", func(t *testing.T) {
+		db, fake := newFakeDB()
+		scriptTestUsers(fake, 1)
+
+		deleted, err := DeleteRows(context.Background(), db, testUsers)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+		assert.Equal(t, []string{
+			"BEGIN",
+			"SELECT COUNT(*) FROM users WHERE email LIKE '%@test.com'",
+			"DELETE FROM users WHERE email LIKE '%@test.com'",
+			"COMMIT",
+		}, fake.Events())
+	})
+
+	t.Run("Over limit deletes nothing", func(t *testing.T) {
+		db, fake := newFakeDB()
+		scriptTestUsers(fake, 101)
+
+		_, err := DeleteRows(context.Background(), db, testUsers)
+
+		assert.ErrorContains(t, err, "101 rows match, more than the limit of 100")
+		assert.Equal(t, []string{"BEGIN", "SELECT COUNT(*) FROM users WHERE email LIKE '%@test.com'", "ROLLBACK"}, fake.Events())
+	})
+}
+
+// TestCLIDeleteCommand tests the preview and confirmation of the delete command
+func TestCLIDeleteCommand(t *testing.T) {
+	args := []string{"delete", "users", "--where", "email LIKE '%@test.com'", "--limit", "100"}
+	deletedRows := func(fake *fakeDB) bool {
+		return strings.Contains(strings.Join(fake.Events(), "\n"), "DELETE FROM")
+	}
+	newCLI := func(matches int64, input string) (*CLI, *fakeDB) {
+		db, fake := newFakeDB()
+		scriptTestUsers(fake, matches)
+		cli := NewCLI(NewSeederManager())
+		cli.SetDB(db)
+		cli.stdin = strings.NewReader(input)
+		return cli, fake
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		cli, fake := newCLI(1, "users\n")
+		assert.NoError(t, cli.runCommand(context.Background(), append(args, "--dry-run")))
+		assert.False(t, deletedRows(fake))
+	})
+
+	t.Run("Confirmed", func(t *testing.T) {
+		cli, fake := newCLI(1, "users\n")
+		assert.NoError(t, cli.runCommand(context.Background(), args))
+		assert.True(t, deletedRows(fake))
+	})
+
+	t.Run("Not confirmed", func(t *testing.T) {
+		cli, fake := newCLI(1, "y\n")
+		assert.ErrorContains(t, cli.runCommand(context.Background(), args), "not confirmed")
+		assert.False(t, deletedRows(fake))
+	})
+
+	t.Run("Yes flag", func(t *testing.T) {
+		cli, fake := newCLI(1, "")
+		assert.NoError(t, cli.runCommand(context.Background(), append(args, "-yes")))
+		assert.True(t, deletedRows(fake))
+	})
+
+	t.Run("Over limit", func(t *testing.T) {
+		cli, fake := newCLI(500, "users\n")
+		assert.ErrorContains(t, cli.runCommand(context.Background(), append(args, "-yes")), "more than the limit")
+		assert.False(t, deletedRows(fake))
+	})
+
+	t.Run("Missing arguments", func(t *testing.T) {
+		cli, _ := newCLI(1, "")
+		assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"delete", "users", "-limit=1"}), "where")
+		err := cli.runCommand(context.Background(), []string{"delete", "users", "-where=id = 1"})
+		assert.ErrorIs(t, err, ErrUsage)
+		assert.ErrorContains(t, err, "delete requires a positive -limit")
+		assert.ErrorIs(t, cli.runCommand(context.Background(), []string{"delete", "users", "-where=id = 1", "-limit=0", "-dry-run"}), ErrUsage)
+		assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"delete"}), "requires a table")
+	})
+}
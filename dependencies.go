@@ -0,0 +1,123 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Dependencies is a typed registry of app-level services (object storage,
+// API clients, ...) that seeders retrieve from the run context
+type Dependencies struct {
+	mu     sync.RWMutex
+	values map[reflect.Type]any
+}
+
+// NewDependencies creates an empty dependency registry
+func NewDependencies() *Dependencies {
+	return &Dependencies{
+		values: make(map[reflect.Type]any),
+	}
+}
+
+// Provide registers values keyed by their concrete type, replacing any
+// value previously registered for the same type
+func (d *Dependencies) Provide(values ...any) *Dependencies {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, value := range values {
+		d.values[reflect.TypeOf(value)] = value
+	}
+	return d
+}
+
+// ProvideAs registers value under type T, typically an interface type
+func ProvideAs[T any](d *Dependencies, value T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.values[reflect.TypeFor[T]()] = value
+}
+
+// DependencyError reports a dependency that GetDependency cannot return
+type DependencyError struct {
+	Type reflect.Type
+	Nil  bool // A nil interface value was registered for Type with ProvideAs
+}
+
+// Error implements the error interface
+func (e *DependencyError) Error() string {
+	if e.Nil {
+		return fmt.Sprintf("dependency of type %s is provided as nil", e.Type)
+	}
+	return fmt.Sprintf("dependency of type %s not provided", e.Type)
+}
+
+// lookup finds the value registered for T, falling back to the single
+// registered value assignable to T
+func lookup[T any](d *Dependencies) (T, error) {
+	var zero T
+	target := reflect.TypeFor[T]()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if value, exists := d.values[target]; exists {
+		typed, ok := value.(T)
+		if !ok {
+			return zero, &DependencyError{Type: target, Nil: true}
+		}
+		return typed, nil
+	}
+
+	var found []any
+	for valueType, value := range d.values {
+		if valueType != nil && valueType.AssignableTo(target) {
+			found = append(found, value)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return zero, &DependencyError{Type: target}
+	case 1:
+		return found[0].(T), nil
+	default:
+		return zero, fmt.Errorf("dependency of type %s is ambiguous: %d candidates, use ProvideAs", target, len(found))
+	}
+}
+
+// WithDependencies makes deps available to every seeder through GetDependency
+func (sm *SeederManager) WithDependencies(deps *Dependencies) *SeederManager {
+	return sm.WithContextValue(dependenciesKey, deps)
+}
+
+// ContextWithDependencies returns a copy of ctx carrying deps
+func ContextWithDependencies(ctx context.Context, deps *Dependencies) context.Context {
+	return context.WithValue(ctx, dependenciesKey, deps)
+}
+
+// DependenciesFromContext returns the registry carried by ctx, or nil
+func DependenciesFromContext(ctx context.Context) *Dependencies {
+	deps, _ := ctx.Value(dependenciesKey).(*Dependencies)
+	return deps
+}
+
+// GetDependency returns the dependency of type T from the run context
+func GetDependency[T any](ctx context.Context) (T, error) {
+	deps := DependenciesFromContext(ctx)
+	if deps == nil {
+		var zero T
+		return zero, fmt.Errorf("no dependencies in context")
+	}
+	return lookup[T](deps)
+}
+
+// MustGetDependency is like GetDependency but panics if T is not provided
+func MustGetDependency[T any](ctx context.Context) T {
+	value, err := GetDependency[T](ctx)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
@@ -0,0 +1,115 @@
+package goseeder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMailer interface {
+	Send(to string) error
+}
+
+type testSMTPMailer struct{ host string }
+
+func (m *testSMTPMailer) Send(to string) error { return nil }
+
+type testStorage struct{ bucket string }
+
+// TestDependencies tests the typed dependency registry
+func TestDependencies(t *testing.T) {
+	t.Run("Get concrete type", func(t *testing.T) {
+		deps := NewDependencies().Provide(&testStorage{bucket: "demo"})
+		ctx := ContextWithDependencies(context.Background(), deps)
+
+		storage, err := GetDependency[*testStorage](ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "demo", storage.bucket)
+	})
+
+	t.Run("Get interface implemented by provided value", func(t *testing.T) {
+		deps := NewDependencies().Provide(&testSMTPMailer{host: "smtp"}, &testStorage{})
+		ctx := ContextWithDependencies(context.Background(), deps)
+
+		mailer, err := GetDependency[testMailer](ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "smtp", mailer.(*testSMTPMailer).host)
+	})
+
+	t.Run("ProvideAs registers interface type", func(t *testing.T) {
+		deps := NewDependencies()
+		ProvideAs[testMailer](deps, &testSMTPMailer{host: "primary"})
+		ctx := ContextWithDependencies(context.Background(), deps)
+
+		mailer := MustGetDependency[testMailer](ctx)
+
+		assert.Equal(t, "primary", mailer.(*testSMTPMailer).host)
+	})
+
+	t.Run("Ambiguous interface", func(t *testing.T) {
+		type otherMailer struct{ testSMTPMailer }
+		deps := NewDependencies().Provide(&testSMTPMailer{}, &otherMailer{})
+		ctx := ContextWithDependencies(context.Background(), deps)
+
+		_, err := GetDependency[testMailer](ctx)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ambiguous")
+	})
+
+	t.Run("Missing dependency", func(t *testing.T) {
+		ctx := ContextWithDependencies(context.Background(), NewDependencies())
+
+		_, err := GetDependency[*testStorage](ctx)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not provided")
+		var dependencyErr *DependencyError
+		assert.ErrorAs(t, err, &dependencyErr)
+		assert.False(t, dependencyErr.Nil)
+		assert.Panics(t, func() { MustGetDependency[*testStorage](ctx) })
+	})
+
+	t.Run("Interface provided as nil", func(t *testing.T) {
+		deps := NewDependencies()
+		ProvideAs[testMailer](deps, nil)
+		ctx := ContextWithDependencies(context.Background(), deps)
+
+		var mailer testMailer
+		var err error
+		assert.NotPanics(t, func() { mailer, err = GetDependency[testMailer](ctx) })
+
+		var dependencyErr *DependencyError
+		assert.ErrorAs(t, err, &dependencyErr)
+		assert.True(t, dependencyErr.Nil)
+		assert.EqualError(t, err, "dependency of type goseeder.testMailer is provided as nil")
+		assert.Nil(t, mailer)
+	})
+
+	t.Run("No registry in context", func(t *testing.T) {
+		_, err := GetDependency[*testStorage](context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+// TestWithDependencies tests wiring dependencies through the manager
+func TestWithDependencies(t *testing.T) {
+	manager := NewSeederManager().WithDependencies(NewDependencies().Provide(&testStorage{bucket: "assets"}))
+	var bucket string
+
+	manager.RegisterSeederContext("avatars", func(ctx context.Context) error {
+		storage, err := GetDependency[*testStorage](ctx)
+		if err != nil {
+			return err
+		}
+		bucket = storage.bucket
+		return nil
+	})
+
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, "assets", bucket)
+}
@@ -0,0 +1,66 @@
+package goseeder
+
+import (
+	"fmt"
+	"sync"
+)
+
+// discovered holds the seeders registered with Register, typically from
+// init functions in files guarded by build tags such as:
+//
+//	//go:build seed_demo
+//
+//	package seeds
+//
+//	func init() {
+//		goseeder.Register(goseeder.SeederItem{Name: "demo_orders", Function: seedDemoOrders, Tags: []string{goseeder.TagDemo}})
+//	}
+//
+// Building without -tags seed_demo leaves the file, and everything only it
+// references, out of the binary.
+var discovered = struct {
+	mu      sync.Mutex
+	seeders []SeederItem
+	names   map[string]bool
+}{names: make(map[string]bool)}
+
+// Register adds seeders to the package-level registry read by
+// DiscoverTagged. Like database/sql.Register it is meant to be called from
+// init functions and panics on an empty or duplicate name.
+func Register(seeders ...SeederItem) {
+	discovered.mu.Lock()
+	defer discovered.mu.Unlock()
+
+	for _, seeder := range seeders {
+		if seeder.Name == "" {
+			panic("goseeder: Register seeder name cannot be empty")
+		}
+		if discovered.names[seeder.Name] {
+			panic(fmt.Sprintf("goseeder: Register called twice for seeder '%s'", seeder.Name))
+		}
+		discovered.names[seeder.Name] = true
+		discovered.seeders = append(discovered.seeders, seeder)
+	}
+}
+
+// DiscoverTagged registers, in Register order, the seeders compiled into the
+// binary that carry at least one of the given tags, or all of them when no
+// tag is given. Seeders already registered with the manager are skipped.
+func (sm *SeederManager) DiscoverTagged(tags ...string) error {
+	discovered.mu.Lock()
+	seeders := append([]SeederItem{}, discovered.seeders...)
+	discovered.mu.Unlock()
+
+	for _, seeder := range seeders {
+		if len(tags) > 0 && !seeder.HasAnyTag(tags...) {
+			continue
+		}
+		if sm.IsSeederRegistered(seeder.Name) {
+			continue
+		}
+		if err := sm.registerItem(seeder); err != nil {
+			return fmt.Errorf("failed to register discovered seeder '%s': %w", seeder.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package goseeder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// resetDiscovered clears the package-level registry after a test
+func resetDiscovered(t *testing.T) {
+	t.Cleanup(func() {
+		discovered.mu.Lock()
+		defer discovered.mu.Unlock()
+		discovered.seeders = nil
+		discovered.names = make(map[string]bool)
+	})
+}
+
+// TestRegister tests the package-level registry
+func TestRegister(t *testing.T) {
+	resetDiscovered(t)
+
+	Register(SeederItem{Name: "countries", Function: func() error { return nil }})
+
+	assert.Panics(t, func() { Register(SeederItem{Name: "countries"}) })
+	assert.Panics(t, func() { Register(SeederItem{}) })
+}
+
+// TestDiscoverTagged tests registering discovered seeders with a manager
+func TestDiscoverTagged(t *testing.T) {
+	resetDiscovered(t)
+	noop := func() error { return nil }
+	Register(
+		SeederItem{Name: "countries", Function: noop, Tags: []string{TagCore}},
+		SeederItem{Name: "demo_orders", Function: noop, Tags: []string{TagDemo}},
+		SeederItem{Name: "demo_reviews", Function: noop, Tags: []string{TagDemo}},
+	)
+
+	t.Run("Filters by tag", func(t *testing.T) {
+		manager := NewSeederManager()
+
+		assert.NoError(t, manager.DiscoverTagged(TagDemo))
+		assert.Equal(t, []string{"demo_orders", "demo_reviews"}, manager.GetRegisteredSeeders())
+	})
+
+	t.Run("Discovers all and skips registered seeders", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("demo_orders", noop)
+
+		assert.NoError(t, manager.DiscoverTagged())
+		assert.Equal(t, []string{"demo_orders", "countries", "demo_reviews"}, manager.GetRegisteredSeeders())
+	})
+}
@@ -0,0 +1,62 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DripOptions configures Drip
+type DripOptions struct {
+	Interval time.Duration // Time between two inserts, e.g. 5s
+	Duration time.Duration // Stop after this long, zero to run until Count or cancellation
+	Count    int           // Stop after this many inserts, zero for no limit
+}
+
+// Drip calls insert gradually, once every Interval, to produce live-looking
+// data for demos, e.g. one order every 5s for an hour. The first insert
+// happens immediately. Drip returns nil when Duration or Count is reached,
+// or when ctx is canceled, so a demo can be stopped cleanly; an insert
+// error stops it early.
+func Drip(ctx context.Context, options DripOptions, insert func(ctx context.Context, i int) error) error {
+	if options.Interval <= 0 {
+		return fmt.Errorf("drip interval must be positive")
+	}
+	if options.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Duration)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(options.Interval)
+	defer ticker.Stop()
+
+	for i := 0; options.Count == 0 || i < options.Count; i++ {
+		if err := insert(ctx, i); err != nil {
+			if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+				break
+			}
+			return fmt.Errorf("drip insert %d failed: %w", i, err)
+		}
+		if i+1 == options.Count {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			logInfo(ctx, "Drip stopped after %d inserts", i+1)
+			return nil
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// DripSeeder returns a seeder running Drip with the run context, so the
+// CLI or a canceled context stops it
+func DripSeeder(options DripOptions, insert func(ctx context.Context, i int) error) SeederFunc {
+	return func(ctx context.Context) error {
+		return Drip(ctx, options, insert)
+	}
+}
@@ -0,0 +1,78 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDrip tests gradual inserts
+func TestDrip(t *testing.T) {
+	t.Run("Stops after count", func(t *testing.T) {
+		inserted := []int{}
+
+		err := Drip(context.Background(), DripOptions{Interval: time.Millisecond, Count: 3}, func(ctx context.Context, i int) error {
+			inserted = append(inserted, i)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2}, inserted)
+	})
+
+	t.Run("Returns after the last insert without waiting", func(t *testing.T) {
+		inserts := 0
+		start := time.Now()
+
+		err := Drip(context.Background(), DripOptions{Interval: time.Hour, Count: 1}, func(ctx context.Context, i int) error {
+			inserts++
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, inserts)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Stops after duration", func(t *testing.T) {
+		inserts := 0
+		start := time.Now()
+
+		err := Drip(context.Background(), DripOptions{Interval: 5 * time.Millisecond, Duration: 30 * time.Millisecond}, func(ctx context.Context, i int) error {
+			inserts++
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+		assert.Greater(t, inserts, 1)
+	})
+
+	t.Run("Cancellation stops cleanly", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		manager := NewSeederManager()
+		inserts := 0
+		manager.RegisterSeederContext("live_orders", DripSeeder(DripOptions{Interval: time.Millisecond}, func(ctx context.Context, i int) error {
+			inserts++
+			if inserts == 2 {
+				cancel()
+			}
+			return nil
+		}))
+
+		assert.NoError(t, manager.RunSeederByNameContext(ctx, "live_orders"))
+		assert.Equal(t, 2, inserts)
+	})
+
+	t.Run("Insert errors and invalid interval", func(t *testing.T) {
+		err := Drip(context.Background(), DripOptions{Interval: time.Millisecond}, func(ctx context.Context, i int) error {
+			return errors.New("duplicate key")
+		})
+		assert.ErrorContains(t, err, "drip insert 0 failed")
+
+		assert.Error(t, Drip(context.Background(), DripOptions{}, nil))
+	})
+}
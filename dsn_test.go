@@ -0,0 +1,100 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dsnTestDriver is a database/sql driver recording the data sources it opens
+type dsnTestDriver struct {
+	mu     sync.Mutex
+	opened []string
+	fake   *fakeDB
+}
+
+func (d *dsnTestDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.opened = append(d.opened, name)
+	return d.fake.Connect(context.Background())
+}
+
+// testDSNDriver is registered once as "goseeder-dsn-test"
+var testDSNDriver = &dsnTestDriver{}
+
+func init() {
+	sql.Register("goseeder-dsn-test", testDSNDriver)
+}
+
+// withTestScheme maps the "seedtest" DSN scheme to the test driver
+func withTestScheme(t *testing.T) *fakeDB {
+	_, fake := newFakeDB()
+	testDSNDriver.mu.Lock()
+	testDSNDriver.fake = fake
+	testDSNDriver.opened = nil
+	testDSNDriver.mu.Unlock()
+
+	dsnDrivers["seedtest"] = dsnDriver{[]string{"goseeder-dsn-test"}, QuestionPlaceholder, "example.com/seedtest"}
+	t.Cleanup(func() { delete(dsnDrivers, "seedtest") })
+	return fake
+}
+
+// TestDetectDriver tests picking drivers and converting DSNs
+func TestDetectDriver(t *testing.T) {
+	withTestScheme(t)
+
+	name, dataSource, placeholder, err := detectDriver("seedtest://localhost/app")
+	assert.NoError(t, err)
+	assert.Equal(t, "goseeder-dsn-test", name)
+	assert.Equal(t, "seedtest://localhost/app", dataSource)
+	assert.Equal(t, "?", placeholder(1))
+
+	_, _, _, err = detectDriver("postgres://localhost/app")
+	assert.ErrorContains(t, err, `no database/sql driver for postgres DSNs is linked in, import one of pgx, postgres, e.g. _ "github.com/jackc/pgx/v5/stdlib"`)
+
+	_, _, _, err = detectDriver("msyql://localhost/app")
+	assert.ErrorContains(t, err, "did you mean 'mysql'?")
+
+	_, _, _, err = detectDriver("localhost/app")
+	assert.ErrorContains(t, err, "must start with a scheme")
+
+	for dsn, expected := range map[string]string{
+		"mysql://root:secret@db:3306/shop?parseTime=true": "root:secret@tcp(db:3306)/shop?parseTime=true",
+		"mysql://db/shop":           "tcp(db)/shop",
+		"sqlite://seed.db":          "seed.db",
+		"sqlite3:///tmp/seed.db":    "/tmp/seed.db",
+		"file:seed.db?cache=shared": "file:seed.db?cache=shared",
+	} {
+		scheme, _, _ := strings.Cut(dsn, ":")
+		dataSource, err := driverDataSource(scheme, dsn)
+		assert.NoError(t, err, dsn)
+		assert.Equal(t, expected, dataSource, dsn)
+	}
+}
+
+// TestCLIDSN tests that commands connect to the -dsn database on demand
+func TestCLIDSN(t *testing.T) {
+	fake := withTestScheme(t)
+	fake.On("SELECT COUNT(*) FROM users WHERE id = 1", []string{"count"}, []driver.Value{int64(0)})
+	fake.On("SELECT * FROM users WHERE id = 1 LIMIT 5", []string{"id"})
+
+	cli := NewCLI(NewSeederManager())
+	cli.dsn = "seedtest://localhost/app"
+	defer cli.closeDatabase()
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"delete", "users", "-where=id = 1", "-limit=1", "-dry-run"}))
+	assert.Equal(t, []string{"seedtest://localhost/app"}, testDSNDriver.opened)
+	assert.Equal(t, "?", cli.placeholder(1), "placeholder of the detected driver")
+
+	cli.closeDatabase()
+	assert.Nil(t, cli.db)
+
+	cli.dsn = ""
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"delete", "users", "-where=id = 1", "-limit=1"}), "requires a database, see -dsn or CLI.SetDB")
+}
@@ -0,0 +1,252 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultDualWriteExamples is the number of differing rows kept per table
+// when DualWriteOptions.Examples is zero
+const defaultDualWriteExamples = 5
+
+// DualWriteOptions configures VerifyDualWrite
+type DualWriteOptions struct {
+	Primary   string   // DSN of the database seeded first, e.g. the current MySQL
+	Secondary string   // DSN of the database compared with it, e.g. the new Postgres
+	Tables    []string // Tables compared after seeding both
+	Seeders   []string // Seeders run, in order; all registered seeders when empty
+	Examples  int      // Differing rows listed per table, default 5
+}
+
+// DualWriteReport is the outcome of VerifyDualWrite
+type DualWriteReport struct {
+	Tables []TableComparison `json:"tables"`
+}
+
+// Mismatched returns the tables whose contents differ
+func (r *DualWriteReport) Mismatched() []string {
+	var tables []string
+	for _, table := range r.Tables {
+		if !table.Identical() {
+			tables = append(tables, table.Table)
+		}
+	}
+	return tables
+}
+
+// TableComparison compares the rows of a table in two databases. Rows are
+// compared as multisets of their normalized values, so row order and
+// representation differences between engines, such as MySQL's 1 and
+// Postgres' true, do not count.
+type TableComparison struct {
+	Table          string   `json:"table"`
+	PrimaryRows    int      `json:"primary_rows"`
+	SecondaryRows  int      `json:"secondary_rows"`
+	OnlyPrimary    []string `json:"only_primary,omitempty"`    // Columns only in the primary table
+	OnlySecondary  []string `json:"only_secondary,omitempty"`  // Columns only in the secondary table
+	Missing        int      `json:"missing"`                   // Primary rows absent from the secondary table
+	Extra          int      `json:"extra"`                     // Secondary rows absent from the primary table
+	MissingSamples []string `json:"missing_samples,omitempty"` // Some missing rows, e.g. "{email: a@b.c, id: 1}"
+	ExtraSamples   []string `json:"extra_samples,omitempty"`
+}
+
+// Identical reports whether both tables have the same columns and rows
+func (tc TableComparison) Identical() bool {
+	return len(tc.OnlyPrimary) == 0 && len(tc.OnlySecondary) == 0 && tc.Missing == 0 && tc.Extra == 0
+}
+
+// VerifyDualWrite runs the seeders against two databases, with the DSN of
+// each in the run context (see DSNFromContext), then compares the tables,
+// e.g. to check that seeds behave identically on the old and new engine
+// during a migration. Seeders must open their database from the context
+// DSN, as command seeders do. The databases should start out empty.
+func VerifyDualWrite(ctx context.Context, manager Manager, options DualWriteOptions) (*DualWriteReport, error) {
+	if options.Primary == "" || options.Secondary == "" {
+		return nil, fmt.Errorf("dual-write verification requires a primary and a secondary DSN")
+	}
+	if len(options.Tables) == 0 {
+		return nil, fmt.Errorf("dual-write verification requires the tables to compare")
+	}
+
+	for _, target := range []struct{ name, dsn string }{{"primary", options.Primary}, {"secondary", options.Secondary}} {
+		logInfo(ctx, "Seeding the %s database", target.name)
+		// Both runs use the manager's history and completion markers, which
+		// would otherwise skip every seeder of the second run as applied
+		runCtx := ContextWithForceRun(ContextWithDSN(ctx, target.dsn))
+		var err error
+		if len(options.Seeders) > 0 {
+			err = manager.RunSeedersInOrderContext(runCtx, options.Seeders)
+		} else {
+			err = manager.RunAllSeedersContext(runCtx)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("seeding the %s database failed: %w", target.name, err)
+		}
+	}
+
+	primary, _, err := OpenDSN(ctx, options.Primary)
+	if err != nil {
+		return nil, fmt.Errorf("primary database: %w", err)
+	}
+	defer primary.Close()
+	secondary, _, err := OpenDSN(ctx, options.Secondary)
+	if err != nil {
+		return nil, fmt.Errorf("secondary database: %w", err)
+	}
+	defer secondary.Close()
+
+	return CompareTables(ctx, primary, secondary, options.Tables, options.Examples)
+}
+
+// CompareTables compares the contents of tables in two databases, listing
+// up to examples differing rows per table (default 5)
+func CompareTables(ctx context.Context, primary, secondary *sql.DB, tables []string, examples int) (*DualWriteReport, error) {
+	if examples <= 0 {
+		examples = defaultDualWriteExamples
+	}
+	report := &DualWriteReport{}
+	for _, table := range tables {
+		primaryColumns, primaryRows, err := readComparableRows(ctx, primary, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from the primary database: %w", table, err)
+		}
+		secondaryColumns, secondaryRows, err := readComparableRows(ctx, secondary, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from the secondary database: %w", table, err)
+		}
+
+		comparison := TableComparison{Table: table, PrimaryRows: len(primaryRows), SecondaryRows: len(secondaryRows)}
+		common := make([]string, 0, len(primaryColumns))
+		for _, column := range primaryColumns {
+			if slices.Contains(secondaryColumns, column) {
+				common = append(common, column)
+			} else {
+				comparison.OnlyPrimary = append(comparison.OnlyPrimary, column)
+			}
+		}
+		for _, column := range secondaryColumns {
+			if !slices.Contains(primaryColumns, column) {
+				comparison.OnlySecondary = append(comparison.OnlySecondary, column)
+			}
+		}
+
+		remaining := make(map[string]int)
+		for _, row := range secondaryRows {
+			remaining[formatComparableRow(row, common)]++
+		}
+		for _, row := range primaryRows {
+			key := formatComparableRow(row, common)
+			if remaining[key] > 0 {
+				remaining[key]--
+				continue
+			}
+			comparison.Missing++
+			if len(comparison.MissingSamples) < examples {
+				comparison.MissingSamples = append(comparison.MissingSamples, key)
+			}
+		}
+		var extra []string
+		for key, count := range remaining {
+			comparison.Extra += count
+			for range count {
+				extra = append(extra, key)
+			}
+		}
+		if len(extra) > 0 {
+			slices.Sort(extra)
+			comparison.ExtraSamples = extra[:min(len(extra), examples)]
+		}
+		report.Tables = append(report.Tables, comparison)
+	}
+	return report, nil
+}
+
+// readComparableRows reads a table's rows as normalized values keyed by
+// lowercased column name, and returns the sorted column names
+func readComparableRows(ctx context.Context, db *sql.DB, table string) ([]string, []map[string]string, error) {
+	if !identifierPattern.MatchString(table) {
+		return nil, nil, fmt.Errorf("invalid table name %q", table)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT * FROM "+table)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, column := range columns {
+		columns[i] = strings.ToLower(column)
+	}
+
+	var result []map[string]string
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+		row := make(map[string]string, len(columns))
+		for i, column := range columns {
+			row[column] = normalizeComparable(values[i])
+		}
+		result = append(result, row)
+	}
+	sorted := slices.Clone(columns)
+	slices.Sort(sorted)
+	return sorted, result, rows.Err()
+}
+
+// mysqlTimeLayouts are the layouts of DATETIME, TIMESTAMP and DATE values
+// as text, which MySQL drivers return without parseTime
+var mysqlTimeLayouts = []string{"2006-01-02 15:04:05.999999999", time.DateOnly}
+
+// normalizeComparable formats a scanned value so equal values read from
+// different engines compare equal: booleans as 1 and 0, times, including
+// MySQL's time text, in UTC
+func normalizeComparable(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return normalizeComparable(string(v))
+	case string:
+		for _, layout := range mysqlTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.Format(time.RFC3339Nano)
+			}
+		}
+		return v
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// formatComparableRow formats the given columns of a row, e.g.
+// "{email: a@b.c, id: 1}"
+func formatComparableRow(row map[string]string, columns []string) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		parts[i] = column + ": " + row[column]
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
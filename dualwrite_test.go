@@ -0,0 +1,125 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCompareTables tests comparing tables across engines
+func TestCompareTables(t *testing.T) {
+	mysql, mysqlFake := newFakeDB()
+	postgres, postgresFake := newFakeDB()
+	created := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	mysqlFake.On("SELECT * FROM users", []string{"ID", "email", "active", "created_at"},
+		[]driver.Value{int64(1), []byte("a@example.com"), int64(1), created},
+		[]driver.Value{int64(2), []byte("b@example.com"), int64(0), created})
+	postgresFake.On("SELECT * FROM users", []string{"id", "email", "active", "created_at"},
+		[]driver.Value{int64(2), "b@example.com", false, created.In(time.FixedZone("CET", 3600))},
+		[]driver.Value{int64(1), "a@example.com", true, created})
+
+	mysqlFake.On("SELECT * FROM events", []string{"id", "happened_at", "day"},
+		[]driver.Value{int64(1), []byte("2025-01-02 15:04:05"), []byte("2025-01-02")},
+		[]driver.Value{int64(2), []byte("2025-01-02 15:04:05.250000"), []byte("2025-01-03")})
+	postgresFake.On("SELECT * FROM events", []string{"id", "happened_at", "day"},
+		[]driver.Value{int64(1), created, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		[]driver.Value{int64(2), created.Add(250 * time.Millisecond), time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)})
+
+	mysqlFake.On("SELECT * FROM plans", []string{"id", "price", "legacy_code"},
+		[]driver.Value{int64(1), []byte("9.90"), nil},
+		[]driver.Value{int64(2), []byte("19.90"), nil})
+	postgresFake.On("SELECT * FROM plans", []string{"id", "price"},
+		[]driver.Value{int64(1), []byte("9.90")},
+		[]driver.Value{int64(2), []byte("19.9")},
+		[]driver.Value{int64(3), []byte("29.90")})
+
+	report, err := CompareTables(context.Background(), mysql, postgres, []string{"users", "events", "plans"}, 1)
+	assert.NoError(t, err)
+	assert.True(t, report.Tables[0].Identical(), "order, case of columns, booleans and time zones do not count")
+	assert.True(t, report.Tables[1].Identical(), "MySQL time text matches times")
+	assert.Equal(t, []string{"plans"}, report.Mismatched())
+	assert.Equal(t, TableComparison{
+		Table:          "plans",
+		PrimaryRows:    2,
+		SecondaryRows:  3,
+		OnlyPrimary:    []string{"legacy_code"},
+		Missing:        1,
+		Extra:          2,
+		MissingSamples: []string{"{id: 2, price: 19.90}"},
+		ExtraSamples:   []string{"{id: 2, price: 19.9}"},
+	}, report.Tables[2])
+
+	_, err = CompareTables(context.Background(), mysql, postgres, []string{"orders"}, 0)
+	assert.ErrorContains(t, err, "failed to read orders from the primary database")
+	_, err = CompareTables(context.Background(), mysql, postgres, []string{"users; DROP TABLE users"}, 0)
+	assert.ErrorContains(t, err, "invalid table name")
+}
+
+// TestVerifyDualWrite tests seeding both databases through their DSNs
+func TestVerifyDualWrite(t *testing.T) {
+	fake := withTestScheme(t)
+	fake.On("SELECT * FROM users", []string{"id"}, []driver.Value{int64(1)})
+
+	var seeded []string
+	manager := NewSeederManager()
+	manager.RegisterSeeders(SeederItem{Name: "users", ContextFunction: func(ctx context.Context) error {
+		seeded = append(seeded, DSNFromContext(ctx))
+		return nil
+	}})
+
+	manager.SetHistoryStore(NewMemoryHistoryStore())
+
+	options := DualWriteOptions{Primary: "seedtest://mysql/shop", Secondary: "seedtest://postgres/shop", Tables: []string{"users"}}
+	report, err := VerifyDualWrite(context.Background(), manager, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"seedtest://mysql/shop", "seedtest://postgres/shop"}, seeded, "the history store does not skip the secondary run")
+	assert.Empty(t, report.Mismatched())
+
+	_, err = VerifyDualWrite(context.Background(), manager, DualWriteOptions{Primary: "seedtest://a", Tables: []string{"users"}})
+	assert.ErrorContains(t, err, "requires a primary and a secondary DSN")
+}
+
+// TestCLIDualWrite tests the dual-write command
+func TestCLIDualWrite(t *testing.T) {
+	fake := withTestScheme(t)
+	fake.On("SELECT * FROM users", []string{"id"}, []driver.Value{int64(1)}, []driver.Value{int64(2)})
+
+	manager := NewSeederManager()
+	manager.RegisterSeeder("users", func() error { return nil })
+	cli := NewCLI(manager)
+	var out bytes.Buffer
+	cli.SetOutput(&out)
+	cli.dsn = "seedtest://mysql/shop"
+
+	err := cli.runCommand(context.Background(), []string{"dual-write", "-secondary=seedtest://postgres/shop", "-tables=users"})
+	assert.NoError(t, err)
+	assert.Equal(t, "users: identical (2 rows)\n", out.String())
+
+	cli.dsn = ""
+	err = cli.runCommand(context.Background(), []string{"dual-write", "-secondary=seedtest://postgres/shop", "-tables=users"})
+	assert.ErrorIs(t, err, ErrUsage)
+}
+
+// TestPrintDualWriteReport tests printing differing tables
+func TestPrintDualWriteReport(t *testing.T) {
+	cli := NewCLI(NewSeederManager())
+	var out bytes.Buffer
+	cli.SetOutput(&out)
+
+	cli.printDualWriteReport(&DualWriteReport{Tables: []TableComparison{{
+		Table: "plans", PrimaryRows: 2, SecondaryRows: 2, OnlySecondary: []string{"tier"},
+		Missing: 1, MissingSamples: []string{"{id: 2}"}, Extra: 1, ExtraSamples: []string{"{id: 3}"},
+	}}})
+	assert.Equal(t, `plans: differs (2 primary rows, 2 secondary rows)
+  columns only in secondary: tier
+  1 rows missing from secondary, e.g.:
+    - {id: 2}
+  1 rows only in secondary, e.g.:
+    + {id: 3}
+`, out.String())
+}
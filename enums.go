@@ -0,0 +1,221 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ValueLister lists the values the columns of a table accept, from enum
+// types and check constraints such as CHECK (status IN ('active',
+// 'banned')). Columns accepting any value of their type are left out.
+type ValueLister interface {
+	Values(ctx context.Context, table string) (map[string][]string, error)
+}
+
+// ValueListerFunc adapts a function to the ValueLister interface
+type ValueListerFunc func(ctx context.Context, table string) (map[string][]string, error)
+
+// Values calls f(ctx, table)
+func (f ValueListerFunc) Values(ctx context.Context, table string) (map[string][]string, error) {
+	return f(ctx, table)
+}
+
+// postgresEnumsQuery lists the labels of the enum columns of a table
+const postgresEnumsQuery = `SELECT c.column_name, e.enumlabel
+FROM information_schema.columns c
+JOIN pg_namespace n ON n.nspname = c.udt_schema
+JOIN pg_type t ON t.typname = c.udt_name AND t.typnamespace = n.oid
+JOIN pg_enum e ON e.enumtypid = t.oid
+WHERE c.table_schema = current_schema() AND c.table_name = $1
+ORDER BY c.column_name, e.enumsortorder`
+
+// postgresChecksQuery lists the definitions of the check constraints of a table
+const postgresChecksQuery = `SELECT pg_get_constraintdef(con.oid)
+FROM pg_constraint con
+JOIN pg_class cl ON cl.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = cl.relnamespace
+WHERE con.contype = 'c' AND n.nspname = current_schema() AND cl.relname = $1
+ORDER BY con.conname`
+
+// PostgresValues lists the values of the enum columns of tables in the
+// current schema of a Postgres database, and of the columns restricted by
+// check constraints of the form "column IN (...)" or "column = ANY
+// (ARRAY[...])". Other check constraints are ignored. A column restricted
+// several times accepts the values allowed by all restrictions.
+func PostgresValues(db *sql.DB) ValueLister {
+	return ValueListerFunc(func(ctx context.Context, table string) (map[string][]string, error) {
+		values := make(map[string][]string)
+		rows, err := db.QueryContext(ctx, postgresEnumsQuery, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list enum values of %s: %w", table, err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var column, label string
+			if err := rows.Scan(&column, &label); err != nil {
+				return nil, err
+			}
+			values[column] = append(values[column], label)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		checks, err := db.QueryContext(ctx, postgresChecksQuery, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list check constraints of %s: %w", table, err)
+		}
+		defer checks.Close()
+		for checks.Next() {
+			var definition string
+			if err := checks.Scan(&definition); err != nil {
+				return nil, err
+			}
+			column, allowed, ok := parseCheckValues(definition)
+			if !ok {
+				continue
+			}
+			if existing, restricted := values[column]; restricted {
+				allowed = slices.DeleteFunc(slices.Clone(existing), func(value string) bool {
+					return !slices.Contains(allowed, value)
+				})
+			}
+			values[column] = allowed
+		}
+		return values, checks.Err()
+	})
+}
+
+// Check constraint definitions listing a column's values, as written by
+// pg_get_constraintdef, e.g.
+//
+//	CHECK (((status)::text = ANY ((ARRAY['active'::character varying, 'banned'::character varying])::text[])))
+//	CHECK ((level = ANY (ARRAY[1, 2, 3])))
+//	CHECK (status IN ('active', 'banned'))
+var (
+	checkAnyPattern = regexp.MustCompile(`^CHECK \(+"?(\w+)"?\)?(?:::[\w ]+)? = ANY \(+ARRAY\[(.*?)\]`)
+	checkInPattern  = regexp.MustCompile(`(?i)^CHECK \(+"?(\w+)"?\)?(?:::[\w ]+)? IN \((.*?)\)+$`)
+)
+
+// parseCheckValues returns the column and the values of a check
+// constraint restricting a column to a list of values
+func parseCheckValues(definition string) (string, []string, bool) {
+	match := checkAnyPattern.FindStringSubmatch(definition)
+	if match == nil {
+		if match = checkInPattern.FindStringSubmatch(definition); match == nil {
+			return "", nil, false
+		}
+	}
+	values, ok := parseSQLList(match[2])
+	if !ok || len(values) == 0 {
+		return "", nil, false
+	}
+	return match[1], values, true
+}
+
+// parseSQLList parses a comma-separated list of SQL literals such as
+// 'active'::text or 42, dropping type casts and undoubling the quotes of
+// strings
+func parseSQLList(list string) ([]string, bool) {
+	var values []string
+	for rest := strings.TrimSpace(list); rest != ""; {
+		var value string
+		if rest[0] == '\'' {
+			var b strings.Builder
+			i := 1
+			for ; i < len(rest); i++ {
+				if rest[i] == '\'' {
+					if i+1 < len(rest) && rest[i+1] == '\'' {
+						b.WriteByte('\'')
+						i++
+						continue
+					}
+					break
+				}
+				b.WriteByte(rest[i])
+			}
+			if i == len(rest) {
+				return nil, false
+			}
+			value, rest = b.String(), rest[i+1:]
+		} else {
+			end := strings.IndexAny(rest, ":,")
+			if end < 0 {
+				end = len(rest)
+			}
+			value, rest = strings.TrimSpace(rest[:end]), rest[end:]
+			if !decimalPattern.MatchString(value) {
+				return nil, false
+			}
+		}
+		if comma := strings.IndexByte(rest, ','); comma >= 0 {
+			rest = strings.TrimSpace(rest[comma+1:])
+		} else {
+			rest = ""
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+// checkValues checks the values of records against the values their
+// columns accept. In lenient mode rows with other values are skipped with
+// a warning and the remaining records returned with ctx describing their
+// fixture rows; otherwise every issue is returned as a *ValidationError.
+func (w *SQLFixtureWriter) checkValues(ctx context.Context, table string, records []Record, mode FixtureMode) ([]Record, context.Context, error) {
+	values, err := w.Values.Values(ctx, table)
+	if err != nil {
+		return nil, ctx, err
+	}
+	if len(values) == 0 {
+		return records, ctx, nil
+	}
+
+	issues := &ValidationError{}
+	kept := make([]Record, 0, len(records))
+	indexes := make([]int, 0, len(records))
+	for i, record := range records {
+		valid := true
+		for _, column := range recordColumns(record) {
+			allowed, restricted := values[column]
+			if !restricted || record[column] == nil {
+				continue
+			}
+			if text := fmt.Sprint(record[column]); !slices.Contains(allowed, text) {
+				valid = false
+				encoded, _ := json.Marshal(record[column])
+				suggestion := ""
+				if _, ok := record[column].(string); ok {
+					suggestion = didYouMean(text, allowed)
+				}
+				issues.add("fixture", "%s: column '%s' is %s, not one of %s%s",
+					recordLocation(ctx, i), column, encoded, strings.Join(allowed, ", "), suggestion)
+			}
+		}
+		if valid {
+			kept = append(kept, record)
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(issues.Issues) == 0 {
+		return records, ctx, nil
+	}
+	if mode != FixtureLenient {
+		return nil, ctx, issues
+	}
+	logWarn(ctx, "Lenient fixture of %s: skipped %d of %d row(s) with values their column does not accept", table, len(records)-len(kept), len(records))
+	for i, issue := range issues.Issues {
+		if i == maxFixtureWarnings {
+			logWarn(ctx, "  ... and %d more", len(issues.Issues)-i)
+			break
+		}
+		logWarn(ctx, "  %s", issue.Message)
+	}
+	return kept, selectFixtureSource(ctx, indexes), nil
+}
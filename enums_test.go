@@ -0,0 +1,115 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseCheckValues tests reading value lists from check constraints
+func TestParseCheckValues(t *testing.T) {
+	tests := []struct {
+		definition string
+		column     string
+		values     []string
+	}{
+		{"CHECK (((status)::text = ANY ((ARRAY['active'::character varying, 'banned'::character varying])::text[])))", "status", []string{"active", "banned"}},
+		{"CHECK ((kind = ANY (ARRAY['a'::text, 'it''s'::text])))", "kind", []string{"a", "it's"}},
+		{"CHECK ((level = ANY (ARRAY[1, 2, 3])))", "level", []string{"1", "2", "3"}},
+		{"CHECK (status IN ('active', 'banned'))", "status", []string{"active", "banned"}},
+	}
+	for _, test := range tests {
+		column, values, ok := parseCheckValues(test.definition)
+		assert.True(t, ok, test.definition)
+		assert.Equal(t, test.column, column, test.definition)
+		assert.Equal(t, test.values, values, test.definition)
+	}
+
+	for _, definition := range []string{"CHECK ((price > (0)::numeric))", "CHECK ((level = ANY (ARRAY[lower(name)])))", "CHECK ((a = ANY (ARRAY['open])))"} {
+		_, _, ok := parseCheckValues(definition)
+		assert.False(t, ok, definition)
+	}
+}
+
+// TestPostgresValues tests listing enum labels and check constraint values
+func TestPostgresValues(t *testing.T) {
+	db, fake := newFakeDB()
+	fake.On(postgresEnumsQuery, []string{"column_name", "enumlabel"},
+		[]driver.Value{"role", "admin"}, []driver.Value{"role", "member"}, []driver.Value{"role", "guest"})
+	fake.On(postgresChecksQuery, []string{"pg_get_constraintdef"},
+		[]driver.Value{"CHECK ((role = ANY (ARRAY['admin'::user_role, 'member'::user_role])))"},
+		[]driver.Value{"CHECK ((status = ANY (ARRAY['active'::text, 'banned'::text])))"},
+		[]driver.Value{"CHECK ((age >= 18))"})
+
+	values, err := PostgresValues(db).Values(context.Background(), "users")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"role": {"admin", "member"}, "status": {"active", "banned"}}, values)
+	assert.Contains(t, fake.Events(), postgresEnumsQuery+" [users]")
+
+	fake.Fail(postgresChecksQuery+" [users]", errors.New("permission denied"))
+	_, err = PostgresValues(db).Values(context.Background(), "users")
+	assert.EqualError(t, err, "failed to list check constraints of users: permission denied")
+}
+
+// TestSQLFixtureWriterValues tests catching values enum and check-constrained columns reject
+func TestSQLFixtureWriterValues(t *testing.T) {
+	lister := ValueListerFunc(func(ctx context.Context, table string) (map[string][]string, error) {
+		return map[string][]string{"status": {"active", "banned"}, "level": {"1", "2"}}, nil
+	})
+	records := []Record{
+		{"email": "a@example.com", "status": "active", "level": json.Number("1")},
+		{"email": "b@example.com", "status": "actve", "level": json.Number("2")},
+		{"email": "c@example.com", "status": nil, "level": json.Number("3")},
+	}
+
+	t.Run("Invalid values fail with their fixture row", func(t *testing.T) {
+		db, fake := newFakeDB()
+		writer := &SQLFixtureWriter{DB: db, Values: lister}
+		err := writer.WriteFixture(withFixtureSource(context.Background(), "fixtures/users.json", nil), "users", records)
+		var validation *ValidationError
+		require.ErrorAs(t, err, &validation)
+		assert.Equal(t, []ValidationIssue{
+			{Check: "fixture", Message: `fixtures/users.json:2: column 'status' is "actve", not one of active, banned, did you mean 'active'?`},
+			{Check: "fixture", Message: "fixtures/users.json:3: column 'level' is 3, not one of 1, 2"},
+		}, validation.Issues)
+		assert.Empty(t, fake.Events())
+	})
+
+	t.Run("Lenient mode skips rows", func(t *testing.T) {
+		db, fake := newFakeDB()
+		writer := &SQLFixtureWriter{DB: db, Values: lister, Mode: FixtureLenient, Placeholder: QuestionPlaceholder}
+		fake.OnTyped("SELECT * FROM users WHERE 1 = 0", []string{"email", "status", "level"}, []string{"TEXT", "TEXT", "INT4"})
+		assert.NoError(t, writer.WriteFixture(context.Background(), "users", records))
+		var inserts []string
+		for _, event := range fake.Events() {
+			if strings.HasPrefix(event, "INSERT") {
+				inserts = append(inserts, event)
+			}
+		}
+		assert.Equal(t, []string{"INSERT INTO users (email, level, status) VALUES (?, ?, ?) [a@example.com 1 active]"}, inserts)
+	})
+}
+
+// TestSchemaGeneratorValues tests picking values of enum and check-constrained columns
+func TestSchemaGeneratorValues(t *testing.T) {
+	generator := &SchemaGenerator{
+		Columns: ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+			return []ColumnInfo{{Name: "role", Type: "user_role"}, {Name: "level", Type: "int4"}}, nil
+		}),
+		Values: ValueListerFunc(func(ctx context.Context, table string) (map[string][]string, error) {
+			return map[string][]string{"role": {"admin", "member"}, "level": {"1", "2"}}, nil
+		}),
+	}
+	records, err := generator.Generate(context.Background(), "users", 20)
+	require.NoError(t, err)
+	for _, record := range records {
+		assert.Contains(t, []any{"admin", "member"}, record["role"])
+		assert.Contains(t, []any{json.Number("1"), json.Number("2")}, record["level"])
+	}
+}
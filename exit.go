@@ -0,0 +1,79 @@
+package goseeder
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+)
+
+// Exit codes returned by CLI.Main, so pipelines can branch on the kind of
+// failure
+const (
+	ExitOK       = 0 // Success
+	ExitFailure  = 1 // A seeder or command failed
+	ExitUsage    = 2 // Unknown command or seeder, invalid flags or arguments
+	ExitLockHeld = 3 // The seed lock is held by another process, see ErrLockHeld
+	ExitDrift    = 4 // Data drifted from its expected state, see ErrDriftDetected
+)
+
+var (
+	// ErrUsage is wrapped by the errors of invalid command lines
+	ErrUsage = errors.New("usage error")
+
+	// ErrDriftDetected is wrapped by checks that find data differing from
+	// its expected state, e.g. seeded rows changed by hand
+	ErrDriftDetected = errors.New("drift detected")
+)
+
+// usageError is an invalid command line error, keeping its message
+type usageError struct {
+	err error
+}
+
+func (ue usageError) Error() string { return ue.err.Error() }
+
+func (ue usageError) Unwrap() []error { return []error{ErrUsage, ue.err} }
+
+// asUsageError marks err as an invalid command line
+func asUsageError(err error) error {
+	if err == nil || errors.Is(err, ErrUsage) || errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return usageError{err: err}
+}
+
+// usageErrorf formats an invalid command line error
+func usageErrorf(format string, args ...any) error {
+	return usageError{err: fmt.Errorf(format, args...)}
+}
+
+// ExitCode returns the exit code for the error returned by CLI.Run
+func ExitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.Is(err, ErrUsage):
+		return ExitUsage
+	case errors.Is(err, ErrLockHeld):
+		return ExitLockHeld
+	case errors.Is(err, ErrDriftDetected):
+		return ExitDrift
+	default:
+		return ExitFailure
+	}
+}
+
+// Main runs the CLI, prints the error if any and returns its exit code,
+// see ExitCode:
+//
+//	func main() {
+//		os.Exit(cli.Main())
+//	}
+func (cli *CLI) Main() int {
+	err := cli.Run()
+	code := ExitCode(err)
+	if code != ExitOK {
+		cli.errorf("Error: %v", err)
+	}
+	return code
+}
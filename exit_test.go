@@ -0,0 +1,61 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExitCode tests classifying errors into exit codes
+func TestExitCode(t *testing.T) {
+	locker := NewMemoryLocker()
+	unlock, _ := locker.Lock(context.Background())
+	defer unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, lockErr := locker.Lock(ctx)
+
+	for name, test := range map[string]struct {
+		err  error
+		code int
+	}{
+		"success":        {nil, ExitOK},
+		"help":           {flag.ErrHelp, ExitOK},
+		"seeder failure": {errors.New("seeder 'users' failed"), ExitFailure},
+		"usage":          {usageErrorf("bench requires a seeder name"), ExitUsage},
+		"lock held":      {fmt.Errorf("failed to acquire seed lock: %w", lockErr), ExitLockHeld},
+		"drift":          {fmt.Errorf("users: %w", ErrDriftDetected), ExitDrift},
+	} {
+		assert.Equal(t, test.code, ExitCode(test.err), name)
+	}
+}
+
+// TestCLIUsageErrors tests that invalid command lines are usage errors
+func TestCLIUsageErrors(t *testing.T) {
+	cli := NewCLI(NewSeederManager())
+	cli.SetOutput(&bytes.Buffer{})
+
+	for _, args := range [][]string{
+		{"unknown"},
+		{"bench"},
+		{"bench", "-unknown"},
+		{"bench", "users", "extra"},
+		{"fmt"},
+		{"insert", "users", "-set", "nokey"},
+	} {
+		err := cli.runCommand(context.Background(), args)
+		assert.ErrorIs(t, err, ErrUsage, args)
+		assert.Equal(t, ExitUsage, ExitCode(err), args)
+	}
+
+	err := cli.runCommand(context.Background(), []string{"profile", "-tables=users"})
+	assert.ErrorContains(t, err, "requires a database")
+	assert.Equal(t, ExitFailure, ExitCode(err), "missing setup is not a usage error")
+
+	assert.Equal(t, ExitOK, ExitCode(cli.runCommand(context.Background(), []string{"bench", "-h"})))
+}
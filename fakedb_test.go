@@ -0,0 +1,16 @@
+package goseeder
+
+import (
+	"database/sql"
+
+	"go.risoftinc.com/goseeder/internal/fakedb"
+)
+
+// fakeDB is the scripted driver goseedertest exports as FakeDB, which this
+// package's tests cannot import
+type fakeDB = fakedb.FakeDB
+
+// newFakeDB returns a *sql.DB backed by a new fakeDB
+func newFakeDB() (*sql.DB, *fakeDB) {
+	return fakedb.New()
+}
@@ -0,0 +1,98 @@
+package goseeder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Fingerprint returns a stable SHA-256 hash of the seed inputs: the names,
+// order, aliases, tags, phases and dependencies of the registered seeders
+// and the contents of the files in their Paths, resolved against root. Use
+// it as a Docker or CI cache key, so a pre-seeded database layer is rebuilt
+// only when seed inputs change. Seeder code is not hashed; declare the
+// package directories of code seeders in Paths to cover it.
+func (sm *SeederManager) Fingerprint(root string) (string, error) {
+	seeders, _ := sm.registry()
+	h := sha256.New()
+	for _, seeder := range seeders {
+		fmt.Fprintf(h, "seeder %q aliases=%q tags=%q phase=%q depends=%q\n",
+			seeder.Name, seeder.Aliases, seeder.Tags, seeder.SeederPhase(), seeder.DependsOn)
+		for _, source := range seeder.Paths {
+			if err := fingerprintPath(h, root, source); err != nil {
+				return "", fmt.Errorf("failed to fingerprint path '%s' of seeder '%s': %w", source, seeder.Name, err)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fingerprintPath hashes the files of a seeder path: a file, a directory
+// (optionally ending in "/...") or a glob. Files are labelled by their path
+// as declared, so the hash does not depend on where root is; a path
+// matching no file is hashed as missing.
+func fingerprintPath(h hash.Hash, root, source string) error {
+	source = cleanChangedPath(strings.TrimSuffix(source, "/..."))
+	base := filepath.FromSlash(source)
+	if !filepath.IsAbs(base) {
+		base = filepath.Join(root, base)
+	}
+
+	matches := []string{base}
+	if IsGlob(source) {
+		var err error
+		if matches, err = filepath.Glob(base); err != nil {
+			return err
+		}
+	}
+
+	found := false
+	for _, match := range matches {
+		label := source
+		if IsGlob(source) {
+			label = path.Join(path.Dir(source), filepath.Base(match))
+		}
+		err := filepath.WalkDir(match, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil || !entry.Type().IsRegular() {
+				return err
+			}
+			rel, err := filepath.Rel(match, file)
+			if err != nil {
+				return err
+			}
+			found = true
+			return fingerprintFile(h, path.Join(label, filepath.ToSlash(rel)), file)
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	if !found {
+		fmt.Fprintf(h, "missing %q\n", source)
+	}
+	return nil
+}
+
+// fingerprintFile hashes a file's label and contents
+func fingerprintFile(h hash.Hash, label, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	content := sha256.New()
+	if _, err := io.Copy(content, f); err != nil {
+		return err
+	}
+	fmt.Fprintf(h, "file %q %x\n", label, content.Sum(nil))
+	return nil
+}
@@ -0,0 +1,83 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFingerprintManager registers seeders reading files under root
+func newFingerprintManager() *SeederManager {
+	noop := func() error { return nil }
+	manager := NewSeederManager()
+	manager.RegisterSeeders(
+		SeederItem{Name: "users", Function: noop, Paths: []string{"fixtures/users.json"}},
+		SeederItem{Name: "orders", Function: noop, Paths: []string{"fixtures/orders/...", "fixtures/order_*.sql"}, Tags: []string{"demo"}},
+	)
+	return manager
+}
+
+// TestFingerprint tests that the fingerprint only changes with seed inputs
+func TestFingerprint(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, content string) {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		assert.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	}
+	write("fixtures/users.json", `[{"id": 1}]`)
+	write("fixtures/orders/2024.json", `[]`)
+	write("fixtures/order_items.sql", `INSERT INTO order_items VALUES (1)`)
+	write("README.md", "unrelated")
+
+	manager := newFingerprintManager()
+	fingerprint, err := manager.Fingerprint(root)
+	assert.NoError(t, err)
+	assert.Len(t, fingerprint, 64)
+
+	copied := t.TempDir()
+	assert.NoError(t, os.CopyFS(copied, os.DirFS(root)))
+	again, _ := newFingerprintManager().Fingerprint(copied)
+	assert.Equal(t, fingerprint, again, "stable across checkouts")
+
+	write("README.md", "changed")
+	again, _ = manager.Fingerprint(root)
+	assert.Equal(t, fingerprint, again, "files outside Paths do not count")
+
+	for _, change := range []func(){
+		func() { write("fixtures/orders/2024.json", `[{"id": 7}]`) },
+		func() { write("fixtures/orders/2025/jan.json", `[]`) },
+		func() { write("fixtures/order_lines.sql", ``) },
+		func() { manager.RegisterSeeder("plans", func() error { return nil }) },
+	} {
+		change()
+		changed, err := manager.Fingerprint(root)
+		assert.NoError(t, err)
+		assert.NotEqual(t, fingerprint, changed)
+		fingerprint = changed
+	}
+
+	missing, err := newFingerprintManager().Fingerprint(t.TempDir())
+	assert.NoError(t, err, "missing paths are hashed, not errors")
+	assert.NotEqual(t, fingerprint, missing)
+}
+
+// TestCLIFingerprint tests the fingerprint command
+func TestCLIFingerprint(t *testing.T) {
+	root := t.TempDir()
+	manager := newFingerprintManager()
+	expected, err := manager.Fingerprint(root)
+	assert.NoError(t, err)
+
+	cli := NewCLI(manager)
+	var out bytes.Buffer
+	cli.SetOutput(&out)
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"fingerprint", "-root=" + root}))
+	assert.Equal(t, expected, strings.TrimSpace(out.String()))
+	assert.ErrorIs(t, cli.runCommand(context.Background(), []string{"fingerprint", "extra"}), ErrUsage)
+}
@@ -0,0 +1,704 @@
+package goseeder
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultInsertBatchSize is the number of rows per INSERT statement when
+// SQLFixtureWriter.BatchSize is zero
+const defaultInsertBatchSize = 100
+
+// Fixture formats accepted by ReadFixture
+const (
+	FormatJSON   = "json"   // An array of records
+	FormatNDJSON = "ndjson" // One record per line
+)
+
+// ReadFixtureFile reads a fixture file: a JSON array of records, or one
+// record per line for files ending in .ndjson or .jsonl. Binary values
+// written as "!file <path>", relative to the file, or "!base64 <data>" are
+// resolved. Templated files, named like "users.tmpl.json", are rendered
+// first with the default hasher, see ReadFixtureFileContext.
+func ReadFixtureFile(path string) ([]Record, error) {
+	return ReadFixtureFileContext(context.Background(), path)
+}
+
+// ReadFixtureFileContext reads a fixture file like ReadFixtureFile, with
+// _generate directives drawing from the run of ctx. Templated files, named
+// like "users.tmpl.json", are rendered with RenderFixture first. Records
+// can include and extend records of other files, see includeKey. With an
+// environment set by ContextWithEnvironment, the file's overlay for it,
+// such as users.staging.json, is merged in, see mergeOverlay.
+func ReadFixtureFileContext(ctx context.Context, path string) ([]Record, error) {
+	records, _, err := readFixtureFile(ctx, path)
+	return records, err
+}
+
+// readFixtureFile implements ReadFixtureFileContext, also returning where
+// every record was read from
+func readFixtureFile(ctx context.Context, path string) ([]Record, []recordOrigin, error) {
+	records, err := decodeFixtureFile(ctx, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, origins, err := resolveIncludes(ctx, path, records)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fixture '%s': %w", path, err)
+	}
+	if records, origins, err = applyFixtureOverlay(ctx, path, records, origins); err != nil {
+		return nil, nil, err
+	}
+
+	records, origins, err = expandGenerated(ctx, records, origins)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse fixture '%s': %w", path, err)
+	}
+	for i, record := range records {
+		// Binary file paths are relative to the file holding the record
+		if err := resolveRecordBinaryValues(record, filepath.Dir(origins[i].file)); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse fixture '%s': %s: %w", path, origins[i], err)
+		}
+	}
+	return records, origins, nil
+}
+
+// recordOrigin is where a fixture record was read from, through includes,
+// overlays and _generate directives
+type recordOrigin struct {
+	file  string // File holding the record, e.g. an included file
+	row   int    // 1-based row of the record in file
+	entry int    // 1-based row of the file read that holds the record, or the _include or _generate record producing it
+}
+
+// String returns "file:row", or "record N" when the file is unknown
+func (o recordOrigin) String() string {
+	if o.file == "" {
+		return fmt.Sprintf("record %d", o.row)
+	}
+	return fmt.Sprintf("%s:%d", o.file, o.row)
+}
+
+// fileOrigins returns the origins of count records read as they are
+// written in file
+func fileOrigins(file string, count int) []recordOrigin {
+	origins := make([]recordOrigin, count)
+	for i := range origins {
+		origins[i] = recordOrigin{file: file, row: i + 1, entry: i + 1}
+	}
+	return origins
+}
+
+// decodeFixtureFile reads the records of a fixture file, rendering
+// templated files, and leaves _generate directives as they are written
+func decodeFixtureFile(ctx context.Context, path string) ([]Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	if isTemplatedFixture(path) {
+		if data, err = RenderFixture(ctx, path, data); err != nil {
+			return nil, fmt.Errorf("fixture '%s': %w", path, err)
+		}
+	}
+
+	records, err := decodeFixture(bytes.NewReader(data), fixtureFormat(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture '%s': %w", path, err)
+	}
+	return records, nil
+}
+
+// ReadFixture reads records in the given format, FormatJSON or
+// FormatNDJSON. An empty format detects JSON arrays by their leading '['.
+// Numbers are kept as json.Number so large IDs don't lose precision.
+// Records holding a _generate directive expand into generated rows, e.g.
+// {"_generate": {"count": 500, "template": {"name": "[[ name ]]"}}}; see
+// generateFuncs for the functions templates can call.
+func ReadFixture(r io.Reader, format string) ([]Record, error) {
+	records, _, err := readFixture(context.Background(), r, format, "")
+	return records, err
+}
+
+// readFixture implements ReadFixture, expanding _generate directives in the
+// run of ctx, and also returns where every record was read from, row
+// numbers being those of file
+func readFixture(ctx context.Context, r io.Reader, format, file string) ([]Record, []recordOrigin, error) {
+	records, err := decodeFixture(r, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	return expandGenerated(ctx, records, fileOrigins(file, len(records)))
+}
+
+// decodeFixture reads records like ReadFixture, leaving _generate
+// directives as they are written, e.g. for FormatFixtureFile
+func decodeFixture(r io.Reader, format string) ([]Record, error) {
+	reader := bufio.NewReader(r)
+	if format == "" {
+		format = FormatNDJSON
+		for {
+			c, _, err := reader.ReadRune()
+			if err != nil {
+				break
+			}
+			if !unicode.IsSpace(c) {
+				if c == '[' {
+					format = FormatJSON
+				}
+				reader.UnreadRune()
+				break
+			}
+		}
+	}
+
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	switch format {
+	case FormatJSON:
+		var records []Record
+		if err := decoder.Decode(&records); err != nil {
+			return nil, err
+		}
+		return records, nil
+	case FormatNDJSON:
+		records := make([]Record, 0)
+		for {
+			var record Record
+			err := decoder.Decode(&record)
+			if err == io.EOF {
+				return records, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("record %d: %w", len(records)+1, err)
+			}
+			records = append(records, record)
+		}
+	default:
+		return nil, fmt.Errorf("unknown fixture format %q, expected %s or %s", format, FormatJSON, FormatNDJSON)
+	}
+}
+
+// fixtureTable returns the table a fixture file is loaded into by default,
+// its file name without extension, e.g. "users" for "fixtures/users.json"
+// and "fixtures/users.tmpl.json"
+func fixtureTable(path string) string {
+	base := filepath.Base(path)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+	if isTemplatedFixture(path) {
+		name = name[:len(name)-len(templatedFixtureSuffix)]
+	}
+	return name
+}
+
+// Placeholder formats the n-th (1-based) bind parameter of a statement
+type Placeholder func(n int) string
+
+// DollarPlaceholder formats parameters as $1, $2, ... (Postgres, CockroachDB)
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// QuestionPlaceholder formats parameters as ? (MySQL, SQLite)
+func QuestionPlaceholder(n int) string {
+	return "?"
+}
+
+// BuildInsert returns a single INSERT statement writing records into table,
+// and its arguments. Columns are the sorted keys of the records, which must
+// all have the same keys.
+func BuildInsert(table string, records []Record, placeholder Placeholder) (string, []any, error) {
+	if !identifierPattern.MatchString(table) {
+		return "", nil, fmt.Errorf("invalid table name %q", table)
+	}
+	if len(records) == 0 {
+		return "", nil, fmt.Errorf("no records to insert into %s", table)
+	}
+
+	columns := recordColumns(records[0])
+	for _, column := range columns {
+		if !identifierPattern.MatchString(column) {
+			return "", nil, fmt.Errorf("invalid column name %q", column)
+		}
+	}
+
+	var query strings.Builder
+	args := make([]any, 0, len(records)*len(columns))
+	fmt.Fprintf(&query, "INSERT INTO %s (%s) VALUES ", table, strings.Join(columns, ", "))
+	for i, record := range records {
+		if len(record) != len(columns) {
+			return "", nil, fmt.Errorf("record %d has columns %v, expected %v", i+1, recordColumns(record), columns)
+		}
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(")
+		for j, column := range columns {
+			value, ok := record[column]
+			if !ok {
+				return "", nil, fmt.Errorf("record %d has columns %v, expected %v", i+1, recordColumns(record), columns)
+			}
+			if j > 0 {
+				query.WriteString(", ")
+			}
+			args = append(args, insertArg(value))
+			query.WriteString(placeholder(len(args)))
+		}
+		query.WriteString(")")
+	}
+	return query.String(), args, nil
+}
+
+// recordColumns returns the sorted keys of record
+func recordColumns(record Record) []string {
+	columns := make([]string, 0, len(record))
+	for column := range record {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// SQLFixtureWriter is a FixtureWriter inserting records with plain SQL.
+// Every write runs in a single transaction, in multi-row INSERT statements
+// of consecutive records with the same columns. Statements are recorded
+// with LogStatement.
+type SQLFixtureWriter struct {
+	DB          *sql.DB
+	Placeholder Placeholder // Defaults to DollarPlaceholder
+	BatchSize   int         // Maximum rows per statement, default 100
+
+	// Mode checks records against their table before inserting them, see
+	// FixtureMode. ContextWithFixtureMode overrides it for a run, and
+	// FileModes for fixture files matching a pattern such as
+	// "fixtures/shared/*.json" or "users.json". When several patterns
+	// match, an exact path beats an exact file name, which beats globs on
+	// the path, which beat globs on the file name; among globs the one
+	// with more literal characters wins.
+	Mode      FixtureMode
+	FileModes map[string]FixtureMode
+
+	// Columns, when set, makes writes omit generated and identity
+	// GENERATED ALWAYS columns, and NULLs of NOT NULL columns with a
+	// default so the database fills in the default, e.g. PostgresColumns
+	Columns ColumnLister
+
+	// Values, when set, fails writes of records with values their enum or
+	// check-constrained column does not accept, naming the fixture row,
+	// e.g. PostgresValues. Lenient mode skips such rows instead.
+	Values ValueLister
+
+	// Transforms rewrite column values before they are inserted, keyed by
+	// "table.column" or by "column" for every table, see ColumnTransform
+	Transforms map[string]ColumnTransform
+}
+
+// NewSQLFixtureWriter creates a SQLFixtureWriter with the default batch size
+func NewSQLFixtureWriter(db *sql.DB, placeholder Placeholder) *SQLFixtureWriter {
+	return &SQLFixtureWriter{DB: db, Placeholder: placeholder}
+}
+
+// WriteFixture implements FixtureWriter. When a multi-row statement
+// fails, the batch is bisected in a separate transaction that is rolled
+// back, and the error is a *RowError naming the offending record. Records
+// are checked against Mode and Values first. Writes exceeding the Quota of
+// ctx fail with a *QuotaError before inserting anything.
+func (w *SQLFixtureWriter) WriteFixture(ctx context.Context, table string, records []Record) (err error) {
+	if records, ctx, err = w.prepare(ctx, table, records); err != nil {
+		return err
+	}
+	reserved := int64(len(records))
+	if err := ReserveRows(ctx, table, reserved); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			releaseRows(ctx, table, reserved)
+		}
+	}()
+
+	tx, err := w.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for start := 0; start < len(records); {
+		end := w.batchEnd(records, start)
+		query, args, err := BuildInsert(table, records[start:end], w.placeholder())
+		if err != nil {
+			return fmt.Errorf("records %d-%d of %s: %w", start+1, end, table, err)
+		}
+		LogStatement(ctx, query, args...)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			tx.Rollback()
+			return w.locateFailure(ctx, table, records, start, end, err)
+		}
+		start = end
+	}
+	return tx.Commit()
+}
+
+// prepare turns records into the ones WriteFixture inserts: converted with
+// Columns and Transforms, then checked against Mode and Values. Records
+// lenient mode skips are left out, and the returned ctx describes the
+// fixture rows of the remaining ones.
+func (w *SQLFixtureWriter) prepare(ctx context.Context, table string, records []Record) ([]Record, context.Context, error) {
+	var err error
+	if w.Columns != nil {
+		columns, err := w.Columns.Columns(ctx, table)
+		if err != nil {
+			return nil, ctx, err
+		}
+		if records, err = applyColumnInfo(records, columns); err != nil {
+			return nil, ctx, fmt.Errorf("failed to convert records of %s: %w", table, err)
+		}
+	}
+	if records, err = w.transformRecords(ctx, table, records); err != nil {
+		return nil, ctx, fmt.Errorf("failed to transform records of %s: %w", table, err)
+	}
+	mode := w.mode(ctx)
+	if mode != FixtureUnchecked {
+		if records, ctx, err = w.checkFixture(ctx, table, records, mode); err != nil {
+			return nil, ctx, err
+		}
+	}
+	if w.Values != nil {
+		if records, ctx, err = w.checkValues(ctx, table, records, mode); err != nil {
+			return nil, ctx, err
+		}
+	}
+	return records, ctx, nil
+}
+
+// placeholder returns the writer's placeholder, DollarPlaceholder by default
+func (w *SQLFixtureWriter) placeholder() Placeholder {
+	if w.Placeholder == nil {
+		return DollarPlaceholder
+	}
+	return w.Placeholder
+}
+
+// batchEnd returns the end of the batch starting at start: consecutive
+// records with the same columns, at most BatchSize of them
+func (w *SQLFixtureWriter) batchEnd(records []Record, start int) int {
+	batchSize := w.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultInsertBatchSize
+	}
+	end := start + 1
+	columns := strings.Join(recordColumns(records[start]), ",")
+	for end < len(records) && end-start < batchSize && strings.Join(recordColumns(records[end]), ",") == columns {
+		end++
+	}
+	return end
+}
+
+// insert inserts records in a single statement
+func (w *SQLFixtureWriter) insert(ctx context.Context, tx *sql.Tx, table string, records []Record) error {
+	query, args, err := BuildInsert(table, records, w.placeholder())
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// locateFailure turns the failure of the batch records[start:end] into a
+// *RowError, bisecting the batch when it has several records. The batch
+// error is kept when no single record fails on its own.
+func (w *SQLFixtureWriter) locateFailure(ctx context.Context, table string, records []Record, start, end int, batchErr error) error {
+	index, err := start, batchErr
+	if end-start > 1 {
+		if index, err = w.bisect(ctx, table, records, start, end); err == nil {
+			return fmt.Errorf("insert of records %d-%d into %s failed: %w", start+1, end, table, batchErr)
+		}
+	}
+	return &RowError{Table: table, Index: index + 1, Source: fixtureSourceRow(ctx, index), Record: records[index], Err: err}
+}
+
+// bisect finds the record of the failed batch records[start:end] that
+// fails: it replays the preceding batches in a new transaction, then
+// inserts halves of the batch under a savepoint, keeping halves that
+// succeed, until one record is left. It returns the record's index and
+// error, or a nil error when no record fails on its own. Nothing is
+// committed.
+func (w *SQLFixtureWriter) bisect(ctx context.Context, table string, records []Record, start, end int) (int, error) {
+	tx, err := w.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil
+	}
+	defer tx.Rollback()
+
+	for s := 0; s < start; {
+		e := w.batchEnd(records, s)
+		if err := w.insert(ctx, tx, table, records[s:e]); err != nil {
+			return 0, nil
+		}
+		s = e
+	}
+
+	low, high := start, end
+	for high-low > 1 {
+		middle := (low + high) / 2
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT goseeder_bisect"); err != nil {
+			return 0, nil
+		}
+		if err := w.insert(ctx, tx, table, records[low:middle]); err != nil {
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT goseeder_bisect"); err != nil {
+				return 0, nil
+			}
+			high = middle
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT goseeder_bisect"); err != nil {
+			return 0, nil
+		}
+		low = middle
+	}
+
+	if err := w.insert(ctx, tx, table, records[low:high]); err != nil {
+		return low, err
+	}
+	return 0, nil // Only fails together with other records
+}
+
+// RowError is an insert failure narrowed down to a single record, e.g. the
+// row of a fixture file violating a unique constraint
+type RowError struct {
+	Table  string
+	Index  int    // 1-based position of the record among those written
+	Source string // File and row of the record when known, e.g. "fixtures/users.json:17"
+	Record Record
+	Err    error
+}
+
+// Error implements the error interface
+func (e *RowError) Error() string {
+	location := ""
+	if e.Source != "" {
+		location = " (" + e.Source + ")"
+	}
+	values, _ := json.Marshal(e.Record)
+	return fmt.Sprintf("insert of record %d%s into %s failed: %v; record: %s", e.Index, location, e.Table, e.Err, values)
+}
+
+// Unwrap returns the database error
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// fixtureSource is the file records written through a FixtureWriter were
+// read from, so insert errors can point at the offending row
+type fixtureSource struct {
+	file    string
+	origins []recordOrigin // Where every record was read from; nil when record i is row i+1 of file
+}
+
+// withFixtureSource returns a copy of ctx recording that the written
+// records come from file, record i being read from origins[i] (or row i+1
+// of file when origins is nil)
+func withFixtureSource(ctx context.Context, file string, origins []recordOrigin) context.Context {
+	return context.WithValue(ctx, fixtureSourceKey, fixtureSource{file: file, origins: origins})
+}
+
+// sliceFixtureSource returns a copy of ctx whose fixture source describes
+// only the records start to end, for writers splitting a write
+func sliceFixtureSource(ctx context.Context, start, end int) context.Context {
+	indexes := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		indexes = append(indexes, i)
+	}
+	return selectFixtureSource(ctx, indexes)
+}
+
+// selectFixtureSource returns a copy of ctx whose fixture source describes
+// only the records at indexes, for writers skipping records
+func selectFixtureSource(ctx context.Context, indexes []int) context.Context {
+	source, ok := ctx.Value(fixtureSourceKey).(fixtureSource)
+	if !ok {
+		return ctx
+	}
+	origins := make([]recordOrigin, len(indexes))
+	for i, index := range indexes {
+		origins[i] = source.origin(index)
+	}
+	return withFixtureSource(ctx, source.file, origins)
+}
+
+// origin returns where record i was read from
+func (fs fixtureSource) origin(i int) recordOrigin {
+	if fs.origins == nil {
+		return recordOrigin{file: fs.file, row: i + 1, entry: i + 1}
+	}
+	return fs.origins[i]
+}
+
+// fixtureSourceRow returns "file:row" of the record at index, or "" when
+// the records' source is unknown
+func fixtureSourceRow(ctx context.Context, index int) string {
+	source, ok := ctx.Value(fixtureSourceKey).(fixtureSource)
+	if !ok {
+		return ""
+	}
+	return source.origin(index).String()
+}
+
+// FixtureFilter selects a subset of a fixture's records, e.g. to debug a
+// slice of a large file or seed a thin slice of reference data
+type FixtureFilter struct {
+	Rows  string // 1-based inclusive range of rows such as "10:20", "10:" or ":20"
+	Where string // Comma-separated conditions such as "country=ID,active!=false", all of which must hold
+}
+
+// Apply returns the records selected by the filter, in file order. Rows are
+// selected first, so they refer to positions in the file.
+func (f FixtureFilter) Apply(records []Record) ([]Record, error) {
+	selected, _, err := f.apply(records, fileOrigins("", len(records)))
+	return selected, err
+}
+
+// apply is Apply also returning the origins of the selected records. Rows
+// refer to the entries of the file read, so an _include or _generate
+// record selects every record it produces.
+func (f FixtureFilter) apply(records []Record, origins []recordOrigin) ([]Record, []recordOrigin, error) {
+	first, last := 1, math.MaxInt
+	if f.Rows != "" {
+		entries := 0
+		for _, origin := range origins {
+			entries = max(entries, origin.entry)
+		}
+		var err error
+		if first, last, err = parseRowRange(f.Rows, entries); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var conditions []condition
+	if f.Where != "" {
+		var err error
+		if conditions, err = parseConditions(f.Where); err != nil {
+			return nil, nil, err
+		}
+	}
+	selected := make([]Record, 0, len(records))
+	selectedOrigins := make([]recordOrigin, 0, len(records))
+	for i, record := range records {
+		entry := origins[i].entry
+		if entry >= first && entry <= last && matchesConditions(record, conditions) {
+			selected = append(selected, record)
+			selectedOrigins = append(selectedOrigins, origins[i])
+		}
+	}
+	return selected, selectedOrigins, nil
+}
+
+// condition compares a record field with a value
+type condition struct {
+	column string
+	value  string
+	negate bool
+}
+
+// parseConditions parses "key=value,key!=value"
+func parseConditions(where string) ([]condition, error) {
+	var conditions []condition
+	for _, part := range splitList(where) {
+		column, value, found := strings.Cut(part, "=")
+		negate := strings.HasSuffix(column, "!")
+		column = strings.TrimSpace(strings.TrimSuffix(column, "!"))
+		if !found || column == "" {
+			return nil, fmt.Errorf("invalid condition %q, expected key=value or key!=value", part)
+		}
+		conditions = append(conditions, condition{column: column, value: strings.TrimSpace(value), negate: negate})
+	}
+	return conditions, nil
+}
+
+// matchesConditions reports whether record satisfies every condition.
+// Values are compared as text; a missing or null field equals "null".
+func matchesConditions(record Record, conditions []condition) bool {
+	for _, c := range conditions {
+		value, ok := record[c.column]
+		text := "null"
+		if ok && value != nil {
+			text = fmt.Sprint(value)
+		}
+		if (text == c.value) == c.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// parseRowRange parses a 1-based inclusive range within total rows
+func parseRowRange(rows string, total int) (int, int, error) {
+	firstText, lastText, found := strings.Cut(rows, ":")
+	if !found {
+		lastText = firstText
+	}
+
+	first, last := 1, total
+	var err error
+	if firstText != "" {
+		if first, err = strconv.Atoi(firstText); err != nil {
+			return 0, 0, fmt.Errorf("invalid row range %q", rows)
+		}
+	}
+	if lastText != "" {
+		if last, err = strconv.Atoi(lastText); err != nil {
+			return 0, 0, fmt.Errorf("invalid row range %q", rows)
+		}
+	}
+	if first < 1 || last < first {
+		return 0, 0, fmt.Errorf("invalid row range %q", rows)
+	}
+	if last > total {
+		last = total
+	}
+	if first > total {
+		return 0, 0, fmt.Errorf("row range %q starts after the last row (%d)", rows, total)
+	}
+	return first, last, nil
+}
+
+// CheckColumns verifies that every column of records exists in table,
+// suggesting the closest column for typos
+func CheckColumns(ctx context.Context, db *sql.DB, table string, records []Record) error {
+	if !identifierPattern.MatchString(table) {
+		return fmt.Errorf("invalid table name %q", table)
+	}
+	columns, err := tableColumns(ctx, db, table)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[column] = true
+	}
+	for _, record := range records {
+		for _, column := range recordColumns(record) {
+			if !known[column] {
+				return fmt.Errorf("table %s has no column '%s'%s", table, column, didYouMean(column, columns))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeFixture writes content to a fixture file in a temp directory
+func writeFixture(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+// TestReadFixtureFile tests reading JSON fixtures
+func TestReadFixtureFile(t *testing.T) {
+	records, err := ReadFixtureFile(writeFixture(t, "users.json", `[{"id": 9007199254740993, "name": "Alice"}]`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{{"id": json.Number("9007199254740993"), "name": "Alice"}}, records)
+	assert.Equal(t, "users", fixtureTable("fixtures/users.json"))
+
+	records, err = ReadFixtureFile(writeFixture(t, "users.ndjson", "{\"id\": 1}\n{\"id\": 2}\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{{"id": json.Number("1")}, {"id": json.Number("2")}}, records)
+
+	_, err = ReadFixtureFile(writeFixture(t, "bad.json", `{`))
+	assert.ErrorContains(t, err, "failed to parse fixture")
+	_, err = ReadFixtureFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+// TestReadFixture tests reading fixtures in every format
+func TestReadFixture(t *testing.T) {
+	cases := map[string]struct {
+		input, format string
+	}{
+		"JSON":            {`[{"id": 1}, {"id": 2}]`, FormatJSON},
+		"NDJSON":          {"{\"id\": 1}\n\n{\"id\": 2}", FormatNDJSON},
+		"Detected JSON":   {"\n  [{\"id\": 1}, {\"id\": 2}]", ""},
+		"Detected NDJSON": {"{\"id\": 1}\n{\"id\": 2}\n", ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			records, err := ReadFixture(strings.NewReader(tc.input), tc.format)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []Record{{"id": json.Number("1")}, {"id": json.Number("2")}}, records)
+		})
+	}
+
+	records, err := ReadFixture(strings.NewReader(""), "")
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	_, err = ReadFixture(strings.NewReader("{\"id\": 1}\n{"), FormatNDJSON)
+	assert.ErrorContains(t, err, "record 2")
+	_, err = ReadFixture(strings.NewReader("[]"), "csv")
+	assert.ErrorContains(t, err, "unknown fixture format")
+}
+
+// TestBuildInsert tests INSERT statement generation
+func TestBuildInsert(t *testing.T) {
+	records := []Record{{"name": "Alice", "id": 1}, {"name": "Bob", "id": 2}}
+
+	query, args, err := BuildInsert("users", records, DollarPlaceholder)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id, name) VALUES ($1, $2), ($3, $4)", query)
+	assert.Equal(t, []any{1, "Alice", 2, "Bob"}, args)
+
+	query, _, err = BuildInsert("app.users", records[:1], QuestionPlaceholder)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO app.users (id, name) VALUES (?, ?)", query)
+
+	invalid := map[string][]Record{
+		"users; DROP TABLE users": records,
+		"users":                   {{"id": 1}, {"name": "Bob"}},
+		"orders":                  {},
+		"accounts":                {{"bad column": 1}},
+	}
+	for table, records := range invalid {
+		_, _, err := BuildInsert(table, records, DollarPlaceholder)
+		assert.Error(t, err, table)
+	}
+}
+
+// idRecords returns count records with IDs 1 to count
+func idRecords(count int) []Record {
+	records := make([]Record, count)
+	for i := range records {
+		records[i] = Record{"id": i + 1}
+	}
+	return records
+}
+
+// TestSQLFixtureWriter tests batched inserts in a transaction
+func TestSQLFixtureWriter(t *testing.T) {
+	t.Run("Batches by size and columns", func(t *testing.T) {
+		db, fake := newFakeDB()
+		writer := &SQLFixtureWriter{DB: db, Placeholder: QuestionPlaceholder, BatchSize: 2}
+
+		err := writer.WriteFixture(context.Background(), "users", []Record{
+			{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4, "name": "Dan"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"BEGIN",
+			"INSERT INTO users (id) VALUES (?), (?) [1 2]",
+			"INSERT INTO users (id) VALUES (?) [3]",
+			"INSERT INTO users (id, name) VALUES (?, ?) [4 Dan]",
+			"COMMIT",
+		}, fake.Events())
+	})
+
+	t.Run("Failure rolls back", func(t *testing.T) {
+		db, fake := newFakeDB()
+		fake.Fail("INSERT INTO users (id) VALUES ($1) [2]", errors.New("duplicate key"))
+		writer := NewSQLFixtureWriter(db, nil)
+		writer.BatchSize = 1
+
+		err := writer.WriteFixture(context.Background(), "users", []Record{{"id": 1}, {"id": 2}})
+
+		assert.EqualError(t, err, `insert of record 2 into users failed: duplicate key; record: {"id":2}`)
+		assert.Equal(t, "ROLLBACK", fake.Events()[len(fake.Events())-1])
+	})
+
+	t.Run("Failed batch is bisected to the offending record", func(t *testing.T) {
+		db, fake := newFakeDB()
+		fake.Fail("INSERT INTO users (id) VALUES (?), (?), (?) [5 6 7]", errors.New("duplicate key"))
+		fake.Fail("INSERT INTO users (id) VALUES (?) [6]", errors.New("duplicate key (id)=(6)"))
+		writer := &SQLFixtureWriter{DB: db, Placeholder: QuestionPlaceholder, BatchSize: 4}
+		ctx := withFixtureSource(context.Background(), "fixtures/users.json", nil)
+
+		err := writer.WriteFixture(ctx, "users", idRecords(7))
+
+		var rowErr *RowError
+		assert.ErrorAs(t, err, &rowErr)
+		assert.Equal(t, 6, rowErr.Index)
+		assert.Equal(t, "fixtures/users.json:6", rowErr.Source)
+		assert.EqualError(t, err, `insert of record 6 (fixtures/users.json:6) into users failed: duplicate key (id)=(6); record: {"id":6}`)
+		assert.Equal(t, []string{
+			"BEGIN",
+			"INSERT INTO users (id) VALUES (?), (?), (?), (?) [1 2 3 4]",
+			"INSERT INTO users (id) VALUES (?), (?), (?) [5 6 7]",
+			"ROLLBACK",
+			"BEGIN",
+			"INSERT INTO users (id) VALUES (?), (?), (?), (?) [1 2 3 4]",
+			"SAVEPOINT goseeder_bisect",
+			"INSERT INTO users (id) VALUES (?) [5]",
+			"RELEASE SAVEPOINT goseeder_bisect",
+			"SAVEPOINT goseeder_bisect",
+			"INSERT INTO users (id) VALUES (?) [6]",
+			"ROLLBACK TO SAVEPOINT goseeder_bisect",
+			"INSERT INTO users (id) VALUES (?) [6]",
+			"ROLLBACK",
+		}, fake.Events(), "the bisect replays earlier batches and commits nothing")
+	})
+
+	t.Run("Batch error is kept when no record fails alone", func(t *testing.T) {
+		db, fake := newFakeDB()
+		fake.Fail("INSERT INTO users (id) VALUES ($1), ($2) [1 2]", errors.New("too many parameters"))
+		writer := NewSQLFixtureWriter(db, nil)
+
+		err := writer.WriteFixture(context.Background(), "users", idRecords(2))
+
+		assert.EqualError(t, err, "insert of records 1-2 into users failed: too many parameters")
+	})
+}
+
+// TestCLIDebugRowCommand tests inserting a single fixture row
+func TestCLIDebugRowCommand(t *testing.T) {
+	file := writeFixture(t, "users.json", `[{"id": 1, "name": "Alice"}, {"id": 2, "name": "Bob"}]`)
+	cli := NewCLI(NewSeederManager())
+
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"debug-row", file + ":2"}), "SetDB")
+
+	db, fake := newFakeDB()
+	cli.SetDB(db)
+
+	t.Run("Rolls back by default", func(t *testing.T) {
+		assert.NoError(t, cli.runCommand(context.Background(), []string{"debug-row", file + ":2"}))
+		assert.Equal(t, []string{"BEGIN", "INSERT INTO users (id, name) VALUES ($1, $2) [2 Bob]", "ROLLBACK"}, fake.Events())
+	})
+
+	t.Run("Commit into another table", func(t *testing.T) {
+		db, fake := newFakeDB()
+		cli.SetDB(db)
+		cli.SetPlaceholder(QuestionPlaceholder)
+		defer cli.SetPlaceholder(nil)
+
+		assert.NoError(t, cli.runCommand(context.Background(), []string{"debug-row", file + ":1", "-table=staff", "-commit"}))
+		assert.Equal(t, []string{"BEGIN", "INSERT INTO staff (id, name) VALUES (?, ?) [1 Alice]", "COMMIT"}, fake.Events())
+	})
+
+	t.Run("Reports the failing row", func(t *testing.T) {
+		db, fake := newFakeDB()
+		fake.Fail("INSERT INTO users (id, name) VALUES ($1, $2) [1 Alice]", errors.New("null value in column \"email\""))
+		cli.SetDB(db)
+
+		err := cli.runCommand(context.Background(), []string{"debug-row", file + ":1"})
+
+		assert.ErrorContains(t, err, "row 1 of "+file+" failed: null value")
+		assert.Equal(t, "ROLLBACK", fake.Events()[len(fake.Events())-1])
+	})
+
+	t.Run("Converts and checks the row like load", func(t *testing.T) {
+		db, fake := newFakeDB()
+		replay := NewCLI(NewSeederManager())
+		replay.SetDB(db)
+		replay.SetColumnLister(ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+			return []ColumnInfo{{Name: "id", Type: "int4", Generated: true}, {Name: "name", Type: "text"}}, nil
+		}))
+		replay.SetColumnTransforms(map[string]ColumnTransform{"name": func(ctx context.Context, value any) (any, error) {
+			return strings.ToUpper(value.(string)), nil
+		}})
+		assert.NoError(t, replay.runCommand(context.Background(), []string{"debug-row", file + ":2"}))
+		assert.Equal(t, []string{"BEGIN", "INSERT INTO users (name) VALUES ($1) [BOB]", "ROLLBACK"}, fake.Events())
+
+		replay.SetValueLister(ValueListerFunc(func(ctx context.Context, table string) (map[string][]string, error) {
+			return map[string][]string{"name": {"ALICE"}}, nil
+		}))
+		err := replay.runCommand(context.Background(), []string{"debug-row", file + ":2"})
+		var validation *ValidationError
+		assert.ErrorAs(t, err, &validation)
+		assert.ErrorContains(t, err, file+":2: column 'name' is \"BOB\", not one of ALICE")
+	})
+
+	t.Run("Invalid locations", func(t *testing.T) {
+		for _, location := range []string{file, file + ":0", file + ":x", file + ":3"} {
+			assert.Error(t, cli.runCommand(context.Background(), []string{"debug-row", location}), location)
+		}
+	})
+}
+
+// TestFixtureFilter tests selecting a subset of a fixture
+func TestFixtureFilter(t *testing.T) {
+	records := []Record{
+		{"id": json.Number("1"), "country": "ID", "capital": true},
+		{"id": json.Number("2"), "country": "NL", "capital": false},
+		{"id": json.Number("3"), "country": "ID", "capital": false},
+		{"id": json.Number("4"), "country": "ID"},
+	}
+	ids := func(records []Record) []string {
+		result := []string{}
+		for _, record := range records {
+			result = append(result, record["id"].(json.Number).String())
+		}
+		return result
+	}
+
+	cases := map[string]struct {
+		filter   FixtureFilter
+		expected []string
+	}{
+		"No filter":     {FixtureFilter{}, []string{"1", "2", "3", "4"}},
+		"Range":         {FixtureFilter{Rows: "2:3"}, []string{"2", "3"}},
+		"Open end":      {FixtureFilter{Rows: "3:"}, []string{"3", "4"}},
+		"Open start":    {FixtureFilter{Rows: ":2"}, []string{"1", "2"}},
+		"Single row":    {FixtureFilter{Rows: "4"}, []string{"4"}},
+		"Clamped":       {FixtureFilter{Rows: "3:100"}, []string{"3", "4"}},
+		"Where":         {FixtureFilter{Where: "country=ID"}, []string{"1", "3", "4"}},
+		"Negated":       {FixtureFilter{Where: "country=ID, capital!=false"}, []string{"1", "4"}},
+		"Null":          {FixtureFilter{Where: "capital=null"}, []string{"4"}},
+		"Range + where": {FixtureFilter{Rows: "2:4", Where: "country=ID"}, []string{"3", "4"}},
+		"No match":      {FixtureFilter{Where: "country=DE"}, []string{}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			selected, err := tc.filter.Apply(records)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ids(selected))
+		})
+	}
+
+	for _, invalid := range []FixtureFilter{{Rows: "x"}, {Rows: "0:2"}, {Rows: "3:2"}, {Rows: "5:"}, {Where: "country"}, {Where: "=ID"}} {
+		_, err := invalid.Apply(records)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// TestCLILoadCommand tests loading a filtered fixture file
+func TestCLILoadCommand(t *testing.T) {
+	file := writeFixture(t, "cities.json", `[{"name": "Jakarta", "country": "ID"}, {"name": "Delft", "country": "NL"}, {"name": "Bandung", "country": "ID"}]`)
+	cli := NewCLI(NewSeederManager())
+
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"load", file}), "SetDB")
+
+	db, fake := newFakeDB()
+	cli.SetDB(db)
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"load", file, "-where=country=ID", "-rows=2:3"}))
+	assert.Equal(t, []string{"BEGIN", "INSERT INTO cities (country, name) VALUES ($1, $2) [ID Bandung]", "COMMIT"}, fake.Events())
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"load", file, "-where=country=DE"}), "nothing selected")
+	assert.Len(t, fake.Events(), 3)
+
+	assert.Error(t, cli.runCommand(context.Background(), []string{"load", file, "-rows=9:"}))
+
+	fake.Fail("INSERT INTO cities (country, name) VALUES ($1, $2), ($3, $4) [ID Jakarta ID Bandung]", errors.New("duplicate key"))
+	fake.Fail("INSERT INTO cities (country, name) VALUES ($1, $2) [ID Bandung]", errors.New("duplicate key"))
+	err := cli.runCommand(context.Background(), []string{"load", file, "-where=country=ID"})
+	assert.ErrorContains(t, err, "insert of record 2 ("+file+":3) into cities failed: duplicate key", "errors point at the row in the file")
+}
+
+// TestCLILoadFromStdin tests loading records piped to stdin
+func TestCLILoadFromStdin(t *testing.T) {
+	db, fake := newFakeDB()
+	cli := NewCLI(NewSeederManager())
+	cli.SetDB(db)
+
+	cli.stdin = strings.NewReader("{\"name\": \"Alice\"}\n{\"name\": \"Bob\"}\n")
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"load", "--table=users", "--format=ndjson"}))
+	assert.Equal(t, []string{"BEGIN", "INSERT INTO users (name) VALUES ($1), ($2) [Alice Bob]", "COMMIT"}, fake.Events())
+
+	cli.stdin = strings.NewReader(`[{"name": "Carol"}]`)
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"load", "-", "-table=staff"}))
+	assert.Contains(t, fake.Events(), "INSERT INTO staff (name) VALUES ($1) [Carol]")
+
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"load"}), "requires -table")
+	cli.stdin = strings.NewReader("{")
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"load", "-table=users"}), "failed to parse stdin")
+}
+
+// TestCheckColumns tests validating record columns against a table
+func TestCheckColumns(t *testing.T) {
+	db, fake := newFakeDB()
+	fake.On("SELECT * FROM users WHERE 1 = 0", []string{"id", "name", "email"})
+	ctx := context.Background()
+
+	assert.NoError(t, CheckColumns(ctx, db, "users", []Record{{"name": "Test", "email": "test@example.com"}}))
+	assert.EqualError(t, CheckColumns(ctx, db, "users", []Record{{"emial": "x"}}), "table users has no column 'emial', did you mean 'email'?")
+	assert.Error(t, CheckColumns(ctx, db, "orders", []Record{{"id": 1}}), "unknown table")
+	assert.Error(t, CheckColumns(ctx, db, "users; --", nil))
+}
+
+// TestCLIInsertCommand tests inserting a row with inline values
+func TestCLIInsertCommand(t *testing.T) {
+	cli := NewCLI(NewSeederManager())
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"insert", "users", "--set", "name=Test"}), "SetDB")
+
+	db, fake := newFakeDB()
+	fake.On("SELECT * FROM users WHERE 1 = 0", []string{"id", "name", "email", "deleted_at"})
+	cli.SetDB(db)
+
+	err := cli.runCommand(context.Background(), []string{"insert", "users", "--set", "name=Test", "--set", "email=test@example.com", "-set=deleted_at=null"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"SELECT * FROM users WHERE 1 = 0",
+		"BEGIN",
+		"INSERT INTO users (deleted_at, email, name) VALUES ($1, $2, $3) [<nil> test@example.com Test]",
+		"COMMIT",
+	}, fake.Events())
+
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"insert", "users", "-set", "nmae=Test"}), "did you mean 'name'?")
+	assert.Error(t, cli.runCommand(context.Background(), []string{"insert", "users"}))
+	assert.Error(t, cli.runCommand(context.Background(), []string{"insert", "-set", "name=Test"}))
+}
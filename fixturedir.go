@@ -0,0 +1,276 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// FixtureOrderFile is the order manifest RegisterFixtureDir reads from the
+// root of a fixture directory, as written by "order -write"
+const FixtureOrderFile = "order.json"
+
+// fixtureDirExtensions are the files RegisterFixtureDir registers seeders for
+var fixtureDirExtensions = []string{".json", ".ndjson", ".jsonl", ".sql"}
+
+// fixtureDir is a directory registered with RegisterFixtureDir
+type fixtureDir struct {
+	dir     string
+	options fixtureDirOptions
+	names   []string // Seeders registered from the directory, guarded by registryMu
+}
+
+// FixtureDirOption configures RegisterFixtureDir
+type FixtureDirOption func(*fixtureDirOptions)
+
+// fixtureDirOptions are the settings of a RegisterFixtureDir call
+type fixtureDirOptions struct {
+	ignore    []string
+	recursive bool
+}
+
+// WithIgnore skips the files and folders of a fixture directory matching
+// one of the patterns, e.g. "**/draft/**" or "*.wip.json". Patterns are
+// relative to the directory and use path.Match syntax per path segment,
+// plus "**" for any number of segments; a pattern without "/" matches the
+// file or folder name at any depth.
+func WithIgnore(patterns ...string) FixtureDirOption {
+	return func(o *fixtureDirOptions) {
+		o.ignore = append(o.ignore, patterns...)
+	}
+}
+
+// WithRecursive reads subdirectories at any depth instead of one level
+// deep. Every folder adds a tag and a name segment, e.g.
+// fixtures/demo/eu/users.json is "demo.eu.users", tagged "demo" and "eu".
+func WithRecursive(recursive bool) FixtureDirOption {
+	return func(o *fixtureDirOptions) {
+		o.recursive = recursive
+	}
+}
+
+// RegisterFixtureDir registers a seeder for every JSON fixture (.json,
+// .ndjson, .jsonl) and SQL file of dir and of its subdirectories. Names and
+// tags derive from the folders, so directory layout replaces manual
+// metadata:
+//
+//	fixtures/countries.json  -> "countries"
+//	fixtures/demo/users.json -> "demo.users", tagged "demo"
+//
+// Subdirectories are read one level deep unless WithRecursive is set;
+// files and folders starting with "." or matching WithIgnore are skipped,
+// and so are environment overlays such as users.staging.json next to
+// users.json, which are merged into their file, see ContextWithEnvironment.
+// Seeders are registered in the order of the FixtureOrderFile manifest,
+// then, for files it does not list, with the files of a folder before its
+// subfolders and both in name order. Each seeder has its file as Paths.
+// Fixtures are written into the table named after the file and SQL files
+// executed, both through the target set with SetPackTarget. Files are
+// read when the seeder runs, templated fixtures such as "users.tmpl.json"
+// rendered with RenderFixture.
+func (sm *SeederManager) RegisterFixtureDir(dir string, options ...FixtureDirOption) error {
+	var settings fixtureDirOptions
+	for _, option := range options {
+		option(&settings)
+	}
+
+	items, err := sm.fixtureDirSeeders(dir, settings)
+	if err != nil {
+		return fmt.Errorf("failed to read fixture directory '%s': %w", dir, err)
+	}
+	if err := sm.RegisterSeeders(items...); err != nil {
+		return fmt.Errorf("fixture directory '%s': %w", dir, err)
+	}
+
+	source := &fixtureDir{dir: dir, options: settings}
+	for _, item := range items {
+		source.names = append(source.names, item.Name)
+	}
+	sm.registryMu.Lock()
+	sm.fixtureDirs = append(sm.fixtureDirs, source)
+	sm.registryMu.Unlock()
+	return nil
+}
+
+// fixtureDirSeeders returns the seeders of the files of dir, in load order
+func (sm *SeederManager) fixtureDirSeeders(dir string, options fixtureDirOptions) ([]SeederItem, error) {
+	for _, pattern := range options.ignore {
+		if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern '%s': %w", pattern, err)
+		}
+	}
+
+	files, err := listFixtureDir(dir, ".", options)
+	if err != nil {
+		return nil, err
+	}
+	if files, err = applyFixtureOrder(dir, files, options); err != nil {
+		return nil, err
+	}
+
+	items := make([]SeederItem, 0, len(files))
+	for _, file := range files {
+		var folders []string
+		if folder := path.Dir(file); folder != "." {
+			folders = strings.Split(folder, "/")
+		}
+		items = append(items, SeederItem{
+			Name:            strings.Join(append(append([]string{}, folders...), fixtureTable(file)), "."),
+			ContextFunction: sm.fixtureFileSeeder(filepath.Join(dir, filepath.FromSlash(file))),
+			Tags:            folders,
+			Paths:           []string{path.Join(filepath.ToSlash(dir), file)},
+		})
+	}
+	return items, nil
+}
+
+// listFixtureDir returns the fixture and SQL files of folder, a slash path
+// relative to dir, files first and then those of its subfolders
+func listFixtureDir(dir, folder string, options fixtureDirOptions) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(folder)))
+	if err != nil {
+		return nil, err
+	}
+
+	var files, subfolders []string
+	for _, entry := range entries {
+		file := path.Join(folder, entry.Name())
+		if strings.HasPrefix(entry.Name(), ".") || options.ignored(file) {
+			continue
+		}
+		switch {
+		case entry.IsDir():
+			if folder == "." || options.recursive {
+				subfolders = append(subfolders, file)
+			}
+		case isFixtureDirFile(file) && file != FixtureOrderFile:
+			files = append(files, file)
+		}
+	}
+
+	folderFiles := slices.Clone(files)
+	files = slices.DeleteFunc(files, func(file string) bool {
+		return isFixtureOverlay(file, folderFiles)
+	})
+	for _, subfolder := range subfolders {
+		nested, err := listFixtureDir(dir, subfolder, options)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, nested...)
+	}
+	return files, nil
+}
+
+// applyFixtureOrder moves the files listed in the FixtureOrderFile of dir,
+// if any, to the front in the listed order
+func applyFixtureOrder(dir string, files []string, options fixtureDirOptions) ([]string, error) {
+	manifest := filepath.Join(dir, FixtureOrderFile)
+	if _, err := os.Stat(manifest); err != nil {
+		return files, nil
+	}
+	listed, err := ReadFixtureOrder(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := make([]string, 0, len(files))
+	for _, file := range listed {
+		relative, err := filepath.Rel(dir, file)
+		if err != nil {
+			return nil, err
+		}
+		relative = filepath.ToSlash(relative)
+		if !slices.Contains(files, relative) {
+			if options.ignored(relative) {
+				continue
+			}
+			return nil, fmt.Errorf("%s lists '%s', which is not a fixture of the directory", FixtureOrderFile, relative)
+		}
+		if !slices.Contains(ordered, relative) {
+			ordered = append(ordered, relative)
+		}
+	}
+	for _, file := range files {
+		if !slices.Contains(ordered, file) {
+			ordered = append(ordered, file)
+		}
+	}
+	return ordered, nil
+}
+
+// ignored reports whether file, a slash path relative to the fixture
+// directory, matches an ignore pattern
+func (o fixtureDirOptions) ignored(file string) bool {
+	for _, pattern := range o.ignore {
+		if !strings.Contains(pattern, "/") {
+			if matched, _ := path.Match(pattern, path.Base(file)); matched {
+				return true
+			}
+			continue
+		}
+		if matchPathSegments(strings.Split(pattern, "/"), strings.Split(file, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathSegments matches path segments against pattern segments, where
+// "**" matches any number of segments
+func matchPathSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchPathSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// isFixtureDirFile reports whether file is a fixture or SQL file
+func isFixtureDirFile(file string) bool {
+	return slices.Contains(fixtureDirExtensions, strings.ToLower(path.Ext(file)))
+}
+
+// fixtureFileSeeder returns the function loading a fixture or SQL file
+// through the pack target
+func (sm *SeederManager) fixtureFileSeeder(file string) SeederFunc {
+	return func(ctx context.Context) error {
+		if strings.EqualFold(filepath.Ext(file), ".sql") {
+			if sm.packExec == nil {
+				return fmt.Errorf("SQL files require an executor, see SeederManager.SetPackTarget")
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read SQL file: %w", err)
+			}
+			_, err = sm.packExec.ExecContext(ctx, string(data))
+			return err
+		}
+
+		if sm.packFixtures == nil {
+			return fmt.Errorf("fixtures require a writer, see SeederManager.SetPackTarget")
+		}
+		records, origins, err := readFixtureFile(ctx, file)
+		if err != nil {
+			return err
+		}
+		return sm.packFixtures.WriteFixture(withFixtureSource(ctx, file, origins), fixtureTable(file), records)
+	}
+}
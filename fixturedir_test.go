@@ -0,0 +1,121 @@
+package goseeder
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRegisterFixtureDir tests deriving names and tags from folders
+func TestRegisterFixtureDir(t *testing.T) {
+	dir := writePackDir(t, map[string]string{
+		"countries.sql":          "INSERT INTO countries (code) VALUES ('NL');",
+		"demo/users.json":        `[{"name": "Alice"}, {"name": "Bob"}]`,
+		"demo/orders.ndjson":     `{"id": 1}`,
+		"load/events.jsonl":      `{"id": 1}`,
+		"demo/eu/users.json":     `[]`,
+		"demo/README.md":         "Demo data",
+		".drafts/users.json":     `[]`,
+		"demo/.scratch.json":     `[]`,
+		"staging/plans/free.sql": "",
+	})
+	manager := NewSeederManager()
+
+	assert.NoError(t, manager.RegisterFixtureDir(dir))
+
+	assert.Equal(t, []string{"countries", "demo.orders", "demo.users", "load.events"}, manager.GetRegisteredSeeders())
+	assert.Equal(t, []string{"demo.orders", "demo.users"}, manager.GetSeedersByTag(TagDemo))
+	assert.Equal(t, []string{"load.events"}, manager.AffectedSeeders([]string{filepath.ToSlash(filepath.Join(dir, "load", "events.jsonl"))}))
+
+	exec := &recordingExecutor{}
+	written := map[string][]Record{}
+	manager.SetPackTarget(exec, FixtureWriterFunc(func(ctx context.Context, table string, records []Record) error {
+		written[table] = records
+		return nil
+	}))
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []string{"INSERT INTO countries (code) VALUES ('NL');"}, exec.queries)
+	assert.Len(t, written["users"], 2)
+	assert.Len(t, written["orders"], 1)
+	assert.Len(t, written["events"], 1)
+}
+
+// TestRegisterFixtureDirErrors tests missing directories and targets
+func TestRegisterFixtureDirErrors(t *testing.T) {
+	manager := NewSeederManager()
+	assert.ErrorContains(t, manager.RegisterFixtureDir(filepath.Join(t.TempDir(), "missing")), "failed to read fixture directory")
+
+	assert.NoError(t, manager.RegisterFixtureDir(writePackDir(t, map[string]string{"users.json": `[]`})))
+	assert.ErrorContains(t, manager.RunSeederByName("users"), "see SeederManager.SetPackTarget")
+
+	err := manager.RegisterFixtureDir(writePackDir(t, map[string]string{"users.sql": ""}))
+	assert.ErrorContains(t, err, "seeder with name 'users' already exists")
+}
+
+// TestRegisterFixtureDirOptions tests recursion, ignore patterns and the
+// registration order
+func TestRegisterFixtureDirOptions(t *testing.T) {
+	files := map[string]string{
+		"zones.json":             `[]`,
+		"countries.sql":          "",
+		"demo/users.json":        `[]`,
+		"demo/eu/users.json":     `[]`,
+		"demo/draft/orders.json": `[]`,
+		"demo/plans.wip.json":    `[]`,
+		"billing/plans.json":     `[]`,
+	}
+
+	t.Run("Recursive with ignore patterns", func(t *testing.T) {
+		manager := NewSeederManager()
+
+		assert.NoError(t, manager.RegisterFixtureDir(writePackDir(t, files), WithIgnore("**/draft/**", "*.wip.json"), WithRecursive(true)))
+
+		assert.Equal(t, []string{"countries", "zones", "billing.plans", "demo.users", "demo.eu.users"}, manager.GetRegisteredSeeders(),
+			"files before subfolders, both in name order")
+		assert.Equal(t, []string{"demo.eu.users"}, manager.GetSeedersByTag("eu"))
+		assert.Equal(t, []string{"demo.users", "demo.eu.users"}, manager.GetSeedersByTag(TagDemo))
+	})
+
+	t.Run("Order manifest", func(t *testing.T) {
+		dir := writePackDir(t, files)
+		assert.NoError(t, WriteFixtureOrder(filepath.Join(dir, FixtureOrderFile), []string{
+			filepath.Join(dir, "demo", "users.json"),
+			filepath.Join(dir, "demo", "draft", "orders.json"),
+			filepath.Join(dir, "zones.json"),
+		}))
+		manager := NewSeederManager()
+
+		assert.NoError(t, manager.RegisterFixtureDir(dir, WithIgnore("**/draft/**")))
+
+		assert.Equal(t, []string{"demo.users", "zones", "countries", "billing.plans", "demo.plans.wip"}, manager.GetRegisteredSeeders(),
+			"listed files first, ignored ones skipped")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		dir := writePackDir(t, files)
+		assert.NoError(t, WriteFixtureOrder(filepath.Join(dir, FixtureOrderFile), []string{filepath.Join(dir, "missing.json")}))
+
+		assert.ErrorContains(t, NewSeederManager().RegisterFixtureDir(dir), "order.json lists 'missing.json', which is not a fixture of the directory")
+		assert.ErrorContains(t, NewSeederManager().RegisterFixtureDir(dir, WithIgnore("[draft")), "invalid ignore pattern '[draft'")
+	})
+}
+
+// TestMatchPathSegments tests "**" patterns
+func TestMatchPathSegments(t *testing.T) {
+	options := fixtureDirOptions{ignore: []string{"**/draft/**", "demo/*.sql", "*.bak"}}
+
+	for file, expected := range map[string]bool{
+		"draft":                 true,
+		"draft/users.json":      true,
+		"demo/draft/users.json": true,
+		"demo/drafts/x.json":    false,
+		"demo/seed.sql":         true,
+		"demo/eu/seed.sql":      false,
+		"demo/eu/users.bak":     true,
+		"users.json":            false,
+	} {
+		assert.Equal(t, expected, options.ignored(file), file)
+	}
+}
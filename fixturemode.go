@@ -0,0 +1,272 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// maxFixtureWarnings is the number of lenient mode warnings logged per write
+const maxFixtureWarnings = 10
+
+// FixtureMode is how strictly SQLFixtureWriter checks records against the
+// columns of their table before inserting them
+type FixtureMode int
+
+const (
+	FixtureUnchecked FixtureMode = iota // Insert records as they are, leaving errors to the database (default)
+	FixtureStrict                       // Fail on unknown columns and on values the database would have to coerce
+	FixtureLenient                      // Drop unknown columns and skip rows with such values, logging a warning report
+)
+
+// String returns the name of the mode
+func (m FixtureMode) String() string {
+	switch m {
+	case FixtureStrict:
+		return "strict"
+	case FixtureLenient:
+		return "lenient"
+	default:
+		return "unchecked"
+	}
+}
+
+// ParseFixtureMode parses "strict", "lenient" or "unchecked" (also "")
+func ParseFixtureMode(name string) (FixtureMode, error) {
+	switch name {
+	case "", "unchecked":
+		return FixtureUnchecked, nil
+	case "strict":
+		return FixtureStrict, nil
+	case "lenient":
+		return FixtureLenient, nil
+	default:
+		return FixtureUnchecked, fmt.Errorf("unknown fixture mode %q, expected strict, lenient or unchecked", name)
+	}
+}
+
+// ContextWithFixtureMode returns a copy of ctx setting the fixture mode of
+// a run, overriding SQLFixtureWriter.Mode but not FileModes
+func ContextWithFixtureMode(ctx context.Context, mode FixtureMode) context.Context {
+	return context.WithValue(ctx, fixtureModeKey, mode)
+}
+
+// mode returns the mode records written with ctx are checked in: the mode
+// of the most specific FileModes pattern matching their fixture file, else
+// the run's, else the writer's
+func (w *SQLFixtureWriter) mode(ctx context.Context) FixtureMode {
+	if source, ok := ctx.Value(fixtureSourceKey).(fixtureSource); ok {
+		if pattern, found := mostSpecificPattern(w.FileModes, source.file); found {
+			return w.FileModes[pattern]
+		}
+	}
+	if mode, ok := ctx.Value(fixtureModeKey).(FixtureMode); ok {
+		return mode
+	}
+	return w.Mode
+}
+
+// mostSpecificPattern returns the pattern of modes matching file that is
+// most specific: the file's exact path, then its exact name, then globs
+// matching the path, then globs matching the name. Among globs the one with
+// the most literal characters wins, and ties go to the alphabetically first.
+func mostSpecificPattern(modes map[string]FixtureMode, file string) (string, bool) {
+	best, bestRank, bestLiterals := "", 0, 0
+	for pattern := range modes {
+		rank := 0
+		switch {
+		case pattern == file:
+			rank = 4
+		case pattern == filepath.Base(file):
+			rank = 3
+		case globMatches(pattern, file):
+			rank = 2
+		case globMatches(pattern, filepath.Base(file)):
+			rank = 1
+		default:
+			continue
+		}
+		literals := len(pattern) - strings.Count(pattern, "*") - strings.Count(pattern, "?")
+		if rank > bestRank || rank == bestRank && (literals > bestLiterals || literals == bestLiterals && pattern < best) {
+			best, bestRank, bestLiterals = pattern, rank, literals
+		}
+	}
+	return best, bestRank > 0
+}
+
+// globMatches reports whether the filepath.Match pattern matches name
+func globMatches(pattern, name string) bool {
+	matched, _ := filepath.Match(pattern, name)
+	return matched
+}
+
+// checkFixture checks records against the columns of table. In strict mode
+// every issue is returned as a *ValidationError. In lenient mode unknown
+// columns are dropped and rows with values needing coercion skipped; the
+// remaining records are returned with ctx describing their fixture rows.
+func (w *SQLFixtureWriter) checkFixture(ctx context.Context, table string, records []Record, mode FixtureMode) ([]Record, context.Context, error) {
+	columns, types, err := tableColumnTypes(ctx, w.DB, table)
+	if err != nil {
+		return nil, ctx, err
+	}
+	location := table
+	if source, ok := ctx.Value(fixtureSourceKey).(fixtureSource); ok {
+		location = source.file
+	}
+
+	issues := &ValidationError{}
+	var unknown []string
+	for _, record := range records {
+		for _, column := range recordColumns(record) {
+			if !slices.Contains(columns, column) && !slices.Contains(unknown, column) {
+				unknown = append(unknown, column)
+				issues.add("fixture", "%s: table %s has no column '%s'%s", location, table, column, didYouMean(column, columns))
+			}
+		}
+	}
+
+	kept := make([]Record, 0, len(records))
+	indexes := make([]int, 0, len(records))
+	skipped := 0
+	for i, record := range records {
+		valid := true
+		for _, column := range recordColumns(record) {
+			if problem := coercionProblem(types[column], record[column]); problem != "" {
+				valid = false
+				issues.add("fixture", "%s: column '%s' %s", recordLocation(ctx, i), column, problem)
+			}
+		}
+		if !valid {
+			skipped++
+			continue
+		}
+		if len(unknown) > 0 {
+			trimmed := make(Record, len(record))
+			for column, value := range record {
+				if !slices.Contains(unknown, column) {
+					trimmed[column] = value
+				}
+			}
+			record = trimmed
+		}
+		kept = append(kept, record)
+		indexes = append(indexes, i)
+	}
+
+	if len(issues.Issues) == 0 {
+		return records, ctx, nil
+	}
+	if mode == FixtureStrict {
+		return nil, ctx, issues
+	}
+
+	logWarn(ctx, "Lenient fixture %s: dropped %d unknown column(s), skipped %d of %d row(s)", location, len(unknown), skipped, len(records))
+	for i, issue := range issues.Issues {
+		if i == maxFixtureWarnings {
+			logWarn(ctx, "  ... and %d more", len(issues.Issues)-i)
+			break
+		}
+		logWarn(ctx, "  %s", issue.Message)
+	}
+	return kept, selectFixtureSource(ctx, indexes), nil
+}
+
+// recordLocation returns "file:row" of the record at index, or "record N"
+// when the records' source is unknown
+func recordLocation(ctx context.Context, index int) string {
+	if row := fixtureSourceRow(ctx, index); row != "" {
+		return row
+	}
+	return fmt.Sprintf("record %d", index+1)
+}
+
+// tableColumnTypes returns the columns of table and their database type
+// names, such as "INT4" or "VARCHAR". Types are empty when the driver does
+// not report them.
+func tableColumnTypes(ctx context.Context, db *sql.DB, table string) ([]string, map[string]string, error) {
+	if !identifierPattern.MatchString(table) {
+		return nil, nil, fmt.Errorf("invalid table name %q", table)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT * FROM "+table+" WHERE 1 = 0")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make([]string, len(columnTypes))
+	types := make(map[string]string, len(columnTypes))
+	for i, columnType := range columnTypes {
+		columns[i] = columnType.Name()
+		types[columnType.Name()] = strings.ToUpper(columnType.DatabaseTypeName())
+	}
+	return columns, types, nil
+}
+
+// coercionProblem describes why the database would have to coerce value to
+// store it in a column of databaseType, e.g. the string "42" in an INT
+// column, or returns "". NULLs and types it does not know are accepted.
+// DECIMAL and NUMERIC columns take decimal strings such as "19.99", which
+// keep money exact, but not floats, which may not be.
+func coercionProblem(databaseType string, value any) string {
+	if value == nil || databaseType == "" {
+		return ""
+	}
+	if databaseType == "DECIMAL" || databaseType == "NUMERIC" {
+		switch v := value.(type) {
+		case string:
+			if decimalPattern.MatchString(v) {
+				return ""
+			}
+		case float32, float64:
+			return fmt.Sprintf("is %s but %v is a float, which may not be exact; use a JSON number, a string or Decimal", databaseType, v)
+		}
+	}
+	kind := valueKind(value)
+	if kind == "" {
+		return ""
+	}
+
+	expected := ""
+	switch {
+	case databaseType == "BOOL" || databaseType == "BOOLEAN":
+		expected = "boolean"
+	case databaseType == "TINYINT" && kind == "boolean":
+		return "" // MySQL's BOOLEAN
+	case strings.Contains(databaseType, "INT") || databaseType == "SERIAL" || databaseType == "BIGSERIAL",
+		databaseType == "DECIMAL" || databaseType == "NUMERIC" || databaseType == "REAL" || databaseType == "DOUBLE",
+		strings.HasPrefix(databaseType, "FLOAT"):
+		expected = "number"
+	case strings.Contains(databaseType, "CHAR") || strings.Contains(databaseType, "TEXT"):
+		expected = "string"
+	default:
+		return ""
+	}
+	if kind == expected {
+		return ""
+	}
+	encoded, _ := json.Marshal(value)
+	return fmt.Sprintf("is %s but %s is a %s", databaseType, encoded, kind)
+}
+
+// valueKind returns "number", "string" or "boolean" for fixture values of
+// those JSON types, or "" for others such as objects
+func valueKind(value any) string {
+	switch value.(type) {
+	case json.Number, Decimal, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,134 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseFixtureMode tests parsing fixture mode names
+func TestParseFixtureMode(t *testing.T) {
+	for name, expected := range map[string]FixtureMode{"": FixtureUnchecked, "unchecked": FixtureUnchecked, "strict": FixtureStrict, "lenient": FixtureLenient} {
+		mode, err := ParseFixtureMode(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, mode)
+	}
+	assert.Equal(t, "lenient", FixtureLenient.String())
+
+	_, err := ParseFixtureMode("loose")
+	assert.ErrorContains(t, err, `unknown fixture mode "loose"`)
+}
+
+// fixtureModeRecords are records with an unknown column and values needing coercion
+func fixtureModeRecords() []Record {
+	return []Record{
+		{"id": json.Number("1"), "name": "Ana", "active": true},
+		{"id": "2", "name": "Bo", "nickname": "b"},
+		{"id": json.Number("3"), "name": json.Number("7"), "active": nil},
+		{"id": json.Number("4"), "name": "Di", "nickname": "d"},
+	}
+}
+
+// TestSQLFixtureWriterModes tests strict and lenient fixture checks
+func TestSQLFixtureWriterModes(t *testing.T) {
+	setup := func(mode FixtureMode) (*SQLFixtureWriter, *fakeDB) {
+		db, fake := newFakeDB()
+		fake.OnTyped("SELECT * FROM users WHERE 1 = 0", []string{"id", "name", "active"}, []string{"INT4", "VARCHAR", "BOOL"})
+		return &SQLFixtureWriter{DB: db, Placeholder: QuestionPlaceholder, Mode: mode}, fake
+	}
+	ctx := withFixtureSource(context.Background(), "fixtures/users.json", nil)
+
+	t.Run("Strict reports every issue and inserts nothing", func(t *testing.T) {
+		writer, fake := setup(FixtureStrict)
+
+		err := writer.WriteFixture(ctx, "users", fixtureModeRecords())
+
+		var validation *ValidationError
+		assert.ErrorAs(t, err, &validation)
+		assert.Equal(t, []ValidationIssue{
+			{Check: "fixture", Message: "fixtures/users.json: table users has no column 'nickname'"},
+			{Check: "fixture", Message: `fixtures/users.json:2: column 'id' is INT4 but "2" is a string`},
+			{Check: "fixture", Message: "fixtures/users.json:3: column 'name' is VARCHAR but 7 is a number"},
+		}, validation.Issues)
+		assert.Equal(t, []string{"SELECT * FROM users WHERE 1 = 0"}, fake.Events())
+	})
+
+	t.Run("Lenient drops unknown columns and skips coerced rows", func(t *testing.T) {
+		writer, fake := setup(FixtureLenient)
+
+		assert.NoError(t, writer.WriteFixture(ctx, "users", fixtureModeRecords()))
+		assert.Equal(t, []string{
+			"SELECT * FROM users WHERE 1 = 0",
+			"BEGIN",
+			"INSERT INTO users (active, id, name) VALUES (?, ?, ?) [true 1 Ana]",
+			"INSERT INTO users (id, name) VALUES (?, ?) [4 Di]",
+			"COMMIT",
+		}, fake.Events())
+
+		fake.Fail("INSERT INTO users (id, name) VALUES (?, ?) [4 Di]", errors.New("duplicate key"))
+		err := writer.WriteFixture(ctx, "users", fixtureModeRecords())
+		assert.ErrorContains(t, err, "insert of record 2 (fixtures/users.json:4) into users failed", "rows keep their place in the file")
+	})
+
+	t.Run("Run and file modes override the writer", func(t *testing.T) {
+		writer, fake := setup(FixtureUnchecked)
+		runCtx := ContextWithFixtureMode(ctx, FixtureLenient)
+		assert.NoError(t, writer.WriteFixture(runCtx, "users", fixtureModeRecords()))
+		assert.Contains(t, fake.Events(), "INSERT INTO users (id, name) VALUES (?, ?) [4 Di]")
+
+		writer.FileModes = map[string]FixtureMode{"users.json": FixtureStrict}
+		assert.Error(t, writer.WriteFixture(runCtx, "users", fixtureModeRecords()))
+		writer.FileModes = map[string]FixtureMode{"fixtures/*.json": FixtureUnchecked}
+		assert.NoError(t, writer.WriteFixture(runCtx, "users", fixtureModeRecords()))
+		assert.Contains(t, fake.Events(), "INSERT INTO users (id, name, nickname) VALUES (?, ?, ?) [2 Bo b]", "unchecked leaves the unknown column to the database")
+	})
+}
+
+// TestMostSpecificPattern tests the precedence of FileModes patterns
+func TestMostSpecificPattern(t *testing.T) {
+	modes := map[string]FixtureMode{
+		"*.json":                      FixtureLenient,
+		"fixtures/*/*.json":           FixtureLenient,
+		"fixtures/shared/*.json":      FixtureLenient,
+		"users.json":                  FixtureStrict,
+		"fixtures/shared/users.json":  FixtureUnchecked,
+		"fixtures/shared/orders.json": FixtureStrict,
+	}
+	cases := map[string]string{
+		"fixtures/shared/users.json":  "fixtures/shared/users.json",
+		"fixtures/demo/users.json":    "users.json",
+		"fixtures/shared/plans.json":  "fixtures/shared/*.json",
+		"fixtures/demo/plans.json":    "fixtures/*/*.json",
+		"seeds/plans.json":            "*.json",
+		"fixtures/shared/orders.json": "fixtures/shared/orders.json",
+	}
+	for file, expected := range cases {
+		pattern, found := mostSpecificPattern(modes, file)
+		assert.True(t, found, file)
+		assert.Equal(t, expected, pattern, file)
+	}
+
+	_, found := mostSpecificPattern(modes, "fixtures/users.sql")
+	assert.False(t, found)
+
+	pattern, _ := mostSpecificPattern(map[string]FixtureMode{"b?.json": FixtureStrict, "?a.json": FixtureLenient}, "ba.json")
+	assert.Equal(t, "?a.json", pattern, "ties go to the alphabetically first")
+}
+
+// TestCoercionProblem tests detecting values the database would coerce
+func TestCoercionProblem(t *testing.T) {
+	assert.Empty(t, coercionProblem("INT8", json.Number("42")))
+	assert.Empty(t, coercionProblem("TINYINT", true), "MySQL's BOOLEAN")
+	assert.Empty(t, coercionProblem("TIMESTAMPTZ", "2025-01-02T15:04:05Z"), "unknown types are accepted")
+	assert.Empty(t, coercionProblem("TEXT", nil))
+	assert.Empty(t, coercionProblem("", "42"), "drivers not reporting types")
+	assert.Equal(t, "is BOOL but 1 is a number", coercionProblem("BOOL", json.Number("1")))
+	assert.Empty(t, coercionProblem("NUMERIC", "9.90"), "decimal strings keep money exact")
+	assert.Empty(t, coercionProblem("DECIMAL", NewDecimal(990, 2)))
+	assert.Equal(t, `is NUMERIC but "cheap" is a string`, coercionProblem("NUMERIC", "cheap"))
+	assert.Equal(t, "is DECIMAL but 0.1 is a float, which may not be exact; use a JSON number, a string or Decimal", coercionProblem("DECIMAL", 0.1))
+	assert.Equal(t, "is TEXT but true is a boolean", coercionProblem("TEXT", true))
+}
@@ -0,0 +1,64 @@
+package goseeder
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FeatureFlags reports whether feature flags are on, so seed data for
+// unreleased features only appears where the flag is. Adapt LaunchDarkly,
+// OpenFeature or an in-house service with FeatureFlagsFunc.
+type FeatureFlags interface {
+	Enabled(ctx context.Context, flag string) bool
+}
+
+// FeatureFlagsFunc adapts a function to the FeatureFlags interface
+type FeatureFlagsFunc func(ctx context.Context, flag string) bool
+
+// Enabled calls f(ctx, flag)
+func (f FeatureFlagsFunc) Enabled(ctx context.Context, flag string) bool {
+	return f(ctx, flag)
+}
+
+// MapFeatureFlags is a fixed set of flags, e.g. for tests
+type MapFeatureFlags map[string]bool
+
+// Enabled implements FeatureFlags; unknown flags are off
+func (m MapFeatureFlags) Enabled(ctx context.Context, flag string) bool {
+	return m[flag]
+}
+
+// EnvFeatureFlags reads flags from environment variables named prefix
+// followed by the upper-cased flag, with "-" and "." replaced by "_", e.g.
+// FEATURE_NEW_CHECKOUT=true for "new-checkout" with prefix "FEATURE_".
+// Values are parsed with strconv.ParseBool; anything else is off.
+func EnvFeatureFlags(prefix string) FeatureFlags {
+	return FeatureFlagsFunc(func(ctx context.Context, flag string) bool {
+		name := prefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(flag))
+		enabled, _ := strconv.ParseBool(os.Getenv(name))
+		return enabled
+	})
+}
+
+// SetFeatureFlags sets the flags FlagEnabled, ShouldRun predicates and the
+// {{ flag "..." }} fixture function query
+func (sm *SeederManager) SetFeatureFlags(flags FeatureFlags) {
+	sm.featureFlags = flags
+}
+
+// FlagEnabled reports whether flag is on for the run ctx belongs to. All
+// flags are off without a provider, see SetFeatureFlags.
+func FlagEnabled(ctx context.Context, flag string) bool {
+	flags, ok := ctx.Value(featureFlagsKey).(FeatureFlags)
+	return ok && flags.Enabled(ctx, flag)
+}
+
+// WhenFlag returns a ShouldRun predicate running the seeder only when flag
+// is on
+func WhenFlag(flag string) func(ctx context.Context) bool {
+	return func(ctx context.Context) bool {
+		return FlagEnabled(ctx, flag)
+	}
+}
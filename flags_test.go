@@ -0,0 +1,54 @@
+package goseeder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFeatureFlags tests skipping seeders of flags that are off
+func TestFeatureFlags(t *testing.T) {
+	manager := NewSeederManager()
+	ran := []string{}
+	manager.RegisterSeeders(
+		SeederItem{Name: "users", Function: func() error { ran = append(ran, "users"); return nil }},
+		SeederItem{Name: "checkout_v2", ShouldRun: WhenFlag("new-checkout"), Function: func() error {
+			ran = append(ran, "checkout_v2")
+			return nil
+		}},
+	)
+
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []string{"users"}, ran, "flags are off without a provider")
+	report := manager.LastRunReport()
+	assert.True(t, report.Seeders[1].Skipped)
+
+	manager.SetFeatureFlags(MapFeatureFlags{"new-checkout": true})
+	ran = []string{}
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []string{"users", "checkout_v2"}, ran)
+	assert.Empty(t, manager.LastRunReport().Regressions, "skipped seeders are not compared")
+}
+
+// TestEnvFeatureFlags tests reading flags from the environment
+func TestEnvFeatureFlags(t *testing.T) {
+	t.Setenv("FEATURE_NEW_CHECKOUT", "true")
+	t.Setenv("FEATURE_BETA_SEARCH", "nope")
+	flags := EnvFeatureFlags("FEATURE_")
+
+	assert.True(t, flags.Enabled(context.Background(), "new-checkout"))
+	assert.False(t, flags.Enabled(context.Background(), "beta.search"))
+	assert.False(t, flags.Enabled(context.Background(), "missing"))
+}
+
+// TestFlagFixtureFunc tests conditioning fixture records on flags
+func TestFlagFixtureFunc(t *testing.T) {
+	ctx := context.WithValue(context.Background(), featureFlagsKey, FeatureFlags(MapFeatureFlags{"gift-cards": true}))
+	fixture := []byte(`[{"sku": "basic"}{{ if flag "gift-cards" }}, {"sku": "gift"}{{ end }}{{ if flag "bundles" }}, {"sku": "bundle"}{{ end }}]`)
+
+	rendered, err := RenderFixture(ctx, "products.json", fixture)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"sku": "basic"}, {"sku": "gift"}]`, string(rendered))
+}
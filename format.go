@@ -0,0 +1,111 @@
+package goseeder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FormatFixture returns records in the canonical fixture layout: keys in
+// sorted order, two-space indentation for FormatJSON and one record per
+// line for FormatNDJSON, without HTML escaping. When key is set, records
+// are sorted by that column first, numerically for numbers.
+func FormatFixture(records []Record, format, key string) ([]byte, error) {
+	if key != "" {
+		SortRecords(records, key)
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if records == nil {
+			records = []Record{}
+		}
+		if err := encoder.Encode(records); err != nil {
+			return nil, err
+		}
+	case FormatNDJSON:
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown fixture format %q, expected %s or %s", format, FormatJSON, FormatNDJSON)
+	}
+	return buf.Bytes(), nil
+}
+
+// SortRecords sorts records by the key column, stably. Numbers compare
+// numerically, other values by their text; records without the column
+// come last.
+func SortRecords(records []Record, key string) {
+	sort.SliceStable(records, func(i, j int) bool {
+		a, aok := records[i][key]
+		b, bok := records[j][key]
+		if !aok || !bok || a == nil || b == nil {
+			return (aok && a != nil) && !(bok && b != nil)
+		}
+
+		an, aNumber := exactNumber(a)
+		bn, bNumber := exactNumber(b)
+		if aNumber && bNumber {
+			return an.Cmp(bn) < 0
+		}
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	})
+}
+
+// ErrTemplatedFixture is returned by FormatFixtureFile for a templated
+// fixture whose template actions are not all inside JSON strings, e.g.
+// {{ if flag "x" }} around records, which cannot be formatted without
+// rendering them
+var ErrTemplatedFixture = errors.New("templated fixture is not valid JSON before rendering")
+
+// FormatFixtureFile rewrites a fixture file in the canonical layout, see
+// FormatFixture, and reports whether it changed. With check set, the file
+// is left untouched. Template actions of templated files are kept as they
+// are written; write them inside strings with backticks, e.g.
+// "{{ hash `password123` }}", so the file is valid JSON.
+func FormatFixtureFile(path, key string, check bool) (bool, error) {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	records, err := decodeFixture(bytes.NewReader(original), fixtureFormat(path))
+	if err != nil && isTemplatedFixture(path) {
+		return false, fmt.Errorf("fixture '%s': %w: %v", path, ErrTemplatedFixture, err)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to parse fixture '%s': %w", path, err)
+	}
+
+	formatted, err := FormatFixture(records, fixtureFormat(path), key)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(original, formatted) {
+		return false, nil
+	}
+	if check {
+		return true, nil
+	}
+	return true, os.WriteFile(path, formatted, 0o644)
+}
+
+// fixtureFormat returns the format of a fixture file from its extension
+func fixtureFormat(path string) string {
+	if ext := filepath.Ext(path); ext == ".ndjson" || ext == ".jsonl" {
+		return FormatNDJSON
+	}
+	return FormatJSON
+}
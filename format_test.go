@@ -0,0 +1,78 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFormatFixture tests the canonical fixture layout
+func TestFormatFixture(t *testing.T) {
+	records := []Record{
+		{"name": "<Bob>", "id": json.Number("10")},
+		{"id": json.Number("9"), "name": "Alice"},
+		{"name": "No ID"},
+	}
+
+	formatted, err := FormatFixture(records, FormatJSON, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, `[
+  {
+    "id": 9,
+    "name": "Alice"
+  },
+  {
+    "id": 10,
+    "name": "<Bob>"
+  },
+  {
+    "name": "No ID"
+  }
+]
+`, string(formatted))
+
+	formatted, err = FormatFixture(records, FormatNDJSON, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"id\":9,\"name\":\"Alice\"}\n{\"id\":10,\"name\":\"<Bob>\"}\n{\"name\":\"No ID\"}\n", string(formatted))
+
+	formatted, err = FormatFixture(nil, FormatJSON, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "[]\n", string(formatted))
+
+	_, err = FormatFixture(records, "yaml", "")
+	assert.Error(t, err)
+}
+
+// TestSortRecords tests sorting by a key column
+func TestSortRecords(t *testing.T) {
+	records := []Record{{"code": "b"}, {"code": nil}, {"code": "a"}, {}, {"code": "c"}}
+
+	SortRecords(records, "code")
+
+	assert.Equal(t, []Record{{"code": "a"}, {"code": "b"}, {"code": "c"}, {"code": nil}, {}}, records)
+}
+
+// TestCLIFmtCommand tests formatting fixture files
+func TestCLIFmtCommand(t *testing.T) {
+	cli := NewCLI(NewSeederManager())
+	messy := writeFixture(t, "users.json", `[{"name":"Bob","id":2},{"name":"Alice","id":1}]`)
+	clean := writeFixture(t, "roles.ndjson", "{\"id\":1}\n")
+
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"fmt", "-check", "-key=id", messy, clean}), "1 fixture files are not formatted")
+	original, _ := os.ReadFile(messy)
+	assert.Equal(t, `[{"name":"Bob","id":2},{"name":"Alice","id":1}]`, string(original), "check leaves files untouched")
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"fmt", "-key=id", messy, clean}))
+	formatted, _ := os.ReadFile(messy)
+	assert.Equal(t, "[\n  {\n    \"id\": 1,\n    \"name\": \"Alice\"\n  },\n  {\n    \"id\": 2,\n    \"name\": \"Bob\"\n  }\n]\n", string(formatted))
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"fmt", "-check", "-key=id", messy, clean}))
+	assert.Error(t, cli.runCommand(context.Background(), []string{"fmt"}))
+	assert.Error(t, cli.runCommand(context.Background(), []string{"fmt", messy + ".missing"}))
+
+	conditional := writeFixture(t, "products.tmpl.json", `[{"sku": "basic"}{{ if flag "gift-cards" }}, {"sku": "gift"}{{ end }}]`)
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"fmt", "-check", conditional, clean}), "templated files that are not JSON are skipped")
+}
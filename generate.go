@@ -0,0 +1,320 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// generateKey is the key of fixture records expanding into generated rows:
+//
+//	{"_generate": {"count": 500, "seed": 7, "template": {
+//	  "email": "user[[ .Index ]]@example.com",
+//	  "name": "[[ name ]]",
+//	  "age": "[[ int 18 90 ]]"
+//	}}}
+//
+// .Index is the row number, starting at 1, or drawn from the run sequence
+// named by "sequence" so append runs continue it, see ContextWithAppend.
+// Template strings are Go templates with [[ ]] delimiters, so they are left
+// alone by the {{ }} actions of fixture templates, see RenderFixture.
+const generateKey = "_generate"
+
+// generateDirective is the value of a _generate record
+type generateDirective struct {
+	Count    int            `json:"count"`
+	Seed     *int64         `json:"seed"`     // Defaults to 1, so fixtures load the same rows every time
+	Sequence string         `json:"sequence"` // Run sequence .Index is drawn from, see ReserveSequence
+	Template map[string]any `json:"template"`
+}
+
+// Names used by the name functions of generated rows
+var (
+	generateFirstNames = []string{"Ana", "Ben", "Chloe", "David", "Elena", "Farah", "George", "Hana", "Ivan", "Julia",
+		"Kenji", "Laura", "Mateo", "Nadia", "Omar", "Priya", "Quinn", "Rosa", "Sam", "Tariq"}
+	generateLastNames = []string{"Garcia", "Smith", "Nguyen", "Müller", "Rossi", "Kowalski", "Tanaka", "Silva", "Haddad",
+		"Johnson", "Okafor", "Petrov", "Jansen", "Cohen", "Novak", "Kim", "Dubois", "Larsen", "Santos", "Brown"}
+)
+
+// asciiFolder spells the accented letters of generated names in ASCII, for
+// email addresses
+var asciiFolder = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss", "Ä", "Ae", "Ö", "Oe", "Ü", "Ue",
+	"á", "a", "à", "a", "é", "e", "è", "e", "í", "i", "ó", "o", "ú", "u", "ñ", "n", "ç", "c")
+
+// asciiFold returns name spelled in ASCII letters, dropping the characters
+// asciiFolder does not know
+func asciiFold(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 127 {
+			return -1
+		}
+		return r
+	}, asciiFolder.Replace(name))
+}
+
+// generateFuncs returns the functions available in the templates of
+// generated rows, drawing from r. index points to the .Index of the row
+// being rendered.
+//
+//	firstName, lastName, name  e.g. "Ana", "Müller", "Ana Müller"
+//	email                      e.g. "ana.mueller42@example.com", ASCII and
+//	                           unique per row through the row's .Index
+//	word                       lowercase letters
+//	int 1 100                  an integer between the bounds, inclusive
+//	decimal 1 100 2            a decimal between the bounds with 2 digits
+//	pick "a" "b" "c"           one of the arguments
+//	bool                       true or false
+//	date, datetime             a day or an RFC 3339 time from 2020 to 2025
+//	uuid                       a version 4 UUID
+func generateFuncs(r *rand.Rand, index *int64) template.FuncMap {
+	firstName := func() string { return generateFirstNames[r.Intn(len(generateFirstNames))] }
+	lastName := func() string { return generateLastNames[r.Intn(len(generateLastNames))] }
+	return template.FuncMap{
+		"firstName": firstName,
+		"lastName":  lastName,
+		"name": func() string {
+			return firstName() + " " + lastName()
+		},
+		"email": func() string {
+			return strings.ToLower(fmt.Sprintf("%s.%s%d@example.com", asciiFold(firstName()), asciiFold(lastName()), *index))
+		},
+		"word": func() string {
+			return randomWord(r, 4, 10)
+		},
+		"int": func(low, high int) (int, error) {
+			if high < low {
+				return 0, fmt.Errorf("int: %d is below %d", high, low)
+			}
+			return low + r.Intn(high-low+1), nil
+		},
+		"decimal": func(low, high, scale int) (Decimal, error) {
+			if high < low || scale < 0 || scale > 9 {
+				return "", fmt.Errorf("decimal: invalid bounds %d and %d or scale %d", low, high, scale)
+			}
+			unit := int64(1)
+			for range scale {
+				unit *= 10
+			}
+			return NewDecimal(int64(low)*unit+r.Int63n(int64(high-low)*unit+1), scale), nil
+		},
+		"pick": func(choices ...any) (any, error) {
+			if len(choices) == 0 {
+				return nil, fmt.Errorf("pick: no choices")
+			}
+			return choices[r.Intn(len(choices))], nil
+		},
+		"bool": func() bool {
+			return r.Intn(2) == 1
+		},
+		"date": func() string {
+			return randomTime(r).Format(time.DateOnly)
+		},
+		"datetime": func() string {
+			return randomTime(r).Format(time.RFC3339)
+		},
+		"uuid": func() string {
+			b := make([]byte, 16)
+			r.Read(b)
+			b[6] = b[6]&0x0f | 0x40
+			b[8] = b[8]&0x3f | 0x80
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+		},
+	}
+}
+
+// expandGenerated replaces the _generate records of records with the rows
+// they generate, keeping the order of the file. Generated rows are read
+// from the origin of their directive. Directives naming a sequence reserve
+// their rows' indexes from it when ctx is part of a run.
+func expandGenerated(ctx context.Context, records []Record, origins []recordOrigin) ([]Record, []recordOrigin, error) {
+	var expanded []Record
+	var expandedOrigins []recordOrigin
+	for i, record := range records {
+		value, ok := record[generateKey]
+		if !ok {
+			if expanded != nil {
+				expanded = append(expanded, record)
+				expandedOrigins = append(expandedOrigins, origins[i])
+			}
+			continue
+		}
+		if expanded == nil {
+			expanded = append(make([]Record, 0, len(records)), records[:i]...)
+			expandedOrigins = append(make([]recordOrigin, 0, len(records)), origins[:i]...)
+		}
+		if len(record) != 1 {
+			return nil, nil, fmt.Errorf("%s: %s must be the only key of its record", origins[i], generateKey)
+		}
+		rows, err := generateRows(ctx, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %s: %w", origins[i], generateKey, err)
+		}
+		expanded = append(expanded, rows...)
+		for range rows {
+			expandedOrigins = append(expandedOrigins, origins[i])
+		}
+	}
+	if expanded == nil {
+		return records, origins, nil
+	}
+	return expanded, expandedOrigins, nil
+}
+
+// generateRows renders the rows of a _generate directive
+func generateRows(ctx context.Context, value any) ([]Record, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+	var directive generateDirective
+	if err := decoder.Decode(&directive); err != nil {
+		return nil, fmt.Errorf("invalid directive: %w", err)
+	}
+	if directive.Count < 1 {
+		return nil, fmt.Errorf("count must be at least 1, got %d", directive.Count)
+	}
+	if len(directive.Template) == 0 {
+		return nil, fmt.Errorf("template is empty")
+	}
+	seed := int64(1)
+	if directive.Seed != nil {
+		seed = *directive.Seed
+	}
+	var index int64
+	funcs := generateFuncs(rand.New(rand.NewSource(seed)), &index)
+	first := int64(1)
+	if _, running := ctx.Value(runKey).(*runState); running && directive.Sequence != "" {
+		if first, err = ReserveSequence(ctx, directive.Sequence, int64(directive.Count)); err != nil {
+			return nil, err
+		}
+	}
+
+	columns := recordColumns(directive.Template)
+	templates := make(map[string]any, len(columns))
+	for _, column := range columns {
+		parsed, err := parseGenerateTemplate(column, directive.Template[column], funcs)
+		if err != nil {
+			return nil, err
+		}
+		templates[column] = parsed
+	}
+
+	rows := make([]Record, directive.Count)
+	for i := range rows {
+		index = first + int64(i)
+		data := struct{ Index int64 }{Index: index}
+		row := make(Record, len(columns))
+		for _, column := range columns {
+			value, err := renderGenerateTemplate(templates[column], data)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: column '%s': %w", i+1, column, err)
+			}
+			row[column] = value
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// generateTemplate is a parsed template string; whole is set when the
+// string is a single action, whose output is kept as a number, boolean or
+// null when it is one
+type generateTemplate struct {
+	tmpl  *template.Template
+	whole bool
+}
+
+// parseGenerateTemplate parses the template strings in value, walking
+// objects and arrays
+func parseGenerateTemplate(name string, value any, funcs template.FuncMap) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "[[") {
+			return v, nil
+		}
+		tmpl, err := template.New(name).Delims("[[", "]]").Funcs(funcs).Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("column '%s': %w", name, err)
+		}
+		trimmed := strings.TrimSpace(v)
+		whole := strings.HasPrefix(trimmed, "[[") && strings.HasSuffix(trimmed, "]]") && strings.Count(trimmed, "[[") == 1
+		return generateTemplate{tmpl: tmpl, whole: whole}, nil
+	case map[string]any:
+		parsed := make(map[string]any, len(v))
+		for key, item := range v {
+			p, err := parseGenerateTemplate(name, item, funcs)
+			if err != nil {
+				return nil, err
+			}
+			parsed[key] = p
+		}
+		return parsed, nil
+	case []any:
+		parsed := make([]any, len(v))
+		for i, item := range v {
+			p, err := parseGenerateTemplate(name, item, funcs)
+			if err != nil {
+				return nil, err
+			}
+			parsed[i] = p
+		}
+		return parsed, nil
+	default:
+		return value, nil
+	}
+}
+
+// renderGenerateTemplate renders a value parsed by parseGenerateTemplate
+func renderGenerateTemplate(value any, data any) (any, error) {
+	switch v := value.(type) {
+	case generateTemplate:
+		var rendered strings.Builder
+		if err := v.tmpl.Execute(&rendered, data); err != nil {
+			return nil, err
+		}
+		text := rendered.String()
+		if v.whole {
+			if text == "true" || text == "false" {
+				return text == "true", nil
+			}
+			if text == "<nil>" || text == "null" {
+				return nil, nil
+			}
+			if decimalPattern.MatchString(text) && json.Valid([]byte(text)) {
+				return json.Number(text), nil
+			}
+		}
+		return text, nil
+	case map[string]any:
+		// Keys are rendered in order so a seed always draws the same values
+		rendered := make(map[string]any, len(v))
+		for _, key := range recordColumns(v) {
+			r, err := renderGenerateTemplate(v[key], data)
+			if err != nil {
+				return nil, err
+			}
+			rendered[key] = r
+		}
+		return rendered, nil
+	case []any:
+		rendered := make([]any, len(v))
+		for i, item := range v {
+			r, err := renderGenerateTemplate(item, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered[i] = r
+		}
+		return rendered, nil
+	default:
+		return value, nil
+	}
+}
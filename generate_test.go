@@ -0,0 +1,173 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGenerateDirective tests expanding _generate records into templated rows
+func TestGenerateDirective(t *testing.T) {
+	fixture := `[
+		{"email": "admin@example.com", "name": "Admin"},
+		{"_generate": {"count": 3, "template": {
+			"email": "user[[ .Index ]]@example.com",
+			"name": "[[ name ]]",
+			"age": "[[ int 18 90 ]]",
+			"active": "[[ bool ]]",
+			"price": "[[ decimal 1 100 2 ]]",
+			"plan": "[[ pick \"free\" \"pro\" ]]",
+			"profile": {"id": "[[ uuid ]]", "tags": ["[[ word ]]"]},
+			"role": "member"
+		}}},
+		{"email": "last@example.com", "name": "Last"}
+	]`
+	records, err := ReadFixture(strings.NewReader(fixture), FormatJSON)
+	assert.NoError(t, err)
+	assert.Len(t, records, 5)
+	assert.Equal(t, Record{"email": "admin@example.com", "name": "Admin"}, records[0])
+	assert.Equal(t, Record{"email": "last@example.com", "name": "Last"}, records[4])
+
+	for i, row := range records[1:4] {
+		assert.Equal(t, "user"+string(rune('1'+i))+"@example.com", row["email"])
+		assert.Regexp(t, `^[A-Z][a-z]+ [A-Z]\p{L}+$`, row["name"])
+		assert.IsType(t, json.Number(""), row["age"], "single numeric actions stay numbers")
+		assert.IsType(t, true, row["active"])
+		assert.Regexp(t, `^\d+\.\d\d$`, string(row["price"].(json.Number)))
+		assert.Contains(t, []any{"free", "pro"}, row["plan"])
+		profile := row["profile"].(map[string]any)
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, profile["id"])
+		assert.Regexp(t, `^[a-z]{4,10}$`, profile["tags"].([]any)[0])
+		assert.Equal(t, "member", row["role"])
+	}
+
+	again, err := ReadFixture(strings.NewReader(fixture), FormatJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, records, again, "rows are the same every time without a seed")
+
+	seeded, err := ReadFixture(strings.NewReader(strings.Replace(fixture, `"count": 3,`, `"count": 3, "seed": 99,`, 1)), FormatJSON)
+	assert.NoError(t, err)
+	assert.NotEqual(t, records, seeded)
+
+	ndjson, err := ReadFixture(strings.NewReader(`{"_generate": {"count": 2, "template": {"id": "[[ .Index ]]"}}}`+"\n"), FormatNDJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{{"id": json.Number("1")}, {"id": json.Number("2")}}, ndjson)
+}
+
+// TestGenerateEmail tests that generated emails are ASCII and unique
+func TestGenerateEmail(t *testing.T) {
+	records, err := ReadFixture(strings.NewReader(`[{"_generate": {"count": 2000, "template": {"email": "[[ email ]]"}}}]`), FormatJSON)
+	assert.NoError(t, err)
+
+	seen := make(map[any]bool, len(records))
+	for i, row := range records {
+		assert.Regexp(t, `^[a-z]+\.[a-z]+\d+@example\.com$`, row["email"])
+		assert.False(t, seen[row["email"]], "row %d repeats %s", i+1, row["email"])
+		seen[row["email"]] = true
+	}
+
+	assert.Equal(t, "mueller", strings.ToLower(asciiFold("Müller")))
+	assert.Equal(t, "Jose", asciiFold("José"))
+}
+
+// TestGenerateDirectiveFixtureTemplate tests generating rows in a fixture
+// file that also uses {{ }} template actions
+func TestGenerateDirectiveFixtureTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.tmpl.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`[
+		{"_generate": {"count": 2, "template": {"email": "user[[ .Index ]]@example.com", "beta": {{ flag "beta" }}}}}
+	]`), 0o644))
+
+	records, err := ReadFixtureFileContext(context.Background(), path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{
+		{"email": "user1@example.com", "beta": false},
+		{"email": "user2@example.com", "beta": false},
+	}, records)
+}
+
+// TestGenerateDirectiveErrors tests reporting invalid directives
+func TestGenerateDirectiveErrors(t *testing.T) {
+	for fixture, expected := range map[string]string{
+		`[{"_generate": {"count": 0, "template": {"id": 1}}}]`:                         "record 1: _generate: count must be at least 1, got 0",
+		`[{"id": 1}, {"_generate": {"count": 2}}]`:                                     "record 2: _generate: template is empty",
+		`[{"_generate": {"count": 2, "template": {"id": 1}}, "id": 2}]`:                "record 1: _generate must be the only key of its record",
+		`[{"_generate": {"count": 2, "rows": 3, "template": {"id": 1}}}]`:              `record 1: _generate: invalid directive: json: unknown field "rows"`,
+		`[{"_generate": {"count": 2, "template": {"id": "[[ nope ]]"}}}]`:              `function "nope" not defined`,
+		`[{"_generate": {"count": 2, "template": {"age": "[[ int 9 1 ]]"}}}]`:          "record 1: _generate: row 1: column 'age':",
+		`[{"_generate": {"count": 2, "template": {"plan": "[[ pick ]]"}}}]`:            "pick: no choices",
+		`[{"_generate": {"count": 2, "template": {"price": "[[ decimal 1 2 -1 ]]"}}}]`: "decimal: invalid bounds 1 and 2 or scale -1",
+	} {
+		_, err := ReadFixture(strings.NewReader(fixture), FormatJSON)
+		assert.ErrorContains(t, err, expected, fixture)
+	}
+}
+
+// TestGenerateDirectiveRows tests pointing at the file row of generated records and those after them
+func TestGenerateDirectiveRows(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`[
+		{"_generate": {"count": 3, "template": {"id": "[[ .Index ]]"}}},
+		{"id": 99}
+	]`), 0o644))
+
+	_, origins, err := readFixtureFile(context.Background(), path)
+	assert.NoError(t, err)
+	assert.Equal(t, []recordOrigin{
+		{file: path, row: 1, entry: 1},
+		{file: path, row: 1, entry: 1},
+		{file: path, row: 1, entry: 1},
+		{file: path, row: 2, entry: 2},
+	}, origins)
+
+	db, fake := newFakeDB()
+	cli := NewCLI(NewSeederManager())
+	cli.SetDB(db)
+	fake.Fail("INSERT INTO users (id) VALUES ($1), ($2), ($3), ($4) [1 2 3 99]", errors.New("duplicate key"))
+	fake.Fail("INSERT INTO users (id) VALUES ($1), ($2) [3 99]", errors.New("duplicate key"))
+	fake.Fail("INSERT INTO users (id) VALUES ($1) [99]", errors.New("duplicate key"))
+	err = cli.runCommand(context.Background(), []string{"load", path})
+	assert.ErrorContains(t, err, "insert of record 4 ("+path+":2) into users failed")
+
+	t.Run("Pack fixtures", func(t *testing.T) {
+		db, fake := newFakeDB()
+		fake.Fail("INSERT INTO users (id) VALUES ($1), ($2), ($3), ($4) [1 2 3 99]", errors.New("duplicate key"))
+		fake.Fail("INSERT INTO users (id) VALUES ($1), ($2) [3 99]", errors.New("duplicate key"))
+		fake.Fail("INSERT INTO users (id) VALUES ($1) [99]", errors.New("duplicate key"))
+		manager := NewSeederManager()
+		manager.SetPackTarget(nil, NewSQLFixtureWriter(db, nil))
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		run, err := manager.packSeeder(fstest.MapFS{"users.json": {Data: data}}, PackSeeder{Name: "users", Fixture: "users.json", Table: "users"})
+		assert.NoError(t, err)
+		assert.ErrorContains(t, run(context.Background()), "insert of record 4 (users.json:2) into users failed")
+	})
+
+	other := filepath.Join(dir, "more.json")
+	assert.NoError(t, os.WriteFile(other, []byte(`[{"_include": "bad.json"}]`), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte(`[{"id": 1}, {"_generate": {"count": 0, "template": {"id": 1}}}]`), 0o644))
+	_, err = ReadFixtureFile(other)
+	assert.ErrorContains(t, err, filepath.Join(dir, "bad.json")+":2: _generate: count must be at least 1")
+}
+
+// TestGenerateDirectiveFormat tests that formatting keeps directives unexpanded
+func TestGenerateDirectiveFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`[{"_generate": {"count": 500, "template": {"name": "[[ name ]]"}}}]`), 0o644))
+
+	changed, err := FormatFixtureFile(path, "", false)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"count": 500`)
+	assert.Equal(t, 1, strings.Count(string(data), `"name"`), "rows are not generated")
+}
@@ -0,0 +1,44 @@
+package goseedertest
+
+import (
+	"sync"
+	"time"
+
+	"go.risoftinc.com/goseeder"
+)
+
+// Ensure MockClock implements goseeder.Clock
+var _ goseeder.Clock = (*MockClock)(nil)
+
+// MockClock is a clock that only moves when told to, for stable run IDs,
+// history entries and retention expiries, see goseeder.SeederManager.SetClock
+type MockClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewMockClock creates a clock stopped at now
+func NewMockClock(now time.Time) *MockClock {
+	return &MockClock{now: now}
+}
+
+// Now returns the time of the clock
+func (c *MockClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to now
+func (c *MockClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d
+func (c *MockClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,45 @@
+package goseedertest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.risoftinc.com/goseeder"
+)
+
+// TestMockClock tests moving the mock clock and using it for runs
+func TestMockClock(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Moves only when told to", func(t *testing.T) {
+		clock := NewMockClock(at)
+		assert.Equal(t, at, clock.Now())
+
+		clock.Advance(time.Minute)
+		assert.Equal(t, at.Add(time.Minute), clock.Now())
+
+		clock.Set(at)
+		assert.Equal(t, at, clock.Now())
+	})
+
+	t.Run("Stamps the runs of a manager", func(t *testing.T) {
+		clock := NewMockClock(at)
+		manager := goseeder.NewSeederManager()
+		manager.SetClock(clock)
+
+		var started []time.Time
+		require.NoError(t, manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			info, _ := goseeder.RunInfoFromContext(ctx)
+			started = append(started, info.StartedAt)
+			return nil
+		}))
+		require.NoError(t, manager.RunAllSeeders())
+		clock.Advance(time.Hour)
+		require.NoError(t, manager.RunAllSeeders())
+
+		assert.Equal(t, []time.Time{at, at.Add(time.Hour)}, started)
+	})
+}
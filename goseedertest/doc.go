@@ -0,0 +1,4 @@
+// Package goseedertest provides test doubles and helpers for code that uses
+// goseeder: a fake manager, a testify mock of the manager, configurable test
+// seeders, a mock clock, a scripted fake database and output capture.
+package goseedertest
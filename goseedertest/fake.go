@@ -0,0 +1,382 @@
+package goseedertest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+
+	"go.risoftinc.com/goseeder"
+)
+
+// Ensure FakeManager implements goseeder.Manager
+var _ goseeder.Manager = (*FakeManager)(nil)
+
+// FakeManager is an in-memory manager that records executions without
+// logging. Besides goseeder.Manager it has the scenario, rollback,
+// validation and other methods of *goseeder.SeederManager that CLI commands
+// such as scenario and rollback require.
+type FakeManager struct {
+	seeders     []goseeder.SeederItem
+	seederMap   map[string]goseeder.SeederItem
+	shouldError bool
+	errorMsg    string
+	executed    []string
+	scenarios   []goseeder.Scenario
+}
+
+// NewFakeManager creates a new fake manager
+func NewFakeManager() *FakeManager {
+	return &FakeManager{
+		seeders:   make([]goseeder.SeederItem, 0),
+		seederMap: make(map[string]goseeder.SeederItem),
+	}
+}
+
+// SetErrorBehavior makes registration and execution fail with errorMsg
+func (fm *FakeManager) SetErrorBehavior(shouldError bool, errorMsg string) {
+	fm.shouldError = shouldError
+	fm.errorMsg = errorMsg
+}
+
+// Executed returns the names of the seeders run so far, in order
+func (fm *FakeManager) Executed() []string {
+	return append([]string{}, fm.executed...)
+}
+
+// RegisterSeeder registers a seeder with the same validation as goseeder
+func (fm *FakeManager) RegisterSeeder(name string, function func() error, options ...goseeder.SeederOption) error {
+	item := goseeder.SeederItem{
+		Name:     name,
+		Function: function,
+	}
+	for _, option := range options {
+		option(&item)
+	}
+	return fm.registerItem(item)
+}
+
+// registerItem validates and stores a seeder
+func (fm *FakeManager) registerItem(seeder goseeder.SeederItem) error {
+	if fm.shouldError {
+		return fm.injectedError("registration error")
+	}
+
+	if seeder.Name == "" {
+		return &Error{Message: "seeder name cannot be empty"}
+	}
+
+	if _, exists := fm.seederMap[seeder.Name]; exists {
+		return &Error{Message: "seeder with name '" + seeder.Name + "' already exists"}
+	}
+
+	position := len(fm.seeders)
+	for position > 0 && fm.seeders[position-1].Priority > seeder.Priority {
+		position--
+	}
+	fm.seeders = slices.Insert(fm.seeders, position, seeder)
+	fm.seederMap[seeder.Name] = seeder
+
+	return nil
+}
+
+// RegisterSeeders registers multiple seeders at once
+func (fm *FakeManager) RegisterSeeders(seeders ...goseeder.SeederItem) error {
+	for _, seeder := range seeders {
+		if err := fm.registerItem(seeder); err != nil {
+			return &Error{Message: "failed to register seeder '" + seeder.Name + "': " + err.Error()}
+		}
+	}
+	return nil
+}
+
+// GetRegisteredSeeders returns the registered seeder names
+func (fm *FakeManager) GetRegisteredSeeders() []string {
+	names := make([]string, len(fm.seeders))
+	for i, seeder := range fm.seeders {
+		names[i] = seeder.Name
+	}
+	return names
+}
+
+// GetSeedersByTag returns the names of the seeders carrying any of the tags
+func (fm *FakeManager) GetSeedersByTag(tags ...string) []string {
+	names := make([]string, 0)
+	for _, seeder := range fm.seeders {
+		if seeder.HasAnyTag(tags...) {
+			names = append(names, seeder.Name)
+		}
+	}
+	return names
+}
+
+// GetSeedersByPhase returns the names of the seeders of the phase
+func (fm *FakeManager) GetSeedersByPhase(phase goseeder.Phase) []string {
+	names := make([]string, 0)
+	for _, seeder := range fm.seeders {
+		if seeder.SeederPhase() == phase {
+			names = append(names, seeder.Name)
+		}
+	}
+	return names
+}
+
+// AffectedSeeders returns the names of the seeders whose paths contain one
+// of the changed files
+func (fm *FakeManager) AffectedSeeders(changed []string) []string {
+	names := make([]string, 0)
+	for _, seeder := range fm.seeders {
+		if seeder.Affects(changed...) {
+			names = append(names, seeder.Name)
+		}
+	}
+	return names
+}
+
+// RunSeederByName runs a specific seeder by name
+func (fm *FakeManager) RunSeederByName(name string) error {
+	return fm.RunSeederByNameContext(context.Background(), name)
+}
+
+// RunSeederByNameContext runs a specific seeder by name, failing if ctx is done
+func (fm *FakeManager) RunSeederByNameContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if fm.shouldError {
+		return fm.injectedError("execution error")
+	}
+
+	seeder, exists := fm.seederMap[name]
+	if !exists {
+		return &Error{Message: "seeder with name '" + name + "' not found"}
+	}
+
+	if seeder.ShouldRun != nil && !seeder.ShouldRun(ctx) {
+		return nil
+	}
+	fm.executed = append(fm.executed, name)
+	var err error
+	if seeder.ContextFunction != nil {
+		err = seeder.ContextFunction(ctx)
+	} else {
+		err = seeder.Function()
+	}
+	if err != nil {
+		return &Error{Message: "seeder '" + name + "' failed: " + err.Error()}
+	}
+	return nil
+}
+
+// RollbackSeederContext runs the rollback of a seeder, recording it as
+// "rollback:<name>" in Executed
+func (fm *FakeManager) RollbackSeederContext(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	seeder, exists := fm.seederMap[name]
+	if !exists {
+		return &Error{Message: "seeder with name '" + name + "' not found"}
+	}
+	if seeder.Rollback == nil {
+		return &Error{Message: "seeder '" + name + "' has no rollback"}
+	}
+	fm.executed = append(fm.executed, "rollback:"+name)
+	if err := seeder.Rollback(); err != nil {
+		return &Error{Message: "rollback of seeder '" + name + "' failed: " + err.Error()}
+	}
+	return nil
+}
+
+// RollbackAllContext runs the rollbacks of all seeders having one, in
+// reverse registration order
+func (fm *FakeManager) RollbackAllContext(ctx context.Context) error {
+	for i := len(fm.seeders) - 1; i >= 0; i-- {
+		if fm.seeders[i].Rollback == nil {
+			continue
+		}
+		if err := fm.RollbackSeederContext(ctx, fm.seeders[i].Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunSeedersInOrder runs multiple seeders in the specified order
+func (fm *FakeManager) RunSeedersInOrder(names []string) error {
+	return fm.RunSeedersInOrderContext(context.Background(), names)
+}
+
+// RunSeedersInOrderContext runs multiple seeders in the specified order
+func (fm *FakeManager) RunSeedersInOrderContext(ctx context.Context, names []string) error {
+	for _, name := range names {
+		if err := fm.RunSeederByNameContext(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunAllSeeders runs all registered seeders in registration order
+func (fm *FakeManager) RunAllSeeders() error {
+	return fm.RunAllSeedersContext(context.Background())
+}
+
+// RunAllSeedersContext runs all registered seeders in registration order
+func (fm *FakeManager) RunAllSeedersContext(ctx context.Context) error {
+	return fm.RunSeedersInOrderContext(ctx, fm.GetRegisteredSeeders())
+}
+
+// RunAllSeedersParallelContext runs all registered seeders serially in
+// registration order, so Executed stays deterministic; workers is ignored
+func (fm *FakeManager) RunAllSeedersParallelContext(ctx context.Context, workers int) error {
+	return fm.RunAllSeedersContext(ctx)
+}
+
+// Converge runs all registered seeders in registration order and reports
+// each as applied, skipped, failed or pending; nothing is ever unchanged
+func (fm *FakeManager) Converge(ctx context.Context) (*goseeder.ConvergeResult, error) {
+	result := &goseeder.ConvergeResult{Status: "converged", Seeders: make([]goseeder.ConvergeSeeder, 0, len(fm.seeders))}
+	var failure error
+	for _, seeder := range fm.seeders {
+		entry := goseeder.ConvergeSeeder{Name: seeder.Name, Status: goseeder.ConvergePending}
+		if failure == nil {
+			ran := len(fm.executed)
+			failure = fm.RunSeederByNameContext(ctx, seeder.Name)
+			switch {
+			case failure != nil:
+				entry.Status = goseeder.ConvergeFailed
+				entry.Error = failure.Error()
+			case len(fm.executed) == ran:
+				entry.Status = goseeder.ConvergeSkipped
+			default:
+				entry.Status = goseeder.ConvergeApplied
+				result.Changed = true
+			}
+		}
+		result.Seeders = append(result.Seeders, entry)
+	}
+	if failure != nil {
+		result.Status = "failed"
+		result.Error = failure.Error()
+	}
+	return result, failure
+}
+
+// Fingerprint returns a hash of the registered seeder names, in order;
+// Paths are not read
+func (fm *FakeManager) Fingerprint(root string) (string, error) {
+	h := sha256.New()
+	for _, seeder := range fm.seeders {
+		h.Write([]byte(seeder.Name + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// IsSeederRegistered checks if a seeder with the given name is registered
+func (fm *FakeManager) IsSeederRegistered(name string) bool {
+	_, exists := fm.seederMap[name]
+	return exists
+}
+
+// RegisterScenario registers a scenario
+func (fm *FakeManager) RegisterScenario(scenario goseeder.Scenario) error {
+	if scenario.Name == "" {
+		return &Error{Message: "scenario name cannot be empty"}
+	}
+	fm.scenarios = append(fm.scenarios, scenario)
+	return nil
+}
+
+// GetRegisteredScenarios returns the registered scenario names
+func (fm *FakeManager) GetRegisteredScenarios() []string {
+	names := make([]string, len(fm.scenarios))
+	for i, scenario := range fm.scenarios {
+		names[i] = scenario.Name
+	}
+	return names
+}
+
+// RunScenario runs the seeders of a scenario with its parameters in the context
+func (fm *FakeManager) RunScenario(ctx context.Context, name string, params map[string]string) error {
+	for _, scenario := range fm.scenarios {
+		if scenario.Name != name {
+			continue
+		}
+
+		merged := make(map[string]string)
+		for key, value := range scenario.Params {
+			merged[key] = value
+		}
+		for key, value := range params {
+			merged[key] = value
+		}
+		scenario.Params = merged
+
+		return fm.RunSeedersInOrderContext(goseeder.ContextWithScenario(ctx, scenario), scenario.Seeders)
+	}
+	return &Error{Message: "scenario with name '" + name + "' not found"}
+}
+
+// TeardownScenario calls the scenario's teardown with empty outputs
+func (fm *FakeManager) TeardownScenario(ctx context.Context, name string) error {
+	for _, scenario := range fm.scenarios {
+		if scenario.Name != name {
+			continue
+		}
+		if scenario.Teardown == nil {
+			return &Error{Message: "scenario '" + name + "' has no teardown"}
+		}
+		return scenario.Teardown(goseeder.ContextWithScenario(ctx, scenario), goseeder.ScenarioOutputs{})
+	}
+	return &Error{Message: "scenario with name '" + name + "' not found"}
+}
+
+// BenchmarkSeeder runs the seeder the requested number of times without timing it
+func (fm *FakeManager) BenchmarkSeeder(name string, options goseeder.BenchmarkOptions) (*goseeder.BenchmarkResult, error) {
+	iterations := options.Iterations
+	if iterations <= 0 {
+		iterations = 1
+	}
+
+	for i := 0; i < iterations; i++ {
+		if err := fm.RunSeederByName(name); err != nil {
+			return nil, err
+		}
+	}
+	return &goseeder.BenchmarkResult{Name: name, Iterations: iterations}, nil
+}
+
+// Validate checks that scenarios only reference registered seeders
+func (fm *FakeManager) Validate() error {
+	return fm.ValidateContext(context.Background())
+}
+
+// ValidateContext checks that scenarios only reference registered seeders
+func (fm *FakeManager) ValidateContext(ctx context.Context) error {
+	result := &goseeder.ValidationError{}
+	for _, scenario := range fm.scenarios {
+		for _, name := range scenario.Seeders {
+			if !fm.IsSeederRegistered(name) {
+				result.Issues = append(result.Issues, goseeder.ValidationIssue{
+					Check:   "scenarios",
+					Message: "scenario '" + scenario.Name + "' references unknown seeder '" + name + "'",
+				})
+			}
+		}
+	}
+	if len(result.Issues) > 0 {
+		return result
+	}
+	return nil
+}
+
+// injectedError returns the configured error or the fallback message
+func (fm *FakeManager) injectedError(fallback string) error {
+	if fm.errorMsg != "" {
+		return &Error{Message: fm.errorMsg}
+	}
+	return &Error{Message: fallback}
+}
@@ -0,0 +1,134 @@
+package goseedertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.risoftinc.com/goseeder"
+)
+
+// TestFakeManager tests the in-memory fake manager
+func TestFakeManager(t *testing.T) {
+	t.Run("Register and run", func(t *testing.T) {
+		manager := NewFakeManager()
+
+		err := manager.RegisterSeeders(
+			goseeder.SeederItem{Name: "users", Function: func() error { return nil }},
+			goseeder.SeederItem{Name: "roles", Function: func() error { return nil }},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"users", "roles"}, manager.GetRegisteredSeeders())
+		assert.NoError(t, manager.RunAllSeeders())
+		assert.NoError(t, manager.RunSeedersInOrder([]string{"roles"}))
+		assert.Equal(t, []string{"users", "roles", "roles"}, manager.Executed())
+	})
+
+	t.Run("Priority orders seeders", func(t *testing.T) {
+		manager := NewFakeManager()
+		noop := func() error { return nil }
+		manager.RegisterSeeders(
+			goseeder.SeederItem{Name: "orders", Function: noop, Priority: 10},
+			goseeder.SeederItem{Name: "users", Function: noop},
+		)
+		manager.RegisterSeeder("roles", noop, goseeder.WithPriority(-1))
+
+		assert.NoError(t, manager.RunAllSeeders())
+		assert.Equal(t, []string{"roles", "users", "orders"}, manager.Executed())
+	})
+
+	t.Run("Affected seeders", func(t *testing.T) {
+		manager := NewFakeManager()
+		manager.RegisterSeeders(
+			goseeder.SeederItem{Name: "users", Function: func() error { return nil }, Paths: []string{"fixtures/users.json"}},
+			goseeder.SeederItem{Name: "roles", Function: func() error { return nil }, Paths: []string{"fixtures/roles"}},
+		)
+
+		assert.Equal(t, []string{"roles"}, manager.AffectedSeeders([]string{"fixtures/roles/admin.json"}))
+	})
+
+	t.Run("ShouldRun skips seeders", func(t *testing.T) {
+		manager := NewFakeManager()
+		manager.RegisterSeeders(goseeder.SeederItem{
+			Name:      "beta",
+			Function:  func() error { return nil },
+			ShouldRun: func(ctx context.Context) bool { return false },
+		})
+
+		assert.NoError(t, manager.RunAllSeeders())
+		assert.Empty(t, manager.Executed())
+	})
+
+	t.Run("Rollback in reverse order", func(t *testing.T) {
+		manager := NewFakeManager()
+		noop := func() error { return nil }
+		manager.RegisterSeeders(
+			goseeder.SeederItem{Name: "roles", Function: noop, Rollback: noop},
+			goseeder.SeederItem{Name: "settings", Function: noop},
+			goseeder.SeederItem{Name: "users", Function: noop, Rollback: noop},
+		)
+
+		assert.NoError(t, manager.RollbackAllContext(context.Background()))
+		assert.Equal(t, []string{"rollback:users", "rollback:roles"}, manager.Executed())
+		assert.Error(t, manager.RollbackSeederContext(context.Background(), "settings"))
+	})
+
+	t.Run("Converge reports statuses", func(t *testing.T) {
+		manager := NewFakeManager()
+		manager.RegisterSeeder("roles", func() error { return nil })
+		manager.RegisterSeeder("users", func() error { return errors.New("boom") })
+		manager.RegisterSeeder("orders", func() error { return nil })
+
+		result, err := manager.Converge(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "failed", result.Status)
+		assert.Equal(t, []goseeder.ConvergeSeeder{
+			{Name: "roles", Status: goseeder.ConvergeApplied},
+			{Name: "users", Status: goseeder.ConvergeFailed, Error: "seeder 'users' failed: boom"},
+			{Name: "orders", Status: goseeder.ConvergePending},
+		}, result.Seeders)
+	})
+
+	t.Run("Validation errors", func(t *testing.T) {
+		manager := NewFakeManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		assert.Error(t, manager.RegisterSeeder("", func() error { return nil }))
+		assert.Error(t, manager.RegisterSeeder("users", func() error { return nil }))
+		assert.Error(t, manager.RunSeederByName("missing"))
+		assert.True(t, manager.IsSeederRegistered("users"))
+	})
+
+	t.Run("Error behavior", func(t *testing.T) {
+		manager := NewFakeManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+		manager.SetErrorBehavior(true, "database down")
+
+		assert.EqualError(t, manager.RunSeederByName("users"), "database down")
+		assert.EqualError(t, manager.RegisterSeeder("roles", func() error { return nil }), "database down")
+	})
+
+	t.Run("Benchmark runs iterations", func(t *testing.T) {
+		manager := NewFakeManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		result, err := manager.BenchmarkSeeder("users", goseeder.BenchmarkOptions{Iterations: 3})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Iterations)
+		assert.Len(t, manager.Executed(), 3)
+	})
+
+	t.Run("Validate reports unknown scenario seeders", func(t *testing.T) {
+		manager := NewFakeManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+		assert.NoError(t, manager.ValidateContext(context.Background()))
+
+		manager.RegisterScenario(goseeder.Scenario{Name: "trial", Seeders: []string{"users", "missing"}})
+		err := manager.ValidateContext(context.Background())
+
+		assert.ErrorContains(t, err, "unknown seeder 'missing'")
+	})
+}
@@ -0,0 +1,21 @@
+package goseedertest
+
+import (
+	"database/sql"
+
+	"go.risoftinc.com/goseeder/internal/fakedb"
+)
+
+// FakeDB is a scripted database/sql driver for tests of seeders: queries
+// return the rows scripted with On or OnTyped and every statement, with
+// its arguments, and transaction event ("BEGIN", "BEGIN READ ONLY",
+// "COMMIT" and "ROLLBACK") is logged, see Events. Statements other than
+// queries succeed and affect one row unless scripted to fail with Fail,
+// which also fails transaction events. The tests of goseeder use the same
+// driver.
+type FakeDB = fakedb.FakeDB
+
+// NewFakeDB returns a *sql.DB backed by a new FakeDB
+func NewFakeDB() (*sql.DB, *FakeDB) {
+	return fakedb.New()
+}
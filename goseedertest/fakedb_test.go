@@ -0,0 +1,68 @@
+package goseedertest
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.risoftinc.com/goseeder"
+)
+
+// TestFakeDB tests scripted queries, failures and the event log
+func TestFakeDB(t *testing.T) {
+	t.Run("Returns scripted rows", func(t *testing.T) {
+		db, fake := NewFakeDB()
+		fake.OnTyped("SELECT id, name FROM users", []string{"id", "name"}, []string{"INT", "TEXT"},
+			[]driver.Value{int64(1), "Ana"}, []driver.Value{int64(2), "Ben"})
+
+		rows, err := db.Query("SELECT id, name FROM users")
+		require.NoError(t, err)
+		defer rows.Close()
+		types, err := rows.ColumnTypes()
+		require.NoError(t, err)
+		assert.Equal(t, "TEXT", types[1].DatabaseTypeName())
+		var names []string
+		for rows.Next() {
+			var id int64
+			var name string
+			require.NoError(t, rows.Scan(&id, &name))
+			names = append(names, name)
+		}
+		assert.Equal(t, []string{"Ana", "Ben"}, names)
+	})
+
+	t.Run("Rejects unscripted queries", func(t *testing.T) {
+		db, _ := NewFakeDB()
+
+		_, err := db.Query("SELECT 1")
+
+		assert.EqualError(t, err, "unexpected query: SELECT 1")
+	})
+
+	t.Run("Logs the statements of a fixture write", func(t *testing.T) {
+		db, fake := NewFakeDB()
+		writer := goseeder.NewSQLFixtureWriter(db, goseeder.QuestionPlaceholder)
+
+		err := writer.WriteFixture(context.Background(), "users", []goseeder.Record{{"id": 1}, {"id": 2}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"BEGIN",
+			"INSERT INTO users (id) VALUES (?), (?) [1 2]",
+			"COMMIT",
+		}, fake.Events())
+	})
+
+	t.Run("Fails scripted statements", func(t *testing.T) {
+		db, fake := NewFakeDB()
+		fake.Fail("DELETE FROM users", errors.New("permission denied"))
+
+		_, err := db.Exec("DELETE FROM users")
+
+		assert.EqualError(t, err, "permission denied")
+		assert.Equal(t, []string{"DELETE FROM users"}, fake.Events())
+	})
+}
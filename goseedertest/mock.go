@@ -0,0 +1,34 @@
+package goseedertest
+
+import (
+	"context"
+
+	"go.risoftinc.com/goseeder"
+)
+
+//go:generate mockery --name=manager --structname=MockManager --filename=mock_manager.go --inpackage --unroll-variadic=false --disable-version-string
+
+// Ensure MockManager implements goseeder.Manager
+var _ goseeder.Manager = (*MockManager)(nil)
+
+// manager is the interface MockManager is generated from: goseeder.Manager
+// and the methods of *goseeder.SeederManager that CLI commands such as
+// scenario and rollback require. Variadic arguments are passed to the mock
+// as a single slice, nil when empty, so RegisterSeeder expectations always
+// take three arguments. Run go generate after changing it.
+type manager interface {
+	goseeder.Manager
+	RunAllSeedersParallelContext(ctx context.Context, workers int) error
+	Fingerprint(root string) (string, error)
+	Converge(ctx context.Context) (*goseeder.ConvergeResult, error)
+	RollbackSeederContext(ctx context.Context, name string) error
+	RollbackAllContext(ctx context.Context) error
+	GetSeedersByPhase(phase goseeder.Phase) []string
+	AffectedSeeders(changed []string) []string
+	GetRegisteredScenarios() []string
+	RunScenario(ctx context.Context, name string, params map[string]string) error
+	TeardownScenario(ctx context.Context, name string) error
+	BenchmarkSeeder(name string, options goseeder.BenchmarkOptions) (*goseeder.BenchmarkResult, error)
+	Validate() error
+	ValidateContext(ctx context.Context) error
+}
@@ -0,0 +1,26 @@
+package goseedertest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.risoftinc.com/goseeder"
+)
+
+// TestMockManager tests the testify mock
+func TestMockManager(t *testing.T) {
+	manager := NewMockManager(t)
+	manager.On("IsSeederRegistered", "users").Return(true)
+	manager.On("GetRegisteredSeeders").Return([]string{"users"})
+	manager.On("RunSeederByName", "users").Return(errors.New("boom"))
+	manager.On("RegisterSeeder", "roles", mock.Anything, mock.Anything).Return(nil)
+	manager.On("RegisterSeeder", "users", mock.Anything, mock.Anything).Return(errors.New("taken"))
+
+	assert.True(t, manager.IsSeederRegistered("users"))
+	assert.Equal(t, []string{"users"}, manager.GetRegisteredSeeders())
+	assert.EqualError(t, manager.RunSeederByName("users"), "boom")
+	assert.NoError(t, manager.RegisterSeeder("roles", func() error { return nil }))
+	assert.EqualError(t, manager.RegisterSeeder("users", func() error { return nil }, goseeder.WithTransaction()), "taken")
+}
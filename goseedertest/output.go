@@ -0,0 +1,92 @@
+package goseedertest
+
+import (
+	"io"
+	"log"
+	"os"
+)
+
+// OutputCapture captures stdout, stderr and the standard logger output
+type OutputCapture struct {
+	originalStdout *os.File
+	originalStderr *os.File
+	originalLog    io.Writer
+	stdoutFile     *os.File
+	stderrFile     *os.File
+}
+
+// NewOutputCapture creates a new output capture instance
+func NewOutputCapture() *OutputCapture {
+	return &OutputCapture{}
+}
+
+// Start begins capturing stdout and stderr. Output written through the
+// standard logger is captured as stderr.
+func (oc *OutputCapture) Start() error {
+	var err error
+	oc.stdoutFile, err = os.CreateTemp("", "goseedertest_stdout_*")
+	if err != nil {
+		return err
+	}
+
+	oc.stderrFile, err = os.CreateTemp("", "goseedertest_stderr_*")
+	if err != nil {
+		oc.stdoutFile.Close()
+		os.Remove(oc.stdoutFile.Name())
+		return err
+	}
+
+	// Save the originals and redirect
+	oc.originalStdout = os.Stdout
+	oc.originalStderr = os.Stderr
+	oc.originalLog = log.Writer()
+
+	os.Stdout = oc.stdoutFile
+	os.Stderr = oc.stderrFile
+	log.SetOutput(oc.stderrFile)
+
+	return nil
+}
+
+// Stop stops capturing and returns the captured output
+func (oc *OutputCapture) Stop() (stdout, stderr string, err error) {
+	os.Stdout = oc.originalStdout
+	os.Stderr = oc.originalStderr
+	log.SetOutput(oc.originalLog)
+
+	stdout, err = readAndRemove(oc.stdoutFile)
+	stderrText, stderrErr := readAndRemove(oc.stderrFile)
+	if err == nil {
+		err = stderrErr
+	}
+
+	return stdout, stderrText, err
+}
+
+// readAndRemove closes a temporary file and returns its contents
+func readAndRemove(file *os.File) (string, error) {
+	if file == nil {
+		return "", nil
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// CaptureOutput captures output from a function execution
+func CaptureOutput(fn func()) (stdout, stderr string, err error) {
+	capture := NewOutputCapture()
+
+	if err := capture.Start(); err != nil {
+		return "", "", err
+	}
+
+	fn()
+
+	return capture.Stop()
+}
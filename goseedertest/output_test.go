@@ -0,0 +1,37 @@
+package goseedertest
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCaptureOutput tests the CaptureOutput helper
+func TestCaptureOutput(t *testing.T) {
+	t.Run("Captures stdout, stderr and log output", func(t *testing.T) {
+		stdout, stderr, err := CaptureOutput(func() {
+			fmt.Fprint(os.Stdout, "to stdout")
+			fmt.Fprint(os.Stderr, "to stderr ")
+			log.Print("to log")
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "to stdout", stdout)
+		assert.Contains(t, stderr, "to stderr")
+		assert.Contains(t, stderr, "to log")
+	})
+
+	t.Run("Restores original writers", func(t *testing.T) {
+		originalStdout := os.Stdout
+		originalLog := log.Writer()
+
+		_, _, err := CaptureOutput(func() {})
+
+		assert.NoError(t, err)
+		assert.Equal(t, originalStdout, os.Stdout)
+		assert.Equal(t, originalLog, log.Writer())
+	})
+}
@@ -0,0 +1,109 @@
+package goseedertest
+
+import (
+	"sync"
+
+	"go.risoftinc.com/goseeder"
+)
+
+// Error is the error type returned by test seeders and the fake manager
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ExecutionLog records seeder executions and is safe for concurrent use
+type ExecutionLog struct {
+	mu    sync.Mutex
+	names []string
+}
+
+// Record appends a seeder name to the log
+func (el *ExecutionLog) Record(name string) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	el.names = append(el.names, name)
+}
+
+// Names returns a copy of the recorded seeder names
+func (el *ExecutionLog) Names() []string {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	return append([]string{}, el.names...)
+}
+
+// Reset clears the log
+func (el *ExecutionLog) Reset() {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	el.names = nil
+}
+
+// SeederConfig describes the behavior of a test seeder
+type SeederConfig struct {
+	Name         string
+	ShouldError  bool
+	ErrorMsg     string
+	ExecutionLog *ExecutionLog
+}
+
+// NewSeeder creates a seeder function with the configured behavior
+func NewSeeder(config SeederConfig) func() error {
+	return func() error {
+		if config.ExecutionLog != nil {
+			config.ExecutionLog.Record(config.Name)
+		}
+
+		if config.ShouldError {
+			if config.ErrorMsg != "" {
+				return &Error{Message: config.ErrorMsg}
+			}
+			return &Error{Message: "test error"}
+		}
+
+		return nil
+	}
+}
+
+// NewSeederItem creates a SeederItem with the configured behavior
+func NewSeederItem(config SeederConfig) goseeder.SeederItem {
+	return goseeder.SeederItem{
+		Name:     config.Name,
+		Function: NewSeeder(config),
+	}
+}
+
+// Builder provides a fluent interface for building test seeders
+type Builder struct {
+	seeders []goseeder.SeederItem
+}
+
+// NewBuilder creates a new builder
+func NewBuilder() *Builder {
+	return &Builder{
+		seeders: make([]goseeder.SeederItem, 0),
+	}
+}
+
+// AddSeeder adds a seeder with the given function
+func (b *Builder) AddSeeder(name string, function func() error) *Builder {
+	b.seeders = append(b.seeders, goseeder.SeederItem{
+		Name:     name,
+		Function: function,
+	})
+	return b
+}
+
+// AddTestSeeder adds a seeder with predefined behavior
+func (b *Builder) AddTestSeeder(config SeederConfig) *Builder {
+	b.seeders = append(b.seeders, NewSeederItem(config))
+	return b
+}
+
+// Build returns the built seeders
+func (b *Builder) Build() []goseeder.SeederItem {
+	return b.seeders
+}
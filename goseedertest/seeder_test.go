@@ -0,0 +1,44 @@
+package goseedertest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewSeeder tests the configurable test seeder
+func TestNewSeeder(t *testing.T) {
+	t.Run("Success records execution", func(t *testing.T) {
+		executionLog := &ExecutionLog{}
+		function := NewSeeder(SeederConfig{Name: "users", ExecutionLog: executionLog})
+
+		assert.NoError(t, function())
+		assert.Equal(t, []string{"users"}, executionLog.Names())
+	})
+
+	t.Run("Error with custom message", func(t *testing.T) {
+		function := NewSeeder(SeederConfig{Name: "users", ShouldError: true, ErrorMsg: "boom"})
+
+		err := function()
+
+		assert.EqualError(t, err, "boom")
+	})
+
+	t.Run("Error with default message", func(t *testing.T) {
+		function := NewSeeder(SeederConfig{Name: "users", ShouldError: true})
+
+		assert.EqualError(t, function(), "test error")
+	})
+}
+
+// TestBuilder tests the fluent seeder builder
+func TestBuilder(t *testing.T) {
+	seeders := NewBuilder().
+		AddSeeder("first", func() error { return nil }).
+		AddTestSeeder(SeederConfig{Name: "second"}).
+		Build()
+
+	assert.Len(t, seeders, 2)
+	assert.Equal(t, "first", seeders[0].Name)
+	assert.Equal(t, "second", seeders[1].Name)
+}
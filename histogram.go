@@ -0,0 +1,137 @@
+package goseeder
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+)
+
+// Histogram is the anonymized value distribution of a column, as produced
+// by a profiler run against production. Numeric columns use Buckets,
+// categorical columns use Values.
+type Histogram struct {
+	NullRatio float64           `json:"null_ratio"`
+	Buckets   []HistogramBucket `json:"buckets"`
+	Values    []HistogramValue  `json:"values"`
+}
+
+// HistogramBucket counts the values in [Min, Max)
+type HistogramBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int64   `json:"count"`
+}
+
+// HistogramValue counts the occurrences of a categorical value
+type HistogramValue struct {
+	Value any   `json:"value"`
+	Count int64 `json:"count"`
+}
+
+// Histograms maps "table.column" to the column's histogram
+type Histograms map[string]Histogram
+
+// LoadHistograms reads histograms from a JSON file such as:
+//
+//	{
+//	  "orders.total":  {"null_ratio": 0.02, "buckets": [{"min": 0, "max": 50, "count": 700}, {"min": 50, "max": 500, "count": 300}]},
+//	  "orders.status": {"values": [{"value": "paid", "count": 90}, {"value": "refunded", "count": 10}]}
+//	}
+func LoadHistograms(path string) (Histograms, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read histograms: %w", err)
+	}
+
+	var histograms Histograms
+	if err := json.Unmarshal(data, &histograms); err != nil {
+		return nil, fmt.Errorf("failed to parse histograms '%s': %w", path, err)
+	}
+	for key, h := range histograms {
+		if err := h.validate(); err != nil {
+			return nil, fmt.Errorf("histogram '%s': %w", key, err)
+		}
+	}
+	return histograms, nil
+}
+
+// validate checks that the histogram can be sampled
+func (h Histogram) validate() error {
+	if h.NullRatio < 0 || h.NullRatio > 1 {
+		return fmt.Errorf("null_ratio must be between 0 and 1")
+	}
+	if len(h.Buckets) > 0 && len(h.Values) > 0 {
+		return fmt.Errorf("buckets and values are mutually exclusive")
+	}
+	for _, b := range h.Buckets {
+		if b.Max < b.Min || b.Count < 0 {
+			return fmt.Errorf("invalid bucket [%v, %v) with count %d", b.Min, b.Max, b.Count)
+		}
+	}
+	for _, v := range h.Values {
+		if v.Count < 0 {
+			return fmt.Errorf("invalid count %d for value %v", v.Count, v.Value)
+		}
+	}
+	return nil
+}
+
+// Sample draws a value following the histogram: nil with probability
+// NullRatio, otherwise a float64 from a bucket chosen by weight (uniform
+// within the bucket) or a categorical value chosen by weight. It returns
+// nil for an empty histogram.
+func (h Histogram) Sample(r *rand.Rand) any {
+	if h.NullRatio > 0 && r.Float64() < h.NullRatio {
+		return nil
+	}
+
+	if len(h.Buckets) > 0 {
+		weights := make([]int64, len(h.Buckets))
+		for i, b := range h.Buckets {
+			weights[i] = b.Count
+		}
+		if i := pickWeighted(r, weights); i >= 0 {
+			b := h.Buckets[i]
+			return b.Min + r.Float64()*(b.Max-b.Min)
+		}
+		return nil
+	}
+
+	weights := make([]int64, len(h.Values))
+	for i, v := range h.Values {
+		weights[i] = v.Count
+	}
+	if i := pickWeighted(r, weights); i >= 0 {
+		return h.Values[i].Value
+	}
+	return nil
+}
+
+// SampleFloat draws a numeric value, reporting false for NULL or a
+// categorical histogram
+func (h Histogram) SampleFloat(r *rand.Rand) (float64, bool) {
+	value, ok := h.Sample(r).(float64)
+	return value, ok
+}
+
+// pickWeighted returns an index chosen with probability proportional to its
+// weight, or -1 when all weights are zero
+func pickWeighted(r *rand.Rand, weights []int64) int {
+	var total int64
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return -1
+	}
+
+	n := r.Int63n(total)
+	for i, w := range weights {
+		if n < w {
+			return i
+		}
+		n -= w
+	}
+	return -1
+}
@@ -0,0 +1,89 @@
+package goseeder
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadHistograms tests reading histograms from JSON
+func TestLoadHistograms(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "histograms.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	histograms, err := LoadHistograms(write(`{
+		"orders.total": {"null_ratio": 0.5, "buckets": [{"min": 0, "max": 10, "count": 3}]},
+		"orders.status": {"values": [{"value": "paid", "count": 9}]}
+	}`))
+	assert.NoError(t, err)
+	assert.Len(t, histograms, 2)
+	assert.Equal(t, 0.5, histograms["orders.total"].NullRatio)
+
+	invalid := []string{
+		`{`,
+		`{"a.b": {"null_ratio": 2}}`,
+		`{"a.b": {"buckets": [{"min": 5, "max": 1, "count": 1}]}}`,
+		`{"a.b": {"buckets": [{"min": 0, "max": 1, "count": 1}], "values": [{"value": 1, "count": 1}]}}`,
+		`{"a.b": {"values": [{"value": 1, "count": -1}]}}`,
+	}
+	for _, content := range invalid {
+		_, err := LoadHistograms(write(content))
+		assert.Error(t, err, content)
+	}
+
+	_, err = LoadHistograms(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+// TestHistogramSample tests that samples follow the distribution
+func TestHistogramSample(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	t.Run("Numeric buckets", func(t *testing.T) {
+		h := Histogram{NullRatio: 0.2, Buckets: []HistogramBucket{
+			{Min: 0, Max: 10, Count: 75},
+			{Min: 100, Max: 200, Count: 25},
+		}}
+		nulls, low, high := 0, 0, 0
+		for i := 0; i < 10000; i++ {
+			value, ok := h.SampleFloat(r)
+			switch {
+			case !ok:
+				nulls++
+			case value >= 0 && value < 10:
+				low++
+			case value >= 100 && value < 200:
+				high++
+			default:
+				t.Fatalf("value %v outside every bucket", value)
+			}
+		}
+
+		assert.InDelta(t, 2000, nulls, 200)
+		assert.InDelta(t, 6000, low, 300)
+		assert.InDelta(t, 2000, high, 300)
+	})
+
+	t.Run("Categorical values", func(t *testing.T) {
+		h := Histogram{Values: []HistogramValue{{Value: "paid", Count: 9}, {Value: "refunded", Count: 1}}}
+		counts := map[any]int{}
+		for i := 0; i < 10000; i++ {
+			counts[h.Sample(r)]++
+		}
+
+		assert.InDelta(t, 9000, counts["paid"], 300)
+		assert.InDelta(t, 1000, counts["refunded"], 300)
+	})
+
+	t.Run("Empty histogram", func(t *testing.T) {
+		assert.Nil(t, Histogram{}.Sample(r))
+		assert.Nil(t, Histogram{Buckets: []HistogramBucket{{Min: 0, Max: 1}}}.Sample(r))
+	})
+}
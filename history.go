@@ -0,0 +1,284 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultHistoryTable is the table SQLHistoryStore records applied seeders
+// in when its Table is empty
+const DefaultHistoryTable = "seeder_history"
+
+// HistoryEntry records a seeder applied by a run
+type HistoryEntry struct {
+	Name      string    `json:"name"`
+	RunID     string    `json:"run_id"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// HistoryStore records the seeders applied to a database, like the table
+// of a migration tool, so each seeder runs once, see SetHistoryStore
+type HistoryStore interface {
+	// Record marks a seeder applied, replacing an earlier entry
+	Record(ctx context.Context, entry HistoryEntry) error
+	IsApplied(ctx context.Context, name string) (bool, error)
+	// Applied returns the entries ordered by the time they were applied
+	Applied(ctx context.Context) ([]HistoryEntry, error)
+	// Remove forgets a seeder, e.g. after it was rolled back
+	Remove(ctx context.Context, name string) error
+}
+
+// SetHistoryStore gives every seeder run-once semantics: seeders recorded
+// in store are skipped, whichever way they are run, and seeders completing
+// successfully are recorded. Rolling a seeder back removes its entry. Use
+// ForceRun or ContextWithForceRun to run applied seeders again.
+func (sm *SeederManager) SetHistoryStore(store HistoryStore) {
+	sm.historyStore = store
+}
+
+// ContextWithForceRun returns a copy of ctx whose runs ignore the history
+// store and run applied seeders again, as the CLI's -force flag does
+func ContextWithForceRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceRunKey, true)
+}
+
+// forceRun reports whether ctx runs applied seeders again
+func forceRun(ctx context.Context) bool {
+	force, _ := ctx.Value(forceRunKey).(bool)
+	return force
+}
+
+// ForceRun runs a seeder even if the history store records it as applied,
+// and records it again
+func (sm *SeederManager) ForceRun(name string) error {
+	return sm.RunSeederByNameContext(ContextWithForceRun(context.Background()), name)
+}
+
+// AppliedSeeders returns the history store's entries, ordered by the time
+// they were applied; nil without a history store
+func (sm *SeederManager) AppliedSeeders(ctx context.Context) ([]HistoryEntry, error) {
+	if sm.historyStore == nil {
+		return nil, nil
+	}
+	return sm.historyStore.Applied(ctx)
+}
+
+// PendingSeeders returns, in registration order, the registered seeders the
+// history store has no entry for; all of them without a history store
+func (sm *SeederManager) PendingSeeders(ctx context.Context) ([]string, error) {
+	applied, err := sm.AppliedSeeders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[string]bool, len(applied))
+	for _, entry := range applied {
+		done[entry.Name] = true
+	}
+
+	pending := make([]string, 0)
+	for _, name := range sm.GetRegisteredSeeders() {
+		if !done[name] {
+			pending = append(pending, name)
+		}
+	}
+	return pending, nil
+}
+
+// alreadyApplied reports whether the history store records a seeder as
+// applied and the run does not force it
+func (sm *SeederManager) alreadyApplied(ctx context.Context, name string) (bool, error) {
+	if sm.historyStore == nil || forceRun(ctx) {
+		return false, nil
+	}
+	applied, err := sm.historyStore.IsApplied(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to check the history of seeder '%s': %w", name, err)
+	}
+	return applied, nil
+}
+
+// recordHistory records a seeder applied, if a history store is set
+func (sm *SeederManager) recordHistory(ctx context.Context, name string) error {
+	if sm.historyStore == nil {
+		return nil
+	}
+	entry := HistoryEntry{Name: name, RunID: RunIDFromContext(ctx), AppliedAt: sm.now().UTC()}
+	if err := sm.historyStore.Record(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record seeder '%s' in the history: %w", name, err)
+	}
+	return nil
+}
+
+// forgetHistory removes a rolled back seeder from the history store
+func (sm *SeederManager) forgetHistory(ctx context.Context, name string) error {
+	if sm.historyStore == nil {
+		return nil
+	}
+	if err := sm.historyStore.Remove(ctx, name); err != nil {
+		return fmt.Errorf("failed to remove seeder '%s' from the history: %w", name, err)
+	}
+	return nil
+}
+
+// MemoryHistoryStore keeps the history in memory, for tests and
+// single-process setups
+type MemoryHistoryStore struct {
+	mu      sync.Mutex
+	entries []HistoryEntry
+}
+
+// NewMemoryHistoryStore creates an empty in-memory history store
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{}
+}
+
+// Record implements HistoryStore
+func (s *MemoryHistoryStore) Record(ctx context.Context, entry HistoryEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = slices.DeleteFunc(s.entries, func(e HistoryEntry) bool { return e.Name == entry.Name })
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+// IsApplied implements HistoryStore
+func (s *MemoryHistoryStore) IsApplied(ctx context.Context, name string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.ContainsFunc(s.entries, func(e HistoryEntry) bool { return e.Name == name }), nil
+}
+
+// Applied implements HistoryStore
+func (s *MemoryHistoryStore) Applied(ctx context.Context) ([]HistoryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.entries), nil
+}
+
+// Remove implements HistoryStore
+func (s *MemoryHistoryStore) Remove(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = slices.DeleteFunc(s.entries, func(e HistoryEntry) bool { return e.Name == name })
+	return nil
+}
+
+// SQLHistoryStore keeps the history in a table of the seeded database,
+// created on first use:
+//
+//	CREATE TABLE IF NOT EXISTS seeder_history (
+//		name VARCHAR(255) PRIMARY KEY,
+//		run_id VARCHAR(64) NOT NULL,
+//		applied_at TIMESTAMP NOT NULL
+//	)
+type SQLHistoryStore struct {
+	DB          *sql.DB
+	Placeholder Placeholder // Defaults to DollarPlaceholder
+	Table       string      // Defaults to DefaultHistoryTable
+
+	mu      sync.Mutex
+	created bool
+}
+
+// NewSQLHistoryStore creates a history store writing to db
+func NewSQLHistoryStore(db *sql.DB, placeholder Placeholder) *SQLHistoryStore {
+	return &SQLHistoryStore{DB: db, Placeholder: placeholder}
+}
+
+// table returns the table name, creating the table on first use
+func (s *SQLHistoryStore) table(ctx context.Context) (string, error) {
+	table := s.Table
+	if table == "" {
+		table = DefaultHistoryTable
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.created {
+		query := "CREATE TABLE IF NOT EXISTS " + table + " (name VARCHAR(255) PRIMARY KEY, run_id VARCHAR(64) NOT NULL, applied_at TIMESTAMP NOT NULL)"
+		if _, err := s.DB.ExecContext(ctx, query); err != nil {
+			return "", fmt.Errorf("failed to create table %s: %w", table, err)
+		}
+		s.created = true
+	}
+	return table, nil
+}
+
+// placeholder returns the n-th bind parameter
+func (s *SQLHistoryStore) placeholder(n int) string {
+	if s.Placeholder == nil {
+		return DollarPlaceholder(n)
+	}
+	return s.Placeholder(n)
+}
+
+// Record implements HistoryStore, replacing an earlier entry in a
+// transaction as upserts differ between databases
+func (s *SQLHistoryStore) Record(ctx context.Context, entry HistoryEntry) error {
+	table, err := s.table(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE name = "+s.placeholder(1), entry.Name); err != nil {
+		return err
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (name, run_id, applied_at) VALUES (%s, %s, %s)", table, s.placeholder(1), s.placeholder(2), s.placeholder(3))
+	if _, err := tx.ExecContext(ctx, insert, entry.Name, entry.RunID, entry.AppliedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// IsApplied implements HistoryStore
+func (s *SQLHistoryStore) IsApplied(ctx context.Context, name string) (bool, error) {
+	table, err := s.table(ctx)
+	if err != nil {
+		return false, err
+	}
+	var count int64
+	err = s.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE name = "+s.placeholder(1), name).Scan(&count)
+	return count > 0, err
+}
+
+// Applied implements HistoryStore
+func (s *SQLHistoryStore) Applied(ctx context.Context) ([]HistoryEntry, error) {
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.DB.QueryContext(ctx, "SELECT name, run_id, applied_at FROM "+table+" ORDER BY applied_at, name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []HistoryEntry
+	for rows.Next() {
+		var entry HistoryEntry
+		if err := rows.Scan(&entry.Name, &entry.RunID, &entry.AppliedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// Remove implements HistoryStore
+func (s *SQLHistoryStore) Remove(ctx context.Context, name string) error {
+	table, err := s.table(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.DB.ExecContext(ctx, "DELETE FROM "+table+" WHERE name = "+s.placeholder(1), name)
+	return err
+}
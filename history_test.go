@@ -0,0 +1,98 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetHistoryStore tests that applied seeders run once unless forced
+func TestSetHistoryStore(t *testing.T) {
+	runs := map[string]int{}
+	seeder := func(name string) SeederItem {
+		return SeederItem{
+			Name:     name,
+			Function: func() error { runs[name]++; return nil },
+			Rollback: func() error { return nil },
+		}
+	}
+	store := NewMemoryHistoryStore()
+	manager := NewSeederManager()
+	manager.SetHistoryStore(store)
+	manager.RegisterSeeders(seeder("roles"), seeder("users"))
+
+	pending, err := manager.PendingSeeders(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"roles", "users"}, pending)
+
+	assert.NoError(t, manager.RunSeederByName("roles"))
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, map[string]int{"roles": 1, "users": 1}, runs)
+	assert.True(t, manager.LastRunReport().Seeders[0].Skipped, "applied seeders are reported skipped")
+
+	applied, err := manager.AppliedSeeders(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, applied, 2)
+	assert.Equal(t, "roles", applied[0].Name)
+	assert.NotEmpty(t, applied[0].RunID)
+	pending, _ = manager.PendingSeeders(context.Background())
+	assert.Empty(t, pending)
+
+	assert.NoError(t, manager.ForceRun("roles"))
+	assert.NoError(t, manager.RunAllSeedersContext(ContextWithForceRun(context.Background())))
+	assert.Equal(t, map[string]int{"roles": 3, "users": 2}, runs)
+
+	assert.NoError(t, manager.RollbackSeeder("users"))
+	pending, _ = manager.PendingSeeders(context.Background())
+	assert.Equal(t, []string{"users"}, pending, "rolled back seeders run again")
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, map[string]int{"roles": 3, "users": 3}, runs)
+}
+
+// TestConvergeWithHistory tests that history-applied seeders are unchanged
+func TestConvergeWithHistory(t *testing.T) {
+	manager := NewSeederManager()
+	manager.SetHistoryStore(NewMemoryHistoryStore())
+	manager.RegisterSeeder("roles", func() error { return nil })
+	assert.NoError(t, manager.RunAllSeeders())
+
+	result, err := manager.Converge(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.Changed)
+	assert.Equal(t, ConvergeUnchanged, result.Seeders[0].Status)
+}
+
+// TestSQLHistoryStore tests the statements of the SQL history store
+func TestSQLHistoryStore(t *testing.T) {
+	db, fake := newFakeDB()
+	store := NewSQLHistoryStore(db, QuestionPlaceholder)
+	ctx := context.Background()
+	appliedAt := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	fake.On("SELECT COUNT(*) FROM seeder_history WHERE name = ?", []string{"count"}, []driver.Value{int64(1)})
+	fake.On("SELECT name, run_id, applied_at FROM seeder_history ORDER BY applied_at, name",
+		[]string{"name", "run_id", "applied_at"}, []driver.Value{"roles", "run-1", appliedAt})
+
+	assert.NoError(t, store.Record(ctx, HistoryEntry{Name: "roles", RunID: "run-1", AppliedAt: appliedAt}))
+	applied, err := store.IsApplied(ctx, "roles")
+	assert.NoError(t, err)
+	assert.True(t, applied)
+	entries, err := store.Applied(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []HistoryEntry{{Name: "roles", RunID: "run-1", AppliedAt: appliedAt}}, entries)
+	assert.NoError(t, store.Remove(ctx, "roles"))
+
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS seeder_history (name VARCHAR(255) PRIMARY KEY, run_id VARCHAR(64) NOT NULL, applied_at TIMESTAMP NOT NULL)",
+		"BEGIN",
+		"DELETE FROM seeder_history WHERE name = ? [roles]",
+		"INSERT INTO seeder_history (name, run_id, applied_at) VALUES (?, ?, ?) [roles run-1 " + appliedAt.String() + "]",
+		"COMMIT",
+		"SELECT COUNT(*) FROM seeder_history WHERE name = ? [roles]",
+		"SELECT name, run_id, applied_at FROM seeder_history ORDER BY applied_at, name",
+		"DELETE FROM seeder_history WHERE name = ? [roles]",
+	}, fake.Events())
+}
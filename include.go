@@ -0,0 +1,172 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Keys of fixture records composing fixture files from other files:
+//
+//	{"_include": "common/base_users.json"}
+//	{"_anchor": "admin", "email": "admin@example.com", "role": "admin"}
+//	{"_merge": "admin", "email": "ops@example.com"}
+//	{"_merge": "common/base_users.json#admin", "email": "root@example.com"}
+//
+// A record holding only _include is replaced by the records of the file,
+// relative to the including file. _anchor names a record, and a record
+// with _merge starts from the columns of the named record, set with its
+// own columns: a name alone refers to a record of the file or of the files
+// it included before, "file#name" to a record of another file.
+const (
+	includeKey = "_include"
+	anchorKey  = "_anchor"
+	mergeKey   = "_merge"
+)
+
+// resolveIncludes resolves the _include and _merge records of the records
+// of the fixture file at file, and removes _anchor fields. It also returns
+// where every resolved record was read from.
+func resolveIncludes(ctx context.Context, file string, records []Record) ([]Record, []recordOrigin, error) {
+	resolved, origins, err := resolveComposition(ctx, file, records, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, record := range resolved {
+		if _, ok := record[anchorKey]; ok {
+			resolved[i] = without(record, anchorKey)
+		}
+	}
+	return resolved, origins, nil
+}
+
+// resolveComposition implements resolveIncludes, keeping _anchor fields so
+// including files can refer to them. stack holds the files being
+// resolved, the including ones first, to detect cycles.
+func resolveComposition(ctx context.Context, file string, records []Record, stack []string) ([]Record, []recordOrigin, error) {
+	stack = append(stack, filepath.Clean(file))
+	anchors := make(map[string]Record)
+	resolved := make([]Record, 0, len(records))
+	origins := make([]recordOrigin, 0, len(records))
+	add := func(index int, record Record, origin recordOrigin) error {
+		if name, ok := record[anchorKey]; ok {
+			anchor, ok := name.(string)
+			if !ok || anchor == "" {
+				return fmt.Errorf("record %d: %s must be a name", index+1, anchorKey)
+			}
+			if _, exists := anchors[anchor]; exists {
+				return fmt.Errorf("record %d: anchor '%s' is defined twice", index+1, anchor)
+			}
+			anchors[anchor] = record
+		}
+		origin.entry = index + 1
+		resolved = append(resolved, record)
+		origins = append(origins, origin)
+		return nil
+	}
+
+	for i, record := range records {
+		if value, ok := record[includeKey]; ok {
+			name, ok := value.(string)
+			if len(record) != 1 || !ok || name == "" {
+				return nil, nil, fmt.Errorf("record %d: %s must be the only key of its record and name a file", i+1, includeKey)
+			}
+			included, includedOrigins, err := readComposed(ctx, file, name, stack)
+			if err != nil {
+				return nil, nil, fmt.Errorf("record %d: %w", i+1, err)
+			}
+			for j, record := range included {
+				if err := add(i, record, includedOrigins[j]); err != nil {
+					return nil, nil, err
+				}
+			}
+			continue
+		}
+
+		if value, ok := record[mergeKey]; ok {
+			reference, ok := value.(string)
+			if !ok || reference == "" {
+				return nil, nil, fmt.Errorf("record %d: %s must name an anchor", i+1, mergeKey)
+			}
+			base, err := mergeBase(ctx, file, reference, anchors, stack)
+			if err != nil {
+				return nil, nil, fmt.Errorf("record %d: %w", i+1, err)
+			}
+			merged := without(base, anchorKey)
+			for column, value := range record {
+				if column != mergeKey {
+					merged[column] = value
+				}
+			}
+			record = merged
+		}
+		if err := add(i, record, recordOrigin{file: file, row: i + 1}); err != nil {
+			return nil, nil, err
+		}
+	}
+	return resolved, origins, nil
+}
+
+// readComposed reads the fixture file name, relative to the file including
+// it, with its own includes and merges resolved
+func readComposed(ctx context.Context, from, name string, stack []string) ([]Record, []recordOrigin, error) {
+	path := filepath.Join(filepath.Dir(from), filepath.FromSlash(name))
+	if slices.Contains(stack, filepath.Clean(path)) {
+		return nil, nil, fmt.Errorf("include cycle: %s -> %s", strings.Join(stack, " -> "), filepath.Clean(path))
+	}
+	records, err := decodeFixtureFile(ctx, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved, origins, err := resolveComposition(ctx, path, records, stack)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fixture '%s': %w", path, err)
+	}
+	return resolved, origins, nil
+}
+
+// mergeBase returns the record a _merge reference names: "name" among the
+// anchors defined so far, or "file#name" in another file
+func mergeBase(ctx context.Context, file, reference string, anchors map[string]Record, stack []string) (Record, error) {
+	name, anchor, found := strings.Cut(reference, "#")
+	if !found {
+		if record, ok := anchors[reference]; ok {
+			return record, nil
+		}
+		return nil, fmt.Errorf("unknown anchor '%s'%s", reference, didYouMean(reference, anchorNames(anchors)))
+	}
+
+	records, _, err := readComposed(ctx, file, name, stack)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record[anchorKey] == anchor {
+			return record, nil
+		}
+	}
+	return nil, fmt.Errorf("'%s' has no anchor '%s'", name, anchor)
+}
+
+// anchorNames returns the sorted names of anchors
+func anchorNames(anchors map[string]Record) []string {
+	names := make([]string, 0, len(anchors))
+	for name := range anchors {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// without returns a copy of record without the given column
+func without(record Record, column string) Record {
+	copied := make(Record, len(record))
+	for key, value := range record {
+		if key != column {
+			copied[key] = value
+		}
+	}
+	return copied
+}
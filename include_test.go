@@ -0,0 +1,133 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFixtureIncludes tests composing fixture files with _include, _anchor and _merge
+func TestFixtureIncludes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	write("common/base_users.json", `[
+		{"_anchor": "admin", "email": "admin@example.com", "role": "admin", "active": true},
+		{"email": "member@example.com", "role": "member", "active": true}
+	]`)
+	write("common/roles.json", `[{"_anchor": "auditor", "role": "auditor", "active": false}]`)
+
+	t.Run("Include and merge", func(t *testing.T) {
+		users := write("checkout/users.json", `[
+			{"_include": "../common/base_users.json"},
+			{"_merge": "admin", "email": "ops@example.com"},
+			{"_merge": "../common/roles.json#auditor", "email": "audit@example.com"}
+		]`)
+		records, err := ReadFixtureFileContext(context.Background(), users)
+		require.NoError(t, err)
+		assert.Equal(t, []Record{
+			{"email": "admin@example.com", "role": "admin", "active": true},
+			{"email": "member@example.com", "role": "member", "active": true},
+			{"email": "ops@example.com", "role": "admin", "active": true},
+			{"email": "audit@example.com", "role": "auditor", "active": false},
+		}, records)
+	})
+
+	t.Run("Included files keep generating rows", func(t *testing.T) {
+		write("common/bulk.json", `[{"_generate": {"count": 2, "template": {"n": "[[ .Index ]]"}}}]`)
+		bulk := write("bulk.json", `[{"_include": "common/bulk.json"}]`)
+		records, err := ReadFixtureFileContext(context.Background(), bulk)
+		require.NoError(t, err)
+		assert.Equal(t, []Record{{"n": json.Number("1")}, {"n": json.Number("2")}}, records)
+	})
+
+	t.Run("Cycles", func(t *testing.T) {
+		a := write("cycle/a.json", `[{"_include": "b.json"}]`)
+		b := write("cycle/b.json", `[{"_include": "a.json"}]`)
+		_, err := ReadFixtureFileContext(context.Background(), a)
+		assert.ErrorContains(t, err, "include cycle: "+a+" -> "+b+" -> "+a)
+
+		self := write("cycle/self.json", `[{"_anchor": "x", "id": 1}, {"_merge": "self.json#x"}]`)
+		_, err = ReadFixtureFileContext(context.Background(), self)
+		assert.ErrorContains(t, err, "include cycle: "+self+" -> "+self)
+	})
+
+	t.Run("Invalid directives", func(t *testing.T) {
+		tests := map[string]string{
+			`[{"_include": "common/roles.json", "id": 1}]`:      "record 1: _include must be the only key of its record and name a file",
+			`[{"_merge": "admn", "id": 1}]`:                     "record 1: unknown anchor 'admn'",
+			`[{"_merge": "common/roles.json#admin", "id": 1}]`:  "record 1: 'common/roles.json' has no anchor 'admin'",
+			`[{"_anchor": "a", "id": 1}, {"_anchor": "a"}]`:     "record 2: anchor 'a' is defined twice",
+			`[{"_include": "common/missing.json"}]`:             "record 1: failed to read fixture",
+			`[{"_anchor": "a"}, {"_merge": "b", "_anchor": 3}]`: "record 2: unknown anchor 'b'",
+		}
+		for content, expected := range tests {
+			_, err := ReadFixtureFileContext(context.Background(), write("invalid.json", content))
+			assert.ErrorContains(t, err, expected, content)
+		}
+	})
+}
+
+// TestFixtureIncludeRows tests pointing at the file and row included records come from
+func TestFixtureIncludeRows(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.json")
+	require.NoError(t, os.WriteFile(base, []byte(`[{"id": 1, "role": "admin"}, {"id": 2, "role": "member"}, {"id": 3, "role": "guest"}]`), 0o644))
+	users := filepath.Join(dir, "users.json")
+	require.NoError(t, os.WriteFile(users, []byte(`[{"_include": "base.json"}, {"id": 4, "role": "superuser"}]`), 0o644))
+
+	records, origins, err := readFixtureFile(context.Background(), users)
+	require.NoError(t, err)
+	assert.Len(t, records, 4)
+	assert.Equal(t, []recordOrigin{
+		{file: base, row: 1, entry: 1},
+		{file: base, row: 2, entry: 1},
+		{file: base, row: 3, entry: 1},
+		{file: users, row: 2, entry: 2},
+	}, origins)
+
+	cli := NewCLI(NewSeederManager())
+	t.Run("Insert errors", func(t *testing.T) {
+		db, fake := newFakeDB()
+		cli.SetDB(db)
+		fake.Fail("INSERT INTO users (id, role) VALUES ($1, $2), ($3, $4), ($5, $6), ($7, $8) [1 admin 2 member 3 guest 4 superuser]", errors.New("invalid role"))
+		fake.Fail("INSERT INTO users (id, role) VALUES ($1, $2) [4 superuser]", errors.New("invalid role"))
+		err := cli.runCommand(context.Background(), []string{"load", users})
+		assert.ErrorContains(t, err, "insert of record 4 ("+users+":2) into users failed")
+
+		db, fake = newFakeDB()
+		cli.SetDB(db)
+		fake.Fail("INSERT INTO users (id, role) VALUES ($1, $2), ($3, $4), ($5, $6), ($7, $8) [1 admin 2 member 3 guest 4 superuser]", errors.New("invalid role"))
+		fake.Fail("INSERT INTO users (id, role) VALUES ($1, $2), ($3, $4) [1 admin 2 member]", errors.New("invalid role"))
+		fake.Fail("INSERT INTO users (id, role) VALUES ($1, $2) [2 member]", errors.New("invalid role"))
+		err = cli.runCommand(context.Background(), []string{"load", users})
+		assert.ErrorContains(t, err, "insert of record 2 ("+base+":2) into users failed")
+	})
+
+	t.Run("Rows are the file's", func(t *testing.T) {
+		db, fake := newFakeDB()
+		cli.SetDB(db)
+		assert.NoError(t, cli.runCommand(context.Background(), []string{"load", users, "-rows=2"}))
+		assert.Equal(t, []string{"BEGIN", "INSERT INTO users (id, role) VALUES ($1, $2) [4 superuser]", "COMMIT"}, fake.Events())
+
+		db, fake = newFakeDB()
+		cli.SetDB(db)
+		assert.NoError(t, cli.runCommand(context.Background(), []string{"debug-row", users + ":2"}))
+		assert.Equal(t, []string{"BEGIN", "INSERT INTO users (id, role) VALUES ($1, $2) [4 superuser]", "ROLLBACK"}, fake.Events())
+
+		err := cli.runCommand(context.Background(), []string{"debug-row", users + ":1"})
+		assert.ErrorIs(t, err, ErrUsage)
+		assert.ErrorContains(t, err, "row 1 of "+users+" expands into 3 records, debug-row replays a row holding a single record, e.g. "+base+":1")
+		assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"debug-row", users + ":3"}), "has 2 rows")
+	})
+}
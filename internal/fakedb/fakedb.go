@@ -0,0 +1,193 @@
+// Package fakedb is the scripted database/sql driver behind
+// goseedertest.FakeDB, shared with the tests of package goseeder, which
+// cannot import goseedertest.
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// FakeDB is a scripted database/sql driver for tests of seeders: queries
+// return the results scripted with On and every statement and transaction
+// event is logged, see Events. Statements other than queries succeed and
+// affect one row unless scripted to fail with Fail.
+type FakeDB struct {
+	mu      sync.Mutex
+	results map[string]fakeRows
+	errors  map[string]error
+	log     []string
+}
+
+// fakeRows is the result of a scripted query
+type fakeRows struct {
+	columns []string
+	types   []string // Database type names of the columns, reported when set
+	values  [][]driver.Value
+}
+
+// New returns a *sql.DB backed by a new FakeDB
+func New() (*sql.DB, *FakeDB) {
+	fake := &FakeDB{results: make(map[string]fakeRows), errors: make(map[string]error)}
+	return sql.OpenDB(fake), fake
+}
+
+// On scripts the rows query returns
+func (f *FakeDB) On(query string, columns []string, values ...[]driver.Value) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[query] = fakeRows{columns: columns, values: values}
+}
+
+// OnTyped scripts the rows query returns, reporting the database type
+// names of its columns, e.g. for code reading sql.ColumnType
+func (f *FakeDB) OnTyped(query string, columns, types []string, values ...[]driver.Value) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[query] = fakeRows{columns: columns, types: types, values: values}
+}
+
+// Fail makes query, or a statement or transaction event such as "COMMIT",
+// fail with err
+func (f *FakeDB) Fail(query string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors[query] = err
+}
+
+// Events returns the logged statements, with their arguments, and
+// transaction events: "BEGIN", "BEGIN READ ONLY", "COMMIT" and "ROLLBACK"
+func (f *FakeDB) Events() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.log...)
+}
+
+func (f *FakeDB) record(event string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.log = append(f.log, event)
+	return f.errors[event]
+}
+
+// Connect implements driver.Connector
+func (f *FakeDB) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeConn{db: f}, nil
+}
+
+// Driver implements driver.Connector
+func (f *FakeDB) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("use sql.OpenDB")
+}
+
+type fakeConn struct {
+	db *FakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	event := "BEGIN"
+	if opts.ReadOnly {
+		event = "BEGIN READ ONLY"
+	}
+	if err := c.db.record(event); err != nil {
+		return nil, err
+	}
+	return &fakeTx{db: c.db}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.db.record(withArgs(query, args)); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.db.record(withArgs(query, args)); err != nil {
+		return nil, err
+	}
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	result, ok := c.db.results[query]
+	if !ok {
+		return nil, fmt.Errorf("unexpected query: %s", query)
+	}
+	return &fakeRowsIter{rows: result}, nil
+}
+
+// withArgs formats a statement with its arguments for the log
+func withArgs(query string, args []driver.NamedValue) string {
+	if len(args) == 0 {
+		return query
+	}
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return fmt.Sprintf("%s %v", query, values)
+}
+
+type fakeTx struct {
+	db *FakeDB
+}
+
+func (tx *fakeTx) Commit() error {
+	return tx.db.record("COMMIT")
+}
+
+func (tx *fakeTx) Rollback() error {
+	return tx.db.record("ROLLBACK")
+}
+
+type fakeRowsIter struct {
+	rows fakeRows
+	pos  int
+}
+
+func (r *fakeRowsIter) Columns() []string {
+	return r.rows.columns
+}
+
+// ColumnTypeDatabaseTypeName implements driver.RowsColumnTypeDatabaseTypeName
+func (r *fakeRowsIter) ColumnTypeDatabaseTypeName(index int) string {
+	if index < len(r.rows.types) {
+		return r.rows.types[index]
+	}
+	return ""
+}
+
+func (r *fakeRowsIter) Close() error {
+	return nil
+}
+
+func (r *fakeRowsIter) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows.values) {
+		return io.EOF
+	}
+	copy(dest, r.rows.values[r.pos])
+	r.pos++
+	return nil
+}
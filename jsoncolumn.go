@@ -0,0 +1,386 @@
+package goseeder
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// JSON is a value stored in a JSON or JSONB column. It is sent as JSON
+// text, which Postgres (json, jsonb), MySQL (JSON) and SQLite (TEXT)
+// accept. BuildInsert wraps nested objects and arrays of fixture records
+// in JSON, so fixtures can hold the payload as is:
+//
+//	{"id": 1, "settings": {"theme": "dark", "tags": ["beta"]}}
+type JSON struct {
+	Data any
+}
+
+// Value implements driver.Valuer
+func (j JSON) Value() (driver.Value, error) {
+	encoded, err := j.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the wrapped value without
+// escaping HTML characters
+func (j JSON) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(j.Data); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON value: %w", err)
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+// insertArg returns the statement argument of a record value: nested
+// objects and arrays become JSON
+func insertArg(value any) any {
+	switch value.(type) {
+	case map[string]any, []any, Record:
+		return JSON{Data: value}
+	default:
+		return value
+	}
+}
+
+// jsonSchema is the subset of JSON Schema JSONGenerator understands
+type jsonSchema struct {
+	Type       any                    `json:"type"` // A type name or a list of them
+	Properties map[string]*jsonSchema `json:"properties"`
+	Required   []string               `json:"required"`
+	Items      *jsonSchema            `json:"items"`
+	Enum       []any                  `json:"enum"`
+	Format     string                 `json:"format"`
+	Minimum    *float64               `json:"minimum"`
+	Maximum    *float64               `json:"maximum"`
+	MinLength  *int                   `json:"minLength"`
+	MaxLength  *int                   `json:"maxLength"`
+	MinItems   *int                   `json:"minItems"`
+	MaxItems   *int                   `json:"maxItems"`
+
+	Ref   string            `json:"$ref"`
+	OneOf []json.RawMessage `json:"oneOf"`
+	AnyOf []json.RawMessage `json:"anyOf"`
+	AllOf []json.RawMessage `json:"allOf"`
+}
+
+// JSONGenerator produces random JSON payloads of one shape, e.g. for the
+// metadata column of generated rows. Payloads are deterministic for a
+// seeded random source.
+type JSONGenerator struct {
+	schema *jsonSchema
+}
+
+// NewJSONGenerator returns a generator of payloads shaped like prototype,
+// a Go value or type such as Settings{} or (*Settings)(nil). Fields follow
+// their json tags; omitempty fields and pointers are left out at random,
+// and time.Time fields are RFC 3339 timestamps.
+func NewJSONGenerator(prototype any) (*JSONGenerator, error) {
+	t := reflect.TypeOf(prototype)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	schema, err := schemaOfType(t, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONGenerator{schema: schema}, nil
+}
+
+// NewJSONGeneratorFromSchema returns a generator of payloads valid against
+// a JSON Schema. It understands type (also lists of types), properties,
+// required, items, enum, minimum, maximum, minLength, maxLength, minItems,
+// maxItems and the formats email, date, date-time and uuid; $ref, oneOf,
+// anyOf and allOf are rejected.
+func NewJSONGeneratorFromSchema(schema []byte) (*JSONGenerator, error) {
+	var parsed jsonSchema
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON schema: %w", err)
+	}
+	if err := parsed.check("#"); err != nil {
+		return nil, err
+	}
+	return &JSONGenerator{schema: &parsed}, nil
+}
+
+// Generate returns a new payload, wrapped in JSON for insertion
+func (g *JSONGenerator) Generate(r *rand.Rand) JSON {
+	return JSON{Data: g.schema.generate(r)}
+}
+
+// check rejects schemas using keywords the generator does not understand
+func (s *jsonSchema) check(path string) error {
+	switch {
+	case s.Ref != "":
+		return fmt.Errorf("JSON schema %s: $ref is not supported", path)
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 0:
+		return fmt.Errorf("JSON schema %s: oneOf, anyOf and allOf are not supported", path)
+	}
+	for _, name := range s.types() {
+		if !slices.Contains([]string{"null", "boolean", "integer", "number", "string", "array", "object"}, name) {
+			return fmt.Errorf("JSON schema %s: unknown type %q", path, name)
+		}
+	}
+	for name, property := range s.Properties {
+		if err := property.check(path + "/properties/" + name); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.check(path + "/items")
+	}
+	return nil
+}
+
+// types returns the allowed types, inferring one when none is declared
+func (s *jsonSchema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, name := range t {
+			types = append(types, fmt.Sprint(name))
+		}
+		return types
+	}
+	switch {
+	case s.Properties != nil:
+		return []string{"object"}
+	case s.Items != nil:
+		return []string{"array"}
+	default:
+		return []string{"string"}
+	}
+}
+
+// generate returns a random value valid against the schema
+func (s *jsonSchema) generate(r *rand.Rand) any {
+	if len(s.Enum) > 0 {
+		return s.Enum[r.Intn(len(s.Enum))]
+	}
+	types := s.types()
+	switch types[r.Intn(len(types))] {
+	case "null":
+		return nil
+	case "boolean":
+		return r.Intn(2) == 1
+	case "integer":
+		low, high := s.bounds(0, 1000)
+		low, high = math.Ceil(low), math.Floor(high)
+		if high < low {
+			return int64(low) // No integer between fractional bounds
+		}
+		return int64(low) + r.Int63n(int64(high-low)+1)
+	case "number":
+		low, high := s.bounds(0, 1000)
+		return math.Round((low+r.Float64()*(high-low))*100) / 100
+	case "array":
+		count := lengthBetween(r, s.MinItems, s.MaxItems, 1, 3)
+		items := make([]any, count)
+		for i := range items {
+			if s.Items == nil {
+				items[i] = randomWord(r, 5, 8)
+			} else {
+				items[i] = s.Items.generate(r)
+			}
+		}
+		return items
+	case "object":
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		object := make(map[string]any, len(names))
+		for _, name := range names {
+			if slices.Contains(s.Required, name) || r.Intn(2) == 1 {
+				object[name] = s.Properties[name].generate(r)
+			}
+		}
+		return object
+	default:
+		return s.generateString(r)
+	}
+}
+
+// bounds returns minimum and maximum, or the defaults
+func (s *jsonSchema) bounds(low, high float64) (float64, float64) {
+	if s.Minimum != nil {
+		low = *s.Minimum
+		high = max(high, low)
+	}
+	if s.Maximum != nil {
+		high = *s.Maximum
+		low = min(low, high)
+	}
+	return low, high
+}
+
+// generateString returns a random string of the schema's format and length
+func (s *jsonSchema) generateString(r *rand.Rand) string {
+	switch s.Format {
+	case "email":
+		return fmt.Sprintf("%s%d@example.com", randomWord(r, 4, 8), r.Intn(1000))
+	case "date":
+		return randomTime(r).Format(time.DateOnly)
+	case "date-time":
+		return randomTime(r).Format(time.RFC3339)
+	case "uuid":
+		b := make([]byte, 16)
+		r.Read(b)
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	}
+	length := lengthBetween(r, s.MinLength, s.MaxLength, 5, 12)
+	return randomWord(r, length, length)
+}
+
+// lengthBetween returns a random count between the schema's limits, or the
+// defaults when a limit is not set
+func lengthBetween(r *rand.Rand, minimum, maximum *int, low, high int) int {
+	if minimum != nil {
+		low = *minimum
+		high = max(high, low)
+	}
+	if maximum != nil {
+		high = *maximum
+		low = min(low, high)
+	}
+	return low + r.Intn(high-low+1)
+}
+
+// randomWord returns lowercase letters, between minLength and maxLength of them
+func randomWord(r *rand.Rand, minLength, maxLength int) string {
+	length := minLength
+	if maxLength > minLength {
+		length += r.Intn(maxLength - minLength + 1)
+	}
+	var b strings.Builder
+	for range length {
+		b.WriteByte(byte('a' + r.Intn(26)))
+	}
+	return b.String()
+}
+
+// randomTime returns a time between 2020 and 2025, in UTC
+func randomTime(r *rand.Rand) time.Time {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration(r.Int63n(int64(6 * 365 * 24 * time.Hour)))).Truncate(time.Second)
+}
+
+// timeType is the type of time.Time, stored as an RFC 3339 string
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaOfType derives a schema from a Go type; seen guards against
+// recursive types
+func schemaOfType(t reflect.Type, seen []reflect.Type) (*jsonSchema, error) {
+	if t == nil {
+		return nil, fmt.Errorf("JSON generator requires a prototype value")
+	}
+	if t.Kind() == reflect.Pointer {
+		schema, err := schemaOfType(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+		schema.Type = append(schema.types(), "null")
+		return schema, nil
+	}
+	if t == timeType {
+		return &jsonSchema{Type: "string", Format: "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema := &jsonSchema{Type: "integer"}
+		if t.Kind() == reflect.Int8 {
+			maximum := float64(math.MaxInt8)
+			schema.Maximum = &maximum
+		}
+		return schema, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		schema := &jsonSchema{Type: "integer", Minimum: &zero}
+		if t.Kind() == reflect.Uint8 {
+			maximum := float64(math.MaxUint8)
+			schema.Maximum = &maximum
+		}
+		return schema, nil
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}, nil
+	case reflect.String:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &jsonSchema{Type: "string"}, nil // Encoded as base64 by encoding/json
+		}
+		items, err := schemaOfType(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("JSON generator: map keys of %s must be strings", t)
+		}
+		return &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}}, nil
+	case reflect.Interface:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Struct:
+		if slices.Contains(seen, t) {
+			return nil, fmt.Errorf("JSON generator: %s is recursive", t)
+		}
+		seen = append(seen, t)
+		schema := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}}
+		for i := range t.NumField() {
+			field := t.Field(i)
+			embedded := field.Anonymous && field.Type.Kind() == reflect.Struct
+			if !field.IsExported() && !embedded {
+				continue
+			}
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" && options == "" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			property, err := schemaOfType(field.Type, seen)
+			if err != nil {
+				return nil, err
+			}
+			if embedded && field.Tag.Get("json") == "" {
+				// Fields of embedded structs are promoted, as encoding/json does
+				for promoted, promotedSchema := range property.Properties {
+					schema.Properties[promoted] = promotedSchema
+				}
+				schema.Required = append(schema.Required, property.Required...)
+				continue
+			}
+			schema.Properties[name] = property
+			if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Pointer {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("JSON generator: unsupported type %s", t)
+	}
+}
@@ -0,0 +1,141 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestJSONColumns tests inserting nested fixture values as JSON text
+func TestJSONColumns(t *testing.T) {
+	records, err := ReadFixture(strings.NewReader(`[{"id": 1, "settings": {"theme": "dark", "tags": ["beta"], "limit": 10}, "links": ["<a>"]}]`), FormatJSON)
+	assert.NoError(t, err)
+
+	db, fake := newFakeDB()
+	assert.NoError(t, NewSQLFixtureWriter(db, nil).WriteFixture(context.Background(), "users", records))
+	assert.Equal(t, []string{
+		"BEGIN",
+		`INSERT INTO users (id, links, settings) VALUES ($1, $2, $3) [1 ["<a>"] {"limit":10,"tags":["beta"],"theme":"dark"}]`,
+		"COMMIT",
+	}, fake.Events(), "numbers keep their text and HTML is not escaped")
+
+	value, err := JSON{Data: map[string]any{"a": 1}}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, value)
+	_, err = JSON{Data: func() {}}.Value()
+	assert.ErrorContains(t, err, "failed to encode JSON value")
+}
+
+// jsonSettings is a payload shape for the generator tests
+type jsonSettings struct {
+	jsonAudit
+	Theme    string         `json:"theme"`
+	Volume   uint8          `json:"volume"`
+	Beta     bool           `json:"beta,omitempty"`
+	Tags     []string       `json:"tags"`
+	Limits   map[string]int `json:"limits"`
+	Owner    *jsonOwner     `json:"owner"`
+	Ignored  string         `json:"-"`
+	Ratio    float64        `json:"ratio"`
+	internal string
+}
+
+type jsonAudit struct {
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type jsonOwner struct {
+	Email string `json:"email"`
+}
+
+// TestJSONGenerator tests generating payloads shaped like a Go struct
+func TestJSONGenerator(t *testing.T) {
+	generator, err := NewJSONGenerator((*jsonSettings)(nil))
+	assert.NoError(t, err)
+
+	r := rand.New(rand.NewSource(1))
+	owners := 0
+	for range 50 {
+		encoded, err := json.Marshal(generator.Generate(r))
+		assert.NoError(t, err)
+
+		var fields map[string]any
+		assert.NoError(t, json.Unmarshal(encoded, &fields))
+		for _, required := range []string{"updated_at", "theme", "volume", "tags", "limits", "ratio"} {
+			assert.Contains(t, fields, required)
+		}
+		assert.NotContains(t, fields, "Ignored")
+		assert.NotContains(t, fields, "internal")
+
+		var settings jsonSettings
+		assert.NoError(t, json.Unmarshal(encoded, &settings), "payloads decode into the struct: %s", encoded)
+		if settings.Owner != nil {
+			owners++
+		}
+	}
+	assert.Greater(t, owners, 0, "pointers are sometimes set")
+	assert.Less(t, owners, 50, "pointers are sometimes left out or null")
+
+	first := generator.Generate(rand.New(rand.NewSource(7)))
+	assert.Equal(t, first, generator.Generate(rand.New(rand.NewSource(7))), "deterministic for a seed")
+
+	type node struct {
+		Children []node `json:"children"`
+	}
+	_, err = NewJSONGenerator(node{})
+	assert.ErrorContains(t, err, "is recursive")
+	_, err = NewJSONGenerator(map[int]string{})
+	assert.ErrorContains(t, err, "map keys")
+	_, err = NewJSONGenerator(nil)
+	assert.Error(t, err)
+}
+
+// TestJSONGeneratorFromSchema tests generating payloads valid against a JSON Schema
+func TestJSONGeneratorFromSchema(t *testing.T) {
+	generator, err := NewJSONGeneratorFromSchema([]byte(`{
+		"type": "object",
+		"required": ["id", "email", "plan", "seats", "features"],
+		"properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"email": {"type": "string", "format": "email"},
+			"plan": {"enum": ["free", "pro"]},
+			"seats": {"type": "integer", "minimum": 1, "maximum": 5},
+			"code": {"type": "string", "minLength": 3, "maxLength": 3},
+			"trial_ends": {"type": ["string", "null"], "format": "date"},
+			"features": {"type": "array", "items": {"type": "boolean"}, "minItems": 2, "maxItems": 2}
+		}
+	}`))
+	assert.NoError(t, err)
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	r := rand.New(rand.NewSource(1))
+	for range 50 {
+		payload := generator.Generate(r).Data.(map[string]any)
+		assert.Regexp(t, uuid, payload["id"])
+		assert.True(t, strings.HasSuffix(payload["email"].(string), "@example.com"))
+		assert.Contains(t, []any{"free", "pro"}, payload["plan"])
+		assert.GreaterOrEqual(t, payload["seats"], int64(1))
+		assert.LessOrEqual(t, payload["seats"], int64(5))
+		assert.Len(t, payload["features"], 2)
+		if code, ok := payload["code"]; ok {
+			assert.Len(t, code, 3)
+		}
+		if trialEnds, ok := payload["trial_ends"].(string); ok {
+			_, err := time.Parse(time.DateOnly, trialEnds)
+			assert.NoError(t, err)
+		}
+	}
+
+	_, err = NewJSONGeneratorFromSchema([]byte(`{"properties": {"owner": {"$ref": "#/definitions/owner"}}}`))
+	assert.EqualError(t, err, "JSON schema #/properties/owner: $ref is not supported")
+	_, err = NewJSONGeneratorFromSchema([]byte(`{"type": "decimal"}`))
+	assert.ErrorContains(t, err, `unknown type "decimal"`)
+	_, err = NewJSONGeneratorFromSchema([]byte(`{`))
+	assert.ErrorContains(t, err, "failed to parse JSON schema")
+}
@@ -0,0 +1,151 @@
+package goseeder
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Defaults of K8sJobOptions
+const (
+	defaultK8sJobName      = "seeder"
+	defaultK8sBackoffLimit = 2
+	defaultK8sTTL          = 86400 // One day
+	defaultK8sDSNKey       = "DATABASE_URL"
+)
+
+// k8sNamePattern matches a Kubernetes object name (an RFC 1123 label)
+var k8sNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// K8sJobOptions configures the manifest written by GenerateK8sJob
+type K8sJobOptions struct {
+	Name         string   // Job name, default "seeder"
+	Namespace    string   // Omitted when empty
+	Image        string   // Image running the seeder binary; required
+	Args         []string // Seeder arguments, e.g. "-type=all", "-timeout=10m"
+	Schedule     string   // Cron schedule; writes a CronJob instead of a Job when set
+	BackoffLimit int      // Retries before the Job fails, default 2; negative for none
+	TTLSeconds   int      // Seconds a finished Job is kept, default one day; negative to keep it
+	DSNSecret    string   // Secret holding the database URL, default "<name>-db"
+	DSNKey       string   // Key of the database URL in DSNSecret, default "DATABASE_URL"
+	EnvFrom      []string // Secrets exposed as environment variables, e.g. webhook or password keys
+}
+
+// GenerateK8sJob returns a Kubernetes Job manifest running the seeder, or a
+// CronJob when options.Schedule is set. The pod never restarts in place,
+// retries with a new pod up to BackoffLimit times, is removed TTLSeconds
+// after finishing and reads the database URL (the -dsn default
+// DATABASE_URL) from a secret.
+func GenerateK8sJob(options K8sJobOptions) (string, error) {
+	if options.Name == "" {
+		options.Name = defaultK8sJobName
+	}
+	maxName := 63
+	if options.Schedule != "" {
+		maxName = 52 // CronJobs append an 11 character suffix to their Jobs
+	}
+	if !k8sNamePattern.MatchString(options.Name) || len(options.Name) > maxName {
+		return "", fmt.Errorf("invalid job name %q: use at most %d lowercase letters, digits and '-'", options.Name, maxName)
+	}
+	if options.Image == "" {
+		return "", fmt.Errorf("an image is required")
+	}
+	if schedule := options.Schedule; schedule != "" && !strings.HasPrefix(schedule, "@") && len(strings.Fields(schedule)) != 5 {
+		return "", fmt.Errorf("invalid schedule %q: expected 5 cron fields or a macro such as @daily", schedule)
+	}
+	switch {
+	case options.BackoffLimit == 0:
+		options.BackoffLimit = defaultK8sBackoffLimit
+	case options.BackoffLimit < 0:
+		options.BackoffLimit = 0
+	}
+	if options.TTLSeconds == 0 {
+		options.TTLSeconds = defaultK8sTTL
+	}
+	if options.DSNSecret == "" {
+		options.DSNSecret = options.Name + "-db"
+	}
+	if options.DSNKey == "" {
+		options.DSNKey = defaultK8sDSNKey
+	}
+
+	var b strings.Builder
+	b.WriteString("apiVersion: batch/v1\n")
+	if options.Schedule != "" {
+		b.WriteString("kind: CronJob\n")
+	} else {
+		b.WriteString("kind: Job\n")
+	}
+	b.WriteString("metadata:\n")
+	fmt.Fprintf(&b, "  name: %s\n", yamlString(options.Name))
+	if options.Namespace != "" {
+		fmt.Fprintf(&b, "  namespace: %s\n", yamlString(options.Namespace))
+	}
+	b.WriteString("  labels:\n")
+	fmt.Fprintf(&b, "    app.kubernetes.io/name: %s\n", yamlString(options.Name))
+	b.WriteString("    app.kubernetes.io/component: \"seeder\"\n")
+	b.WriteString("spec:\n")
+
+	indent := "  "
+	if options.Schedule != "" {
+		fmt.Fprintf(&b, "  schedule: %s\n", yamlString(options.Schedule))
+		b.WriteString("  concurrencyPolicy: Forbid\n")
+		b.WriteString("  jobTemplate:\n")
+		b.WriteString("    spec:\n")
+		indent = "      "
+	}
+	writeK8sJobSpec(&b, indent, options)
+	return b.String(), nil
+}
+
+// writeK8sJobSpec writes the spec of a Job, indented by indent
+func writeK8sJobSpec(b *strings.Builder, indent string, options K8sJobOptions) {
+	lines := []string{
+		fmt.Sprintf("backoffLimit: %d", options.BackoffLimit),
+	}
+	if options.TTLSeconds > 0 {
+		lines = append(lines, fmt.Sprintf("ttlSecondsAfterFinished: %d", options.TTLSeconds))
+	}
+	lines = append(lines,
+		"template:",
+		"  metadata:",
+		"    labels:",
+		"      app.kubernetes.io/name: "+yamlString(options.Name),
+		"  spec:",
+		"    restartPolicy: Never",
+		"    containers:",
+		"      - name: \"seeder\"",
+		"        image: "+yamlString(options.Image),
+	)
+	if len(options.Args) > 0 {
+		lines = append(lines, "        args:")
+		for _, arg := range options.Args {
+			lines = append(lines, "          - "+yamlString(arg))
+		}
+	}
+	lines = append(lines,
+		"        env:",
+		"          - name: \"DATABASE_URL\"",
+		"            valueFrom:",
+		"              secretKeyRef:",
+		"                name: "+yamlString(options.DSNSecret),
+		"                key: "+yamlString(options.DSNKey),
+	)
+	if len(options.EnvFrom) > 0 {
+		lines = append(lines, "        envFrom:")
+		for _, secret := range options.EnvFrom {
+			lines = append(lines, "          - secretRef:", "              name: "+yamlString(secret))
+		}
+	}
+
+	for _, line := range lines {
+		b.WriteString(indent + line + "\n")
+	}
+}
+
+// yamlString quotes s as a YAML double-quoted scalar, which accepts JSON
+// string escapes, so values such as "on" or "-type=all" stay strings
+func yamlString(s string) string {
+	return strconv.Quote(s)
+}
@@ -0,0 +1,94 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGenerateK8sJob tests the Job manifest and its defaults
+func TestGenerateK8sJob(t *testing.T) {
+	manifest, err := GenerateK8sJob(K8sJobOptions{
+		Image:   "registry.example.com/shop-seeder:1.4",
+		Args:    []string{"-type=all", "-timeout=10m"},
+		EnvFrom: []string{"seed-secrets"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: "seeder"
+  labels:
+    app.kubernetes.io/name: "seeder"
+    app.kubernetes.io/component: "seeder"
+spec:
+  backoffLimit: 2
+  ttlSecondsAfterFinished: 86400
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: "seeder"
+    spec:
+      restartPolicy: Never
+      containers:
+        - name: "seeder"
+          image: "registry.example.com/shop-seeder:1.4"
+          args:
+            - "-type=all"
+            - "-timeout=10m"
+          env:
+            - name: "DATABASE_URL"
+              valueFrom:
+                secretKeyRef:
+                  name: "seeder-db"
+                  key: "DATABASE_URL"
+          envFrom:
+            - secretRef:
+                name: "seed-secrets"
+`, manifest)
+}
+
+// TestGenerateK8sCronJob tests the CronJob manifest and option validation
+func TestGenerateK8sCronJob(t *testing.T) {
+	manifest, err := GenerateK8sJob(K8sJobOptions{
+		Name:         "demo-reset",
+		Namespace:    "staging",
+		Image:        "shop-seeder",
+		Schedule:     "0 3 * * *",
+		BackoffLimit: -1,
+		TTLSeconds:   -1,
+		DSNSecret:    "staging-db",
+		DSNKey:       "url",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, manifest, "kind: CronJob\n")
+	assert.Contains(t, manifest, "  namespace: \"staging\"\n")
+	assert.Contains(t, manifest, "  schedule: \"0 3 * * *\"\n  concurrencyPolicy: Forbid\n  jobTemplate:\n    spec:\n      backoffLimit: 0\n      template:\n")
+	assert.NotContains(t, manifest, "ttlSecondsAfterFinished")
+	assert.Contains(t, manifest, "                      name: \"staging-db\"\n                      key: \"url\"\n")
+
+	_, err = GenerateK8sJob(K8sJobOptions{Image: "shop-seeder", Schedule: "daily"})
+	assert.ErrorContains(t, err, `invalid schedule "daily"`)
+	_, err = GenerateK8sJob(K8sJobOptions{Name: "Shop_Seeder", Image: "shop-seeder"})
+	assert.ErrorContains(t, err, `invalid job name "Shop_Seeder"`)
+	_, err = GenerateK8sJob(K8sJobOptions{})
+	assert.ErrorContains(t, err, "an image is required")
+}
+
+// TestCLIGenK8sJob tests the gen-k8s-job command
+func TestCLIGenK8sJob(t *testing.T) {
+	cli := NewCLI(NewSeederManager())
+	var out bytes.Buffer
+	cli.SetOutput(&out)
+
+	err := cli.runCommand(context.Background(), []string{"gen-k8s-job", "-image=shop-seeder", "-args=-type=all,-timeout=10m", "-ttl=1h", "-backoff-limit=0"})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "kind: Job\n")
+	assert.Contains(t, out.String(), "            - \"-type=all\"\n            - \"-timeout=10m\"\n")
+	assert.Contains(t, out.String(), "  backoffLimit: 0\n  ttlSecondsAfterFinished: 3600\n")
+
+	assert.ErrorIs(t, cli.runCommand(context.Background(), []string{"gen-k8s-job"}), ErrUsage)
+	assert.ErrorIs(t, cli.runCommand(context.Background(), []string{"gen-k8s-job", "-image=x", "-name=UPPER"}), ErrUsage)
+}
@@ -0,0 +1,103 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// LazySeeder returns a seeder constructed by factory the first time it runs,
+// so expensive setup (template parsing, file reads) is only paid for the
+// seeders a run selects. The name and tags are needed upfront for listing
+// and selection. Only the function of the factory's seeder is used: a
+// factory setting a different Name or Tags, or any other field such as
+// Transaction or DependsOn, fails the run, as these are read before it is
+// called; set them on the returned SeederItem instead. A failed
+// construction is retried on the next run.
+func LazySeeder(name string, factory func() (SeederItem, error), tags ...string) SeederItem {
+	var mu sync.Mutex
+	var built SeederFunc
+
+	return SeederItem{
+		Name: name,
+		Tags: tags,
+		ContextFunction: func(ctx context.Context) error {
+			mu.Lock()
+			if built == nil {
+				run, err := buildLazySeeder(ctx, name, tags, factory)
+				if err != nil {
+					mu.Unlock()
+					return err
+				}
+				built = run
+			}
+			run := built
+			mu.Unlock()
+			return run(ctx)
+		},
+	}
+}
+
+// RegisterLazySeeder registers a seeder constructed by factory the first
+// time it runs, see LazySeeder
+func (sm *SeederManager) RegisterLazySeeder(name string, factory func() (SeederItem, error), tags ...string) error {
+	return sm.registerItem(LazySeeder(name, factory, tags...))
+}
+
+// buildLazySeeder calls factory and returns the constructed seeder's function
+func buildLazySeeder(ctx context.Context, name string, tags []string, factory func() (SeederItem, error)) (SeederFunc, error) {
+	if factory == nil {
+		return nil, fmt.Errorf("lazy seeder '%s' has no factory", name)
+	}
+
+	logInfo(ctx, "Constructing seeder: %s", name)
+	item, err := factory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct seeder '%s': %w", name, err)
+	}
+	if item.Name != "" && item.Name != name {
+		return nil, fmt.Errorf("factory of seeder '%s' returned seeder '%s'", name, item.Name)
+	}
+	if ignored := lazyIgnoredFields(item, tags); len(ignored) > 0 {
+		return nil, fmt.Errorf("factory of seeder '%s' set %s, which must be set on the LazySeeder item instead", name, strings.Join(ignored, ", "))
+	}
+
+	if item.ContextFunction != nil {
+		return item.ContextFunction, nil
+	}
+	if item.Function != nil {
+		function := item.Function
+		return func(ctx context.Context) error {
+			return function()
+		}, nil
+	}
+	return nil, fmt.Errorf("factory of seeder '%s' returned a seeder without a function", name)
+}
+
+// lazyIgnoredFields returns the fields set by a factory that only take
+// effect at registration, before the factory is called
+func lazyIgnoredFields(item SeederItem, tags []string) []string {
+	var fields []string
+	for _, field := range []struct {
+		name string
+		set  bool
+	}{
+		{"Tags", len(item.Tags) > 0 && !slices.Equal(item.Tags, tags)},
+		{"Aliases", len(item.Aliases) > 0},
+		{"Paths", len(item.Paths) > 0},
+		{"Phase", item.Phase != ""},
+		{"Rollback", item.Rollback != nil},
+		{"DependsOn", len(item.DependsOn) > 0},
+		{"Transaction", item.Transaction},
+		{"Priority", item.Priority != 0},
+		{"ShouldRun", item.ShouldRun != nil},
+		{"SkipIf", item.SkipIf != nil},
+	} {
+		if field.set {
+			fields = append(fields, field.name)
+		}
+	}
+	return fields
+}
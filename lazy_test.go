@@ -0,0 +1,104 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRegisterLazySeeder tests deferred seeder construction
+func TestRegisterLazySeeder(t *testing.T) {
+	t.Run("Constructs only selected seeders, once", func(t *testing.T) {
+		manager := NewSeederManager()
+		built := map[string]int{}
+		runs := 0
+		factory := func(name string) func() (SeederItem, error) {
+			return func() (SeederItem, error) {
+				built[name]++
+				return SeederItem{Function: func() error {
+					runs++
+					return nil
+				}}, nil
+			}
+		}
+
+		assert.NoError(t, manager.RegisterLazySeeder("users", factory("users"), TagCore))
+		assert.NoError(t, manager.RegisterLazySeeder("orders", factory("orders"), TagDemo))
+		assert.Empty(t, built)
+		assert.Equal(t, []string{"users"}, manager.GetSeedersByTag(TagCore))
+
+		assert.NoError(t, manager.RunSeederByName("users"))
+		assert.NoError(t, manager.RunSeederByName("users"))
+
+		assert.Equal(t, map[string]int{"users": 1}, built)
+		assert.Equal(t, 2, runs)
+	})
+
+	t.Run("Context function receives the run context", func(t *testing.T) {
+		manager := NewSeederManager()
+		var got string
+		manager.RegisterSeeders(LazySeeder("users", func() (SeederItem, error) {
+			return SeederItem{Name: "users", ContextFunction: func(ctx context.Context) error {
+				got = SeederNameFromContext(ctx)
+				return nil
+			}}, nil
+		}))
+
+		assert.NoError(t, manager.RunAllSeeders())
+		assert.Equal(t, "users", got)
+	})
+
+	t.Run("Construction errors are retried", func(t *testing.T) {
+		manager := NewSeederManager()
+		attempts := 0
+		manager.RegisterLazySeeder("users", func() (SeederItem, error) {
+			attempts++
+			if attempts == 1 {
+				return SeederItem{}, errors.New("template not found")
+			}
+			return SeederItem{Function: func() error { return nil }}, nil
+		})
+
+		assert.ErrorContains(t, manager.RunSeederByName("users"), "failed to construct seeder 'users': template not found")
+		assert.NoError(t, manager.RunSeederByName("users"))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("Invalid factory results", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterLazySeeder("renamed", func() (SeederItem, error) {
+			return SeederItem{Name: "other", Function: func() error { return nil }}, nil
+		})
+		manager.RegisterLazySeeder("empty", func() (SeederItem, error) {
+			return SeederItem{}, nil
+		})
+		manager.RegisterLazySeeder("missing", nil)
+		manager.RegisterLazySeeder("transactional", func() (SeederItem, error) {
+			return SeederItem{Function: func() error { return nil }, Transaction: true, DependsOn: []string{"empty"},
+				SkipIf: func() (bool, string) { return false, "" }}, nil
+		})
+		manager.RegisterLazySeeder("tagged", func() (SeederItem, error) {
+			return SeederItem{Function: func() error { return nil }, Tags: []string{TagDemo}}, nil
+		}, TagDemo)
+		item := LazySeeder("rollback", func() (SeederItem, error) {
+			return SeederItem{Function: func() error { return nil }}, nil
+		})
+		rolledBack := false
+		item.Rollback = func() error {
+			rolledBack = true
+			return nil
+		}
+		manager.RegisterSeeders(item)
+
+		assert.ErrorContains(t, manager.RunSeederByName("renamed"), "returned seeder 'other'")
+		assert.ErrorContains(t, manager.RunSeederByName("empty"), "without a function")
+		assert.ErrorContains(t, manager.RunSeederByName("missing"), "has no factory")
+		assert.ErrorContains(t, manager.RunSeederByName("transactional"), "set DependsOn, Transaction, SkipIf, which must be set on the LazySeeder item")
+		assert.NoError(t, manager.RunSeederByName("tagged"), "tags matching the registered ones are accepted")
+		assert.NoError(t, manager.RunSeederByName("rollback"))
+		assert.NoError(t, manager.RollbackSeeder("rollback"))
+		assert.True(t, rolledBack, "fields set on the item apply")
+	})
+}
@@ -0,0 +1,129 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrLockHeld is returned by Locker.Lock when ctx is done before the lock
+// was released by its holder
+var ErrLockHeld = errors.New("seed lock is held by another process")
+
+// Locker is a distributed lock shared by the replicas of a service, e.g. a
+// database advisory lock
+type Locker interface {
+	// Lock blocks until the lock is held or ctx is done, and returns the
+	// function releasing it. When ctx is done first, the error wraps both
+	// ErrLockHeld and ctx.Err().
+	Lock(ctx context.Context) (unlock func() error, err error)
+}
+
+// LeaderElection makes exactly one replica seed when the seed job runs as
+// part of every replica's startup. Replicas take the lock in turn: the first
+// one seeds and records Key in Store, the others find Key complete and
+// proceed without seeding. If the leader fails, the next replica retries.
+//
+// Replicas are separate processes, so Locker and Store must be shared
+// between them, e.g. a PostgresAdvisoryLocker and a SQLCompletionStore on
+// the same database. MemoryLocker and MemoryCompletionStore only
+// coordinate the goroutines of one process.
+type LeaderElection struct {
+	Locker Locker
+	Store  CompletionStore // Shared by the replicas, e.g. a SQLCompletionStore
+	Key    string          // Identifies the seeding to do once, e.g. "shop/release-42"
+}
+
+// Run calls run unless another replica already completed it, and reports
+// whether run was called
+func (le LeaderElection) Run(ctx context.Context, run func(ctx context.Context) error) (bool, error) {
+	logInfo(ctx, "Waiting for seed lock '%s'", le.Key)
+	unlock, err := le.Locker.Lock(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire seed lock: %w", err)
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			logError(ctx, "Failed to release seed lock '%s': %v", le.Key, err)
+		}
+	}()
+
+	done, err := le.Store.IsComplete(ctx, le.Key)
+	if err != nil {
+		return false, fmt.Errorf("failed to check completion of '%s': %w", le.Key, err)
+	}
+	if done {
+		logInfo(ctx, "'%s' was already seeded by another replica", le.Key)
+		return false, nil
+	}
+
+	logInfo(ctx, "Acquired seed lock '%s', seeding", le.Key)
+	if err := run(ctx); err != nil {
+		return true, err
+	}
+	if err := le.Store.MarkComplete(ctx, le.Key); err != nil {
+		return true, fmt.Errorf("failed to mark '%s' complete: %w", le.Key, err)
+	}
+	return true, nil
+}
+
+// MemoryLocker is an in-process Locker, for tests and single-process setups
+type MemoryLocker struct {
+	ch chan struct{}
+}
+
+// NewMemoryLocker creates an unlocked in-process lock
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{ch: make(chan struct{}, 1)}
+}
+
+// Lock implements Locker
+func (ml *MemoryLocker) Lock(ctx context.Context) (func() error, error) {
+	select {
+	case ml.ch <- struct{}{}:
+		return func() error {
+			<-ml.ch
+			return nil
+		}, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %w", ErrLockHeld, ctx.Err())
+	}
+}
+
+// PostgresAdvisoryLocker is a Locker backed by a Postgres session-level
+// advisory lock
+type PostgresAdvisoryLocker struct {
+	db  *sql.DB
+	key int64
+}
+
+// NewPostgresAdvisoryLocker creates a lock on pg_advisory_lock(key). All
+// replicas must use the same key.
+func NewPostgresAdvisoryLocker(db *sql.DB, key int64) *PostgresAdvisoryLocker {
+	return &PostgresAdvisoryLocker{db: db, key: key}
+}
+
+// Lock implements Locker. The lock is held by a dedicated connection, so it
+// is released by the database if the process dies.
+func (pl *PostgresAdvisoryLocker) Lock(ctx context.Context) (func() error, error) {
+	conn, err := pl.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", pl.key); err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrLockHeld, ctx.Err())
+		}
+		return nil, err
+	}
+
+	return func() error {
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", pl.key)
+		if closeErr := conn.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}, nil
+}
@@ -0,0 +1,91 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLeaderElection tests that exactly one replica seeds
+func TestLeaderElection(t *testing.T) {
+	t.Run("One replica seeds", func(t *testing.T) {
+		election := LeaderElection{Locker: NewMemoryLocker(), Store: NewMemoryCompletionStore(), Key: "shop/release-42"}
+		var seeded atomic.Int32
+		var leaders atomic.Int32
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ran, err := election.Run(context.Background(), func(ctx context.Context) error {
+					seeded.Add(1)
+					time.Sleep(5 * time.Millisecond)
+					return nil
+				})
+				assert.NoError(t, err)
+				if ran {
+					leaders.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), seeded.Load())
+		assert.Equal(t, int32(1), leaders.Load())
+	})
+
+	t.Run("Next replica retries after a failure", func(t *testing.T) {
+		election := LeaderElection{Locker: NewMemoryLocker(), Store: NewMemoryCompletionStore(), Key: "shop/release-42"}
+
+		ran, err := election.Run(context.Background(), func(ctx context.Context) error { return errors.New("boom") })
+		assert.True(t, ran)
+		assert.Error(t, err)
+
+		ran, err = election.Run(context.Background(), func(ctx context.Context) error { return nil })
+		assert.True(t, ran)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Replicas share a SQL completion store", func(t *testing.T) {
+		db, fake := newFakeDB()
+		locker := NewMemoryLocker()
+		count := "SELECT COUNT(*) FROM seeder_completions WHERE marker = $1"
+		fake.On(count, []string{"count"}, []driver.Value{int64(0)})
+		leader := LeaderElection{Locker: locker, Store: NewSQLCompletionStore(db, nil), Key: "shop/release-42"}
+
+		ran, err := leader.Run(context.Background(), func(ctx context.Context) error { return nil })
+		assert.True(t, ran)
+		assert.NoError(t, err)
+		assert.Contains(t, fake.Events()[len(fake.Events())-2], "INSERT INTO seeder_completions (marker, completed_at)")
+
+		fake.On(count, []string{"count"}, []driver.Value{int64(1)})
+		follower := LeaderElection{Locker: locker, Store: NewSQLCompletionStore(db, nil), Key: "shop/release-42"}
+		ran, err = follower.Run(context.Background(), func(ctx context.Context) error { return nil })
+		assert.False(t, ran)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Lock wait is canceled", func(t *testing.T) {
+		locker := NewMemoryLocker()
+		unlock, err := locker.Lock(context.Background())
+		assert.NoError(t, err)
+		defer unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		election := LeaderElection{Locker: locker, Store: NewMemoryCompletionStore(), Key: "k"}
+
+		ran, err := election.Run(ctx, func(ctx context.Context) error { return nil })
+
+		assert.False(t, ran)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorIs(t, err, ErrLockHeld)
+	})
+}
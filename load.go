@@ -0,0 +1,131 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LoadOptions configures GenerateLoad
+type LoadOptions struct {
+	Rate           float64       // Target inserts per second across all workers, zero for as fast as possible
+	Workers        int           // Concurrent workers (defaults to 1)
+	Duration       time.Duration // Stop after this long, zero for no limit
+	Total          int64         // Stop after this many inserts, zero for no limit
+	ReportInterval time.Duration // How often throughput is reported (defaults to 5s)
+
+	// Report receives live throughput; defaults to logging it
+	Report func(stats LoadStats)
+}
+
+// LoadStats is the throughput of a load generation run
+type LoadStats struct {
+	Rows          int64
+	Elapsed       time.Duration
+	RowsPerSecond float64 // Average since the start
+}
+
+// GenerateLoad calls insert from several workers at a target rate, doubling
+// as a simple ingest load generator for capacity testing. insert receives
+// the worker number and a sequence number unique across workers. It stops
+// at Duration, Total, cancellation of ctx or the first insert error, and
+// returns the final throughput; only an insert error is returned as error.
+func GenerateLoad(ctx context.Context, options LoadOptions, insert func(ctx context.Context, worker int, seq int64) error) (LoadStats, error) {
+	workers := options.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	reportInterval := options.ReportInterval
+	if reportInterval <= 0 {
+		reportInterval = 5 * time.Second
+	}
+	report := options.Report
+	if report == nil {
+		report = func(stats LoadStats) {
+			logInfo(ctx, "Load: %d rows in %s (%.1f rows/s)", stats.Rows, stats.Elapsed.Round(time.Millisecond), stats.RowsPerSecond)
+		}
+	}
+
+	if options.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Duration)
+		defer cancel()
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	start := time.Now()
+	var seq, rows atomic.Int64
+	stats := func() LoadStats {
+		elapsed := time.Since(start)
+		s := LoadStats{Rows: rows.Load(), Elapsed: elapsed}
+		if elapsed > 0 {
+			s.RowsPerSecond = float64(s.Rows) / elapsed.Seconds()
+		}
+		return s
+	}
+
+	var tokens <-chan time.Time
+	if options.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / options.Rate))
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for {
+				if tokens != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case <-tokens:
+					}
+				} else if ctx.Err() != nil {
+					return
+				}
+
+				n := seq.Add(1)
+				if options.Total > 0 && n > options.Total {
+					cancel(nil)
+					return
+				}
+				if err := insert(ctx, worker, n-1); err != nil {
+					if ctx.Err() == nil {
+						cancel(fmt.Errorf("insert %d failed: %w", n-1, err))
+					}
+					return
+				}
+				rows.Add(1)
+			}
+		}(w)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			report(stats())
+		case <-done:
+			final := stats()
+			report(final)
+			if err := context.Cause(ctx); err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+				return final, err
+			}
+			return final, nil
+		}
+	}
+}
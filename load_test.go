@@ -0,0 +1,74 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGenerateLoad tests rate-limited concurrent inserts
+func TestGenerateLoad(t *testing.T) {
+	t.Run("Stops at total with unique sequence numbers", func(t *testing.T) {
+		var mu sync.Mutex
+		seen := map[int64]bool{}
+		workers := map[int]bool{}
+
+		stats, err := GenerateLoad(context.Background(), LoadOptions{Workers: 4, Total: 200, Report: func(LoadStats) {}},
+			func(ctx context.Context, worker int, seq int64) error {
+				mu.Lock()
+				defer mu.Unlock()
+				seen[seq] = true
+				workers[worker] = true
+				return nil
+			})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(200), stats.Rows)
+		assert.Len(t, seen, 200)
+		for seq := int64(0); seq < 200; seq++ {
+			assert.True(t, seen[seq])
+		}
+	})
+
+	t.Run("Respects the target rate", func(t *testing.T) {
+		reports := 0
+		stats, err := GenerateLoad(context.Background(), LoadOptions{
+			Rate:           200,
+			Workers:        3,
+			Duration:       100 * time.Millisecond,
+			ReportInterval: 20 * time.Millisecond,
+			Report:         func(LoadStats) { reports++ },
+		}, func(ctx context.Context, worker int, seq int64) error { return nil })
+
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, stats.Rows, int64(25))
+		assert.Greater(t, stats.Rows, int64(5))
+		assert.Greater(t, reports, 1)
+	})
+
+	t.Run("Stops at the first error", func(t *testing.T) {
+		_, err := GenerateLoad(context.Background(), LoadOptions{Workers: 2, Report: func(LoadStats) {}},
+			func(ctx context.Context, worker int, seq int64) error {
+				if seq == 10 {
+					return errors.New("connection reset")
+				}
+				return nil
+			})
+
+		assert.ErrorContains(t, err, "insert 10 failed: connection reset")
+	})
+
+	t.Run("Cancellation stops cleanly", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := GenerateLoad(ctx, LoadOptions{Rate: 1000, Report: func(LoadStats) {}},
+			func(ctx context.Context, worker int, seq int64) error { return nil })
+
+		assert.NoError(t, err)
+	})
+}
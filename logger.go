@@ -0,0 +1,183 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger receives the log output of the manager, the CLI and the helpers
+// seeders call, e.g. to send it through structured logging. args are
+// alternating keys and values, as in log/slog; runs add "run_id" and
+// "seeder". *slog.Logger implements Logger as it is; see ZapLogger and
+// LogrusLogger for zap and logrus.
+type Logger interface {
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// SugaredLogger is the part of zap's *zap.SugaredLogger ZapLogger uses
+type SugaredLogger interface {
+	Infow(msg string, keysAndValues ...any)
+	Warnw(msg string, keysAndValues ...any)
+	Errorw(msg string, keysAndValues ...any)
+}
+
+// FormatLogger is the part of logrus' *logrus.Logger and *logrus.Entry
+// LogrusLogger uses
+type FormatLogger interface {
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// ZapLogger adapts a zap SugaredLogger, e.g. zap.L().Sugar()
+func ZapLogger(logger SugaredLogger) Logger {
+	return zapLogger{logger}
+}
+
+// zapLogger sends messages to a SugaredLogger with their attributes
+type zapLogger struct {
+	logger SugaredLogger
+}
+
+func (z zapLogger) Info(msg string, args ...any)  { z.logger.Infow(msg, args...) }
+func (z zapLogger) Warn(msg string, args ...any)  { z.logger.Warnw(msg, args...) }
+func (z zapLogger) Error(msg string, args ...any) { z.logger.Errorw(msg, args...) }
+
+// LogrusLogger adapts a logrus Logger or Entry. Attributes are appended to
+// the message as key=value pairs; use logger.WithFields for fields common
+// to every message.
+func LogrusLogger(logger FormatLogger) Logger {
+	return logrusLogger{logger}
+}
+
+// logrusLogger sends messages to a FormatLogger with their attributes
+// appended
+type logrusLogger struct {
+	logger FormatLogger
+}
+
+func (l logrusLogger) Info(msg string, args ...any) {
+	l.logger.Infof("%s", withAttributes(msg, args))
+}
+
+func (l logrusLogger) Warn(msg string, args ...any) {
+	l.logger.Warnf("%s", withAttributes(msg, args))
+}
+
+func (l logrusLogger) Error(msg string, args ...any) {
+	l.logger.Errorf("%s", withAttributes(msg, args))
+}
+
+// withAttributes appends alternating keys and values to msg as key=value
+func withAttributes(msg string, args []any) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", args[i])
+		}
+	}
+	return b.String()
+}
+
+// standardLogger writes messages with the standard log package, without
+// their attributes, which is the output without a Logger
+type standardLogger struct{}
+
+func (standardLogger) Info(msg string, args ...any)  { log.Print(msg) }
+func (standardLogger) Warn(msg string, args ...any)  { log.Print(msg) }
+func (standardLogger) Error(msg string, args ...any) { log.Print(msg) }
+
+// WithLogger sends the manager's log output to logger instead of the
+// standard log package. Seeders receive it through their context, so the
+// helpers they call log to it too. It returns the manager to allow
+// chaining.
+func (sm *SeederManager) WithLogger(logger Logger) *SeederManager {
+	sm.logger = logger
+	return sm
+}
+
+// SetQuiet makes the manager log errors only, dropping informational
+// messages and warnings such as "Registered seeder" or the run summary.
+// Call it before registering seeders to silence registration too.
+func (sm *SeederManager) SetQuiet(quiet bool) {
+	sm.quiet = quiet
+}
+
+// withLogger returns ctx carrying the manager's logger, unless ctx already
+// carries one, and marked quiet when the manager is
+func (sm *SeederManager) withLogger(ctx context.Context) context.Context {
+	if sm.quiet && !Quiet(ctx) {
+		ctx = ContextWithQuiet(ctx)
+	}
+	if sm.logger == nil || ctx.Value(loggerKey) != nil {
+		return ctx
+	}
+	return ContextWithLogger(ctx, sm.logger)
+}
+
+// ContextWithQuiet returns a copy of ctx logging errors only, see SetQuiet
+func ContextWithQuiet(ctx context.Context) context.Context {
+	return context.WithValue(ctx, quietKey, true)
+}
+
+// Quiet reports whether ctx logs errors only
+func Quiet(ctx context.Context) bool {
+	quiet, _ := ctx.Value(quietKey).(bool)
+	return quiet
+}
+
+// ContextWithLogger returns a copy of ctx whose log output goes to logger,
+// e.g. for helpers such as SQLFixtureWriter used outside a run
+func ContextWithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// LoggerFromContext returns the logger of ctx, or one writing with the
+// standard log package
+func LoggerFromContext(ctx context.Context) Logger {
+	if logger, ok := ctx.Value(loggerKey).(Logger); ok {
+		return logger
+	}
+	return standardLogger{}
+}
+
+// logInfo logs a formatted message at info level with the run and seeder
+// of ctx as attributes, unless ctx is quiet
+func logInfo(ctx context.Context, format string, args ...any) {
+	if Quiet(ctx) {
+		return
+	}
+	LoggerFromContext(ctx).Info(fmt.Sprintf(format, args...), logAttributes(ctx)...)
+}
+
+// logWarn logs a formatted message at warning level, see logInfo
+func logWarn(ctx context.Context, format string, args ...any) {
+	if Quiet(ctx) {
+		return
+	}
+	LoggerFromContext(ctx).Warn(fmt.Sprintf(format, args...), logAttributes(ctx)...)
+}
+
+// logError logs a formatted message at error level, see logInfo
+func logError(ctx context.Context, format string, args ...any) {
+	LoggerFromContext(ctx).Error(fmt.Sprintf(format, args...), logAttributes(ctx)...)
+}
+
+// logAttributes returns the run ID and seeder name of ctx, when set
+func logAttributes(ctx context.Context) []any {
+	var attributes []any
+	if state, ok := ctx.Value(runKey).(*runState); ok {
+		attributes = append(attributes, "run_id", state.info.ID)
+	}
+	if name, ok := ctx.Value(seederNameKey).(string); ok {
+		attributes = append(attributes, "seeder", name)
+	}
+	return attributes
+}
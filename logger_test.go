@@ -0,0 +1,187 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingLogger records messages as "level: message key=value ..."
+type recordingLogger struct {
+	lines []string
+}
+
+func (r *recordingLogger) Info(msg string, args ...any)  { r.add("info", msg, args) }
+func (r *recordingLogger) Warn(msg string, args ...any)  { r.add("warn", msg, args) }
+func (r *recordingLogger) Error(msg string, args ...any) { r.add("error", msg, args) }
+
+func (r *recordingLogger) add(level, msg string, args []any) {
+	r.lines = append(r.lines, level+": "+withAttributes(msg, args))
+}
+
+// captureLog returns what run writes with the standard log package
+func captureLog(run func()) string {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+	run()
+	return output.String()
+}
+
+// TestWithLogger tests sending the manager's log output to a structured logger
+func TestWithLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	manager := NewSeederManager().WithLogger(slog.New(slog.NewJSONHandler(&buffer, nil)))
+	manager.RegisterSeeders(
+		SeederItem{Name: "users", ContextFunction: func(ctx context.Context) error {
+			LoggerFromContext(ctx).Info("seeding users", "count", 2)
+			return nil
+		}},
+		SeederItem{Name: "orders", SkipIf: func() (bool, string) { return true, "table not empty" }},
+	)
+
+	output := captureLog(func() {
+		assert.NoError(t, manager.RunAllSeeders())
+	})
+	assert.NotContains(t, output, "Running seeder", "the standard logger is bypassed")
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		delete(entry, "time")
+		entries = append(entries, entry)
+	}
+	runID := manager.LastRunReport().RunID
+	assert.Contains(t, entries, map[string]any{"level": "INFO", "msg": "Registered seeder: users"})
+	assert.Contains(t, entries, map[string]any{"level": "INFO", "msg": "Running seeder: users", "run_id": runID, "seeder": "users"})
+	assert.Contains(t, entries, map[string]any{"level": "INFO", "msg": "seeding users", "count": float64(2)})
+	assert.Contains(t, entries, map[string]any{"level": "INFO", "msg": "Skipping seeder 'orders': table not empty", "run_id": runID, "seeder": "orders"})
+}
+
+// TestLoggerPrecedence tests that a logger in the context wins over the manager's
+func TestLoggerPrecedence(t *testing.T) {
+	managerLogger, runLogger := &recordingLogger{}, &recordingLogger{}
+	manager := NewSeederManager().WithLogger(managerLogger)
+	manager.RegisterSeeders(SeederItem{Name: "users", Function: func() error { return nil }})
+
+	assert.NoError(t, manager.RunAllSeedersContext(ContextWithLogger(context.Background(), runLogger)))
+	assert.Equal(t, []string{"info: Registered seeder: users"}, managerLogger.lines)
+	assert.Contains(t, runLogger.lines[0], "info: Starting seed run ")
+	runID := manager.LastRunReport().RunID
+	assert.Contains(t, runLogger.lines, "info: All seeders completed successfully! run_id="+runID)
+
+	output := captureLog(func() {
+		LoggerFromContext(context.Background()).Warn("plain", "key", "value")
+	})
+	assert.Contains(t, output, "plain")
+	assert.NotContains(t, output, "key=value", "the standard logger prints messages only")
+}
+
+// TestCLISetLogger tests routing the log output of commands
+func TestCLISetLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	manager := NewSeederManager()
+	manager.RegisterSeeders(SeederItem{Name: "users", Function: func() error { return nil }})
+	cli := NewCLI(manager)
+	cli.SetLogger(logger)
+
+	args := os.Args
+	defer func() { os.Args = args }()
+	os.Args = []string{"seeder", "-type=users"}
+
+	assert.NoError(t, cli.Run())
+	assert.Contains(t, logger.lines, fmt.Sprintf("info: Running seeder: users run_id=%s seeder=users", manager.LastRunReport().RunID))
+
+	logger.lines = nil
+	os.Args = []string{"seeder", "-quiet", "-type=users"}
+	assert.NoError(t, cli.Run())
+	assert.Empty(t, logger.lines, "-quiet logs errors only")
+}
+
+// fakeSugaredLogger records calls in the shape of zap's SugaredLogger
+type fakeSugaredLogger struct{ calls []string }
+
+func (f *fakeSugaredLogger) Infow(msg string, kv ...any)  { f.record("Infow", msg, kv) }
+func (f *fakeSugaredLogger) Warnw(msg string, kv ...any)  { f.record("Warnw", msg, kv) }
+func (f *fakeSugaredLogger) Errorw(msg string, kv ...any) { f.record("Errorw", msg, kv) }
+
+func (f *fakeSugaredLogger) record(method, msg string, kv []any) {
+	f.calls = append(f.calls, fmt.Sprint(method, " ", msg, kv))
+}
+
+// fakeFormatLogger records calls in the shape of logrus' Logger
+type fakeFormatLogger struct{ calls []string }
+
+func (f *fakeFormatLogger) Infof(format string, args ...any) {
+	f.calls = append(f.calls, "Infof "+fmt.Sprintf(format, args...))
+}
+func (f *fakeFormatLogger) Warnf(format string, args ...any) {
+	f.calls = append(f.calls, "Warnf "+fmt.Sprintf(format, args...))
+}
+func (f *fakeFormatLogger) Errorf(format string, args ...any) {
+	f.calls = append(f.calls, "Errorf "+fmt.Sprintf(format, args...))
+}
+
+// TestLoggerAdapters tests the zap and logrus adapters
+func TestLoggerAdapters(t *testing.T) {
+	sugared := &fakeSugaredLogger{}
+	zap := ZapLogger(sugared)
+	zap.Info("a", "seeder", "users")
+	zap.Warn("b")
+	zap.Error("c", "error", "boom")
+	assert.Equal(t, []string{"Infow a[seeder users]", "Warnw b[]", "Errorw c[error boom]"}, sugared.calls)
+
+	formatted := &fakeFormatLogger{}
+	logrus := LogrusLogger(formatted)
+	logrus.Info("a 100%", "seeder", "users")
+	logrus.Warn("b")
+	logrus.Error("c", "error", "boom", "dangling")
+	assert.Equal(t, []string{"Infof a 100% seeder=users", "Warnf b", "Errorf c error=boom dangling"}, formatted.calls)
+}
+
+// failingReportStore fails to load and save reports
+type failingReportStore struct{}
+
+func (failingReportStore) SaveReport(ctx context.Context, report *RunReport) error {
+	return errors.New("store unavailable")
+}
+
+func (failingReportStore) LastReport(ctx context.Context) (*RunReport, error) {
+	return nil, errors.New("store unavailable")
+}
+
+// TestQuiet tests logging errors only
+func TestQuiet(t *testing.T) {
+	logger := &recordingLogger{}
+	manager := NewSeederManager().WithLogger(logger)
+	manager.SetQuiet(true)
+	manager.RegisterSeeders(
+		SeederItem{Name: "users", Function: func() error { return nil }},
+		SeederItem{Name: "orders", Transaction: true, Function: func() error { return nil }},
+	)
+	manager.SetReportStore(failingReportStore{})
+
+	assert.Error(t, manager.RunAllSeeders())
+	assert.Equal(t, []string{"error: Failed to load the previous run report: store unavailable run_id=" + manager.LastRunReport().RunID,
+		"error: Failed to save run report: store unavailable run_id=" + manager.LastRunReport().RunID}, logger.lines)
+
+	output := captureLog(func() {
+		ctx := ContextWithQuiet(context.Background())
+		logInfo(ctx, "progress")
+		logWarn(ctx, "warning")
+		logError(ctx, "failure")
+	})
+	assert.Contains(t, output, "failure")
+	assert.NotContains(t, output, "progress")
+	assert.NotContains(t, output, "warning")
+}
@@ -0,0 +1,31 @@
+package goseeder
+
+import "context"
+
+// SeederFunc is the signature seeders are executed with inside the middleware chain
+type SeederFunc func(ctx context.Context) error
+
+// Middleware wraps seeder execution, e.g. for logging, metrics, tracing,
+// retries or transactions. Call next to continue the chain.
+type Middleware func(next SeederFunc) SeederFunc
+
+// Use appends middleware to the execution chain. Middleware registered
+// first is the outermost and runs first.
+func (sm *SeederManager) Use(middleware ...Middleware) {
+	sm.middleware = append(sm.middleware, middleware...)
+}
+
+// chain wraps a seeder function with the registered middleware
+func (sm *SeederManager) chain(function SeederFunc) SeederFunc {
+	for i := len(sm.middleware) - 1; i >= 0; i-- {
+		function = sm.middleware[i](function)
+	}
+	return function
+}
+
+// SeederNameFromContext returns the name of the seeder being executed,
+// allowing middleware to label logs and metrics
+func SeederNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(seederNameKey).(string)
+	return name
+}
@@ -0,0 +1,113 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUse tests the middleware chain
+func TestUse(t *testing.T) {
+	t.Run("Middleware runs in registration order", func(t *testing.T) {
+		manager := NewSeederManager()
+		calls := []string{}
+
+		trace := func(label string) Middleware {
+			return func(next SeederFunc) SeederFunc {
+				return func(ctx context.Context) error {
+					calls = append(calls, label+":before")
+					err := next(ctx)
+					calls = append(calls, label+":after")
+					return err
+				}
+			}
+		}
+
+		manager.Use(trace("outer"), trace("inner"))
+		manager.RegisterSeeder("users", func() error {
+			calls = append(calls, "users")
+			return nil
+		})
+
+		err := manager.RunSeederByName("users")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"outer:before", "inner:before", "users", "inner:after", "outer:after"}, calls)
+	})
+
+	t.Run("Middleware sees the seeder name", func(t *testing.T) {
+		manager := NewSeederManager()
+		names := []string{}
+
+		manager.Use(func(next SeederFunc) SeederFunc {
+			return func(ctx context.Context) error {
+				names = append(names, SeederNameFromContext(ctx))
+				return next(ctx)
+			}
+		})
+		manager.RegisterSeeder("users", func() error { return nil })
+		manager.RegisterSeeder("roles", func() error { return nil })
+
+		err := manager.RunAllSeeders()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"users", "roles"}, names)
+	})
+
+	t.Run("Retry middleware recovers from transient errors", func(t *testing.T) {
+		manager := NewSeederManager()
+		attempts := 0
+
+		manager.Use(func(next SeederFunc) SeederFunc {
+			return func(ctx context.Context) error {
+				var err error
+				for i := 0; i < 3; i++ {
+					if err = next(ctx); err == nil {
+						return nil
+					}
+				}
+				return err
+			}
+		})
+		manager.RegisterSeeder("flaky", func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("transient")
+			}
+			return nil
+		})
+
+		err := manager.RunSeederByName("flaky")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("Middleware can short-circuit", func(t *testing.T) {
+		manager := NewSeederManager()
+		executed := false
+
+		manager.Use(func(next SeederFunc) SeederFunc {
+			return func(ctx context.Context) error {
+				return errors.New("blocked")
+			}
+		})
+		manager.RegisterSeeder("users", func() error {
+			executed = true
+			return nil
+		})
+
+		err := manager.RunSeederByName("users")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "blocked")
+		assert.False(t, executed)
+	})
+}
+
+// TestSeederNameFromContext tests the context accessor without a running seeder
+func TestSeederNameFromContext(t *testing.T) {
+	assert.Equal(t, "", SeederNameFromContext(context.Background()))
+}
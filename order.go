@@ -0,0 +1,190 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ForeignKey is a reference from a column of Table to a column of RefTable
+type ForeignKey struct {
+	Table     string
+	Column    string
+	RefTable  string
+	RefColumn string
+}
+
+// ForeignKeyLister lists the foreign keys of a database
+type ForeignKeyLister interface {
+	ForeignKeys(ctx context.Context) ([]ForeignKey, error)
+}
+
+// ForeignKeyListerFunc adapts a function to the ForeignKeyLister interface
+type ForeignKeyListerFunc func(ctx context.Context) ([]ForeignKey, error)
+
+// ForeignKeys calls f(ctx)
+func (f ForeignKeyListerFunc) ForeignKeys(ctx context.Context) ([]ForeignKey, error) {
+	return f(ctx)
+}
+
+// postgresForeignKeysQuery lists foreign keys from information_schema
+const postgresForeignKeysQuery = `SELECT kcu.table_name, kcu.column_name, ccu.table_name, ccu.column_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu
+  ON kcu.constraint_name = tc.constraint_name AND kcu.constraint_schema = tc.constraint_schema
+JOIN information_schema.constraint_column_usage ccu
+  ON ccu.constraint_name = tc.constraint_name AND ccu.constraint_schema = tc.constraint_schema
+WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = current_schema()`
+
+// PostgresForeignKeys lists the foreign keys of the current schema of a
+// Postgres or CockroachDB database
+func PostgresForeignKeys(db *sql.DB) ForeignKeyLister {
+	return ForeignKeyListerFunc(func(ctx context.Context) ([]ForeignKey, error) {
+		rows, err := db.QueryContext(ctx, postgresForeignKeysQuery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+		}
+		defer rows.Close()
+
+		var keys []ForeignKey
+		for rows.Next() {
+			var key ForeignKey
+			if err := rows.Scan(&key.Table, &key.Column, &key.RefTable, &key.RefColumn); err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		return keys, rows.Err()
+	})
+}
+
+// OrderTables sorts tables so every table comes after the tables it
+// references. Tables without a dependency between them keep alphabetical
+// order, so the result is stable; references to tables outside tables and
+// self-references are ignored. A reference cycle is an error.
+func OrderTables(tables []string, keys []ForeignKey) ([]string, error) {
+	wanted := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		wanted[table] = true
+	}
+
+	dependencies := make(map[string]map[string]bool, len(tables))
+	dependents := make(map[string][]string)
+	for _, table := range tables {
+		dependencies[table] = make(map[string]bool)
+	}
+	for _, key := range keys {
+		if !wanted[key.Table] || !wanted[key.RefTable] || key.Table == key.RefTable || dependencies[key.Table][key.RefTable] {
+			continue
+		}
+		dependencies[key.Table][key.RefTable] = true
+		dependents[key.RefTable] = append(dependents[key.RefTable], key.Table)
+	}
+
+	var ready []string
+	for table, refs := range dependencies {
+		if len(refs) == 0 {
+			ready = append(ready, table)
+		}
+	}
+
+	ordered := make([]string, 0, len(tables))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		table := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, table)
+		for _, dependent := range dependents[table] {
+			delete(dependencies[dependent], table)
+			if len(dependencies[dependent]) == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(ordered) < len(dependencies) {
+		var cycle []string
+		for table, refs := range dependencies {
+			if len(refs) > 0 {
+				cycle = append(cycle, table)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("foreign keys form a cycle between %s", strings.Join(cycle, ", "))
+	}
+	return ordered, nil
+}
+
+// FixtureOrder is an order manifest listing fixture files in load order,
+// replacing "01_", "02_" file name prefixes
+type FixtureOrder struct {
+	Files []string `json:"files"` // Relative to the manifest's directory
+}
+
+// OrderFixtureFiles returns files sorted by the foreign keys between their
+// tables, see OrderTables. The table of a file is its name without extension.
+func OrderFixtureFiles(files []string, keys []ForeignKey) ([]string, error) {
+	byTable := make(map[string]string, len(files))
+	tables := make([]string, 0, len(files))
+	for _, file := range files {
+		table := fixtureTable(file)
+		if other, exists := byTable[table]; exists {
+			return nil, fmt.Errorf("fixtures '%s' and '%s' load the same table %s", other, file, table)
+		}
+		byTable[table] = file
+		tables = append(tables, table)
+	}
+
+	ordered, err := OrderTables(tables, keys)
+	if err != nil {
+		return nil, err
+	}
+	for i, table := range ordered {
+		ordered[i] = byTable[table]
+	}
+	return ordered, nil
+}
+
+// WriteFixtureOrder writes an order manifest of files to path, with the
+// files relative to the manifest's directory
+func WriteFixtureOrder(path string, files []string) error {
+	dir := filepath.Dir(path)
+	order := FixtureOrder{Files: make([]string, len(files))}
+	for i, file := range files {
+		relative, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		order.Files[i] = filepath.ToSlash(relative)
+	}
+
+	data, err := json.MarshalIndent(order, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ReadFixtureOrder reads an order manifest and returns its files, resolved
+// against the manifest's directory
+func ReadFixtureOrder(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture order: %w", err)
+	}
+	var order FixtureOrder
+	if err := json.Unmarshal(data, &order); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture order '%s': %w", path, err)
+	}
+
+	files := make([]string, len(order.Files))
+	for i, file := range order.Files {
+		files[i] = filepath.Join(filepath.Dir(path), filepath.FromSlash(file))
+	}
+	return files, nil
+}
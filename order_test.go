@@ -0,0 +1,80 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// shopKeys are the foreign keys of a small shop schema
+var shopKeys = []ForeignKey{
+	{Table: "orders", Column: "user_id", RefTable: "users", RefColumn: "id"},
+	{Table: "orders", Column: "product_id", RefTable: "products", RefColumn: "id"},
+	{Table: "users", Column: "country_code", RefTable: "countries", RefColumn: "code"},
+	{Table: "users", Column: "referrer_id", RefTable: "users", RefColumn: "id"},
+	{Table: "audit_logs", Column: "user_id", RefTable: "admins", RefColumn: "id"},
+}
+
+// TestOrderTables tests ordering tables after the tables they reference
+func TestOrderTables(t *testing.T) {
+	ordered, err := OrderTables([]string{"orders", "users", "products", "countries", "audit_logs"}, shopKeys)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"audit_logs", "countries", "products", "users", "orders"}, ordered)
+
+	_, err = OrderTables([]string{"a", "b", "c"}, []ForeignKey{
+		{Table: "a", RefTable: "b"},
+		{Table: "b", RefTable: "a"},
+		{Table: "c", RefTable: "a"},
+	})
+	assert.EqualError(t, err, "foreign keys form a cycle between a, b, c")
+}
+
+// TestFixtureOrderManifest tests writing and reading an order manifest
+func TestFixtureOrderManifest(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{filepath.Join(dir, "orders.json"), filepath.Join(dir, "users.ndjson")}
+
+	ordered, err := OrderFixtureFiles(files, shopKeys)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{files[1], files[0]}, ordered)
+
+	manifest := filepath.Join(dir, "order.json")
+	assert.NoError(t, WriteFixtureOrder(manifest, ordered))
+	content, _ := os.ReadFile(manifest)
+	assert.Equal(t, "{\n  \"files\": [\n    \"users.ndjson\",\n    \"orders.json\"\n  ]\n}\n", string(content))
+
+	read, err := ReadFixtureOrder(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, ordered, read)
+
+	_, err = OrderFixtureFiles([]string{"a/users.json", "b/users.ndjson"}, nil)
+	assert.ErrorContains(t, err, "load the same table users")
+}
+
+// TestCLIOrderCommand tests ordering fixtures with the database's foreign keys
+func TestCLIOrderCommand(t *testing.T) {
+	db, fake := newFakeDB()
+	fake.On(postgresForeignKeysQuery, []string{"table_name", "column_name", "table_name", "column_name"},
+		[]driver.Value{"orders", "user_id", "users", "id"})
+	cli := NewCLI(NewSeederManager())
+	cli.SetDB(db)
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "order.json")
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"order", "-write", manifest, filepath.Join(dir, "orders.json"), filepath.Join(dir, "users.json")}))
+	files, err := ReadFixtureOrder(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "users.json"), filepath.Join(dir, "orders.json")}, files)
+
+	cli.SetForeignKeyLister(ForeignKeyListerFunc(func(ctx context.Context) ([]ForeignKey, error) {
+		return []ForeignKey{{Table: "a", RefTable: "b"}, {Table: "b", RefTable: "a"}}, nil
+	}))
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"order", "a.json", "b.json"}), "cycle")
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"order"}), "at least one fixture file")
+	assert.ErrorContains(t, NewCLI(NewSeederManager()).runCommand(context.Background(), []string{"order", "a.json"}), "requires a database")
+}
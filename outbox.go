@@ -0,0 +1,60 @@
+package goseeder
+
+import "context"
+
+// OutboxMode tells application code what to do with the events it raises
+// while seeding, e.g. so seeding 50k orders doesn't publish 50k events
+type OutboxMode int
+
+const (
+	OutboxPublish OutboxMode = iota // Publish events as usual (default)
+	OutboxDivert                    // Write events to a side channel, e.g. a separate outbox table or topic
+	OutboxDiscard                   // Drop events
+)
+
+// String returns the name of the mode
+func (m OutboxMode) String() string {
+	switch m {
+	case OutboxDivert:
+		return "divert"
+	case OutboxDiscard:
+		return "discard"
+	default:
+		return "publish"
+	}
+}
+
+// SetOutboxMode sets the mode application code reads with
+// OutboxModeFromContext during runs
+func (sm *SeederManager) SetOutboxMode(mode OutboxMode) {
+	sm.outboxMode = mode
+}
+
+// OutboxModeFromContext returns the outbox mode of the run ctx belongs to,
+// OutboxPublish outside of runs. Check it where events are written to the
+// transactional outbox:
+//
+//	switch goseeder.OutboxModeFromContext(ctx) {
+//	case goseeder.OutboxDiscard:
+//		return nil
+//	case goseeder.OutboxDivert:
+//		return outbox.InsertDiverted(ctx, tx, event)
+//	}
+//	return outbox.Insert(ctx, tx, event)
+func OutboxModeFromContext(ctx context.Context) OutboxMode {
+	mode, _ := ctx.Value(outboxModeKey).(OutboxMode)
+	return mode
+}
+
+// OutboxPauser pauses and resumes event publication, e.g. the relay that
+// forwards the transactional outbox to Kafka
+type OutboxPauser interface {
+	Pause(ctx context.Context) error
+	Resume(ctx context.Context) error
+}
+
+// SetOutboxPauser makes every run pause publication before the first seeder
+// and resume it after the last one, whether the run succeeds or not
+func (sm *SeederManager) SetOutboxPauser(pauser OutboxPauser) {
+	sm.outboxPauser = pauser
+}
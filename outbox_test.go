@@ -0,0 +1,83 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingPauser records pause and resume calls
+type recordingPauser struct {
+	calls     []string
+	pauseErr  error
+	resumeErr error
+}
+
+func (rp *recordingPauser) Pause(ctx context.Context) error {
+	rp.calls = append(rp.calls, "pause")
+	return rp.pauseErr
+}
+
+func (rp *recordingPauser) Resume(ctx context.Context) error {
+	rp.calls = append(rp.calls, "resume")
+	return rp.resumeErr
+}
+
+// TestSetOutboxMode tests exposing the outbox mode to application code
+func TestSetOutboxMode(t *testing.T) {
+	manager := NewSeederManager()
+	manager.SetOutboxMode(OutboxDivert)
+	var mode OutboxMode
+	manager.RegisterSeederContext("orders", func(ctx context.Context) error {
+		mode = OutboxModeFromContext(ctx)
+		return nil
+	})
+
+	assert.NoError(t, manager.RunSeederByName("orders"))
+	assert.Equal(t, OutboxDivert, mode)
+	assert.Equal(t, "divert", mode.String())
+	assert.Equal(t, OutboxPublish, OutboxModeFromContext(context.Background()))
+}
+
+// TestSetOutboxPauser tests pausing publication around runs
+func TestSetOutboxPauser(t *testing.T) {
+	t.Run("Pauses once per run", func(t *testing.T) {
+		pauser := &recordingPauser{}
+		manager := NewSeederManager()
+		manager.SetOutboxPauser(pauser)
+		calls := []string{}
+		manager.RegisterSeeder("users", func() error {
+			calls = append(calls, "users")
+			return nil
+		})
+		manager.RegisterSeeder("orders", func() error { return errors.New("boom") })
+
+		assert.Error(t, manager.RunAllSeeders())
+		assert.Equal(t, []string{"pause", "resume"}, pauser.calls, "resumed even when the run fails")
+		assert.Equal(t, []string{"users"}, calls)
+	})
+
+	t.Run("Pause failure aborts the run", func(t *testing.T) {
+		pauser := &recordingPauser{pauseErr: errors.New("relay unreachable")}
+		manager := NewSeederManager()
+		manager.SetOutboxPauser(pauser)
+		ran := false
+		manager.RegisterSeeder("users", func() error {
+			ran = true
+			return nil
+		})
+
+		assert.ErrorContains(t, manager.RunAllSeeders(), "failed to pause outbox")
+		assert.False(t, ran)
+	})
+
+	t.Run("Resume failure is reported", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.SetOutboxPauser(&recordingPauser{resumeErr: errors.New("relay unreachable")})
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		assert.ErrorContains(t, manager.RunSeederByName("users"), "failed to resume outbox")
+	})
+}
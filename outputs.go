@@ -0,0 +1,165 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ScenarioOutputs holds the values recorded by seeders during scenario runs,
+// keyed by label, e.g. "customer_ids" -> ["42", "43"]
+type ScenarioOutputs map[string][]string
+
+// merge appends the values of other to outputs
+func (so ScenarioOutputs) merge(other ScenarioOutputs) {
+	for key, values := range other {
+		so[key] = append(so[key], values...)
+	}
+}
+
+// OutputStore persists scenario outputs so a later teardown, possibly in
+// another process, can remove exactly the data a scenario created
+type OutputStore interface {
+	Append(ctx context.Context, scenario string, outputs ScenarioOutputs) error
+	Load(ctx context.Context, scenario string) (ScenarioOutputs, error)
+	Clear(ctx context.Context, scenario string) error
+}
+
+// RecordOutput records a value created by the running seeder, e.g. the ID of
+// an inserted row. It is a no-op outside of scenario runs.
+func RecordOutput(ctx context.Context, key string, values ...string) {
+	collector, ok := ctx.Value(outputsKey).(*outputCollector)
+	if !ok {
+		return
+	}
+	collector.record(key, values...)
+}
+
+// outputCollector gathers the outputs of a single scenario run
+type outputCollector struct {
+	mu      sync.Mutex
+	outputs ScenarioOutputs
+}
+
+func (oc *outputCollector) record(key string, values ...string) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.outputs[key] = append(oc.outputs[key], values...)
+}
+
+// MemoryOutputStore keeps scenario outputs in memory for the lifetime of the process
+type MemoryOutputStore struct {
+	mu      sync.Mutex
+	outputs map[string]ScenarioOutputs
+}
+
+// NewMemoryOutputStore creates an empty in-memory output store
+func NewMemoryOutputStore() *MemoryOutputStore {
+	return &MemoryOutputStore{
+		outputs: make(map[string]ScenarioOutputs),
+	}
+}
+
+// Append adds outputs to those recorded for scenario
+func (ms *MemoryOutputStore) Append(ctx context.Context, scenario string, outputs ScenarioOutputs) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.outputs[scenario] == nil {
+		ms.outputs[scenario] = make(ScenarioOutputs)
+	}
+	ms.outputs[scenario].merge(outputs)
+	return nil
+}
+
+// Load returns the outputs recorded for scenario
+func (ms *MemoryOutputStore) Load(ctx context.Context, scenario string) (ScenarioOutputs, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	loaded := make(ScenarioOutputs)
+	loaded.merge(ms.outputs[scenario])
+	return loaded, nil
+}
+
+// Clear removes the outputs recorded for scenario
+func (ms *MemoryOutputStore) Clear(ctx context.Context, scenario string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.outputs, scenario)
+	return nil
+}
+
+// FileOutputStore keeps scenario outputs as JSON files in a directory, one
+// file per scenario, so teardowns work across CLI invocations
+type FileOutputStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileOutputStore creates a store writing to dir, created on first use
+func NewFileOutputStore(dir string) *FileOutputStore {
+	return &FileOutputStore{dir: dir}
+}
+
+// path returns the file holding the outputs of scenario
+func (fs *FileOutputStore) path(scenario string) string {
+	return filepath.Join(fs.dir, url.PathEscape(scenario)+".json")
+}
+
+// Append adds outputs to those recorded for scenario
+func (fs *FileOutputStore) Append(ctx context.Context, scenario string, outputs ScenarioOutputs) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	existing, err := fs.read(scenario)
+	if err != nil {
+		return err
+	}
+	existing.merge(outputs)
+
+	data, err := json.MarshalIndent(existing, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(fs.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return os.WriteFile(fs.path(scenario), data, 0o644)
+}
+
+// Load returns the outputs recorded for scenario
+func (fs *FileOutputStore) Load(ctx context.Context, scenario string) (ScenarioOutputs, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.read(scenario)
+}
+
+// Clear removes the outputs recorded for scenario
+func (fs *FileOutputStore) Clear(ctx context.Context, scenario string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err := os.Remove(fs.path(scenario)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// read loads the outputs file of scenario, returning empty outputs if missing
+func (fs *FileOutputStore) read(scenario string) (ScenarioOutputs, error) {
+	outputs := make(ScenarioOutputs)
+	data, err := os.ReadFile(fs.path(scenario))
+	if errors.Is(err, os.ErrNotExist) {
+		return outputs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		return nil, fmt.Errorf("invalid outputs file for scenario '%s': %w", scenario, err)
+	}
+	return outputs, nil
+}
@@ -0,0 +1,147 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRecordOutput tests recording outputs outside and inside scenario runs
+func TestRecordOutput(t *testing.T) {
+	t.Run("No-op outside scenarios", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			RecordOutput(context.Background(), "ids", "1")
+		})
+	})
+
+	t.Run("Collected during scenario run", func(t *testing.T) {
+		manager := NewSeederManager()
+		store := NewMemoryOutputStore()
+		manager.SetOutputStore(store)
+		manager.RegisterSeederContext("customer", func(ctx context.Context) error {
+			RecordOutput(ctx, "customer_ids", "42", "43")
+			return nil
+		})
+		manager.RegisterScenario(Scenario{Name: "trial", Seeders: []string{"customer"}})
+
+		assert.NoError(t, manager.RunScenario(context.Background(), "trial", nil))
+		assert.NoError(t, manager.RunScenario(context.Background(), "trial", nil))
+
+		outputs, err := store.Load(context.Background(), "trial")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"42", "43", "42", "43"}, outputs["customer_ids"])
+	})
+
+	t.Run("Outputs of failed runs are kept", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeederContext("customer", func(ctx context.Context) error {
+			RecordOutput(ctx, "customer_ids", "7")
+			return errors.New("boom")
+		})
+		manager.RegisterScenario(Scenario{Name: "trial", Seeders: []string{"customer"}})
+
+		assert.Error(t, manager.RunScenario(context.Background(), "trial", nil))
+
+		outputs, _ := manager.outputStore.Load(context.Background(), "trial")
+		assert.Equal(t, []string{"7"}, outputs["customer_ids"])
+	})
+}
+
+// TestTeardownScenario tests removing scenario data
+func TestTeardownScenario(t *testing.T) {
+	newManager := func(removed *[]string) *SeederManager {
+		manager := NewSeederManager()
+		manager.RegisterSeederContext("customer", func(ctx context.Context) error {
+			RecordOutput(ctx, "customer_ids", "42")
+			return nil
+		})
+		manager.RegisterScenario(Scenario{
+			Name:    "trial",
+			Seeders: []string{"customer"},
+			Teardown: func(ctx context.Context, outputs ScenarioOutputs) error {
+				*removed = append(*removed, outputs["customer_ids"]...)
+				return nil
+			},
+		})
+		manager.RegisterScenario(Scenario{Name: "no-teardown"})
+		return manager
+	}
+
+	t.Run("Teardown receives outputs and clears them", func(t *testing.T) {
+		removed := []string{}
+		manager := newManager(&removed)
+		manager.RunScenario(context.Background(), "trial", nil)
+
+		assert.NoError(t, manager.TeardownScenario(context.Background(), "trial"))
+		assert.Equal(t, []string{"42"}, removed)
+
+		assert.NoError(t, manager.TeardownScenario(context.Background(), "trial"))
+		assert.Equal(t, []string{"42"}, removed)
+	})
+
+	t.Run("Torn down scenarios run again with a history store", func(t *testing.T) {
+		removed := []string{}
+		manager := newManager(&removed)
+		manager.SetHistoryStore(NewMemoryHistoryStore())
+		assert.NoError(t, manager.RunScenario(context.Background(), "trial", nil))
+		assert.NoError(t, manager.TeardownScenario(context.Background(), "trial"))
+
+		assert.NoError(t, manager.RunScenario(context.Background(), "trial", nil))
+		assert.NoError(t, manager.TeardownScenario(context.Background(), "trial"))
+		assert.Equal(t, []string{"42", "42"}, removed)
+	})
+
+	t.Run("Scenario without teardown", func(t *testing.T) {
+		removed := []string{}
+		err := newManager(&removed).TeardownScenario(context.Background(), "no-teardown")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no teardown")
+	})
+
+	t.Run("Unknown scenario", func(t *testing.T) {
+		removed := []string{}
+		err := newManager(&removed).TeardownScenario(context.Background(), "missing")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("CLI teardown command", func(t *testing.T) {
+		removed := []string{}
+		manager := newManager(&removed)
+		manager.RunScenario(context.Background(), "trial", nil)
+		cli := NewCLI(manager)
+
+		assert.NoError(t, cli.runCommand(context.Background(), []string{"teardown", "trial"}))
+		assert.Equal(t, []string{"42"}, removed)
+		assert.Error(t, cli.runCommand(context.Background(), []string{"teardown"}))
+	})
+}
+
+// TestFileOutputStore tests the JSON file store
+func TestFileOutputStore(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "outputs")
+	store := NewFileOutputStore(dir)
+
+	outputs, err := store.Load(ctx, "customer/churn")
+	assert.NoError(t, err)
+	assert.Empty(t, outputs)
+
+	assert.NoError(t, store.Append(ctx, "customer/churn", ScenarioOutputs{"ids": {"1"}}))
+	assert.NoError(t, NewFileOutputStore(dir).Append(ctx, "customer/churn", ScenarioOutputs{"ids": {"2"}}))
+
+	outputs, err = store.Load(ctx, "customer/churn")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, outputs["ids"])
+
+	assert.NoError(t, store.Clear(ctx, "customer/churn"))
+	assert.NoError(t, store.Clear(ctx, "customer/churn"))
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,142 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// overlayKeyField names the column an overlay record is matched on, "id"
+// when the record does not set it, e.g. {"_key": "email", ...}
+const overlayKeyField = "_key"
+
+// defaultOverlayKey is the column overlay records are matched on by default
+const defaultOverlayKey = "id"
+
+// ContextWithEnvironment returns a copy of ctx reading fixture files with
+// the overlay of env: users.json is merged with users.staging.json for
+// "staging", when that file exists, see ReadFixtureFileContext
+func ContextWithEnvironment(ctx context.Context, env string) context.Context {
+	return context.WithValue(ctx, environmentKey, env)
+}
+
+// fixtureEnvironment returns the environment set with ContextWithEnvironment
+func fixtureEnvironment(ctx context.Context) string {
+	env, _ := ctx.Value(environmentKey).(string)
+	return env
+}
+
+// overlayPath returns the overlay of the fixture file at file for env:
+// the table name followed by the environment, e.g. users.staging.json for
+// users.json and users.staging.tmpl.json for users.tmpl.json
+func overlayPath(file, env string) string {
+	base := filepath.Base(file)
+	table := fixtureTable(file)
+	return filepath.Join(filepath.Dir(file), table+"."+env+base[len(table):])
+}
+
+// applyFixtureOverlay merges the overlay of the environment of ctx into
+// records of the fixture file at file, read from origins. Records are
+// returned as they are without an environment or overlay file.
+func applyFixtureOverlay(ctx context.Context, file string, records []Record, origins []recordOrigin) ([]Record, []recordOrigin, error) {
+	env := fixtureEnvironment(ctx)
+	if env == "" {
+		return records, origins, nil
+	}
+	overlay := overlayPath(file, env)
+	if _, err := os.Stat(overlay); errors.Is(err, fs.ErrNotExist) {
+		return records, origins, nil
+	}
+	changes, err := decodeFixtureFile(ctx, overlay)
+	if err != nil {
+		return nil, nil, err
+	}
+	merged, mergedOrigins, err := mergeOverlay(records, origins, overlay, changes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to merge overlay '%s': %w", overlay, err)
+	}
+	return merged, mergedOrigins, nil
+}
+
+// mergeOverlay merges the records of the overlay file at overlay into
+// records: an overlay record sets its columns on the record with the same
+// key, "id" or the column named by its _key field, and is appended when no
+// record has that key. _generate records are appended as they are.
+// Appended records are read from the overlay and follow the last entry of
+// the file; merged ones keep their origin.
+func mergeOverlay(records []Record, origins []recordOrigin, overlay string, changes []Record) ([]Record, []recordOrigin, error) {
+	merged := append(make([]Record, 0, len(records)+len(changes)), records...)
+	mergedOrigins := append(make([]recordOrigin, 0, len(records)+len(changes)), origins...)
+	entries := 0
+	for _, origin := range origins {
+		entries = max(entries, origin.entry)
+	}
+	appendChange := func(i int, record Record) {
+		entries++
+		merged = append(merged, record)
+		mergedOrigins = append(mergedOrigins, recordOrigin{file: overlay, row: i + 1, entry: entries})
+	}
+
+	for i, change := range changes {
+		if _, ok := change[generateKey]; ok {
+			appendChange(i, change)
+			continue
+		}
+		key := defaultOverlayKey
+		if value, ok := change[overlayKeyField]; ok {
+			if key, ok = value.(string); !ok || key == "" {
+				return nil, nil, fmt.Errorf("record %d: %s must name a column", i+1, overlayKeyField)
+			}
+		}
+		value, ok := change[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("record %d has no key column '%s'", i+1, key)
+		}
+
+		target := -1
+		for j, record := range merged {
+			if existing, ok := record[key]; ok && fmt.Sprint(existing) == fmt.Sprint(value) {
+				target = j
+				break
+			}
+		}
+		record := make(Record, len(change))
+		if target >= 0 {
+			for column, value := range merged[target] {
+				record[column] = value
+			}
+		}
+		for column, value := range change {
+			if column != overlayKeyField {
+				record[column] = value
+			}
+		}
+		if target >= 0 {
+			merged[target] = record
+		} else {
+			appendChange(i, record)
+		}
+	}
+	return merged, mergedOrigins, nil
+}
+
+// isFixtureOverlay reports whether file, a slash path, is the overlay of
+// one of the fixture files of its folder, e.g. users.staging.json next to
+// users.json
+func isFixtureOverlay(file string, files []string) bool {
+	table, _, found := strings.Cut(fixtureTable(file), ".")
+	if !found || strings.EqualFold(path.Ext(file), ".sql") {
+		return false
+	}
+	for _, other := range files {
+		if path.Dir(other) == path.Dir(file) && fixtureTable(other) == table {
+			return true
+		}
+	}
+	return false
+}
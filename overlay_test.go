@@ -0,0 +1,105 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFixtureOverlays tests merging environment overlays into fixture files
+func TestFixtureOverlays(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	users := write("users.json", `[
+		{"id": 1, "name": "Alice", "url": "http://localhost", "quota": 10},
+		{"id": 2, "name": "Bob", "url": "http://localhost", "quota": 10}
+	]`)
+	write("users.staging.json", `[
+		{"id": 2, "url": "https://staging.example.com", "quota": 100},
+		{"id": 3, "name": "Staging bot", "url": "https://staging.example.com", "quota": 0}
+	]`)
+
+	t.Run("Overlay merged by key", func(t *testing.T) {
+		records, err := ReadFixtureFileContext(ContextWithEnvironment(context.Background(), "staging"), users)
+		require.NoError(t, err)
+		assert.Equal(t, []Record{
+			{"id": json.Number("1"), "name": "Alice", "url": "http://localhost", "quota": json.Number("10")},
+			{"id": json.Number("2"), "name": "Bob", "url": "https://staging.example.com", "quota": json.Number("100")},
+			{"id": json.Number("3"), "name": "Staging bot", "url": "https://staging.example.com", "quota": json.Number("0")},
+		}, records)
+	})
+
+	t.Run("Merged records keep their row, added ones follow the file", func(t *testing.T) {
+		_, origins, err := readFixtureFile(ContextWithEnvironment(context.Background(), "staging"), users)
+		require.NoError(t, err)
+		assert.Equal(t, []recordOrigin{
+			{file: users, row: 1, entry: 1},
+			{file: users, row: 2, entry: 2},
+			{file: filepath.Join(dir, "users.staging.json"), row: 2, entry: 3},
+		}, origins)
+	})
+
+	t.Run("No overlay for the environment", func(t *testing.T) {
+		for _, ctx := range []context.Context{context.Background(), ContextWithEnvironment(context.Background(), "prod")} {
+			records, err := ReadFixtureFileContext(ctx, users)
+			require.NoError(t, err)
+			assert.Len(t, records, 2)
+			assert.Equal(t, "http://localhost", records[1]["url"])
+		}
+	})
+
+	t.Run("Custom key", func(t *testing.T) {
+		settings := write("settings.json", `[{"key": "checkout", "enabled": false}, {"key": "search", "enabled": true}]`)
+		write("settings.ci.json", `[{"_key": "key", "key": "checkout", "enabled": true}]`)
+		records, err := ReadFixtureFileContext(ContextWithEnvironment(context.Background(), "ci"), settings)
+		require.NoError(t, err)
+		assert.Equal(t, []Record{{"key": "checkout", "enabled": true}, {"key": "search", "enabled": true}}, records)
+	})
+
+	t.Run("Overlay records need their key", func(t *testing.T) {
+		orders := write("orders.json", `[{"id": 1}]`)
+		overlay := write("orders.dev.json", `[{"total": 5}]`)
+		_, err := ReadFixtureFileContext(ContextWithEnvironment(context.Background(), "dev"), orders)
+		assert.EqualError(t, err, "failed to merge overlay '"+overlay+"': record 1 has no key column 'id'")
+	})
+}
+
+// TestOverlayPath tests naming the overlay of a fixture file
+func TestOverlayPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("fixtures", "users.staging.json"), overlayPath(filepath.Join("fixtures", "users.json"), "staging"))
+	assert.Equal(t, filepath.Join("fixtures", "users.staging.tmpl.json"), overlayPath(filepath.Join("fixtures", "users.tmpl.json"), "staging"))
+
+	files := []string{"users.json", "users.staging.json", "demo/users.ci.json", "app.config.json"}
+	assert.False(t, isFixtureOverlay("users.json", files))
+	assert.True(t, isFixtureOverlay("users.staging.json", files))
+	assert.False(t, isFixtureOverlay("demo/users.ci.json", files))
+	assert.False(t, isFixtureOverlay("app.config.json", files))
+}
+
+// TestFixtureDirOverlays tests that fixture directories merge overlays instead of registering them
+func TestFixtureDirOverlays(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users.json"), []byte(`[{"id": 1, "plan": "free"}]`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users.staging.json"), []byte(`[{"id": 1, "plan": "pro"}]`), 0o644))
+
+	var written []Record
+	sm := NewSeederManager()
+	sm.SetPackTarget(nil, FixtureWriterFunc(func(ctx context.Context, table string, records []Record) error {
+		written = records
+		return nil
+	}))
+	require.NoError(t, sm.RegisterFixtureDir(dir))
+	assert.Equal(t, []string{"users"}, sm.GetRegisteredSeeders())
+
+	require.NoError(t, sm.RunSeedersInOrderContext(ContextWithEnvironment(context.Background(), "staging"), []string{"users"}))
+	assert.Equal(t, []Record{{"id": json.Number("1"), "plan": "pro"}}, written)
+}
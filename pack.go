@@ -0,0 +1,353 @@
+package goseeder
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PackManifestFile is the name of the manifest at the root of a seed pack
+const PackManifestFile = "pack.json"
+
+// Pack is a versioned, distributable data set: a manifest plus the fixture
+// and SQL files its seeders load. Packs are directories or zip archives,
+// local or downloaded over HTTP.
+type Pack struct {
+	Name        string       `json:"name"`
+	Version     string       `json:"version"` // Semantic version, e.g. "1.4.0"
+	Description string       `json:"description"`
+	Seeders     []PackSeeder `json:"seeders"`
+
+	// Range of schema (migration) versions the pack's data fits, checked
+	// against the SchemaVersioner before loading; zero means unbounded
+	MinSchemaVersion int64 `json:"min_schema_version"`
+	MaxSchemaVersion int64 `json:"max_schema_version"`
+}
+
+// SemVer returns the parsed pack version
+func (p *Pack) SemVer() (SemVer, error) {
+	return ParseSemVer(p.Version)
+}
+
+// PackSeeder declares a pack seeder loading either a SQL file or a JSON
+// fixture file (an array of objects) into a table
+type PackSeeder struct {
+	Name    string   `json:"name"`
+	SQL     string   `json:"sql"`     // Path of a SQL file inside the pack
+	Fixture string   `json:"fixture"` // Path of a JSON fixture inside the pack
+	Table   string   `json:"table"`   // Target table of the fixture
+	Tags    []string `json:"tags"`
+}
+
+// SQLExecutor executes SQL statements; *sql.DB, *sql.Tx and *sql.Conn
+// implement it
+type SQLExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// FixtureWriter inserts fixture records into a table
+type FixtureWriter interface {
+	WriteFixture(ctx context.Context, table string, records []Record) error
+}
+
+// FixtureWriterFunc adapts a function to the FixtureWriter interface
+type FixtureWriterFunc func(ctx context.Context, table string, records []Record) error
+
+// WriteFixture calls f(ctx, table, records)
+func (f FixtureWriterFunc) WriteFixture(ctx context.Context, table string, records []Record) error {
+	return f(ctx, table, records)
+}
+
+// SchemaVersioner reports the current schema (migration) version. Adapt
+// your migration tool to it, typically alongside Migrator.
+type SchemaVersioner interface {
+	SchemaVersion(ctx context.Context) (int64, error)
+}
+
+// SchemaVersionerFunc adapts a function to the SchemaVersioner interface
+type SchemaVersionerFunc func(ctx context.Context) (int64, error)
+
+// SchemaVersion calls f(ctx)
+func (f SchemaVersionerFunc) SchemaVersion(ctx context.Context) (int64, error) {
+	return f(ctx)
+}
+
+// SetSchemaVersioner sets the source of the schema version packs declaring
+// a schema version range are checked against
+func (sm *SeederManager) SetSchemaVersioner(versioner SchemaVersioner) {
+	sm.schemaVersioner = versioner
+}
+
+// SetPackTarget sets where pack and fixture directory seeders write: exec
+// runs SQL files and fixtures writes fixture records. Either may be nil if no loaded pack
+// needs it.
+func (sm *SeederManager) SetPackTarget(exec SQLExecutor, fixtures FixtureWriter) {
+	sm.packExec = exec
+	sm.packFixtures = fixtures
+}
+
+// packHTTPClient downloads packs given as URLs
+var packHTTPClient = &http.Client{Timeout: 5 * time.Minute}
+
+// RequirePackVersion makes LoadPack reject versions of the named pack that
+// are incompatible with version: lower ones, or ones of another major
+// version, whose data may not match what the application expects
+func (sm *SeederManager) RequirePackVersion(name, version string) error {
+	required, err := ParseSemVer(version)
+	if err != nil {
+		return fmt.Errorf("pack '%s': %w", name, err)
+	}
+	if sm.packVersions == nil {
+		sm.packVersions = make(map[string]SemVer)
+	}
+	sm.packVersions[name] = required
+	return nil
+}
+
+// LoadPack reads the seed pack at location, a directory, a .zip file or an
+// http(s) URL of a .zip file, and registers its seeders in manifest order
+func (sm *SeederManager) LoadPack(location string) (*Pack, error) {
+	return sm.LoadPackContext(context.Background(), location)
+}
+
+// LoadPackContext is LoadPack with a context bounding the download of
+// URLs and the schema version check
+func (sm *SeederManager) LoadPackContext(ctx context.Context, location string) (*Pack, error) {
+	packFS, err := openPack(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pack '%s': %w", location, err)
+	}
+
+	pack, err := readPackManifest(packFS)
+	if err != nil {
+		return nil, fmt.Errorf("pack '%s': %w", location, err)
+	}
+	if err := sm.checkPackVersion(pack); err != nil {
+		return nil, err
+	}
+	if err := sm.checkPackSchema(ctx, pack); err != nil {
+		return nil, err
+	}
+
+	items := make([]SeederItem, 0, len(pack.Seeders))
+	for _, spec := range pack.Seeders {
+		run, err := sm.packSeeder(packFS, spec)
+		if err != nil {
+			return nil, fmt.Errorf("pack '%s' seeder '%s': %w", pack.Name, spec.Name, err)
+		}
+		items = append(items, SeederItem{Name: spec.Name, ContextFunction: run, Tags: spec.Tags})
+	}
+	if err := sm.RegisterSeeders(items...); err != nil {
+		return nil, fmt.Errorf("pack '%s': %w", pack.Name, err)
+	}
+
+	return pack, nil
+}
+
+// readPackManifest parses and checks the manifest of a pack
+func readPackManifest(packFS fs.FS) (*Pack, error) {
+	data, err := fs.ReadFile(packFS, PackManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", PackManifestFile, err)
+	}
+
+	var pack Pack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PackManifestFile, err)
+	}
+	if pack.Name == "" {
+		return nil, fmt.Errorf("%s has no name", PackManifestFile)
+	}
+	if _, err := pack.SemVer(); err != nil {
+		return nil, fmt.Errorf("%s: %w", PackManifestFile, err)
+	}
+	return &pack, nil
+}
+
+// checkPackVersion fails when the pack's version is incompatible with the
+// one required with RequirePackVersion
+func (sm *SeederManager) checkPackVersion(pack *Pack) error {
+	required, ok := sm.packVersions[pack.Name]
+	if !ok {
+		return nil
+	}
+	version, _ := pack.SemVer()
+	if version.Major != required.Major || version.Compare(required) < 0 {
+		return fmt.Errorf("pack '%s' %s is incompatible with the required version %s: use a %d.x version of at least %s",
+			pack.Name, pack.Version, required, required.Major, required)
+	}
+	return nil
+}
+
+// checkPackSchema fails when the current schema version is outside the
+// range the pack declares
+func (sm *SeederManager) checkPackSchema(ctx context.Context, pack *Pack) error {
+	if pack.MinSchemaVersion == 0 && pack.MaxSchemaVersion == 0 {
+		return nil
+	}
+	if sm.schemaVersioner == nil {
+		return fmt.Errorf("pack '%s' %s requires a schema version check, see SeederManager.SetSchemaVersioner", pack.Name, pack.Version)
+	}
+
+	current, err := sm.schemaVersioner.SchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if pack.MinSchemaVersion != 0 && current < pack.MinSchemaVersion {
+		return fmt.Errorf("pack '%s' %s requires schema version >= %d, database is at %d: run migrations first",
+			pack.Name, pack.Version, pack.MinSchemaVersion, current)
+	}
+	if pack.MaxSchemaVersion != 0 && current > pack.MaxSchemaVersion {
+		return fmt.Errorf("pack '%s' %s supports schema version <= %d, database is at %d: use a newer pack",
+			pack.Name, pack.Version, pack.MaxSchemaVersion, current)
+	}
+	return nil
+}
+
+// SemVer is a parsed semantic version
+type SemVer struct {
+	Major, Minor, Patch int
+	Prerelease          string
+}
+
+// ParseSemVer parses versions such as "1.4.0", "v2.0.0" or "1.0.0-rc.1".
+// Build metadata ("+build.5") is ignored.
+func ParseSemVer(version string) (SemVer, error) {
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "+")
+	core, prerelease, _ := strings.Cut(core, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return SemVer{}, fmt.Errorf("invalid semantic version %q", version)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return SemVer{}, fmt.Errorf("invalid semantic version %q", version)
+		}
+		numbers[i] = n
+	}
+	return SemVer{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], Prerelease: prerelease}, nil
+}
+
+// Compare returns -1, 0 or 1 when v is lower than, equal to or higher than
+// other. A pre-release is lower than the release it precedes; pre-releases
+// are compared as strings.
+func (v SemVer) Compare(other SemVer) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+	return strings.Compare(v.Prerelease, other.Prerelease)
+}
+
+// String formats the version without a "v" prefix
+func (v SemVer) String() string {
+	if v.Prerelease != "" {
+		return fmt.Sprintf("%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.Prerelease)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// packSeeder reads the files of a pack seeder eagerly, so the pack can be
+// discarded after loading, and returns the function writing them
+func (sm *SeederManager) packSeeder(packFS fs.FS, spec PackSeeder) (SeederFunc, error) {
+	switch {
+	case spec.SQL != "" && spec.Fixture != "":
+		return nil, fmt.Errorf("sql and fixture are mutually exclusive")
+	case spec.SQL != "":
+		data, err := fs.ReadFile(packFS, path.Clean(spec.SQL))
+		if err != nil {
+			return nil, err
+		}
+		query := string(data)
+		return func(ctx context.Context) error {
+			if sm.packExec == nil {
+				return fmt.Errorf("pack SQL requires an executor, see SeederManager.SetPackTarget")
+			}
+			_, err := sm.packExec.ExecContext(ctx, query)
+			return err
+		}, nil
+	case spec.Fixture != "":
+		if spec.Table == "" {
+			return nil, fmt.Errorf("fixture requires a table")
+		}
+		data, err := fs.ReadFile(packFS, path.Clean(spec.Fixture))
+		if err != nil {
+			return nil, err
+		}
+		records, origins, err := readFixture(context.Background(), bytes.NewReader(data), FormatJSON, spec.Fixture)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse fixture '%s': %w", spec.Fixture, err)
+		}
+		return func(ctx context.Context) error {
+			if sm.packFixtures == nil {
+				return fmt.Errorf("pack fixtures require a writer, see SeederManager.SetPackTarget")
+			}
+			return sm.packFixtures.WriteFixture(withFixtureSource(ctx, spec.Fixture, origins), spec.Table, records)
+		}, nil
+	default:
+		return nil, fmt.Errorf("either sql or fixture is required")
+	}
+}
+
+// openPack returns the file system of a pack directory, zip file or URL
+func openPack(ctx context.Context, location string) (fs.FS, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := packHTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("download failed: %s", resp.Status)
+		}
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	}
+
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return os.DirFS(location), nil
+	}
+
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
@@ -0,0 +1,258 @@
+package goseeder
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// demoPackFiles are the files of a small valid seed pack
+var demoPackFiles = map[string]string{
+	"pack.json": `{
+		"name": "demo",
+		"version": "1.0.0",
+		"seeders": [
+			{"name": "demo_countries", "sql": "sql/countries.sql", "tags": ["core"]},
+			{"name": "demo_users", "fixture": "fixtures/users.json", "table": "users", "tags": ["demo"]}
+		]
+	}`,
+	"sql/countries.sql":   "INSERT INTO countries (code) VALUES ('NL');",
+	"fixtures/users.json": `[{"name": "Alice"}, {"name": "Bob"}]`,
+}
+
+// writePackDir writes files into a temp directory
+func writePackDir(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+// zipPack returns files as a zip archive
+func zipPack(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		assert.NoError(t, err)
+		w.Write([]byte(content))
+	}
+	assert.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+// recordingExecutor records executed statements
+type recordingExecutor struct {
+	queries []string
+}
+
+func (re *recordingExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	re.queries = append(re.queries, query)
+	return nil, nil
+}
+
+// TestLoadPack tests loading packs from directories, zip files and URLs
+func TestLoadPack(t *testing.T) {
+	archive := zipPack(t, demoPackFiles)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	zipPath := filepath.Join(t.TempDir(), "demo.zip")
+	assert.NoError(t, os.WriteFile(zipPath, archive, 0o644))
+
+	locations := map[string]string{
+		"Directory": writePackDir(t, demoPackFiles),
+		"Zip file":  zipPath,
+		"URL":       server.URL + "/demo.zip",
+	}
+	for name, location := range locations {
+		t.Run(name, func(t *testing.T) {
+			manager := NewSeederManager()
+			exec := &recordingExecutor{}
+			written := map[string][]Record{}
+			manager.SetPackTarget(exec, FixtureWriterFunc(func(ctx context.Context, table string, records []Record) error {
+				written[table] = records
+				return nil
+			}))
+
+			pack, err := manager.LoadPack(location)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "demo", pack.Name)
+			assert.Equal(t, "1.0.0", pack.Version)
+			assert.Equal(t, []string{"demo_users"}, manager.GetSeedersByTag(TagDemo))
+
+			assert.NoError(t, manager.RunAllSeeders())
+			assert.Equal(t, []string{"INSERT INTO countries (code) VALUES ('NL');"}, exec.queries)
+			assert.Equal(t, []Record{{"name": "Alice"}, {"name": "Bob"}}, written["users"])
+		})
+	}
+}
+
+// TestLoadPackErrors tests invalid packs and missing targets
+func TestLoadPackErrors(t *testing.T) {
+	t.Run("Invalid packs", func(t *testing.T) {
+		invalid := []map[string]string{
+			{},
+			{"pack.json": `{`},
+			{"pack.json": `{"version": "1.0.0"}`},
+			{"pack.json": `{"name": "p", "version": "latest"}`},
+			{"pack.json": `{"name": "p", "version": "1.0.0", "seeders": [{"name": "s"}]}`},
+			{"pack.json": `{"name": "p", "version": "1.0.0", "seeders": [{"name": "s", "sql": "missing.sql"}]}`},
+			{"pack.json": `{"name": "p", "version": "1.0.0", "seeders": [{"name": "s", "fixture": "f.json"}]}`, "f.json": `[]`},
+			{"pack.json": `{"name": "p", "version": "1.0.0", "seeders": [{"name": "s", "fixture": "f.json", "table": "t"}]}`, "f.json": `{`},
+		}
+		for _, files := range invalid {
+			_, err := NewSeederManager().LoadPack(writePackDir(t, files))
+			assert.Error(t, err, files["pack.json"])
+		}
+
+		_, err := NewSeederManager().LoadPack(filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing target", func(t *testing.T) {
+		manager := NewSeederManager()
+		_, err := manager.LoadPack(writePackDir(t, demoPackFiles))
+		assert.NoError(t, err)
+
+		assert.ErrorContains(t, manager.RunSeederByName("demo_countries"), "SetPackTarget")
+		assert.ErrorContains(t, manager.RunSeederByName("demo_users"), "SetPackTarget")
+	})
+}
+
+// TestLoadPackSchemaVersion tests the schema version compatibility check
+func TestLoadPackSchemaVersion(t *testing.T) {
+	files := map[string]string{
+		"pack.json": `{"name": "demo", "version": "2.1.0", "min_schema_version": 20240101, "max_schema_version": 20250101, "seeders": [{"name": "countries", "sql": "c.sql"}]}`,
+		"c.sql":     "SELECT 1",
+	}
+	dir := writePackDir(t, files)
+	versionAt := func(version int64) SchemaVersioner {
+		return SchemaVersionerFunc(func(ctx context.Context) (int64, error) { return version, nil })
+	}
+
+	t.Run("Compatible schema", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.SetSchemaVersioner(versionAt(20240601))
+
+		_, err := manager.LoadPack(dir)
+
+		assert.NoError(t, err)
+		assert.True(t, manager.IsSeederRegistered("countries"))
+	})
+
+	t.Run("Incompatible schema fails before registering", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.SetSchemaVersioner(versionAt(20230101))
+		_, err := manager.LoadPack(dir)
+		assert.ErrorContains(t, err, "requires schema version >= 20240101, database is at 20230101")
+		assert.Empty(t, manager.GetRegisteredSeeders())
+
+		manager.SetSchemaVersioner(versionAt(20260101))
+		_, err = manager.LoadPack(dir)
+		assert.ErrorContains(t, err, "supports schema version <= 20250101")
+	})
+
+	t.Run("Requires versioner", func(t *testing.T) {
+		_, err := NewSeederManager().LoadPack(dir)
+
+		assert.ErrorContains(t, err, "SetSchemaVersioner")
+	})
+}
+
+// TestRequirePackVersion tests the pack version compatibility check
+func TestRequirePackVersion(t *testing.T) {
+	dir := writePackDir(t, demoPackFiles) // demo 1.0.0
+
+	cases := map[string]struct {
+		required string
+		err      string
+	}{
+		"Same version":      {"1.0.0", ""},
+		"Older minor":       {"0.9.0", "pack 'demo' 1.0.0 is incompatible with the required version 0.9.0: use a 0.x version of at least 0.9.0"},
+		"Newer patch":       {"1.0.1", "pack 'demo' 1.0.0 is incompatible with the required version 1.0.1"},
+		"Lower prerelease":  {"1.0.0-rc.1", ""},
+		"Next major needed": {"2.0.0", "use a 2.x version of at least 2.0.0"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			manager := NewSeederManager()
+			assert.NoError(t, manager.RequirePackVersion("demo", tc.required))
+
+			_, err := manager.LoadPack(dir)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.err)
+			assert.Empty(t, manager.GetRegisteredSeeders())
+		})
+	}
+
+	t.Run("Other packs are unaffected", func(t *testing.T) {
+		manager := NewSeederManager()
+		assert.NoError(t, manager.RequirePackVersion("catalog", "3.0.0"))
+
+		_, err := manager.LoadPack(dir)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid requirement", func(t *testing.T) {
+		assert.ErrorContains(t, NewSeederManager().RequirePackVersion("demo", "latest"), "invalid semantic version")
+	})
+}
+
+// TestLoadPackContext tests that downloads end with their context
+func TestLoadPackContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := NewSeederManager().LoadPackContext(ctx, server.URL+"/demo.zip")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestParseSemVer tests semantic version parsing and comparison
+func TestParseSemVer(t *testing.T) {
+	v, err := ParseSemVer("v1.4.2-rc.1+build.5")
+	assert.NoError(t, err)
+	assert.Equal(t, SemVer{Major: 1, Minor: 4, Patch: 2, Prerelease: "rc.1"}, v)
+	assert.Equal(t, "1.4.2-rc.1", v.String())
+
+	for _, invalid := range []string{"", "1.4", "1.4.x", "1.-4.0", "latest"} {
+		_, err := ParseSemVer(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	ordered := []string{"1.0.0-alpha", "1.0.0-beta", "1.0.0", "1.0.1", "1.2.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		lower, _ := ParseSemVer(ordered[i-1])
+		higher, _ := ParseSemVer(ordered[i])
+		assert.Equal(t, -1, lower.Compare(higher), ordered[i])
+		assert.Equal(t, 1, higher.Compare(lower), ordered[i])
+		assert.Equal(t, 0, higher.Compare(higher), ordered[i])
+	}
+}
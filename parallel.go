@@ -0,0 +1,127 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// RunAllSeedersParallel runs the seeders without DependsOn concurrently on
+// workers goroutines, then the seeders with dependencies serially in
+// registration order. Independent seeders must not rely on the order they
+// were registered in; declare that order with DependsOn instead.
+func (sm *SeederManager) RunAllSeedersParallel(workers int) error {
+	return sm.RunAllSeedersParallelContext(context.Background(), workers)
+}
+
+// RunAllSeedersParallelContext is RunAllSeedersParallel with a context.
+// After a failure or a stop no further seeder starts, running ones finish,
+// and every failure is returned.
+func (sm *SeederManager) RunAllSeedersParallelContext(ctx context.Context, workers int) (err error) {
+	if workers < 1 {
+		return fmt.Errorf("workers must be at least 1, got %d", workers)
+	}
+	seeders, _ := sm.registry()
+	if err := errors.Join(sm.dependencyErrors(seeders)...); err != nil {
+		return err
+	}
+
+	ctx, end, err := sm.beginRun(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
+
+	startProgress(ctx, len(seeders))
+	var independent, dependent []SeederItem
+	for _, seeder := range seeders {
+		if len(seeder.DependsOn) == 0 {
+			independent = append(independent, seeder)
+		} else {
+			dependent = append(dependent, seeder)
+		}
+	}
+
+	logInfo(ctx, "Running %d independent seeders with %d workers...", len(independent), workers)
+	if err := sm.executeParallel(ctx, independent, workers); err != nil {
+		return err
+	}
+	for _, seeder := range dependent {
+		if err := stopCause(ctx); err != nil {
+			return err
+		}
+		if err := sm.executeSeeder(ctx, seeder); err != nil {
+			return err
+		}
+	}
+
+	logInfo(ctx, "All seeders completed successfully!")
+	return nil
+}
+
+// executeParallel runs seeders on a pool of workers, starting no seeder
+// after one failed or the run was stopped
+func (sm *SeederManager) executeParallel(ctx context.Context, seeders []SeederItem, workers int) error {
+	queue := make(chan SeederItem)
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []error
+	)
+	for range min(workers, len(seeders)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seeder := range queue {
+				if err := sm.executeSeeder(ctx, seeder); err != nil {
+					mu.Lock()
+					failed = append(failed, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	var stopped error
+	for _, seeder := range seeders {
+		mu.Lock()
+		failing := len(failed) > 0
+		mu.Unlock()
+		if stopped = stopCause(ctx); stopped != nil || failing {
+			break
+		}
+		queue <- seeder
+	}
+	close(queue)
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return errors.Join(failed...)
+	}
+	return stopped
+}
+
+// dependencyErrors checks that DependsOn only names seeders registered
+// before the dependent one, so running in registration order satisfies
+// every dependency
+func (sm *SeederManager) dependencyErrors(seeders []SeederItem) []error {
+	position := make(map[string]int, len(seeders))
+	for i, seeder := range seeders {
+		position[seeder.Name] = i
+	}
+
+	var errs []error
+	for i, seeder := range seeders {
+		for _, dependency := range seeder.DependsOn {
+			resolved, exists := sm.ResolveSeeder(dependency)
+			switch {
+			case !exists:
+				errs = append(errs, fmt.Errorf("seeder '%s' depends on unknown seeder '%s'%s", seeder.Name, dependency, didYouMean(dependency, sm.GetRegisteredSeeders())))
+			case position[resolved] >= i:
+				errs = append(errs, fmt.Errorf("seeder '%s' depends on '%s', which must be registered before it or have a lower Priority", seeder.Name, dependency))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,102 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunAllSeedersParallel tests running independent seeders concurrently
+// and dependent ones after them
+func TestRunAllSeedersParallel(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		ran     []string
+		running atomic.Int32
+		peak    atomic.Int32
+	)
+	seeder := func(name string, dependsOn ...string) SeederItem {
+		return SeederItem{Name: name, DependsOn: dependsOn, Function: func() error {
+			now := running.Add(1)
+			defer running.Add(-1)
+			for old := peak.Load(); now > old && !peak.CompareAndSwap(old, now); old = peak.Load() {
+			}
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			ran = append(ran, name)
+			mu.Unlock()
+			return nil
+		}}
+	}
+
+	manager := NewSeederManager()
+	manager.RegisterSeeders(
+		seeder("countries"), seeder("currencies"), seeder("languages"), seeder("roles"), seeder("plans"),
+		seeder("users", "roles"),
+		seeder("orders", "users", "plans"),
+	)
+
+	assert.NoError(t, manager.RunAllSeedersParallel(3))
+	assert.Len(t, ran, 7)
+	assert.Equal(t, []string{"users", "orders"}, ran[5:], "dependent seeders run last, in order")
+	assert.Equal(t, int32(3), peak.Load(), "bounded by the workers")
+	assert.Len(t, manager.LastRunReport().Seeders, 7)
+
+	assert.ErrorContains(t, manager.RunAllSeedersParallel(0), "workers must be at least 1")
+}
+
+// TestRunAllSeedersParallelFailure tests that no seeder starts after a
+// failure and that dependent seeders are not run
+func TestRunAllSeedersParallelFailure(t *testing.T) {
+	var started atomic.Int32
+	manager := NewSeederManager()
+	manager.RegisterSeeder("broken", func() error {
+		started.Add(1)
+		return errors.New("boom")
+	})
+	for _, name := range []string{"a", "b", "c"} {
+		manager.RegisterSeeder(name, func() error {
+			started.Add(1)
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+	}
+	manager.RegisterSeeders(SeederItem{Name: "after", DependsOn: []string{"a"}, Function: func() error {
+		t.Error("dependent seeder ran after a failure")
+		return nil
+	}})
+
+	err := manager.RunAllSeedersParallel(1)
+	assert.ErrorContains(t, err, "seeder 'broken' failed: boom")
+	assert.Equal(t, int32(1), started.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, manager.RunAllSeedersParallelContext(ctx, 2), context.Canceled)
+}
+
+// TestDependsOnValidation tests that dependencies must be registered earlier
+func TestDependsOnValidation(t *testing.T) {
+	noop := func() error { return nil }
+	manager := NewSeederManager()
+	manager.RegisterSeeders(
+		SeederItem{Name: "orders", Function: noop, DependsOn: []string{"users", "plan"}},
+		SeederItem{Name: "users", Function: noop, Aliases: []string{"accounts"}},
+		SeederItem{Name: "plans", Function: noop},
+		SeederItem{Name: "invoices", Function: noop, DependsOn: []string{"accounts"}},
+	)
+
+	err := manager.RunAllSeedersParallel(2)
+	assert.ErrorContains(t, err, "seeder 'orders' depends on 'users', which must be registered before it")
+	assert.ErrorContains(t, err, "seeder 'orders' depends on unknown seeder 'plan', did you mean 'plans'?")
+	assert.NotContains(t, err.Error(), "invoices", "aliases resolve")
+	assert.Nil(t, manager.LastRunReport(), "nothing ran")
+
+	err = manager.Validate()
+	assert.ErrorContains(t, err, "seeder 'orders' depends on 'users', which must be registered before it")
+}
@@ -0,0 +1,169 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// defaultPBKDF2Iterations is the PBKDF2-SHA256 iteration count OWASP
+// recommends, used when PBKDF2Hasher.Iterations is zero
+const defaultPBKDF2Iterations = 600000
+
+// PasswordHasher hashes the passwords of seeded user accounts in the format
+// the application's login checks, so seeded accounts can log in
+type PasswordHasher interface {
+	HashPassword(password string) (string, error)
+}
+
+// PasswordHasherFunc adapts a function to the PasswordHasher interface.
+// bcrypt, argon2id and scrypt are deliberately not built in, keeping the
+// module free of dependencies: wrap golang.org/x/crypto with the
+// application's parameters, see the README for each.
+type PasswordHasherFunc func(password string) (string, error)
+
+// HashPassword calls f(password)
+func (f PasswordHasherFunc) HashPassword(password string) (string, error) {
+	return f(password)
+}
+
+// PBKDF2Hasher hashes passwords with PBKDF2-SHA256 in the Django format
+// pbkdf2_sha256$<iterations>$<salt>$<base64 hash>. It is the default
+// hasher, needing no dependency outside the standard library.
+type PBKDF2Hasher struct {
+	Iterations int // Defaults to 600000
+	SaltSize   int // Salt bytes, default 16
+	KeySize    int // Hash bytes, default 32
+}
+
+// HashPassword implements PasswordHasher with a random salt
+func (h PBKDF2Hasher) HashPassword(password string) (string, error) {
+	iterations, saltSize, keySize := h.Iterations, h.SaltSize, h.KeySize
+	if iterations <= 0 {
+		iterations = defaultPBKDF2Iterations
+	}
+	if saltSize <= 0 {
+		saltSize = 16
+	}
+	if keySize <= 0 {
+		keySize = sha256.Size
+	}
+
+	raw := make([]byte, saltSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	salt := base64.RawURLEncoding.EncodeToString(raw)
+	key, err := pbkdf2.Key(sha256.New, password, []byte(salt), iterations, keySize)
+	if err != nil {
+		return "", err
+	}
+	return "pbkdf2_sha256$" + strconv.Itoa(iterations) + "$" + salt + "$" + base64.StdEncoding.EncodeToString(key), nil
+}
+
+// CheckPBKDF2Password reports whether password matches a hash produced by
+// PBKDF2Hasher
+func CheckPBKDF2Password(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != "pbkdf2_sha256" {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	expected, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+	key, err := pbkdf2.Key(sha256.New, password, []byte(parts[2]), iterations, len(expected))
+	return err == nil && bytes.Equal(key, expected)
+}
+
+// SetPasswordHasher sets the hasher of the {{ hash "..." }} fixture
+// function and HashPassword, PBKDF2Hasher by default
+func (sm *SeederManager) SetPasswordHasher(hasher PasswordHasher) {
+	sm.passwordHasher = hasher
+}
+
+// PasswordHasherFromContext returns the hasher of the current run, or the
+// default PBKDF2Hasher
+func PasswordHasherFromContext(ctx context.Context) PasswordHasher {
+	if hasher, ok := ctx.Value(passwordHasherKey).(PasswordHasher); ok {
+		return hasher
+	}
+	return PBKDF2Hasher{}
+}
+
+// HashPassword hashes password with the hasher of the current run, for
+// factories creating user accounts in Go code
+func HashPassword(ctx context.Context, password string) (string, error) {
+	return PasswordHasherFromContext(ctx).HashPassword(password)
+}
+
+// FixtureFuncs returns the functions available in fixture templates:
+//
+//	hash "password123"  the password hashed with the hasher of ctx
+//	flag "new-checkout" whether the feature flag is on, see FlagEnabled
+//
+// Hashes are escaped for use inside a JSON string. A password is hashed
+// once per set of functions, so the rows of a file sharing a password
+// share its hash instead of paying for a slow hasher on every row.
+func FixtureFuncs(ctx context.Context) template.FuncMap {
+	hashes := make(map[string]string)
+	return template.FuncMap{
+		"hash": func(password string) (string, error) {
+			if hashed, ok := hashes[password]; ok {
+				return hashed, nil
+			}
+			hashed, err := HashPassword(ctx, password)
+			if err != nil {
+				return "", err
+			}
+			quoted, _ := json.Marshal(hashed)
+			hashes[password] = string(quoted[1 : len(quoted)-1])
+			return hashes[password], nil
+		},
+		"flag": func(flag string) bool {
+			return FlagEnabled(ctx, flag)
+		},
+	}
+}
+
+// RenderFixture executes fixture data containing template actions such as
+// {"password": "{{ hash "password123" }}"} with FixtureFuncs. Data without
+// "{{" is returned unchanged.
+func RenderFixture(ctx context.Context, name string, data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte("{{")) {
+		return data, nil
+	}
+	tmpl, err := template.New(name).Funcs(FixtureFuncs(ctx)).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture template: %w", err)
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, nil); err != nil {
+		return nil, fmt.Errorf("failed to render fixture template: %w", err)
+	}
+	return rendered.Bytes(), nil
+}
+
+// templatedFixtureSuffix marks fixture files rendered with RenderFixture
+// before they are parsed, e.g. "users.tmpl.json". Other files are read as
+// they are, so "{{" in their values, e.g. in mustache email bodies, is kept.
+const templatedFixtureSuffix = ".tmpl"
+
+// isTemplatedFixture reports whether path is a templated fixture file
+func isTemplatedFixture(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasSuffix(strings.ToLower(strings.TrimSuffix(base, filepath.Ext(base))), templatedFixtureSuffix)
+}
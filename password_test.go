@@ -0,0 +1,115 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPBKDF2Hasher tests hashing and checking passwords
+func TestPBKDF2Hasher(t *testing.T) {
+	hasher := PBKDF2Hasher{Iterations: 1000}
+
+	hash, err := hasher.HashPassword("password123")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^pbkdf2_sha256\$1000\$[A-Za-z0-9_-]{22}\$[A-Za-z0-9+/]{43}=$`, hash)
+	assert.True(t, CheckPBKDF2Password(hash, "password123"))
+	assert.False(t, CheckPBKDF2Password(hash, "password124"))
+	assert.False(t, CheckPBKDF2Password("bcrypt$x", "password123"))
+
+	other, _ := hasher.HashPassword("password123")
+	assert.NotEqual(t, hash, other, "salted")
+}
+
+// TestRenderFixture tests the hash template function
+func TestRenderFixture(t *testing.T) {
+	hasher := PasswordHasherFunc(func(password string) (string, error) {
+		return `plain"` + password, nil
+	})
+	ctx := context.WithValue(context.Background(), passwordHasherKey, PasswordHasher(hasher))
+
+	rendered, err := RenderFixture(ctx, "users.json", []byte(`[{"password": "{{ hash "secret" }}"}, {"password": "{{ hash `+"`other`"+` }}"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"password": "plain\"secret"}, {"password": "plain\"other"}]`, string(rendered))
+
+	plain := []byte(`[{"name": "Alice"}]`)
+	rendered, err = RenderFixture(ctx, "users.json", plain)
+	assert.NoError(t, err)
+	assert.Equal(t, plain, rendered)
+
+	_, err = RenderFixture(ctx, "users.json", []byte(`[{"password": "{{ hash }}"}]`))
+	assert.ErrorContains(t, err, "failed to render fixture template")
+}
+
+// TestSetPasswordHasher tests that fixture directory seeders hash with the
+// manager's hasher
+func TestSetPasswordHasher(t *testing.T) {
+	dir := writePackDir(t, map[string]string{"users.tmpl.json": `[{"email": "demo@example.com", "password": "{{ hash "demo" }}"}]`})
+	manager := NewSeederManager()
+	assert.NoError(t, manager.RegisterFixtureDir(dir))
+	var written []Record
+	manager.SetPackTarget(nil, FixtureWriterFunc(func(ctx context.Context, table string, records []Record) error {
+		written = records
+		return nil
+	}))
+	manager.SetPasswordHasher(PasswordHasherFunc(func(password string) (string, error) {
+		return strings.ToUpper(password), nil
+	}))
+
+	assert.Equal(t, []string{"users"}, manager.GetRegisteredSeeders())
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []Record{{"email": "demo@example.com", "password": "DEMO"}}, written)
+
+	hash, err := HashPassword(context.Background(), "demo")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "pbkdf2_sha256$600000$"), "PBKDF2Hasher by default")
+}
+
+// TestTemplatedFixtureFiles tests that only files named like
+// "users.tmpl.json" are rendered, and that fmt keeps their actions
+func TestTemplatedFixtureFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		return path
+	}
+	hashed := 0
+	ctx := context.WithValue(context.Background(), passwordHasherKey, PasswordHasher(PasswordHasherFunc(func(password string) (string, error) {
+		hashed++
+		return "hashed:" + password, nil
+	})))
+
+	users := write("users.tmpl.json", `[{"password": "{{ hash "demo" }}"}, {"password": "{{ hash `+"`demo`"+` }}"}]`)
+	records, err := ReadFixtureFileContext(ctx, users)
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{{"password": "hashed:demo"}, {"password": "hashed:demo"}}, records)
+	assert.Equal(t, 1, hashed, "a password is hashed once per file")
+	assert.Equal(t, "users", fixtureTable(users))
+
+	records, err = ReadFixtureFile(users)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(records[0]["password"].(string), "pbkdf2_sha256$"))
+
+	emails := write("emails.json", `[{"body": "Hello {{name}}"}]`)
+	records, err = ReadFixtureFileContext(ctx, emails)
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{{"body": "Hello {{name}}"}}, records, "untemplated files keep {{")
+
+	backticks := write("admins.tmpl.json", `[{"password":"{{ hash `+"`demo`"+` }}", "email":"a@example.com"}]`)
+	changed, err := FormatFixtureFile(backticks, "", false)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	formatted, _ := os.ReadFile(backticks)
+	assert.Contains(t, string(formatted), `"password": "{{ hash `+"`demo`"+` }}"`)
+
+	_, err = FormatFixtureFile(users, "", false)
+	assert.True(t, errors.Is(err, ErrTemplatedFixture))
+	unchanged, _ := os.ReadFile(users)
+	assert.Contains(t, string(unchanged), `{{ hash "demo" }}`)
+}
@@ -0,0 +1,204 @@
+package goseeder
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PostgresArray is a value of a Postgres array column such as text[] or
+// int4[], sent as an array literal like {"a","b"}. Nested arrays are
+// multidimensional and nil elements are NULL.
+type PostgresArray []any
+
+// Value implements driver.Valuer
+func (a PostgresArray) Value() (driver.Value, error) {
+	var b strings.Builder
+	if err := writePostgresArray(&b, a); err != nil {
+		return nil, err
+	}
+	return b.String(), nil
+}
+
+// writePostgresArray writes the array literal of elements
+func writePostgresArray(b *strings.Builder, elements []any) error {
+	b.WriteByte('{')
+	for i, element := range elements {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		switch e := element.(type) {
+		case []any:
+			if err := writePostgresArray(b, e); err != nil {
+				return err
+			}
+		case PostgresArray:
+			if err := writePostgresArray(b, e); err != nil {
+				return err
+			}
+		default:
+			text, null, err := postgresElement(e)
+			if err != nil {
+				return err
+			}
+			if null {
+				b.WriteString("NULL")
+			} else {
+				b.WriteString(quotePostgres(text))
+			}
+		}
+	}
+	b.WriteByte('}')
+	return nil
+}
+
+// PostgresRange is a value of a Postgres range column such as int4range,
+// numrange, daterange or tstzrange, e.g. [1,10)
+type PostgresRange struct {
+	Lower  any    // Inclusive or exclusive lower bound, nil when unbounded
+	Upper  any    // Inclusive or exclusive upper bound, nil when unbounded
+	Bounds string // "[)" (default), "[]", "(]" or "()"
+	Empty  bool   // The empty range; bounds are ignored
+}
+
+// Value implements driver.Valuer
+func (r PostgresRange) Value() (driver.Value, error) {
+	if r.Empty {
+		return "empty", nil
+	}
+	bounds := r.Bounds
+	if bounds == "" {
+		bounds = "[)"
+	}
+	if len(bounds) != 2 || !strings.Contains("[(", bounds[:1]) || !strings.Contains("])", bounds[1:]) {
+		return nil, fmt.Errorf("invalid range bounds %q, expected one of [) [] (] ()", r.Bounds)
+	}
+
+	var b strings.Builder
+	b.WriteByte(bounds[0])
+	for i, bound := range []any{r.Lower, r.Upper} {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		text, null, err := postgresElement(bound)
+		if err != nil {
+			return nil, err
+		}
+		if !null {
+			b.WriteString(quotePostgres(text))
+		}
+	}
+	b.WriteByte(bounds[1])
+	return b.String(), nil
+}
+
+// PostgresComposite is a value of a Postgres composite type column, its
+// fields in declaration order, sent as a row literal like ("Main St",12).
+// nil fields are NULL.
+type PostgresComposite []any
+
+// Value implements driver.Valuer
+func (c PostgresComposite) Value() (driver.Value, error) {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, field := range c {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		text, null, err := postgresElement(field)
+		if err != nil {
+			return nil, err
+		}
+		if !null {
+			b.WriteString(quotePostgres(text))
+		}
+	}
+	b.WriteByte(')')
+	return b.String(), nil
+}
+
+// postgresElement formats a scalar as Postgres reads it in literals, or
+// reports that it is NULL. Objects become JSON, e.g. for jsonb[] columns.
+func postgresElement(value any) (string, bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", true, nil
+	case string:
+		return v, false, nil
+	case []byte:
+		return string(v), false, nil
+	case bool:
+		if v {
+			return "t", false, nil
+		}
+		return "f", false, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), false, nil
+	case map[string]any, Record:
+		encoded, err := JSON{Data: v}.MarshalJSON()
+		return string(encoded), false, err
+	case driver.Valuer:
+		nested, err := v.Value()
+		if err != nil {
+			return "", false, err
+		}
+		return postgresElement(nested)
+	case json.Number, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), false, nil
+	default:
+		return "", false, fmt.Errorf("cannot write %T in a Postgres literal", value)
+	}
+}
+
+// quotePostgres double-quotes an element of an array, range or composite
+// literal, escaping backslashes and quotes
+func quotePostgres(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
+
+// postgresValue converts a fixture value for a column of the Postgres type
+// typeName (the udt_name, e.g. "_text" for text[]): arrays for array
+// columns, {"lower", "upper", "bounds"} objects or two-element arrays for
+// range columns, arrays of fields for composite columns, and GeoJSON
+// objects or their text for PostGIS columns. Other values, such as literals
+// or WKT written as strings, are returned as they are.
+func postgresValue(typeName string, composite bool, value any) any {
+	if isPostGIS(typeName) {
+		switch v := value.(type) {
+		case map[string]any:
+			return GeoJSON{Data: v}
+		case string:
+			if strings.HasPrefix(strings.TrimSpace(v), "{") {
+				return GeoJSON{Data: v}
+			}
+		}
+		return value
+	}
+	switch v := value.(type) {
+	case []any:
+		switch {
+		case strings.HasPrefix(typeName, "_"):
+			return PostgresArray(v)
+		case composite:
+			return PostgresComposite(v)
+		case isPostgresRange(typeName) && len(v) == 2:
+			return PostgresRange{Lower: v[0], Upper: v[1]}
+		}
+	case map[string]any:
+		if isPostgresRange(typeName) {
+			if empty, _ := v["empty"].(bool); empty {
+				return PostgresRange{Empty: true}
+			}
+			bounds, _ := v["bounds"].(string)
+			return PostgresRange{Lower: v["lower"], Upper: v["upper"], Bounds: bounds}
+		}
+	}
+	return value
+}
+
+// isPostgresRange reports whether typeName is a range type, e.g. int4range
+func isPostgresRange(typeName string) bool {
+	return strings.HasSuffix(typeName, "range") && !strings.HasSuffix(typeName, "multirange")
+}
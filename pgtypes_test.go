@@ -0,0 +1,95 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPostgresLiterals tests formatting array, range and composite literals
+func TestPostgresLiterals(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    driver.Valuer
+		expected string
+	}{
+		{"text array", PostgresArray{"a", "b"}, `{"a","b"}`},
+		{"empty array", PostgresArray{}, `{}`},
+		{"nested array", PostgresArray{[]any{1, 2}, []any{3, nil}}, `{{"1","2"},{"3",NULL}}`},
+		{"escaping", PostgresArray{`say "hi"`, `C:\tmp`, "a,b"}, `{"say \"hi\"","C:\\tmp","a,b"}`},
+		{"jsonb array", PostgresArray{map[string]any{"k": true}}, `{"{\"k\":true}"}`},
+		{"default bounds", PostgresRange{Lower: json.Number("1"), Upper: json.Number("10")}, `["1","10")`},
+		{"inclusive", PostgresRange{Lower: "2024-01-01", Upper: "2024-12-31", Bounds: "[]"}, `["2024-01-01","2024-12-31"]`},
+		{"unbounded", PostgresRange{Lower: 5, Bounds: "(]"}, `("5",]`},
+		{"empty range", PostgresRange{Lower: 1, Empty: true}, `empty`},
+		{"composite", PostgresComposite{"Main St", nil, 12, false}, `("Main St",,"12","f")`},
+		{"nested composite", PostgresComposite{PostgresComposite{"x", 1}}, `("(\"x\",\"1\")")`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.value.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	_, err := PostgresRange{Lower: 1, Upper: 2, Bounds: "[["}.Value()
+	assert.EqualError(t, err, `invalid range bounds "[[", expected one of [) [] (] ()`)
+	_, err = PostgresArray{struct{}{}}.Value()
+	assert.EqualError(t, err, "cannot write struct {} in a Postgres literal")
+}
+
+// TestPostgresValue tests converting fixture values by column type
+func TestPostgresValue(t *testing.T) {
+	assert.Equal(t, PostgresArray{"a"}, postgresValue("_text", false, []any{"a"}))
+	assert.Equal(t, PostgresComposite{"Main St", 12}, postgresValue("address", true, []any{"Main St", 12}))
+	assert.Equal(t, PostgresRange{Lower: 1, Upper: 10}, postgresValue("int4range", false, []any{1, 10}))
+	assert.Equal(t, PostgresRange{Lower: 1, Upper: nil, Bounds: "[]"},
+		postgresValue("daterange", false, map[string]any{"lower": 1, "bounds": "[]"}))
+	assert.Equal(t, PostgresRange{Empty: true}, postgresValue("numrange", false, map[string]any{"empty": true}))
+
+	assert.Equal(t, "[1,10)", postgresValue("int4range", false, "[1,10)"), "literals are kept")
+	assert.Equal(t, []any{1, 2, 3}, postgresValue("int4range", false, []any{1, 2, 3}), "not a pair of bounds")
+	assert.Equal(t, []any{1, 2}, postgresValue("int4multirange", false, []any{1, 2}))
+	assert.Equal(t, map[string]any{"a": 1}, postgresValue("jsonb", false, map[string]any{"a": 1}))
+	assert.Equal(t, []any{"a"}, postgresValue("", false, []any{"a"}), "unknown columns stay JSON")
+
+	point := map[string]any{"type": "Point", "coordinates": []any{1, 2}}
+	assert.Equal(t, GeoJSON{Data: point}, postgresValue("geometry", false, point))
+	assert.Equal(t, GeoJSON{Data: ` {"type": "Point"}`}, postgresValue("geography", false, ` {"type": "Point"}`))
+	assert.Equal(t, "POINT(1 2)", postgresValue("geometry", false, "POINT(1 2)"), "WKT is kept")
+}
+
+// TestSQLFixtureWriterPostgresTypes tests inserting fixture arrays, ranges and composites
+func TestSQLFixtureWriterPostgresTypes(t *testing.T) {
+	records, err := ReadFixture(strings.NewReader(`[
+		{"id": 1, "tags": ["admin", "beta"], "active": [1, 10], "address": ["Main St", null, "12"], "settings": {"theme": "dark"}},
+		{"id": 2, "tags": [], "active": {"lower": 5, "upper": null, "bounds": "[]"}, "address": "(Side St,,3)", "settings": null}
+	]`), FormatJSON)
+	assert.NoError(t, err)
+
+	db, fake := newFakeDB()
+	writer := NewSQLFixtureWriter(db, nil)
+	writer.Columns = ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+		return []ColumnInfo{
+			{Name: "id", Type: "int4"},
+			{Name: "tags", Type: "_text"},
+			{Name: "active", Type: "int4range"},
+			{Name: "address", Type: "address", Composite: true},
+			{Name: "settings", Type: "jsonb", Nullable: true},
+		}, nil
+	})
+
+	assert.NoError(t, writer.WriteFixture(context.Background(), "users", records))
+	assert.Equal(t, []string{
+		"BEGIN",
+		`INSERT INTO users (active, address, id, settings, tags) VALUES ($1, $2, $3, $4, $5), ($6, $7, $8, $9, $10) ` +
+			`[["1","10") ("Main St",,"12") 1 {"theme":"dark"} {"admin","beta"} ["5",] (Side St,,3) 2 <nil> {}]`,
+		"COMMIT",
+	}, fake.Events())
+	assert.Equal(t, []any{"admin", "beta"}, records[0]["tags"], "records of the caller are not modified")
+}
@@ -0,0 +1,75 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Phase is the stage of a deployment a seeder runs in
+type Phase string
+
+// Phases, in the order a deployment runs them
+const (
+	PhasePreApp  Phase = "pre-app"  // Infrastructure data needed before the app starts, e.g. queues or tenants
+	PhaseCore    Phase = "core"     // Data seeded during the deploy; the phase of seeders without one
+	PhasePostApp Phase = "post-app" // Data created through the app's API, once it is live after rollout
+)
+
+// phases are the known phases, in run order
+var phases = []Phase{PhasePreApp, PhaseCore, PhasePostApp}
+
+// ParsePhase returns the phase named name, e.g. "post-app"
+func ParsePhase(name string) (Phase, error) {
+	phase := Phase(strings.ToLower(strings.TrimSpace(name)))
+	if !slices.Contains(phases, phase) {
+		return "", fmt.Errorf("unknown phase '%s'%s", name, didYouMean(name, phaseNames()))
+	}
+	return phase, nil
+}
+
+// phaseNames returns the names of the known phases, in run order
+func phaseNames() []string {
+	names := make([]string, len(phases))
+	for i, phase := range phases {
+		names[i] = string(phase)
+	}
+	return names
+}
+
+// SeederPhase returns the phase of the seeder, PhaseCore when unset
+func (si SeederItem) SeederPhase() Phase {
+	if si.Phase == "" {
+		return PhaseCore
+	}
+	return si.Phase
+}
+
+// GetSeedersByPhase returns, in registration order, the names of the
+// seeders of a phase; seeders without a phase belong to PhaseCore
+func (sm *SeederManager) GetSeedersByPhase(phase Phase) []string {
+	seeders, _ := sm.registry()
+	names := make([]string, 0)
+	for _, seeder := range seeders {
+		if seeder.SeederPhase() == phase {
+			names = append(names, seeder.Name)
+		}
+	}
+	return names
+}
+
+// RunPhase runs the seeders of the named phase in registration order, e.g.
+// "core" during a deploy and "post-app" after rollout
+func (sm *SeederManager) RunPhase(name string) error {
+	return sm.RunPhaseContext(context.Background(), name)
+}
+
+// RunPhaseContext runs the seeders of the named phase with the given context
+func (sm *SeederManager) RunPhaseContext(ctx context.Context, name string) error {
+	phase, err := ParsePhase(name)
+	if err != nil {
+		return err
+	}
+	return sm.RunSeedersInOrderContext(ctx, sm.GetSeedersByPhase(phase))
+}
@@ -0,0 +1,62 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newPhaseManager registers seeders of every phase
+func newPhaseManager(ran *[]string) *SeederManager {
+	manager := NewSeederManager()
+	seeder := func(name string, phase Phase) SeederItem {
+		return SeederItem{Name: name, Phase: phase, Function: func() error {
+			*ran = append(*ran, name)
+			return nil
+		}}
+	}
+	manager.RegisterSeeders(
+		seeder("tenants", PhasePreApp),
+		seeder("users", ""),
+		seeder("webhooks", PhasePostApp),
+		seeder("plans", PhaseCore),
+	)
+	return manager
+}
+
+// TestRunPhase tests running the seeders of one phase
+func TestRunPhase(t *testing.T) {
+	ran := []string{}
+	manager := newPhaseManager(&ran)
+
+	assert.Equal(t, []string{"users", "plans"}, manager.GetSeedersByPhase(PhaseCore), "seeders without a phase are core")
+	assert.NoError(t, manager.RunPhase("post-app"))
+	assert.Equal(t, []string{"webhooks"}, ran)
+
+	ran = ran[:0]
+	assert.NoError(t, manager.RunPhase("Core"))
+	assert.Equal(t, []string{"users", "plans"}, ran)
+
+	assert.ErrorContains(t, manager.RunPhase("post_app"), "unknown phase 'post_app', did you mean 'post-app'?")
+
+	err := manager.RegisterSeeders(SeederItem{Name: "mail", Phase: "postapp", Function: func() error { return nil }})
+	assert.ErrorContains(t, err, "seeder 'mail' has unknown phase 'postapp', did you mean 'post-app'?")
+}
+
+// TestCLIPhase tests the -phase flag's runner
+func TestCLIPhase(t *testing.T) {
+	ran := []string{}
+	cli := NewCLI(newPhaseManager(&ran))
+	var out bytes.Buffer
+	cli.SetOutput(&out)
+
+	assert.NoError(t, cli.runPhase(context.Background(), "pre-app"))
+	assert.Equal(t, []string{"tenants"}, ran)
+	assert.Contains(t, out.String(), "Running 1 seeders of phase pre-app: tenants")
+
+	err := cli.runPhase(context.Background(), "later")
+	assert.ErrorIs(t, err, ErrUsage)
+	assert.ErrorContains(t, err, "unknown phase 'later'")
+}
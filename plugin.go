@@ -0,0 +1,68 @@
+package goseeder
+
+import (
+	"fmt"
+	"path/filepath"
+	"plugin"
+)
+
+// PluginSymbol is the symbol a seed pack plugin must export: a function
+// returning the seeders it provides.
+//
+//	// go build -buildmode=plugin -o demo.so ./demo
+//	package main
+//
+//	func GoseederSeeders() []goseeder.SeederItem {
+//		return []goseeder.SeederItem{{Name: "demo_orders", Function: seedDemoOrders}}
+//	}
+//
+// Plugins must be built with the same Go version and goseeder version as the
+// host binary, and are only supported where the standard plugin package is.
+const PluginSymbol = "GoseederSeeders"
+
+// LoadPlugin opens a Go plugin .so file and registers the seeders returned
+// by its GoseederSeeders function
+func (sm *SeederManager) LoadPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open plugin '%s': %w", path, err)
+	}
+
+	seeders, err := pluginSeeders(p.Lookup)
+	if err != nil {
+		return fmt.Errorf("plugin '%s': %w", path, err)
+	}
+	if err := sm.RegisterSeeders(seeders...); err != nil {
+		return fmt.Errorf("plugin '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadPlugins loads every .so file in dir, in lexical order
+func (sm *SeederManager) LoadPlugins(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := sm.LoadPlugin(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pluginSeeders resolves PluginSymbol and returns the seeders it provides
+func pluginSeeders(lookup func(string) (plugin.Symbol, error)) ([]SeederItem, error) {
+	symbol, err := lookup(PluginSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("missing %s symbol: %w", PluginSymbol, err)
+	}
+
+	seeders, ok := symbol.(func() []SeederItem)
+	if !ok {
+		return nil, fmt.Errorf("%s has type %T, expected func() []goseeder.SeederItem", PluginSymbol, symbol)
+	}
+	return seeders(), nil
+}
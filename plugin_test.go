@@ -0,0 +1,44 @@
+package goseeder
+
+import (
+	"errors"
+	"path/filepath"
+	"plugin"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPluginSeeders tests resolving the plugin symbol
+func TestPluginSeeders(t *testing.T) {
+	t.Run("Valid symbol", func(t *testing.T) {
+		provider := func() []SeederItem {
+			return []SeederItem{{Name: "demo_orders", Function: func() error { return nil }}}
+		}
+
+		seeders, err := pluginSeeders(func(name string) (plugin.Symbol, error) {
+			assert.Equal(t, PluginSymbol, name)
+			return provider, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, seeders, 1)
+		assert.Equal(t, "demo_orders", seeders[0].Name)
+	})
+
+	t.Run("Missing or mistyped symbol", func(t *testing.T) {
+		_, err := pluginSeeders(func(string) (plugin.Symbol, error) { return nil, errors.New("not found") })
+		assert.ErrorContains(t, err, "missing GoseederSeeders")
+
+		_, err = pluginSeeders(func(string) (plugin.Symbol, error) { return func() {}, nil })
+		assert.ErrorContains(t, err, "expected func() []goseeder.SeederItem")
+	})
+}
+
+// TestLoadPlugin tests that invalid plugin files are reported
+func TestLoadPlugin(t *testing.T) {
+	manager := NewSeederManager()
+
+	assert.Error(t, manager.LoadPlugin(filepath.Join(t.TempDir(), "missing.so")))
+	assert.NoError(t, manager.LoadPlugins(t.TempDir()), "empty directory")
+}
@@ -0,0 +1,184 @@
+package goseeder
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// geoJSONSRID is the SRID of GeoJSON coordinates, WGS 84 longitude and
+// latitude (RFC 7946)
+const geoJSONSRID = 4326
+
+// GeoJSON is a GeoJSON geometry or feature for a PostGIS geometry or
+// geography column, sent as EWKT such as SRID=4326;POINT(13.4 52.5)
+type GeoJSON struct {
+	Data any // Decoded GeoJSON object, or its JSON text as a string or []byte
+	SRID int // Spatial reference of the coordinates, 4326 when zero
+}
+
+// Value implements driver.Valuer
+func (g GeoJSON) Value() (driver.Value, error) {
+	object, err := geoJSONObject(g.Data)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	if err := writeWKT(&b, object); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+	srid := g.SRID
+	if srid == 0 {
+		srid = geoJSONSRID
+	}
+	return fmt.Sprintf("SRID=%d;%s", srid, b.String()), nil
+}
+
+// geoJSONObject returns data as a decoded GeoJSON object
+func geoJSONObject(data any) (map[string]any, error) {
+	switch d := data.(type) {
+	case map[string]any:
+		return d, nil
+	case Record:
+		return d, nil
+	case string:
+		return decodeGeoJSON(d)
+	case []byte:
+		return decodeGeoJSON(string(d))
+	default:
+		return nil, fmt.Errorf("invalid GeoJSON: expected an object, got %T", data)
+	}
+}
+
+// decodeGeoJSON decodes GeoJSON text, keeping coordinates as written
+func decodeGeoJSON(text string) (map[string]any, error) {
+	var object map[string]any
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+	return object, nil
+}
+
+// geoJSONNesting is how deeply the coordinates of each GeoJSON geometry
+// type nest positions
+var geoJSONNesting = map[string]int{
+	"Point":           0,
+	"LineString":      1,
+	"MultiPoint":      1,
+	"Polygon":         2,
+	"MultiLineString": 2,
+	"MultiPolygon":    3,
+}
+
+// writeWKT writes a GeoJSON geometry, or the geometry of a feature, as WKT
+func writeWKT(b *strings.Builder, object map[string]any) error {
+	kind, _ := object["type"].(string)
+	switch kind {
+	case "Feature":
+		geometry, ok := object["geometry"].(map[string]any)
+		if !ok {
+			return fmt.Errorf("feature has no geometry")
+		}
+		return writeWKT(b, geometry)
+	case "GeometryCollection":
+		geometries, _ := object["geometries"].([]any)
+		b.WriteString("GEOMETRYCOLLECTION")
+		if len(geometries) == 0 {
+			b.WriteString(" EMPTY")
+			return nil
+		}
+		b.WriteByte('(')
+		for i, geometry := range geometries {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			member, ok := geometry.(map[string]any)
+			if !ok {
+				return fmt.Errorf("geometry %d of the collection is not an object", i+1)
+			}
+			if err := writeWKT(b, member); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(')')
+		return nil
+	}
+
+	nesting, ok := geoJSONNesting[kind]
+	if !ok {
+		return fmt.Errorf("unknown geometry type %q", kind)
+	}
+	b.WriteString(strings.ToUpper(kind))
+	coordinates, _ := object["coordinates"].([]any)
+	if len(coordinates) == 0 {
+		b.WriteString(" EMPTY")
+		return nil
+	}
+	if nesting == 0 {
+		b.WriteByte('(')
+		defer b.WriteByte(')')
+	}
+	return writeCoordinates(b, coordinates, nesting)
+}
+
+// writeCoordinates writes a position as "x y", or at higher nesting a
+// parenthesized list of its members
+func writeCoordinates(b *strings.Builder, coordinates []any, nesting int) error {
+	if nesting == 0 {
+		if len(coordinates) < 2 {
+			return fmt.Errorf("position %v has fewer than 2 coordinates", coordinates)
+		}
+		for i, coordinate := range coordinates {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			text, err := geoJSONNumber(coordinate)
+			if err != nil {
+				return err
+			}
+			b.WriteString(text)
+		}
+		return nil
+	}
+
+	b.WriteByte('(')
+	for i, member := range coordinates {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		nested, ok := member.([]any)
+		if !ok {
+			return fmt.Errorf("expected an array of coordinates, got %v", member)
+		}
+		if err := writeCoordinates(b, nested, nesting-1); err != nil {
+			return err
+		}
+	}
+	b.WriteByte(')')
+	return nil
+}
+
+// geoJSONNumber formats a coordinate
+func geoJSONNumber(value any) (string, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int, int32, int64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("coordinate %v is not a number", value)
+	}
+}
+
+// isPostGIS reports whether typeName is a PostGIS geometry or geography type
+func isPostGIS(typeName string) bool {
+	return typeName == "geometry" || typeName == "geography"
+}
@@ -0,0 +1,79 @@
+package goseeder
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGeoJSON tests converting GeoJSON geometries to EWKT
+func TestGeoJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry string
+		expected string
+	}{
+		{"point", `{"type": "Point", "coordinates": [13.405, 52.52]}`, "SRID=4326;POINT(13.405 52.52)"},
+		{"point with altitude", `{"type": "Point", "coordinates": [1, 2, 3]}`, "SRID=4326;POINT(1 2 3)"},
+		{"line", `{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`, "SRID=4326;LINESTRING(0 0,1 1)"},
+		{"polygon with hole", `{"type": "Polygon", "coordinates": [[[0, 0], [4, 0], [4, 4], [0, 0]], [[1, 1], [2, 1], [1, 2], [1, 1]]]}`,
+			"SRID=4326;POLYGON((0 0,4 0,4 4,0 0),(1 1,2 1,1 2,1 1))"},
+		{"multipoint", `{"type": "MultiPoint", "coordinates": [[0, 0], [1, 1]]}`, "SRID=4326;MULTIPOINT(0 0,1 1)"},
+		{"multipolygon", `{"type": "MultiPolygon", "coordinates": [[[[0, 0], [1, 0], [1, 1], [0, 0]]]]}`,
+			"SRID=4326;MULTIPOLYGON(((0 0,1 0,1 1,0 0)))"},
+		{"empty", `{"type": "LineString", "coordinates": []}`, "SRID=4326;LINESTRING EMPTY"},
+		{"feature", `{"type": "Feature", "properties": {"name": "Store"}, "geometry": {"type": "Point", "coordinates": [1.5, -2]}}`,
+			"SRID=4326;POINT(1.5 -2)"},
+		{"collection", `{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [0, 0]}, {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}]}`,
+			"SRID=4326;GEOMETRYCOLLECTION(POINT(0 0),LINESTRING(0 0,1 1))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := GeoJSON{Data: tt.geometry}.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	value, err := GeoJSON{Data: map[string]any{"type": "Point", "coordinates": []any{float64(3857), 1.25}}, SRID: 3857}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "SRID=3857;POINT(3857 1.25)", value)
+
+	_, err = GeoJSON{Data: `{"type": "Circle", "coordinates": [0, 0]}`}.Value()
+	assert.EqualError(t, err, `invalid GeoJSON: unknown geometry type "Circle"`)
+	_, err = GeoJSON{Data: `{"type": "Point", "coordinates": [1]}`}.Value()
+	assert.EqualError(t, err, "invalid GeoJSON: position [1] has fewer than 2 coordinates")
+	_, err = GeoJSON{Data: `{"type": "Point", "coordinates": ["1", 2]}`}.Value()
+	assert.EqualError(t, err, "invalid GeoJSON: coordinate 1 is not a number")
+	_, err = GeoJSON{Data: 42}.Value()
+	assert.EqualError(t, err, "invalid GeoJSON: expected an object, got int")
+}
+
+// TestSQLFixtureWriterPostGIS tests inserting GeoJSON and WKT fixture values
+func TestSQLFixtureWriterPostGIS(t *testing.T) {
+	records, err := ReadFixture(strings.NewReader(`[
+		{"id": 1, "location": {"type": "Point", "coordinates": [13.405, 52.52]}, "zone": "SRID=4326;POLYGON((0 0,1 0,1 1,0 0))"},
+		{"id": 2, "location": "{\"type\": \"Point\", \"coordinates\": [2.35, 48.85]}", "zone": null}
+	]`), FormatJSON)
+	assert.NoError(t, err)
+
+	db, fake := newFakeDB()
+	writer := NewSQLFixtureWriter(db, nil)
+	writer.Columns = ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+		return []ColumnInfo{
+			{Name: "id", Type: "int4"},
+			{Name: "location", Type: "geography"},
+			{Name: "zone", Type: "geometry", Nullable: true},
+		}, nil
+	})
+
+	assert.NoError(t, writer.WriteFixture(context.Background(), "stores", records))
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO stores (id, location, zone) VALUES ($1, $2, $3), ($4, $5, $6) " +
+			"[1 SRID=4326;POINT(13.405 52.52) SRID=4326;POLYGON((0 0,1 0,1 1,0 0)) 2 SRID=4326;POINT(2.35 48.85) <nil>]",
+		"COMMIT",
+	}, fake.Events(), "WKT is passed as it is")
+}
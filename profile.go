@@ -0,0 +1,119 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// TableProfile summarizes the shape of a table's data, to compare seeded
+// data with production statistics
+type TableProfile struct {
+	Table   string
+	Rows    int64
+	Columns []ColumnProfile
+}
+
+// ColumnProfile holds the statistics of a single column. Distinct is zero
+// for types without equality, such as json, and Min and Max nil for types
+// without ordering, such as booleans.
+type ColumnProfile struct {
+	Name      string
+	Type      string // Database type name, empty when the driver does not report it
+	Nulls     int64
+	NullRatio float64 // Nulls / Rows, zero for an empty table
+	Distinct  int64
+	Min       any    // Nil for an empty or all-NULL column
+	Max       any    //
+	Error     string // Why the column could not be profiled, its statistics left zero
+}
+
+// Database types ProfileTable leaves aggregates out for: Postgres has no
+// MIN/MAX for types without ordering, nor DISTINCT for json and xml
+var (
+	unorderedColumnTypes = []string{"BOOL", "BOOLEAN", "JSON", "JSONB", "XML", "BYTEA", "UUID"}
+	unequalColumnTypes   = []string{"JSON", "XML"}
+)
+
+// identifierPattern matches the table and column names ProfileTable accepts,
+// optionally schema-qualified, so they can be used in SQL without quoting
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// ProfileTable computes the row count and, per column, the NULL ratio,
+// distinct count and min/max using portable SQL aggregates. Aggregates the
+// column's type does not support are left out, and a column whose query
+// fails gets its Error set rather than failing the profile.
+func ProfileTable(ctx context.Context, db *sql.DB, table string) (*TableProfile, error) {
+	if !identifierPattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+
+	columns, types, err := tableColumnTypes(ctx, db, table)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &TableProfile{Table: table}
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&profile.Rows); err != nil {
+		return nil, fmt.Errorf("failed to count rows of %s: %w", table, err)
+	}
+
+	for _, column := range columns {
+		if !identifierPattern.MatchString(column) {
+			return nil, fmt.Errorf("column %q of %s cannot be profiled", column, table)
+		}
+		profile.Columns = append(profile.Columns, profileColumn(ctx, db, table, profile.Rows, column, types[column]))
+	}
+	return profile, nil
+}
+
+// profileColumn computes the statistics of column with the aggregates its
+// databaseType supports
+func profileColumn(ctx context.Context, db *sql.DB, table string, rows int64, column, databaseType string) ColumnProfile {
+	col := ColumnProfile{Name: column, Type: databaseType}
+	var nonNull int64
+	var min, max any
+	aggregates := []string{"COUNT(%[1]s)"}
+	dest := []any{&nonNull}
+	if !slices.Contains(unequalColumnTypes, col.Type) {
+		aggregates = append(aggregates, "COUNT(DISTINCT %[1]s)")
+		dest = append(dest, &col.Distinct)
+	}
+	if !slices.Contains(unorderedColumnTypes, col.Type) {
+		aggregates = append(aggregates, "MIN(%[1]s)", "MAX(%[1]s)")
+		dest = append(dest, &min, &max)
+	}
+
+	query := fmt.Sprintf("SELECT "+strings.Join(aggregates, ", ")+" FROM %[2]s", col.Name, table)
+	if err := db.QueryRowContext(ctx, query).Scan(dest...); err != nil {
+		return ColumnProfile{Name: col.Name, Type: col.Type, Error: err.Error()}
+	}
+
+	col.Nulls = rows - nonNull
+	if rows > 0 {
+		col.NullRatio = float64(col.Nulls) / float64(rows)
+	}
+	col.Min, col.Max = displayValue(min), displayValue(max)
+	return col
+}
+
+// tableColumns returns the column names of table
+func tableColumns(ctx context.Context, db *sql.DB, table string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT * FROM "+table+" WHERE 1 = 0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+	return rows.Columns()
+}
+
+// displayValue converts driver byte slices to strings
+func displayValue(value any) any {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return value
+}
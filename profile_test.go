@@ -0,0 +1,84 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptUsersProfile scripts the queries profiling a users table
+func scriptUsersProfile(fake *fakeDB) {
+	fake.On("SELECT * FROM users WHERE 1 = 0", []string{"id", "email"})
+	fake.On("SELECT COUNT(*) FROM users", []string{"count"}, []driver.Value{int64(4)})
+	fake.On("SELECT COUNT(id), COUNT(DISTINCT id), MIN(id), MAX(id) FROM users",
+		[]string{"a", "b", "c", "d"}, []driver.Value{int64(4), int64(4), int64(1), int64(4)})
+	fake.On("SELECT COUNT(email), COUNT(DISTINCT email), MIN(email), MAX(email) FROM users",
+		[]string{"a", "b", "c", "d"}, []driver.Value{int64(3), int64(2), []byte("a@example.com"), []byte("b@example.com")})
+}
+
+// TestProfileTable tests column statistics
+func TestProfileTable(t *testing.T) {
+	t.Run("Profiles every column", func(t *testing.T) {
+		db, fake := newFakeDB()
+		scriptUsersProfile(fake)
+
+		profile, err := ProfileTable(context.Background(), db, "users")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), profile.Rows)
+		assert.Equal(t, []ColumnProfile{
+			{Name: "id", Nulls: 0, NullRatio: 0, Distinct: 4, Min: int64(1), Max: int64(4)},
+			{Name: "email", Nulls: 1, NullRatio: 0.25, Distinct: 2, Min: "a@example.com", Max: "b@example.com"},
+		}, profile.Columns)
+	})
+
+	t.Run("Leaves out aggregates the column type lacks", func(t *testing.T) {
+		db, fake := newFakeDB()
+		fake.OnTyped("SELECT * FROM flags WHERE 1 = 0", []string{"id", "active", "settings", "location"}, []string{"int8", "bool", "json", "geometry"})
+		fake.On("SELECT COUNT(*) FROM flags", []string{"count"}, []driver.Value{int64(2)})
+		fake.On("SELECT COUNT(id), COUNT(DISTINCT id), MIN(id), MAX(id) FROM flags",
+			[]string{"a", "b", "c", "d"}, []driver.Value{int64(2), int64(2), int64(1), int64(2)})
+		fake.On("SELECT COUNT(active), COUNT(DISTINCT active) FROM flags", []string{"a", "b"}, []driver.Value{int64(2), int64(1)})
+		fake.On("SELECT COUNT(settings) FROM flags", []string{"a"}, []driver.Value{int64(1)})
+		fake.Fail("SELECT COUNT(location), COUNT(DISTINCT location), MIN(location), MAX(location) FROM flags",
+			errors.New("function min(geometry) does not exist"))
+
+		profile, err := ProfileTable(context.Background(), db, "flags")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ColumnProfile{
+			{Name: "id", Type: "INT8", Distinct: 2, Min: int64(1), Max: int64(2)},
+			{Name: "active", Type: "BOOL", Distinct: 1},
+			{Name: "settings", Type: "JSON", Nulls: 1, NullRatio: 0.5},
+			{Name: "location", Type: "GEOMETRY", Error: "function min(geometry) does not exist"},
+		}, profile.Columns)
+	})
+
+	t.Run("Rejects unsafe names and query errors", func(t *testing.T) {
+		db, fake := newFakeDB()
+
+		_, err := ProfileTable(context.Background(), db, "users; DROP TABLE users")
+		assert.ErrorContains(t, err, "invalid table name")
+
+		fake.Fail("SELECT * FROM orders WHERE 1 = 0", errors.New("relation does not exist"))
+		_, err = ProfileTable(context.Background(), db, "orders")
+		assert.ErrorContains(t, err, "relation does not exist")
+	})
+}
+
+// TestCLIProfileCommand tests the profile command
+func TestCLIProfileCommand(t *testing.T) {
+	db, fake := newFakeDB()
+	scriptUsersProfile(fake)
+	cli := NewCLI(NewSeederManager())
+
+	assert.ErrorContains(t, cli.runCommand(context.Background(), []string{"profile", "-tables=users"}), "SetDB")
+
+	cli.SetDB(db)
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"profile", "-tables=users"}))
+	assert.Error(t, cli.runCommand(context.Background(), []string{"profile"}))
+	assert.Error(t, cli.runCommand(context.Background(), []string{"profile", "-tables=users,orders"}))
+}
@@ -0,0 +1,84 @@
+package goseeder
+
+import (
+	"context"
+	"sync"
+)
+
+// ProgressReporter follows runs as they go, e.g. to render a progress bar
+// or push status to a UI while RunAllSeeders is executing
+type ProgressReporter interface {
+	// OnProgress is called before each seeder starts with the number of
+	// seeders done so far and the name of the starting one, then once with
+	// completed equal to total and an empty current when the last seeder is
+	// done. Skipped seeders count as done. Calls are never concurrent, even
+	// in parallel runs.
+	OnProgress(completed, total int, current string)
+}
+
+// ProgressFunc adapts a function to ProgressReporter
+type ProgressFunc func(completed, total int, current string)
+
+// OnProgress implements ProgressReporter
+func (f ProgressFunc) OnProgress(completed, total int, current string) {
+	f(completed, total, current)
+}
+
+// SetProgressReporter sets the reporter told about the progress of every
+// run; nil disables progress reporting
+func (sm *SeederManager) SetProgressReporter(reporter ProgressReporter) {
+	sm.progressReporter = reporter
+}
+
+// runProgress counts the seeders of a run for its ProgressReporter
+type runProgress struct {
+	mu        sync.Mutex // Also serializes the calls to reporter
+	reporter  ProgressReporter
+	total     int
+	completed int
+}
+
+// progressOf returns the progress of the run of ctx, or nil when the run
+// has no reporter
+func progressOf(ctx context.Context) *runProgress {
+	state, ok := ctx.Value(runKey).(*runState)
+	if !ok || state.progress.reporter == nil {
+		return nil
+	}
+	return &state.progress
+}
+
+// startProgress sets the number of seeders of the run of ctx, unless an
+// enclosing call, e.g. RunSeedersInOrderContext for RunSeederByNameContext,
+// already did
+func startProgress(ctx context.Context, total int) {
+	if progress := progressOf(ctx); progress != nil {
+		progress.mu.Lock()
+		if progress.total == 0 {
+			progress.total = total
+		}
+		progress.mu.Unlock()
+	}
+}
+
+// progressStarted reports that the seeder name is starting
+func progressStarted(ctx context.Context, name string) {
+	if progress := progressOf(ctx); progress != nil {
+		progress.mu.Lock()
+		defer progress.mu.Unlock()
+		progress.reporter.OnProgress(progress.completed, progress.total, name)
+	}
+}
+
+// progressDone counts a seeder as done, reporting the end of the run after
+// the last one
+func progressDone(ctx context.Context) {
+	if progress := progressOf(ctx); progress != nil {
+		progress.mu.Lock()
+		defer progress.mu.Unlock()
+		progress.completed++
+		if progress.completed == progress.total {
+			progress.reporter.OnProgress(progress.completed, progress.total, "")
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordProgress returns a reporter recording calls as "completed/total current"
+func recordProgress(calls *[]string) ProgressReporter {
+	return ProgressFunc(func(completed, total int, current string) {
+		*calls = append(*calls, fmt.Sprintf("%d/%d %s", completed, total, current))
+	})
+}
+
+// TestProgressReporter tests reporting the progress of runs
+func TestProgressReporter(t *testing.T) {
+	var calls []string
+	manager := NewSeederManager()
+	manager.SetProgressReporter(recordProgress(&calls))
+	manager.RegisterSeeders(
+		SeederItem{Name: "users", Function: func() error { return nil }},
+		SeederItem{Name: "orders", SkipIf: func() (bool, string) { return true, "table not empty" }},
+		SeederItem{Name: "products", Function: func() error { return nil }},
+	)
+
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []string{"0/3 users", "1/3 orders", "2/3 products", "3/3 "}, calls)
+
+	calls = nil
+	assert.NoError(t, manager.RunSeedersInOrder([]string{"products", "users"}))
+	assert.Equal(t, []string{"0/2 products", "1/2 users", "2/2 "}, calls)
+
+	calls = nil
+	assert.NoError(t, manager.RunSeederByName("users"))
+	assert.Equal(t, []string{"0/1 users", "1/1 "}, calls)
+}
+
+// TestProgressReporterFailure tests that a failed seeder does not count as done
+func TestProgressReporterFailure(t *testing.T) {
+	var calls []string
+	manager := NewSeederManager()
+	manager.SetProgressReporter(recordProgress(&calls))
+	manager.RegisterSeeders(
+		SeederItem{Name: "users", Function: func() error { return errors.New("boom") }},
+		SeederItem{Name: "orders", Function: func() error { return nil }},
+	)
+
+	assert.Error(t, manager.RunAllSeeders())
+	assert.Equal(t, []string{"0/2 users"}, calls)
+}
+
+// TestProgressReporterParallel tests that parallel runs report every seeder
+// without concurrent calls
+func TestProgressReporterParallel(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		calls   int
+		running bool
+		last    []int
+	)
+	manager := NewSeederManager()
+	manager.SetProgressReporter(ProgressFunc(func(completed, total int, current string) {
+		mu.Lock()
+		assert.False(t, running, "concurrent OnProgress call")
+		running = true
+		mu.Unlock()
+		calls++
+		last = []int{completed, total}
+		mu.Lock()
+		running = false
+		mu.Unlock()
+	}))
+	for i := range 8 {
+		manager.RegisterSeeders(SeederItem{Name: fmt.Sprintf("seeder%d", i), Function: func() error { return nil }})
+	}
+	manager.RegisterSeeders(SeederItem{Name: "dependent", DependsOn: []string{"seeder0"}, Function: func() error { return nil }})
+
+	assert.NoError(t, manager.RunAllSeedersParallelContext(context.Background(), 4))
+	assert.Equal(t, 10, calls)
+	assert.Equal(t, []int{9, 9}, last)
+}
@@ -0,0 +1,99 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Quota caps the rows a run inserts, as a guardrail so a miswritten
+// factory cannot grow a shared database by millions of rows. Limits are
+// enforced by SQLFixtureWriter, which fails a write exceeding them before
+// inserting any of its rows, and by ReserveRows for other insert code.
+type Quota struct {
+	MaxRows      int64            // Rows inserted by a run into all tables, zero for no limit
+	MaxTableRows int64            // Rows inserted by a run into a single table, zero for no limit
+	Tables       map[string]int64 // Per-table limits overriding MaxTableRows, zero lifting it
+}
+
+// tableLimit returns the row limit of table, zero for no limit
+func (q Quota) tableLimit(table string) int64 {
+	if limit, ok := q.Tables[table]; ok {
+		return limit
+	}
+	return q.MaxTableRows
+}
+
+// QuotaError reports a write that would exceed a Quota
+type QuotaError struct {
+	Table     string
+	Requested int64 // Rows of the rejected write
+	Inserted  int64 // Rows inserted before it, into Table or, for the run limit, in total
+	Limit     int64
+	Run       bool // The run limit, MaxRows, was exceeded rather than the table's
+}
+
+// Error implements the error interface
+func (e *QuotaError) Error() string {
+	scope := "the limit of " + e.Table
+	if e.Run {
+		scope = "the run limit"
+	}
+	return fmt.Sprintf("row quota exceeded: inserting %d rows into %s would make %d, over %s of %d rows",
+		e.Requested, e.Table, e.Inserted+e.Requested, scope, e.Limit)
+}
+
+// quotaTracker counts the rows inserted under a Quota
+type quotaTracker struct {
+	quota Quota
+	mu    sync.Mutex
+	total int64
+	rows  map[string]int64
+}
+
+// SetQuota caps the rows every run inserts, see Quota
+func (sm *SeederManager) SetQuota(quota Quota) {
+	sm.quota = &quota
+}
+
+// ContextWithQuota returns a copy of ctx whose inserts are capped by quota,
+// counted across everything written with ctx, as the CLI's -max-rows and
+// -max-table-rows flags do. It overrides the quota of SetQuota.
+func ContextWithQuota(ctx context.Context, quota Quota) context.Context {
+	return context.WithValue(ctx, quotaKey, &quotaTracker{quota: quota, rows: make(map[string]int64)})
+}
+
+// ReserveRows counts n rows about to be inserted into table against the
+// quota of ctx, or returns a *QuotaError without counting them when they
+// would exceed it. SQLFixtureWriter calls it; call it from seeders
+// inserting rows by other means, e.g. COPY. It does nothing without a
+// quota.
+func ReserveRows(ctx context.Context, table string, n int64) error {
+	tracker, ok := ctx.Value(quotaKey).(*quotaTracker)
+	if !ok {
+		return nil
+	}
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	if limit := tracker.quota.tableLimit(table); limit > 0 && tracker.rows[table]+n > limit {
+		return &QuotaError{Table: table, Requested: n, Inserted: tracker.rows[table], Limit: limit}
+	}
+	if limit := tracker.quota.MaxRows; limit > 0 && tracker.total+n > limit {
+		return &QuotaError{Table: table, Requested: n, Inserted: tracker.total, Limit: limit, Run: true}
+	}
+	tracker.rows[table] += n
+	tracker.total += n
+	return nil
+}
+
+// releaseRows returns rows reserved with ReserveRows that were not
+// inserted, e.g. because their write failed
+func releaseRows(ctx context.Context, table string, n int64) {
+	if tracker, ok := ctx.Value(quotaKey).(*quotaTracker); ok {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		tracker.rows[table] -= n
+		tracker.total -= n
+	}
+}
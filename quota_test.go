@@ -0,0 +1,91 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQuota tests capping the rows written with a context
+func TestQuota(t *testing.T) {
+	db, fake := newFakeDB()
+	writer := NewSQLFixtureWriter(db, nil)
+	ctx := ContextWithQuota(context.Background(), Quota{MaxRows: 5, MaxTableRows: 2, Tables: map[string]int64{"logs": 4}})
+
+	assert.NoError(t, writer.WriteFixture(ctx, "users", []Record{{"id": 1}, {"id": 2}}))
+	err := writer.WriteFixture(ctx, "users", []Record{{"id": 3}})
+	var quotaErr *QuotaError
+	assert.ErrorAs(t, err, &quotaErr)
+	assert.EqualError(t, err, "row quota exceeded: inserting 1 rows into users would make 3, over the limit of users of 2 rows")
+
+	assert.NoError(t, writer.WriteFixture(ctx, "logs", []Record{{"id": 1}, {"id": 2}, {"id": 3}}), "per-table limits override MaxTableRows")
+	err = writer.WriteFixture(ctx, "logs", []Record{{"id": 4}})
+	assert.EqualError(t, err, "row quota exceeded: inserting 1 rows into logs would make 6, over the run limit of 5 rows")
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO users (id) VALUES ($1), ($2) [1 2]",
+		"COMMIT",
+		"BEGIN",
+		"INSERT INTO logs (id) VALUES ($1), ($2), ($3) [1 2 3]",
+		"COMMIT",
+	}, fake.Events(), "writes over the quota insert nothing")
+
+	ctx = ContextWithQuota(context.Background(), Quota{MaxTableRows: 2})
+	fake.Fail("INSERT INTO orders (id) VALUES ($1) [1]", errors.New("duplicate key"))
+	assert.Error(t, writer.WriteFixture(ctx, "orders", []Record{{"id": 1}}))
+	assert.NoError(t, ReserveRows(ctx, "orders", 2), "rows of failed writes are not counted")
+	assert.Error(t, ReserveRows(ctx, "orders", 1))
+
+	assert.NoError(t, ReserveRows(context.Background(), "users", 1_000_000), "no quota, no limit")
+}
+
+// TestSetQuota tests that every run counts rows against the quota anew
+func TestSetQuota(t *testing.T) {
+	manager := NewSeederManager()
+	manager.SetQuota(Quota{MaxTableRows: 100})
+	manager.RegisterSeederContext("users", func(ctx context.Context) error {
+		for range 3 {
+			if err := ReserveRows(ctx, "users", 40); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	manager.RegisterSeederContext("orders", func(ctx context.Context) error {
+		return ReserveRows(ctx, "orders", 100)
+	})
+
+	assert.NoError(t, manager.RunSeederByName("orders"))
+	assert.NoError(t, manager.RunSeederByName("orders"), "quotas are per run")
+	err := manager.RunSeederByName("users")
+	var quotaErr *QuotaError
+	assert.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, QuotaError{Table: "users", Requested: 40, Inserted: 80, Limit: 100}, *quotaErr)
+	assert.Contains(t, manager.LastRunReport().Seeders[0].Error, "row quota exceeded")
+}
+
+// TestCLIQuotaFlags tests the -max-rows and -max-table-rows flags
+func TestCLIQuotaFlags(t *testing.T) {
+	db, fake := newFakeDB()
+	path := filepath.Join(t.TempDir(), "users.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`[{"id": 1}, {"id": 2}]`), 0o644))
+	cli := NewCLI(NewSeederManager())
+	cli.SetDB(db)
+
+	args := os.Args
+	defer func() { os.Args = args }()
+	run := func(arguments ...string) error {
+		os.Args = append([]string{"seeder"}, arguments...)
+		return cli.Run()
+	}
+
+	assert.EqualError(t, run("-max-rows=1", "load", path), "row quota exceeded: inserting 2 rows into users would make 2, over the run limit of 1 rows")
+	assert.Empty(t, fake.Events())
+	assert.NoError(t, run("-max-table-rows=2", "load", path))
+	assert.Contains(t, fake.Events(), "INSERT INTO users (id) VALUES ($1), ($2) [1 2]")
+	assert.ErrorContains(t, run("-max-rows=-1", "load", path), "cannot be negative")
+}
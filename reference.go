@@ -0,0 +1,91 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"slices"
+)
+
+// Built-in reference datasets registered by RegisterReferenceData
+const (
+	ReferenceCountries  = "countries"  // ISO 3166-1: code, alpha3, numeric, name
+	ReferenceCurrencies = "currencies" // ISO 4217: code, numeric, name, minor_units (null for funds and metals)
+	ReferenceLanguages  = "languages"  // ISO 639-1: code, alpha3, name
+	ReferenceTimezones  = "timezones"  // IANA time zones: name, country_code
+)
+
+// referenceDatasets are the built-in datasets, in registration order
+var referenceDatasets = []string{ReferenceCountries, ReferenceCurrencies, ReferenceLanguages, ReferenceTimezones}
+
+// referenceData holds the datasets, generated from the iso-codes and tzdata
+// (zone.tab) tables
+//
+//go:embed reference/*.ndjson
+var referenceData embed.FS
+
+// ReferenceOptions selects the built-in datasets RegisterReferenceData
+// registers and the tables they are written into
+type ReferenceOptions struct {
+	Datasets []string          // Datasets to register, all of them when empty
+	Tables   map[string]string // Table of a dataset, e.g. "countries": "iso_countries"; defaults to the dataset name
+}
+
+// RegisterReferenceData registers seeders for common reference data every
+// project re-creates: ISO countries, currencies, languages and IANA time
+// zones. Seeders are named "reference.<dataset>", tagged TagCore and write
+// through the fixture writer set with SetPackTarget.
+func (sm *SeederManager) RegisterReferenceData(options ReferenceOptions) error {
+	datasets := options.Datasets
+	if len(datasets) == 0 {
+		datasets = referenceDatasets
+	}
+	for dataset := range options.Tables {
+		if !slices.Contains(datasets, dataset) {
+			return fmt.Errorf("table mapping of unknown or unselected dataset '%s'%s", dataset, didYouMean(dataset, datasets))
+		}
+	}
+
+	items := make([]SeederItem, 0, len(datasets))
+	for _, dataset := range datasets {
+		records, err := ReferenceRecords(dataset)
+		if err != nil {
+			return err
+		}
+		table := dataset
+		if mapped, ok := options.Tables[dataset]; ok {
+			table = mapped
+		}
+		items = append(items, SeederItem{
+			Name:            "reference." + dataset,
+			ContextFunction: sm.referenceSeeder(table, records),
+			Tags:            []string{TagCore},
+		})
+	}
+	return sm.RegisterSeeders(items...)
+}
+
+// ReferenceRecords returns the records of a built-in dataset, e.g.
+// ReferenceCountries, sorted by code or name
+func ReferenceRecords(dataset string) ([]Record, error) {
+	if !slices.Contains(referenceDatasets, dataset) {
+		return nil, fmt.Errorf("unknown reference dataset '%s'%s", dataset, didYouMean(dataset, referenceDatasets))
+	}
+	data, err := referenceData.ReadFile("reference/" + dataset + ".ndjson")
+	if err != nil {
+		return nil, err
+	}
+	return ReadFixture(bytes.NewReader(data), FormatNDJSON)
+}
+
+// referenceSeeder returns the function writing records into table through
+// the pack target
+func (sm *SeederManager) referenceSeeder(table string, records []Record) SeederFunc {
+	return func(ctx context.Context) error {
+		if sm.packFixtures == nil {
+			return fmt.Errorf("reference data requires a fixture writer, see SeederManager.SetPackTarget")
+		}
+		return sm.packFixtures.WriteFixture(ctx, table, records)
+	}
+}
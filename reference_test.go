@@ -0,0 +1,61 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReferenceRecords tests reading the built-in datasets
+func TestReferenceRecords(t *testing.T) {
+	for dataset, expected := range map[string]Record{
+		ReferenceCountries:  {"code": "NL", "alpha3": "NLD", "numeric": "528", "name": "Netherlands"},
+		ReferenceCurrencies: {"code": "JPY", "numeric": "392", "name": "Yen", "minor_units": json.Number("0")},
+		ReferenceLanguages:  {"code": "id", "alpha3": "ind", "name": "Indonesian"},
+		ReferenceTimezones:  {"name": "Asia/Jakarta", "country_code": "ID"},
+	} {
+		records, err := ReferenceRecords(dataset)
+		assert.NoError(t, err, dataset)
+		assert.NotEmpty(t, records, dataset)
+		assert.Contains(t, records, expected, dataset)
+	}
+
+	countries, _ := ReferenceRecords(ReferenceCountries)
+	assert.Len(t, countries, 249)
+
+	_, err := ReferenceRecords("contries")
+	assert.ErrorContains(t, err, "did you mean 'countries'?")
+}
+
+// TestRegisterReferenceData tests registering datasets with table mapping
+func TestRegisterReferenceData(t *testing.T) {
+	manager := NewSeederManager()
+	written := map[string]int{}
+	manager.SetPackTarget(nil, FixtureWriterFunc(func(ctx context.Context, table string, records []Record) error {
+		written[table] = len(records)
+		return nil
+	}))
+
+	err := manager.RegisterReferenceData(ReferenceOptions{
+		Datasets: []string{ReferenceCountries, ReferenceCurrencies},
+		Tables:   map[string]string{ReferenceCountries: "iso_countries"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reference.countries", "reference.currencies"}, manager.GetSeedersByTag(TagCore))
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, map[string]int{"iso_countries": 249, "currencies": 181}, written)
+
+	err = NewSeederManager().RegisterReferenceData(ReferenceOptions{
+		Datasets: []string{ReferenceCountries},
+		Tables:   map[string]string{ReferenceLanguages: "langs"},
+	})
+	assert.ErrorContains(t, err, "table mapping of unknown or unselected dataset 'languages'")
+
+	all := NewSeederManager()
+	assert.NoError(t, all.RegisterReferenceData(ReferenceOptions{}))
+	assert.Len(t, all.GetRegisteredSeeders(), 4)
+	assert.ErrorContains(t, all.RunSeederByName("reference.languages"), "see SeederManager.SetPackTarget")
+}
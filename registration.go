@@ -0,0 +1,124 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// RegistrationFile declares seeders for the standalone goseeder binary, so
+// simple projects don't need to write their own main. Every seeder runs a
+// command, e.g. psql with a SQL file or a script.
+type RegistrationFile struct {
+	AppName string              `json:"app_name"`
+	Seeders []CommandSeederSpec `json:"seeders"`
+	Plugins []string            `json:"plugins"` // Go plugin .so files, relative to the registration file
+
+	dir string // Directory of the file, commands run relative to it
+}
+
+// CommandSeederSpec declares a seeder that runs an external command
+type CommandSeederSpec struct {
+	Name    string            `json:"name"`
+	Command []string          `json:"command"` // Program and arguments, not run through a shell
+	Dir     string            `json:"dir"`     // Working directory, relative to the registration file
+	Env     map[string]string `json:"env"`     // Added to the inherited environment
+	Tags    []string          `json:"tags"`
+	Aliases []string          `json:"aliases"`
+	Paths   []string          `json:"paths"` // Relative to the repository root, see SeederItem.Affects
+}
+
+// LoadRegistrationFile reads a JSON registration file such as:
+//
+//	{
+//	  "app_name": "shop",
+//	  "seeders": [
+//	    {"name": "countries", "command": ["psql", "-f", "seeds/countries.sql"], "tags": ["core"]}
+//	  ],
+//	  "plugins": ["packs/demo.so"]
+//	}
+func LoadRegistrationFile(path string) (*RegistrationFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registration file: %w", err)
+	}
+
+	var file RegistrationFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse registration file '%s': %w", path, err)
+	}
+	for _, spec := range file.Seeders {
+		if len(spec.Command) == 0 {
+			return nil, fmt.Errorf("seeder '%s' in '%s' has no command", spec.Name, path)
+		}
+	}
+
+	file.dir = filepath.Dir(path)
+	return &file, nil
+}
+
+// Register registers the declared seeders with the manager, in file order,
+// followed by the seeders of the declared plugins
+func (rf *RegistrationFile) Register(manager *SeederManager) error {
+	for _, spec := range rf.Seeders {
+		dir := spec.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(rf.dir, dir)
+		}
+		err := manager.registerItem(SeederItem{
+			Name:            spec.Name,
+			ContextFunction: NewCommandSeeder(dir, spec.Env, spec.Command...),
+			Tags:            spec.Tags,
+			Aliases:         spec.Aliases,
+			Paths:           spec.Paths,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to register seeder '%s': %w", spec.Name, err)
+		}
+	}
+
+	for _, path := range rf.Plugins {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(rf.dir, path)
+		}
+		if err := manager.LoadPlugin(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewCommandSeeder returns a seeder running the given program in dir with
+// env added to the inherited environment. The command's output is passed
+// through, SEEDER_RUN_ID is set to the ID of the current run and
+// DATABASE_URL to the DSN of the CLI, see DSNFromContext.
+func NewCommandSeeder(dir string, env map[string]string, command ...string) SeederFunc {
+	return func(ctx context.Context) error {
+		if len(command) == 0 {
+			return fmt.Errorf("no command to run")
+		}
+
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd.Dir = dir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = os.Environ()
+		if dsn := DSNFromContext(ctx); dsn != "" {
+			cmd.Env = append(cmd.Env, "DATABASE_URL="+dsn)
+		}
+		for key, value := range env {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+		if runID := RunIDFromContext(ctx); runID != "" {
+			cmd.Env = append(cmd.Env, "SEEDER_RUN_ID="+runID)
+		}
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("command %v failed: %w", command, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package goseeder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeRegistrationFile writes content to goseeder.json in a temp directory
+func writeRegistrationFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "goseeder.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+// TestLoadRegistrationFile tests loading and registering command seeders
+func TestLoadRegistrationFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	t.Run("Registers command seeders", func(t *testing.T) {
+		path := writeRegistrationFile(t, `{
+			"app_name": "shop",
+			"seeders": [
+				{"name": "countries", "command": ["sh", "-c", "echo $SEEDER_RUN_ID:$REGION > out.txt"], "env": {"REGION": "eu"}, "tags": ["core"], "paths": ["seeds/countries"]},
+				{"name": "broken", "command": ["sh", "-c", "exit 3"]}
+			]
+		}`)
+
+		file, err := LoadRegistrationFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "shop", file.AppName)
+
+		manager := NewSeederManager()
+		assert.NoError(t, file.Register(manager))
+		assert.Equal(t, []string{"countries"}, manager.GetSeedersByTag(TagCore))
+		assert.Equal(t, []string{"countries"}, manager.AffectedSeeders([]string{"seeds/countries/eu.sql"}))
+
+		assert.NoError(t, manager.RunSeederByName("countries"))
+		out, err := os.ReadFile(filepath.Join(filepath.Dir(path), "out.txt"))
+		assert.NoError(t, err)
+		assert.Regexp(t, `^\d{8}T\d{6}-[0-9a-f]{8}:eu\n$`, string(out))
+
+		assert.Error(t, manager.RunSeederByName("broken"))
+	})
+
+	t.Run("Passes the DSN to commands", func(t *testing.T) {
+		dir := t.TempDir()
+		seeder := NewCommandSeeder(dir, nil, "sh", "-c", "echo $DATABASE_URL > out.txt")
+
+		assert.NoError(t, seeder(ContextWithDSN(context.Background(), "postgres://localhost/shop")))
+		out, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, "postgres://localhost/shop\n", string(out))
+	})
+
+	t.Run("Invalid files", func(t *testing.T) {
+		_, err := LoadRegistrationFile(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+
+		_, err = LoadRegistrationFile(writeRegistrationFile(t, `{"seeders": [`))
+		assert.Error(t, err)
+
+		_, err = LoadRegistrationFile(writeRegistrationFile(t, `{"seeders": [{"name": "users"}]}`))
+		assert.ErrorContains(t, err, "has no command")
+	})
+}
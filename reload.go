@@ -0,0 +1,124 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// FixtureReload reports the changes of a ReloadFixtures call
+type FixtureReload struct {
+	Added   []string `json:"added"`   // Seeders of new files
+	Removed []string `json:"removed"` // Seeders of deleted or now ignored files
+	Seeders int      `json:"seeders"` // Registered seeders after the reload
+}
+
+// ReloadFixtures re-reads the directories registered with
+// RegisterFixtureDir and updates the registry, so a long-running admin
+// service picks up new demo data without a redeploy. The seeders of a
+// directory keep their place in the registration order. The registry is
+// replaced atomically: lookups see it either before or after the reload,
+// and on error it is left unchanged. Runs already in progress finish with
+// the seeders they started with.
+func (sm *SeederManager) ReloadFixtures() (*FixtureReload, error) {
+	sm.registryMu.RLock()
+	sources := slices.Clone(sm.fixtureDirs)
+	sm.registryMu.RUnlock()
+
+	fresh := make(map[*fixtureDir][]SeederItem, len(sources))
+	for _, source := range sources {
+		items, err := sm.fixtureDirSeeders(source.dir, source.options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture directory '%s': %w", source.dir, err)
+		}
+		fresh[source] = items
+	}
+
+	sm.registryMu.Lock()
+	defer sm.registryMu.Unlock()
+
+	owners := make(map[string]*fixtureDir)
+	for _, source := range sources {
+		for _, name := range source.names {
+			owners[name] = source
+		}
+	}
+
+	next := NewSeederManager()
+	next.caseInsensitive = sm.caseInsensitive
+	add := func(items ...SeederItem) error {
+		for _, item := range items {
+			if err := next.addItem(item); err != nil {
+				return fmt.Errorf("failed to reload seeder '%s': %w", item.Name, err)
+			}
+		}
+		return nil
+	}
+	reloaded := make(map[*fixtureDir]bool, len(sources))
+	for _, seeder := range sm.seeders {
+		source, owned := owners[seeder.Name]
+		switch {
+		case !owned:
+			if err := add(seeder); err != nil {
+				return nil, err
+			}
+		case !reloaded[source]:
+			if err := add(fresh[source]...); err != nil {
+				return nil, err
+			}
+			reloaded[source] = true
+		}
+	}
+	for _, source := range sources {
+		if !reloaded[source] {
+			if err := add(fresh[source]...); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	reload := &FixtureReload{Added: make([]string, 0), Removed: make([]string, 0), Seeders: len(next.names)}
+	for _, source := range sources {
+		names := make([]string, 0, len(fresh[source]))
+		for _, item := range fresh[source] {
+			names = append(names, item.Name)
+			if !slices.Contains(source.names, item.Name) {
+				reload.Added = append(reload.Added, item.Name)
+			}
+		}
+		for _, name := range source.names {
+			if !slices.Contains(names, name) {
+				reload.Removed = append(reload.Removed, name)
+			}
+		}
+		source.names = names
+	}
+
+	sm.seeders, sm.seederMap, sm.names, sm.aliases, sm.folded = next.seeders, next.seederMap, next.names, next.aliases, next.folded
+	logInfo(sm.withLogger(context.Background()), "Reloaded fixtures: %d added, %d removed", len(reload.Added), len(reload.Removed))
+	return reload, nil
+}
+
+// ReloadHandler returns an HTTP handler calling ReloadFixtures on POST and
+// responding with the FixtureReload as JSON, e.g.
+//
+//	mux.Handle("/admin/seeders/reload", manager.ReloadHandler())
+func (sm *SeederManager) ReloadHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		reload, err := sm.ReloadFixtures()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(reload)
+	})
+}
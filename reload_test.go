@@ -0,0 +1,114 @@
+package goseeder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReloadFixtures tests picking up added and removed fixture files
+func TestReloadFixtures(t *testing.T) {
+	dir := writePackDir(t, map[string]string{
+		"countries.json":  `[]`,
+		"demo/users.json": `[]`,
+	})
+	manager := NewSeederManager()
+	manager.RegisterSeeder("before", func() error { return nil })
+	assert.NoError(t, manager.RegisterFixtureDir(dir))
+	manager.RegisterSeeder("after", func() error { return nil })
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "demo", "orders.json"), []byte(`[]`), 0o644))
+	assert.NoError(t, os.Remove(filepath.Join(dir, "countries.json")))
+	reload, err := manager.ReloadFixtures()
+
+	assert.NoError(t, err)
+	assert.Equal(t, &FixtureReload{Added: []string{"demo.orders"}, Removed: []string{"countries"}, Seeders: 4}, reload)
+	assert.Equal(t, []string{"before", "demo.orders", "demo.users", "after"}, manager.GetRegisteredSeeders(),
+		"reloaded seeders keep their place")
+	assert.False(t, manager.IsSeederRegistered("countries"))
+	assert.Equal(t, []string{"demo.orders", "demo.users"}, manager.GetSeedersByTag(TagDemo))
+
+	reload, err = manager.ReloadFixtures()
+	assert.NoError(t, err)
+	assert.Empty(t, reload.Added)
+	assert.Empty(t, reload.Removed)
+}
+
+// TestReloadFixturesErrors tests that a failed reload leaves the registry
+// unchanged
+func TestReloadFixturesErrors(t *testing.T) {
+	dir := writePackDir(t, map[string]string{"users.json": `[]`})
+	manager := NewSeederManager()
+	assert.NoError(t, manager.RegisterFixtureDir(dir))
+	manager.RegisterSeeder("orders", func() error { return nil })
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "orders.sql"), []byte(""), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "plans.json"), []byte(`[]`), 0o644))
+	_, err := manager.ReloadFixtures()
+
+	assert.ErrorContains(t, err, "failed to reload seeder 'orders': seeder with name 'orders' already exists")
+	assert.Equal(t, []string{"users", "orders"}, manager.GetRegisteredSeeders())
+
+	assert.NoError(t, os.RemoveAll(dir))
+	_, err = manager.ReloadFixtures()
+	assert.ErrorContains(t, err, "failed to read fixture directory")
+	assert.Equal(t, []string{"users", "orders"}, manager.GetRegisteredSeeders())
+}
+
+// TestReloadFixturesConcurrently tests reloading while other goroutines
+// read the registry
+func TestReloadFixturesConcurrently(t *testing.T) {
+	dir := writePackDir(t, map[string]string{"users.json": `[]`})
+	manager := NewSeederManager()
+	assert.NoError(t, manager.RegisterFixtureDir(dir))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				assert.True(t, manager.IsSeederRegistered("users"))
+				assert.NotEmpty(t, manager.GetRegisteredSeeders())
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		_, err := manager.ReloadFixtures()
+		assert.NoError(t, err)
+	}
+	wg.Wait()
+}
+
+// TestReloadHandler tests the HTTP endpoint
+func TestReloadHandler(t *testing.T) {
+	dir := writePackDir(t, map[string]string{"users.json": `[]`})
+	manager := NewSeederManager()
+	assert.NoError(t, manager.RegisterFixtureDir(dir))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "plans.json"), []byte(`[]`), 0o644))
+	handler := manager.ReloadHandler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/reload", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	assert.Equal(t, []string{"users"}, manager.GetRegisteredSeeders())
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/reload", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var reload FixtureReload
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &reload))
+	assert.Equal(t, FixtureReload{Added: []string{"plans"}, Removed: []string{}, Seeders: 2}, reload)
+
+	assert.NoError(t, os.RemoveAll(dir))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/reload", nil))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
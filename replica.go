@@ -0,0 +1,123 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultReplicaTimeout is how long ValidateContext waits for replicas when
+// ReplicaOptions.Timeout is zero
+const defaultReplicaTimeout = time.Minute
+
+// ReplicaCheck tells whether a replica has replayed everything written to
+// the primary, by comparing replication positions such as Postgres LSNs or
+// MySQL GTID sets
+type ReplicaCheck struct {
+	Name          string // Kind of position, used in messages, e.g. "LSN"
+	PositionQuery string // Run on the primary, returns its current position
+	CaughtUpQuery string // Run on a replica with the position as only argument, returns true once it is replayed
+}
+
+// Built-in replica checks
+var (
+	// PostgresLSNCheck compares the primary's current WAL position with
+	// the position a replica has replayed
+	PostgresLSNCheck = ReplicaCheck{
+		Name:          "LSN",
+		PositionQuery: "SELECT pg_current_wal_lsn()::text",
+		CaughtUpQuery: "SELECT pg_last_wal_replay_lsn() >= $1::pg_lsn",
+	}
+	// MySQLGTIDCheck checks that the primary's executed GTID set is part of
+	// a replica's
+	MySQLGTIDCheck = ReplicaCheck{
+		Name:          "GTID",
+		PositionQuery: "SELECT @@GLOBAL.gtid_executed",
+		CaughtUpQuery: "SELECT GTID_SUBSET(?, @@GLOBAL.gtid_executed)",
+	}
+)
+
+// ReplicaOptions configures read-replica awareness, see SetReplicas
+type ReplicaOptions struct {
+	ForcePrimary bool          // Mark seeder contexts PrimaryOnly, so seeders write and read on the primary
+	Primary      *sql.DB       // Primary whose position replicas must reach
+	Replicas     []*sql.DB     // Waited for before ValidateContext runs its validators
+	Check        ReplicaCheck  // How positions are compared, e.g. PostgresLSNCheck
+	Timeout      time.Duration // Maximum wait, default 1 minute
+}
+
+// SetReplicas makes runs aware of read replicas. With ForcePrimary, seeders
+// see PrimaryOnly(ctx) and should send all their traffic to the primary,
+// e.g. with GORM's dbresolver.Write clause. With Replicas, ValidateContext
+// (and so the validate and verify commands) first waits until every
+// replica has caught up with the primary, so checks do not read stale data.
+func (sm *SeederManager) SetReplicas(options ReplicaOptions) {
+	if options.Timeout <= 0 {
+		options.Timeout = defaultReplicaTimeout
+	}
+	sm.replicas = &options
+}
+
+// ContextWithPrimaryOnly returns a copy of ctx telling seeders to use the
+// primary connection only
+func ContextWithPrimaryOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryOnlyKey, true)
+}
+
+// PrimaryOnly reports whether seeders must send all their traffic, reads
+// included, to the primary connection
+func PrimaryOnly(ctx context.Context) bool {
+	primaryOnly, _ := ctx.Value(primaryOnlyKey).(bool)
+	return primaryOnly
+}
+
+// WaitForReplicas blocks until every replica has replayed the position the
+// primary has when it is called, or timeout elapses (ErrWaitTimeout)
+func WaitForReplicas(ctx context.Context, primary *sql.DB, replicas []*sql.DB, check ReplicaCheck, timeout time.Duration) error {
+	if len(replicas) == 0 {
+		return nil
+	}
+	var position string
+	if err := primary.QueryRowContext(ctx, check.PositionQuery).Scan(&position); err != nil {
+		return fmt.Errorf("failed to read the primary's %s: %w", check.Name, err)
+	}
+
+	for i, replica := range replicas {
+		target := fmt.Sprintf("replica %d to reach %s %s", i+1, check.Name, position)
+		err := waitUntil(ctx, target, timeout, func(ctx context.Context) error {
+			var caughtUp any
+			if err := replica.QueryRowContext(ctx, check.CaughtUpQuery, position).Scan(&caughtUp); err != nil {
+				return err
+			}
+			if !isTrue(caughtUp) {
+				return fmt.Errorf("replica is behind")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForReplicas waits for the replicas set with SetReplicas, if any
+func (sm *SeederManager) waitForReplicas(ctx context.Context) error {
+	if sm.replicas == nil || len(sm.replicas.Replicas) == 0 {
+		return nil
+	}
+	options := sm.replicas
+	return WaitForReplicas(ctx, options.Primary, options.Replicas, options.Check, options.Timeout)
+}
+
+// isTrue reports whether a scanned value is true, as databases return
+// booleans as bool, 1 or "t"
+func isTrue(value any) bool {
+	switch strings.ToLower(normalizeComparable(value)) {
+	case "1", "t", "true":
+		return true
+	}
+	return false
+}
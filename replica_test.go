@@ -0,0 +1,79 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWaitForReplicas tests waiting for replicas to replay the primary's position
+func TestWaitForReplicas(t *testing.T) {
+	fastWaitPolling(t)
+	primary, primaryFake := newFakeDB()
+	replica, replicaFake := newFakeDB()
+	primaryFake.On(PostgresLSNCheck.PositionQuery, []string{"lsn"}, []driver.Value{"0/3000060"})
+	replicaFake.On(PostgresLSNCheck.CaughtUpQuery, []string{"caught_up"}, []driver.Value{false})
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		replicaFake.On(PostgresLSNCheck.CaughtUpQuery, []string{"caught_up"}, []driver.Value{true})
+	}()
+	assert.NoError(t, WaitForReplicas(context.Background(), primary, []*sql.DB{replica}, PostgresLSNCheck, time.Second))
+	assert.Contains(t, replicaFake.Events(), "SELECT pg_last_wal_replay_lsn() >= $1::pg_lsn [0/3000060]")
+
+	other, otherFake := newFakeDB()
+	otherFake.On(PostgresLSNCheck.CaughtUpQuery, []string{"caught_up"}, []driver.Value{[]byte("f")})
+	err := WaitForReplicas(context.Background(), primary, []*sql.DB{replica, other}, PostgresLSNCheck, 20*time.Millisecond)
+	assert.ErrorIs(t, err, ErrWaitTimeout)
+	assert.ErrorContains(t, err, "replica 2 to reach LSN 0/3000060")
+
+	primaryFake.Fail(PostgresLSNCheck.PositionQuery, errors.New("connection refused"))
+	err = WaitForReplicas(context.Background(), primary, []*sql.DB{replica}, PostgresLSNCheck, time.Second)
+	assert.ErrorContains(t, err, "failed to read the primary's LSN: connection refused")
+
+	assert.NoError(t, WaitForReplicas(context.Background(), nil, nil, MySQLGTIDCheck, time.Second), "nothing to wait for")
+}
+
+// TestSetReplicas tests forcing seeders to the primary and waiting before validation
+func TestSetReplicas(t *testing.T) {
+	fastWaitPolling(t)
+	primary, primaryFake := newFakeDB()
+	replica, replicaFake := newFakeDB()
+	primaryFake.On(MySQLGTIDCheck.PositionQuery, []string{"gtid"}, []driver.Value{[]byte("3e11fa47:1-5")})
+	replicaFake.On(MySQLGTIDCheck.CaughtUpQuery, []string{"subset"}, []driver.Value{int64(0)})
+
+	manager := NewSeederManager()
+	var primaryOnly []bool
+	manager.RegisterSeederContext("users", func(ctx context.Context) error {
+		primaryOnly = append(primaryOnly, PrimaryOnly(ctx))
+		return nil
+	})
+	var validated bool
+	manager.AddValidator("references", func(ctx context.Context) error {
+		validated = true
+		return nil
+	})
+
+	assert.NoError(t, manager.RunSeederByName("users"))
+	manager.SetReplicas(ReplicaOptions{ForcePrimary: true, Primary: primary, Replicas: []*sql.DB{replica}, Check: MySQLGTIDCheck, Timeout: 20 * time.Millisecond})
+	assert.NoError(t, manager.RunSeederByName("users"))
+	assert.Equal(t, []bool{false, true}, primaryOnly)
+
+	assert.NoError(t, manager.Validate(), "static checks read no replica")
+	err := manager.ValidateContext(context.Background())
+	assert.ErrorIs(t, err, ErrWaitTimeout)
+	assert.ErrorContains(t, err, "replicas did not catch up")
+	assert.False(t, validated, "validators do not read stale replicas")
+
+	replicaFake.On(MySQLGTIDCheck.CaughtUpQuery, []string{"subset"}, []driver.Value{int64(1)})
+	assert.NoError(t, manager.ValidateContext(context.Background()))
+	assert.True(t, validated)
+
+	manager.SetReplicas(ReplicaOptions{})
+	assert.Equal(t, defaultReplicaTimeout, manager.replicas.Timeout)
+}
@@ -0,0 +1,300 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RunReport summarizes a finished run
+type RunReport struct {
+	RunID       string           `json:"run_id"`
+	Operator    string           `json:"operator"`
+	StartedAt   time.Time        `json:"started_at"`
+	Duration    time.Duration    `json:"duration"`
+	Error       string           `json:"error,omitempty"`
+	Stopped     string           `json:"stopped,omitempty"` // Why the run ended early, e.g. "interrupt" or "interrupt (forced)"
+	Seeders     []SeederReport   `json:"seeders"`
+	Secrets     []Secret         `json:"secrets,omitempty"`     // Credentials created by seeders, masked when printed or saved
+	Sequences   map[string]int64 `json:"sequences,omitempty"`   // Last value of every sequence, continued by append runs
+	Regressions []Regression     `json:"regressions,omitempty"` // Compared with the previous report
+}
+
+// SeederReport is the outcome of one seeder in a run
+type SeederReport struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Rows     int64         `json:"rows"` // Reported by the seeder with ReportRows
+	Error    string        `json:"error,omitempty"`
+	Skipped  bool          `json:"skipped,omitempty"` // ShouldRun returned false, SkipIf true, or the seeder was already applied
+	Reason   string        `json:"reason,omitempty"`  // Why the seeder was skipped, e.g. the reason returned by SkipIf
+}
+
+// Regression flags a seeder that did notably worse than in the previous run
+type Regression struct {
+	Seeder  string `json:"seeder"`
+	Message string `json:"message"` // e.g. "3.1x slower (40ms -> 124ms)"
+}
+
+// RegressionThresholds configures what CompareReports flags
+type RegressionThresholds struct {
+	Slowdown    float64       // Flag seeders at least this many times slower, zero to disable
+	MinDuration time.Duration // Ignore slowdowns of seeders faster than this, which are mostly noise
+	RowDrop     float64       // Flag seeders inserting less than this fraction of the previous rows, zero to disable
+}
+
+// DefaultRegressionThresholds flags seeders that became 2x slower (when
+// taking at least 50ms) or inserted less than half as many rows
+var DefaultRegressionThresholds = RegressionThresholds{
+	Slowdown:    2,
+	MinDuration: 50 * time.Millisecond,
+	RowDrop:     0.5,
+}
+
+// ReportStore keeps run reports so a run can be compared with the previous
+// one across processes
+type ReportStore interface {
+	SaveReport(ctx context.Context, report *RunReport) error
+	// LastReport returns the most recently saved report, or nil if none
+	LastReport(ctx context.Context) (*RunReport, error)
+}
+
+// SetReportStore makes every run compare itself with the last report in
+// store, and save its own report there. Without a store, runs are compared
+// with the previous run of the same manager.
+func (sm *SeederManager) SetReportStore(store ReportStore) {
+	sm.reportStore = store
+}
+
+// LastRunReport returns the report of the manager's most recent run, or nil
+func (sm *SeederManager) LastRunReport() *RunReport {
+	sm.reportMu.Lock()
+	defer sm.reportMu.Unlock()
+	return sm.lastReport
+}
+
+// ReportRows adds n to the rows the current seeder reports as inserted,
+// which run reports compare between runs
+func ReportRows(ctx context.Context, n int64) {
+	state, ok := ctx.Value(runKey).(*runState)
+	name := SeederNameFromContext(ctx)
+	if !ok || name == "" {
+		return
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.rows[name] += n
+}
+
+// recordSeeder adds the outcome of a seeder to the run's report
+func recordSeeder(ctx context.Context, name string, duration time.Duration, err error) {
+	state, ok := ctx.Value(runKey).(*runState)
+	if !ok {
+		return
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	report := SeederReport{Name: name, Duration: duration, Rows: state.rows[name]}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	state.seeders = append(state.seeders, report)
+}
+
+// recordSkipped adds a seeder skipped by its ShouldRun or SkipIf predicate
+// to the run's report
+func recordSkipped(ctx context.Context, name, reason string) {
+	state, ok := ctx.Value(runKey).(*runState)
+	if !ok {
+		return
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.seeders = append(state.seeders, SeederReport{Name: name, Skipped: true, Reason: reason})
+}
+
+// finishReport builds the report of a finished run, compares it with the
+// previous one, logs the summary, saves it and posts it to the webhook
+func (sm *SeederManager) finishReport(ctx context.Context, state *runState, runErr error) {
+	state.mu.Lock()
+	report := &RunReport{
+		RunID:     state.info.ID,
+		Operator:  state.info.Operator,
+		StartedAt: state.info.StartedAt,
+		Duration:  sm.now().Sub(state.info.StartedAt),
+		Seeders:   append([]SeederReport{}, state.seeders...),
+		Secrets:   append([]Secret(nil), state.secrets...),
+	}
+	if len(state.sequences) > 0 {
+		report.Sequences = maps.Clone(state.sequences)
+	}
+	state.mu.Unlock()
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+	var stop *StopError
+	if errors.As(runErr, &stop) {
+		report.Stopped = stop.Signal.String()
+		if stop.Forced {
+			report.Stopped += " (forced)"
+		}
+	}
+
+	previous, err := sm.previousReport(ctx)
+	if err != nil {
+		logError(ctx, "Failed to load the previous run report: %v", err)
+		previous = sm.LastRunReport()
+	}
+	if previous != nil {
+		report.Regressions = CompareReports(previous, report, DefaultRegressionThresholds)
+	}
+
+	logReport(ctx, report, previous, secretsShown(ctx))
+
+	sm.reportMu.Lock()
+	sm.lastReport = report
+	sm.reportMu.Unlock()
+	if sm.reportStore != nil {
+		// Stores outlive the run, e.g. in CI caches, so secrets are never saved
+		if err := sm.reportStore.SaveReport(ctx, maskSecrets(report)); err != nil {
+			logError(ctx, "Failed to save run report: %v", err)
+		}
+	}
+	sm.sendWebhook(ctx, report)
+}
+
+// previousReport returns the last report of the ReportStore, or the
+// report of the manager's previous run without a store
+func (sm *SeederManager) previousReport(ctx context.Context) (*RunReport, error) {
+	if sm.reportStore == nil {
+		return sm.LastRunReport(), nil
+	}
+	return sm.reportStore.LastReport(ctx)
+}
+
+// CompareReports returns the seeders of current that regressed compared
+// with previous. Seeders that failed or were skipped in either run are not
+// compared.
+func CompareReports(previous, current *RunReport, thresholds RegressionThresholds) []Regression {
+	before := make(map[string]SeederReport, len(previous.Seeders))
+	for _, seeder := range previous.Seeders {
+		if seeder.Error == "" && !seeder.Skipped {
+			before[seeder.Name] = seeder
+		}
+	}
+
+	var regressions []Regression
+	for _, seeder := range current.Seeders {
+		old, ok := before[seeder.Name]
+		if !ok || seeder.Error != "" || seeder.Skipped {
+			continue
+		}
+
+		if thresholds.Slowdown > 0 && old.Duration > 0 && seeder.Duration >= thresholds.MinDuration {
+			if factor := float64(seeder.Duration) / float64(old.Duration); factor >= thresholds.Slowdown {
+				regressions = append(regressions, Regression{
+					Seeder:  seeder.Name,
+					Message: fmt.Sprintf("%.1fx slower (%s -> %s)", factor, old.Duration.Round(time.Millisecond), seeder.Duration.Round(time.Millisecond)),
+				})
+			}
+		}
+		if thresholds.RowDrop > 0 && old.Rows > 0 && float64(seeder.Rows) < float64(old.Rows)*thresholds.RowDrop {
+			regressions = append(regressions, Regression{
+				Seeder:  seeder.Name,
+				Message: fmt.Sprintf("inserted %d rows, previously %d", seeder.Rows, old.Rows),
+			})
+		}
+	}
+	return regressions
+}
+
+// logReport prints the run summary, its secrets, masked unless
+// showSecrets, and its regressions
+func logReport(ctx context.Context, report, previous *RunReport, showSecrets bool) {
+	logInfo(ctx, "Run %s summary: %d seeders in %s", report.RunID, len(report.Seeders), report.Duration.Round(time.Millisecond))
+	for _, seeder := range report.Seeders {
+		status := fmt.Sprintf("%d rows", seeder.Rows)
+		if seeder.Error != "" {
+			status = "failed"
+		} else if seeder.Skipped {
+			status = "skipped"
+			if seeder.Reason != "" {
+				status += ": " + seeder.Reason
+			}
+		}
+		logInfo(ctx, "  %-30s %10s  %s", seeder.Name, seeder.Duration.Round(time.Millisecond), status)
+	}
+	if report.Stopped != "" {
+		logInfo(ctx, "Stopped early by %s", report.Stopped)
+	}
+	if len(report.Secrets) > 0 {
+		if showSecrets {
+			logInfo(ctx, "Secrets:")
+		} else {
+			logInfo(ctx, "Secrets (masked, see -show-secrets):")
+		}
+		for _, secret := range report.Secrets {
+			value := secret.Value
+			if !showSecrets {
+				value = MaskSecret(value)
+			}
+			logInfo(ctx, "  %s: %s = %s", secret.Seeder, secret.Name, value)
+		}
+	}
+	if len(report.Regressions) > 0 {
+		logInfo(ctx, "Regressions compared with run %s:", previous.RunID)
+		for _, regression := range report.Regressions {
+			logInfo(ctx, "  %s: %s", regression.Seeder, regression.Message)
+		}
+	}
+}
+
+// FileReportStore keeps the last run report as JSON in a file, e.g. in a CI
+// cache directory
+type FileReportStore struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// NewFileReportStore creates a store keeping the last report in path
+func NewFileReportStore(path string) *FileReportStore {
+	return &FileReportStore{Path: path}
+}
+
+// SaveReport implements ReportStore. Secret values are masked.
+func (s *FileReportStore) SaveReport(ctx context.Context, report *RunReport) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.MarshalIndent(maskSecrets(report), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path, data, 0o644)
+}
+
+// LastReport implements ReportStore
+func (s *FileReportStore) LastReport(ctx context.Context) (*RunReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var report RunReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse run report '%s': %w", s.Path, err)
+	}
+	return &report, nil
+}
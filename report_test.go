@@ -0,0 +1,92 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunReport tests the report recorded for every run
+func TestRunReport(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeederContext("users", func(ctx context.Context) error {
+		ReportRows(ctx, 400)
+		ReportRows(ctx, 100)
+		return nil
+	})
+	manager.RegisterSeeder("orders", func() error { return errors.New("boom") })
+
+	assert.Nil(t, manager.LastRunReport())
+	assert.Error(t, manager.RunAllSeeders())
+
+	report := manager.LastRunReport()
+	assert.NotNil(t, report)
+	assert.NotEmpty(t, report.RunID)
+	assert.Equal(t, "seeder 'orders' failed: boom", report.Error)
+	assert.Len(t, report.Seeders, 2)
+	assert.Equal(t, "users", report.Seeders[0].Name)
+	assert.Equal(t, int64(500), report.Seeders[0].Rows)
+	assert.Empty(t, report.Seeders[0].Error)
+	assert.Equal(t, "boom", report.Seeders[1].Error)
+
+	ReportRows(context.Background(), 1) // Outside a run: ignored
+}
+
+// TestCompareReports tests regression detection between runs
+func TestCompareReports(t *testing.T) {
+	previous := &RunReport{Seeders: []SeederReport{
+		{Name: "users", Duration: 100 * time.Millisecond, Rows: 500},
+		{Name: "orders", Duration: 100 * time.Millisecond, Rows: 500},
+		{Name: "fast", Duration: time.Millisecond},
+		{Name: "flaky", Duration: time.Millisecond, Error: "boom"},
+	}}
+	current := &RunReport{Seeders: []SeederReport{
+		{Name: "users", Duration: 310 * time.Millisecond, Rows: 500},
+		{Name: "orders", Duration: 120 * time.Millisecond, Rows: 0},
+		{Name: "fast", Duration: 10 * time.Millisecond},
+		{Name: "flaky", Duration: time.Second},
+		{Name: "new", Duration: time.Second, Rows: 1},
+	}}
+
+	regressions := CompareReports(previous, current, DefaultRegressionThresholds)
+
+	assert.Equal(t, []Regression{
+		{Seeder: "users", Message: "3.1x slower (100ms -> 310ms)"},
+		{Seeder: "orders", Message: "inserted 0 rows, previously 500"},
+	}, regressions)
+	assert.Empty(t, CompareReports(previous, current, RegressionThresholds{}))
+}
+
+// TestReportStore tests comparing runs across managers through a store
+func TestReportStore(t *testing.T) {
+	store := NewFileReportStore(filepath.Join(t.TempDir(), "reports", "last-run.json"))
+	newManager := func(rows int64) *SeederManager {
+		manager := NewSeederManager()
+		manager.SetReportStore(store)
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			ReportRows(ctx, rows)
+			return nil
+		})
+		return manager
+	}
+
+	last, err := store.LastReport(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, last)
+
+	first := newManager(500)
+	assert.NoError(t, first.RunAllSeeders())
+	assert.Empty(t, first.LastRunReport().Regressions)
+
+	second := newManager(0)
+	assert.NoError(t, second.RunAllSeeders())
+	assert.Equal(t, []Regression{{Seeder: "users", Message: "inserted 0 rows, previously 500"}}, second.LastRunReport().Regressions)
+
+	last, err = store.LastReport(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, second.LastRunReport().RunID, last.RunID)
+}
@@ -0,0 +1,337 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetentionInfo tells seeders how to tag the rows they insert so expired
+// demo data can be pruned from long-lived shared environments
+type RetentionInfo struct {
+	RunID     string    // ID of the run that seeded the row
+	SeededAt  time.Time // Start of the run that seeded the row
+	ExpiresAt time.Time // SeededAt plus the configured TTL
+}
+
+// SetRetention sets the TTL of seeded data. Seeders read the resulting
+// expiry with RetentionFromContext and store it in a column or side table.
+func (sm *SeederManager) SetRetention(ttl time.Duration) {
+	sm.retention = ttl
+}
+
+// RetentionFromContext returns the retention info of the current run. ok is
+// false when no TTL is configured.
+func RetentionFromContext(ctx context.Context) (info RetentionInfo, ok bool) {
+	info, ok = ctx.Value(retentionKey).(RetentionInfo)
+	return info, ok
+}
+
+// PruneRequest describes which seeded rows a Pruner must delete
+type PruneRequest struct {
+	Now       time.Time     // Rows whose expiry is before Now are expired
+	OlderThan time.Duration // When positive, rows seeded before Now-OlderThan are removed too
+}
+
+// Cutoff returns the seeded-at time before which rows are removed, or the
+// zero time when OlderThan is not set
+func (pr PruneRequest) Cutoff() time.Time {
+	if pr.OlderThan <= 0 {
+		return time.Time{}
+	}
+	return pr.Now.Add(-pr.OlderThan)
+}
+
+// Pruner deletes expired seeded data and reports how many rows it removed
+type Pruner interface {
+	Prune(ctx context.Context, request PruneRequest) (int64, error)
+}
+
+// PrunerFunc adapts a function to the Pruner interface
+type PrunerFunc func(ctx context.Context, request PruneRequest) (int64, error)
+
+// Prune calls f(ctx, request)
+func (f PrunerFunc) Prune(ctx context.Context, request PruneRequest) (int64, error) {
+	return f(ctx, request)
+}
+
+// ParseAge parses a duration that, in addition to time.ParseDuration units,
+// accepts days ("7d") and weeks ("2w")
+func ParseAge(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, found := strings.CutSuffix(value, suffix); found {
+			n, err := strconv.ParseFloat(number, 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid age %q", value)
+			}
+			return time.Duration(n * float64(unit)), nil
+		}
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+	return duration, nil
+}
+
+// Default names used by SQLPruner
+const (
+	DefaultExpiresColumn  = "seed_expires_at"
+	DefaultSeededColumn   = "seed_seeded_at"
+	DefaultRetentionTable = "seed_retention"
+)
+
+// defaultPruneBatchSize is the number of row IDs per DELETE statement when
+// SQLPruner.BatchSize is zero, well below the parameter limits of drivers
+const defaultPruneBatchSize = 1000
+
+// SQLPruner is a Pruner deleting expired seeded rows with SQL. Rows are
+// found by expiry columns of the seeded tables, set with Stamp, or by tags
+// in a side table, recorded with Tag, or both:
+//
+//	-- Tables: rows carry their expiry and, for OlderThan, seeding time
+//	DELETE FROM orders WHERE seed_expires_at < $1 OR seed_seeded_at < $2
+//
+//	-- TagTable: tags name the table and ID of every seeded row, for tables
+//	-- without retention columns; created on first use
+//	CREATE TABLE IF NOT EXISTS seed_retention (
+//		table_name VARCHAR(255) NOT NULL,
+//		row_id VARCHAR(255) NOT NULL,
+//		run_id VARCHAR(64) NOT NULL,
+//		seeded_at TIMESTAMP NOT NULL,
+//		expires_at TIMESTAMP NOT NULL
+//	)
+type SQLPruner struct {
+	DB            *sql.DB
+	Placeholder   Placeholder // Defaults to DollarPlaceholder
+	Tables        []string    // Tables with retention columns, pruned in order
+	ExpiresColumn string      // Expiry column of Tables, defaults to DefaultExpiresColumn
+	SeededColumn  string      // Seeding time column of Tables, defaults to DefaultSeededColumn
+	TagTable      string      // Side table of tags, none when empty, see DefaultRetentionTable
+	IDColumn      string      // Column the row IDs of tags refer to, defaults to "id"
+	BatchSize     int         // Maximum row IDs per DELETE of tagged rows, default 1000
+
+	mu      sync.Mutex
+	created bool
+}
+
+// Stamp sets the retention columns of row from the retention info of the
+// current run. It does nothing when no TTL is configured, see SetRetention.
+func (p *SQLPruner) Stamp(ctx context.Context, row Record) {
+	info, ok := RetentionFromContext(ctx)
+	if !ok {
+		return
+	}
+	expires, seeded := p.columns()
+	row[expires] = info.ExpiresAt
+	row[seeded] = info.SeededAt
+}
+
+// Tag records the rows with the given IDs, inserted into table by the
+// current run, in TagTable. It does nothing when no TTL is configured.
+func (p *SQLPruner) Tag(ctx context.Context, table string, ids ...any) error {
+	info, ok := RetentionFromContext(ctx)
+	if !ok || len(ids) == 0 {
+		return nil
+	}
+	tags, err := p.tagTable(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	insert := fmt.Sprintf("INSERT INTO %s (table_name, row_id, run_id, seeded_at, expires_at) VALUES (%s, %s, %s, %s, %s)",
+		tags, p.placeholder(1), p.placeholder(2), p.placeholder(3), p.placeholder(4), p.placeholder(5))
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, insert, table, fmt.Sprint(id), info.RunID, info.SeededAt, info.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to tag %s row %v: %w", table, id, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Prune implements Pruner, deleting the expired rows of Tables and then
+// the rows of expired tags along with the tags
+func (p *SQLPruner) Prune(ctx context.Context, request PruneRequest) (int64, error) {
+	if len(p.Tables) == 0 && p.TagTable == "" {
+		return 0, fmt.Errorf("SQLPruner requires Tables or a TagTable")
+	}
+	var removed int64
+	for _, table := range p.Tables {
+		n, err := p.pruneTable(ctx, table, request)
+		if err != nil {
+			return removed, fmt.Errorf("failed to prune %s: %w", table, err)
+		}
+		removed += n
+	}
+	if p.TagTable != "" {
+		n, err := p.pruneTagged(ctx, request)
+		removed += n
+		if err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// expiredCondition returns the WHERE condition selecting expired rows by
+// the given columns, numbering placeholders from first, and its arguments
+func (p *SQLPruner) expiredCondition(expires, seeded string, first int, request PruneRequest) (string, []any) {
+	condition := expires + " < " + p.placeholder(first)
+	args := []any{request.Now}
+	if cutoff := request.Cutoff(); !cutoff.IsZero() {
+		condition += " OR " + seeded + " < " + p.placeholder(first+1)
+		args = append(args, cutoff)
+	}
+	return condition, args
+}
+
+// pruneTable deletes the expired rows of a table with retention columns
+func (p *SQLPruner) pruneTable(ctx context.Context, table string, request PruneRequest) (int64, error) {
+	expires, seeded := p.columns()
+	for _, name := range []string{table, expires, seeded} {
+		if !identifierPattern.MatchString(name) {
+			return 0, fmt.Errorf("invalid identifier %q", name)
+		}
+	}
+	condition, args := p.expiredCondition(expires, seeded, 1, request)
+	result, err := p.DB.ExecContext(ctx, "DELETE FROM "+table+" WHERE "+condition, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// pruneTagged deletes the rows of expired tags, at most BatchSize per
+// statement, and the tags in a transaction, so a failure leaves tags of
+// rows still present
+func (p *SQLPruner) pruneTagged(ctx context.Context, request PruneRequest) (int64, error) {
+	tags, err := p.tagTable(ctx)
+	if err != nil {
+		return 0, err
+	}
+	idColumn := p.IDColumn
+	if idColumn == "" {
+		idColumn = "id"
+	}
+	if !identifierPattern.MatchString(idColumn) {
+		return 0, fmt.Errorf("invalid identifier %q", idColumn)
+	}
+	condition, args := p.expiredCondition("expires_at", "seeded_at", 1, request)
+	expired, err := p.expiredTags(ctx, tags, condition, args)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	batchSize := p.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPruneBatchSize
+	}
+	var removed int64
+	for _, table := range slices.Sorted(maps.Keys(expired)) {
+		if !identifierPattern.MatchString(table) {
+			return 0, fmt.Errorf("tag table %s names invalid table %q", tags, table)
+		}
+		for ids := range slices.Chunk(expired[table], batchSize) {
+			marks := make([]string, len(ids))
+			for i := range ids {
+				marks[i] = p.placeholder(i + 1)
+			}
+			query := fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)", table, idColumn, strings.Join(marks, ", "))
+			result, err := tx.ExecContext(ctx, query, ids...)
+			if err != nil {
+				return 0, fmt.Errorf("failed to prune %s: %w", table, err)
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return 0, err
+			}
+			removed += n
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+tags+" WHERE "+condition, args...); err != nil {
+		return 0, fmt.Errorf("failed to remove expired tags: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// expiredTags returns the row IDs of expired tags by table
+func (p *SQLPruner) expiredTags(ctx context.Context, tags, condition string, args []any) (map[string][]any, error) {
+	rows, err := p.DB.QueryContext(ctx, "SELECT table_name, row_id FROM "+tags+" WHERE "+condition, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expired tags: %w", err)
+	}
+	defer rows.Close()
+
+	expired := make(map[string][]any)
+	for rows.Next() {
+		var table, id string
+		if err := rows.Scan(&table, &id); err != nil {
+			return nil, err
+		}
+		expired[table] = append(expired[table], id)
+	}
+	return expired, rows.Err()
+}
+
+// tagTable returns the name of the tag table, creating it on first use
+func (p *SQLPruner) tagTable(ctx context.Context) (string, error) {
+	table := p.TagTable
+	if table == "" {
+		return "", fmt.Errorf("tagging rows requires a TagTable")
+	}
+	if !identifierPattern.MatchString(table) {
+		return "", fmt.Errorf("invalid identifier %q", table)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.created {
+		query := "CREATE TABLE IF NOT EXISTS " + table + " (table_name VARCHAR(255) NOT NULL, row_id VARCHAR(255) NOT NULL, " +
+			"run_id VARCHAR(64) NOT NULL, seeded_at TIMESTAMP NOT NULL, expires_at TIMESTAMP NOT NULL)"
+		if _, err := p.DB.ExecContext(ctx, query); err != nil {
+			return "", fmt.Errorf("failed to create table %s: %w", table, err)
+		}
+		p.created = true
+	}
+	return table, nil
+}
+
+// columns returns the retention columns of Tables
+func (p *SQLPruner) columns() (expires, seeded string) {
+	expires, seeded = p.ExpiresColumn, p.SeededColumn
+	if expires == "" {
+		expires = DefaultExpiresColumn
+	}
+	if seeded == "" {
+		seeded = DefaultSeededColumn
+	}
+	return expires, seeded
+}
+
+// placeholder returns the n-th bind parameter
+func (p *SQLPruner) placeholder(n int) string {
+	if p.Placeholder == nil {
+		return DollarPlaceholder(n)
+	}
+	return p.Placeholder(n)
+}
@@ -0,0 +1,224 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSetRetention tests TTL propagation to seeders
+func TestSetRetention(t *testing.T) {
+	t.Run("Seeders see expiry", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.SetRetention(7 * 24 * time.Hour)
+		infos := []RetentionInfo{}
+
+		record := func(ctx context.Context) error {
+			info, ok := RetentionFromContext(ctx)
+			assert.True(t, ok)
+			infos = append(infos, info)
+			return nil
+		}
+		manager.RegisterSeederContext("users", record)
+		manager.RegisterSeederContext("orders", record)
+
+		assert.NoError(t, manager.RunAllSeeders())
+		assert.Len(t, infos, 2)
+		assert.Equal(t, infos[0], infos[1], "seeders of one run share the same expiry")
+		assert.Equal(t, 7*24*time.Hour, infos[0].ExpiresAt.Sub(infos[0].SeededAt))
+	})
+
+	t.Run("No retention by default", func(t *testing.T) {
+		manager := NewSeederManager()
+		var ok bool
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			_, ok = RetentionFromContext(ctx)
+			return nil
+		})
+
+		assert.NoError(t, manager.RunSeederByName("users"))
+		assert.False(t, ok)
+	})
+}
+
+// TestParseAge tests age parsing with day and week units
+func TestParseAge(t *testing.T) {
+	cases := map[string]time.Duration{
+		"7d":   7 * 24 * time.Hour,
+		"1.5d": 36 * time.Hour,
+		"2w":   14 * 24 * time.Hour,
+		"12h":  12 * time.Hour,
+		"90m":  90 * time.Minute,
+	}
+	for input, expected := range cases {
+		age, err := ParseAge(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, age, input)
+	}
+
+	for _, input := range []string{"", "d", "-1d", "abc", "-5h"} {
+		_, err := ParseAge(input)
+		assert.Error(t, err, input)
+	}
+}
+
+// TestPruneRequestCutoff tests the cutoff calculation
+func TestPruneRequestCutoff(t *testing.T) {
+	now := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, PruneRequest{Now: now}.Cutoff().IsZero())
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), PruneRequest{Now: now, OlderThan: 7 * 24 * time.Hour}.Cutoff())
+}
+
+// TestCLIPruneCommand tests the prune command
+func TestCLIPruneCommand(t *testing.T) {
+	t.Run("Passes age to pruner", func(t *testing.T) {
+		var request PruneRequest
+		cli := NewCLI(NewSeederManager())
+		cli.SetPruner(PrunerFunc(func(ctx context.Context, r PruneRequest) (int64, error) {
+			request = r
+			return 3, nil
+		}))
+
+		err := cli.runCommand(context.Background(), []string{"prune", "-older-than=7d"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7*24*time.Hour, request.OlderThan)
+		assert.False(t, request.Now.IsZero())
+	})
+
+	t.Run("Expires by the manager clock", func(t *testing.T) {
+		now := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+		manager := NewSeederManager()
+		manager.SetClock(fixedClock(now))
+		var request PruneRequest
+		cli := NewCLI(manager)
+		cli.SetPruner(PrunerFunc(func(ctx context.Context, r PruneRequest) (int64, error) {
+			request = r
+			return 0, nil
+		}))
+
+		require.NoError(t, cli.runCommand(context.Background(), []string{"prune", "-older-than=7d"}))
+
+		assert.Equal(t, now, request.Now)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), request.Cutoff())
+	})
+
+	t.Run("Requires pruner", func(t *testing.T) {
+		err := NewCLI(NewSeederManager()).runCommand(context.Background(), []string{"prune"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "SetPruner")
+	})
+
+	t.Run("Invalid age and pruner error", func(t *testing.T) {
+		cli := NewCLI(NewSeederManager())
+		cli.SetPruner(PrunerFunc(func(ctx context.Context, r PruneRequest) (int64, error) {
+			return 0, errors.New("locked")
+		}))
+
+		assert.Error(t, cli.runCommand(context.Background(), []string{"prune", "-older-than=soon"}))
+		assert.Error(t, cli.runCommand(context.Background(), []string{"prune"}))
+	})
+}
+
+// TestSQLPruner tests pruning by retention columns and by tags
+func TestSQLPruner(t *testing.T) {
+	now := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+	cutoff := now.Add(-7 * 24 * time.Hour)
+
+	t.Run("Deletes expired rows of tables", func(t *testing.T) {
+		db, fake := newFakeDB()
+		pruner := &SQLPruner{DB: db, Placeholder: QuestionPlaceholder, Tables: []string{"order_items", "orders"}}
+
+		removed, err := pruner.Prune(context.Background(), PruneRequest{Now: now, OlderThan: 7 * 24 * time.Hour})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), removed)
+		assert.Equal(t, []string{
+			fmt.Sprintf("DELETE FROM order_items WHERE seed_expires_at < ? OR seed_seeded_at < ? [%v %v]", now, cutoff),
+			fmt.Sprintf("DELETE FROM orders WHERE seed_expires_at < ? OR seed_seeded_at < ? [%v %v]", now, cutoff),
+		}, fake.Events())
+	})
+
+	t.Run("Deletes rows of expired tags", func(t *testing.T) {
+		db, fake := newFakeDB()
+		pruner := &SQLPruner{DB: db, Placeholder: QuestionPlaceholder, TagTable: DefaultRetentionTable}
+		fake.On("SELECT table_name, row_id FROM seed_retention WHERE expires_at < ?", []string{"table_name", "row_id"},
+			[]driver.Value{"users", "7"}, []driver.Value{"orders", "3"}, []driver.Value{"users", "9"})
+
+		removed, err := pruner.Prune(context.Background(), PruneRequest{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), removed, "one statement per table")
+		events := fake.Events()
+		assert.Equal(t, []string{
+			"BEGIN",
+			"DELETE FROM orders WHERE id IN (?) [3]",
+			"DELETE FROM users WHERE id IN (?, ?) [7 9]",
+			fmt.Sprintf("DELETE FROM seed_retention WHERE expires_at < ? [%v]", now),
+			"COMMIT",
+		}, events[2:])
+	})
+
+	t.Run("Deletes tagged rows in batches", func(t *testing.T) {
+		db, fake := newFakeDB()
+		pruner := &SQLPruner{DB: db, Placeholder: QuestionPlaceholder, TagTable: DefaultRetentionTable, BatchSize: 2}
+		fake.On("SELECT table_name, row_id FROM seed_retention WHERE expires_at < ?", []string{"table_name", "row_id"},
+			[]driver.Value{"users", "1"}, []driver.Value{"users", "2"}, []driver.Value{"users", "3"})
+
+		removed, err := pruner.Prune(context.Background(), PruneRequest{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), removed)
+		assert.Equal(t, []string{
+			"BEGIN",
+			"DELETE FROM users WHERE id IN (?, ?) [1 2]",
+			"DELETE FROM users WHERE id IN (?) [3]",
+			fmt.Sprintf("DELETE FROM seed_retention WHERE expires_at < ? [%v]", now),
+			"COMMIT",
+		}, fake.Events()[2:])
+	})
+
+	t.Run("Stamps and tags rows of a run", func(t *testing.T) {
+		db, fake := newFakeDB()
+		pruner := &SQLPruner{DB: db, Placeholder: QuestionPlaceholder, TagTable: "tags", ExpiresColumn: "expires_at"}
+		info := RetentionInfo{RunID: "run-1", SeededAt: cutoff, ExpiresAt: now}
+		ctx := context.WithValue(context.Background(), retentionKey, info)
+
+		row := Record{"id": 1}
+		pruner.Stamp(ctx, row)
+		require.NoError(t, pruner.Tag(ctx, "users", 1))
+
+		assert.Equal(t, Record{"id": 1, "expires_at": now, "seed_seeded_at": cutoff}, row)
+		assert.Equal(t, fmt.Sprintf("INSERT INTO tags (table_name, row_id, run_id, seeded_at, expires_at) VALUES (?, ?, ?, ?, ?) [users 1 run-1 %v %v]",
+			cutoff, now), fake.Events()[2])
+	})
+
+	t.Run("Does nothing without retention", func(t *testing.T) {
+		db, fake := newFakeDB()
+		pruner := &SQLPruner{DB: db, TagTable: "tags"}
+		row := Record{"id": 1}
+
+		pruner.Stamp(context.Background(), row)
+		require.NoError(t, pruner.Tag(context.Background(), "users", 1))
+
+		assert.Equal(t, Record{"id": 1}, row)
+		assert.Empty(t, fake.Events())
+	})
+
+	t.Run("Rejects invalid configurations", func(t *testing.T) {
+		db, _ := newFakeDB()
+
+		_, err := (&SQLPruner{DB: db}).Prune(context.Background(), PruneRequest{Now: now})
+		assert.ErrorContains(t, err, "requires Tables or a TagTable")
+		_, err = (&SQLPruner{DB: db, Tables: []string{"orders; DROP TABLE users"}}).Prune(context.Background(), PruneRequest{Now: now})
+		assert.ErrorContains(t, err, "invalid identifier")
+	})
+}
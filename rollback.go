@@ -0,0 +1,71 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+)
+
+// TagReversible marks seeders whose data must be removable, e.g. demo data
+// torn down after a review; validation fails when one has no Rollback
+const TagReversible = "reversible"
+
+// RollbackSeeder undoes the data of a seeder with its Rollback function,
+// e.g. deleting the demo users it created
+func (sm *SeederManager) RollbackSeeder(name string) error {
+	return sm.RollbackSeederContext(context.Background(), name)
+}
+
+// RollbackSeederContext undoes the data of a seeder with the given context
+func (sm *SeederManager) RollbackSeederContext(ctx context.Context, name string) error {
+	seeder, exists := sm.lookupSeeder(name)
+	if !exists {
+		return fmt.Errorf("seeder with name '%s' not found%s", name, didYouMean(name, sm.GetRegisteredSeeders()))
+	}
+	if seeder.Rollback == nil {
+		return fmt.Errorf("seeder '%s' has no rollback", seeder.Name)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return sm.rollbackSeeder(ctx, seeder)
+}
+
+// RollbackAll undoes the data of every seeder with a Rollback function, in
+// the reverse of the order they run in, priority included, so data is
+// removed before the data it depends on
+func (sm *SeederManager) RollbackAll() error {
+	return sm.RollbackAllContext(context.Background())
+}
+
+// RollbackAllContext undoes the data of every seeder with a Rollback
+// function, stopping before the next seeder once ctx is canceled
+func (sm *SeederManager) RollbackAllContext(ctx context.Context) error {
+	seeders, _ := sm.registry()
+	for i := len(seeders) - 1; i >= 0; i-- {
+		if seeders[i].Rollback == nil {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := sm.rollbackSeeder(ctx, seeders[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollbackSeeder runs the Rollback function of a seeder with logging and
+// error wrapping, and removes the seeder from the history so it runs again
+func (sm *SeederManager) rollbackSeeder(ctx context.Context, seeder SeederItem) error {
+	ctx = sm.withLogger(ctx)
+	logInfo(ctx, "Rolling back seeder: %s", seeder.Name)
+	if err := seeder.Rollback(); err != nil {
+		return fmt.Errorf("rollback of seeder '%s' failed: %w", seeder.Name, err)
+	}
+	if err := sm.forgetHistory(ctx, seeder.Name); err != nil {
+		return err
+	}
+	logInfo(ctx, "Seeder '%s' rolled back successfully", seeder.Name)
+	return nil
+}
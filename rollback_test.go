@@ -0,0 +1,91 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newRollbackManager registers seeders recording their rollbacks in undone,
+// "settings" having no rollback
+func newRollbackManager(undone *[]string) *SeederManager {
+	manager := NewSeederManager()
+	seeder := func(name string) SeederItem {
+		return SeederItem{
+			Name:     name,
+			Function: func() error { return nil },
+			Rollback: func() error {
+				*undone = append(*undone, name)
+				return nil
+			},
+		}
+	}
+	manager.RegisterSeeders(
+		seeder("roles"),
+		SeederItem{Name: "settings", Function: func() error { return nil }},
+		seeder("users"),
+	)
+	return manager
+}
+
+// TestRollbackSeeder tests rolling back a single seeder
+func TestRollbackSeeder(t *testing.T) {
+	undone := []string{}
+	manager := newRollbackManager(&undone)
+
+	assert.NoError(t, manager.RollbackSeeder("users"))
+	assert.Equal(t, []string{"users"}, undone)
+
+	assert.ErrorContains(t, manager.RollbackSeeder("settings"), "seeder 'settings' has no rollback")
+	assert.ErrorContains(t, manager.RollbackSeeder("user"), "seeder with name 'user' not found, did you mean 'users'?")
+
+	manager.RegisterSeeders(SeederItem{
+		Name:     "orders",
+		Function: func() error { return nil },
+		Rollback: func() error { return errors.New("locked") },
+	})
+	err := manager.RollbackSeeder("orders")
+	assert.EqualError(t, err, "rollback of seeder 'orders' failed: locked")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, manager.RollbackSeederContext(ctx, "users"), context.Canceled)
+}
+
+// TestRollbackAll tests rolling back every seeder in reverse order
+func TestRollbackAll(t *testing.T) {
+	undone := []string{}
+	manager := newRollbackManager(&undone)
+
+	assert.NoError(t, manager.RollbackAll())
+	assert.Equal(t, []string{"users", "roles"}, undone, "seeders without a rollback are skipped")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, manager.RollbackAllContext(ctx), context.Canceled)
+}
+
+// TestCLIRollback tests the rollback command and -type=rollback:<name>
+func TestCLIRollback(t *testing.T) {
+	undone := []string{}
+	cli := NewCLI(newRollbackManager(&undone))
+	var out bytes.Buffer
+	cli.SetOutput(&out)
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"rollback", "roles"}))
+	assert.Equal(t, []string{"roles"}, undone)
+	assert.Contains(t, out.String(), "Rolling back seeder: roles")
+
+	undone = undone[:0]
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"rollback", "-all"}))
+	assert.Equal(t, []string{"users", "roles"}, undone)
+
+	assert.ErrorIs(t, cli.runCommand(context.Background(), []string{"rollback"}), ErrUsage)
+	assert.ErrorIs(t, cli.runCommand(context.Background(), []string{"rollback", "-all", "users"}), ErrUsage)
+	err := cli.runCommand(context.Background(), []string{"rollback", "usrs"})
+	assert.ErrorIs(t, err, ErrUsage)
+	assert.ErrorContains(t, err, "did you mean 'users'?")
+}
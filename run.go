@@ -0,0 +1,183 @@
+package goseeder
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// RunInfo identifies a single execution of the manager, e.g. one
+// RunAllSeeders call, so seeded rows can be traced back to it
+type RunInfo struct {
+	ID        string
+	Operator  string // Who started the run, see SetOperator
+	StartedAt time.Time
+}
+
+// runState holds the values shared by every seeder of a single run
+type runState struct {
+	info RunInfo
+
+	mu      sync.Mutex
+	seeders []SeederReport   // Outcomes so far, for the run report
+	rows    map[string]int64 // Rows reported per seeder, see ReportRows
+	secrets []Secret         // Credentials reported with ReportSecret
+
+	sequences map[string]int64 // Last value of every sequence, see ReserveSequence
+
+	statements     []string // Last statements, see LogStatement
+	statementLimit int      // Zero when statements are not recorded
+
+	progress runProgress // Seeders done, for the ProgressReporter
+}
+
+// SetOperator sets the operator recorded in RunInfo. By default it is taken
+// from the SEEDER_OPERATOR, USER or USERNAME environment variables.
+func (sm *SeederManager) SetOperator(operator string) {
+	sm.operator = operator
+}
+
+// SetProvenanceColumn sets the column seeders stamp with the run ID, see
+// StampProvenance
+func (sm *SeederManager) SetProvenanceColumn(column string) {
+	sm.provenanceColumn = column
+}
+
+// RunInfoFromContext returns the info of the run ctx belongs to
+func RunInfoFromContext(ctx context.Context) (RunInfo, bool) {
+	state, ok := ctx.Value(runKey).(*runState)
+	if !ok {
+		return RunInfo{}, false
+	}
+	return state.info, true
+}
+
+// RunIDFromContext returns the ID of the run ctx belongs to, or ""
+func RunIDFromContext(ctx context.Context) string {
+	info, _ := RunInfoFromContext(ctx)
+	return info.ID
+}
+
+// StampProvenance sets the configured provenance column of row to the run
+// ID. It does nothing when no column is configured or ctx is not part of a run.
+func StampProvenance(ctx context.Context, row map[string]any) {
+	column, _ := ctx.Value(provenanceColumnKey).(string)
+	runID := RunIDFromContext(ctx)
+	if column == "" || runID == "" {
+		return
+	}
+	row[column] = runID
+}
+
+// beginRun attaches the run state to ctx unless an enclosing run already did,
+// so nested calls such as RunSeedersInOrderContext -> RunSeederByNameContext
+// share a single run. The returned function must be called with the run's
+// result when the run ends; it returns the final result.
+func (sm *SeederManager) beginRun(ctx context.Context) (context.Context, func(error) error, error) {
+	ctx = sm.withLogger(ctx)
+	if _, ok := ctx.Value(runKey).(*runState); ok {
+		return ctx, func(err error) error { return err }, nil
+	}
+
+	started := sm.now()
+	state := &runState{
+		info: RunInfo{
+			ID:        newRunID(started),
+			Operator:  sm.runOperator(),
+			StartedAt: started,
+		},
+		rows:      make(map[string]int64),
+		sequences: make(map[string]int64),
+		progress:  runProgress{reporter: sm.progressReporter},
+	}
+	if sm.triage != nil {
+		state.statementLimit = sm.triage.Statements
+	}
+	ctx = ContextWithSeeding(context.WithValue(ctx, runKey, state))
+	logInfo(ctx, "Starting seed run %s (operator: %s)", state.info.ID, state.info.Operator)
+	if appendMode(ctx) {
+		if err := sm.continueSequences(ctx, state); err != nil {
+			return nil, nil, err
+		}
+	}
+	if sm.provenanceColumn != "" {
+		ctx = context.WithValue(ctx, provenanceColumnKey, sm.provenanceColumn)
+	}
+	if sm.completionStore != nil {
+		ctx = context.WithValue(ctx, completionStoreKey, sm.completionStore)
+	}
+	if sm.retention > 0 {
+		ctx = context.WithValue(ctx, retentionKey, RetentionInfo{
+			RunID:     state.info.ID,
+			SeededAt:  state.info.StartedAt,
+			ExpiresAt: state.info.StartedAt.Add(sm.retention),
+		})
+	}
+	if sm.featureFlags != nil {
+		ctx = context.WithValue(ctx, featureFlagsKey, sm.featureFlags)
+	}
+	if sm.passwordHasher != nil {
+		ctx = context.WithValue(ctx, passwordHasherKey, sm.passwordHasher)
+	}
+	if sm.clock != nil {
+		ctx = context.WithValue(ctx, clockKey, sm.clock)
+	}
+	if sm.quota != nil && ctx.Value(quotaKey) == nil {
+		ctx = ContextWithQuota(ctx, *sm.quota)
+	}
+	if sm.outboxMode != OutboxPublish {
+		ctx = context.WithValue(ctx, outboxModeKey, sm.outboxMode)
+	}
+
+	if sm.outboxPauser != nil {
+		if err := sm.outboxPauser.Pause(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to pause outbox: %w", err)
+		}
+	}
+
+	end := func(err error) error {
+		var stop *StopError
+		if err != nil && !errors.As(err, &stop) && errors.As(stopCause(ctx), &stop) {
+			err = fmt.Errorf("%w: %w", stop, err)
+		}
+		sm.finishReport(context.WithoutCancel(ctx), state, err)
+		sm.finishTriage(context.WithoutCancel(ctx), state, err)
+		if sm.outboxPauser != nil {
+			if resumeErr := sm.outboxPauser.Resume(context.WithoutCancel(ctx)); resumeErr != nil {
+				resumeErr = fmt.Errorf("failed to resume outbox: %w", resumeErr)
+				if err == nil {
+					return resumeErr
+				}
+				logError(ctx, "%v", resumeErr)
+			}
+		}
+		return err
+	}
+	return ctx, end, nil
+}
+
+// runOperator returns the configured operator or one derived from the environment
+func (sm *SeederManager) runOperator() string {
+	if sm.operator != "" {
+		return sm.operator
+	}
+	for _, key := range []string{"SEEDER_OPERATOR", "USER", "USERNAME"} {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return "unknown"
+}
+
+// newRunID returns a sortable, unique run ID such as "20250102T150405-1a2b3c4d"
+// for a run started at started
+func newRunID(started time.Time) string {
+	random := make([]byte, 4)
+	rand.Read(random)
+	return started.UTC().Format("20060102T150405") + "-" + hex.EncodeToString(random)
+}
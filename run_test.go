@@ -0,0 +1,116 @@
+package goseeder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunInfo tests run ID generation and propagation
+func TestRunInfo(t *testing.T) {
+	t.Run("Seeders of one run share the run ID", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.SetOperator("alice")
+		infos := []RunInfo{}
+
+		record := func(ctx context.Context) error {
+			info, ok := RunInfoFromContext(ctx)
+			assert.True(t, ok)
+			infos = append(infos, info)
+			return nil
+		}
+		manager.RegisterSeederContext("users", record)
+		manager.RegisterSeederContext("orders", record)
+
+		assert.NoError(t, manager.RunAllSeeders())
+		assert.NoError(t, manager.RunSeedersInOrder([]string{"users", "orders"}))
+
+		assert.Len(t, infos, 4)
+		assert.Equal(t, infos[0].ID, infos[1].ID)
+		assert.Equal(t, infos[2].ID, infos[3].ID)
+		assert.NotEqual(t, infos[0].ID, infos[2].ID)
+		assert.Equal(t, "alice", infos[0].Operator)
+		assert.False(t, infos[0].StartedAt.IsZero())
+	})
+
+	t.Run("Operator from environment", func(t *testing.T) {
+		t.Setenv("SEEDER_OPERATOR", "ci-bot")
+		manager := NewSeederManager()
+		var operator string
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			info, _ := RunInfoFromContext(ctx)
+			operator = info.Operator
+			return nil
+		})
+
+		assert.NoError(t, manager.RunSeederByName("users"))
+		assert.Equal(t, "ci-bot", operator)
+	})
+
+	t.Run("Retention carries the run ID", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.SetRetention(1)
+		var runID, retentionRunID string
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			runID = RunIDFromContext(ctx)
+			info, _ := RetentionFromContext(ctx)
+			retentionRunID = info.RunID
+			return nil
+		})
+
+		assert.NoError(t, manager.RunSeederByName("users"))
+		assert.NotEmpty(t, runID)
+		assert.Equal(t, runID, retentionRunID)
+	})
+
+	t.Run("Outside of a run", func(t *testing.T) {
+		_, ok := RunInfoFromContext(context.Background())
+
+		assert.False(t, ok)
+		assert.Equal(t, "", RunIDFromContext(context.Background()))
+	})
+}
+
+// TestStampProvenance tests stamping rows with the run ID
+func TestStampProvenance(t *testing.T) {
+	t.Run("Stamps configured column", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.SetProvenanceColumn("seed_run_id")
+		row := map[string]any{"name": "Alice"}
+		var runID string
+
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			StampProvenance(ctx, row)
+			runID = RunIDFromContext(ctx)
+			return nil
+		})
+
+		assert.NoError(t, manager.RunSeederByName("users"))
+		assert.Equal(t, runID, row["seed_run_id"])
+	})
+
+	t.Run("No column configured", func(t *testing.T) {
+		manager := NewSeederManager()
+		row := map[string]any{}
+
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			StampProvenance(ctx, row)
+			return nil
+		})
+
+		assert.NoError(t, manager.RunSeederByName("users"))
+		assert.Empty(t, row)
+	})
+}
+
+// TestNewRunID tests run ID uniqueness
+func TestNewRunID(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		id := newRunID(time.Now())
+		assert.False(t, seen[id])
+		seen[id] = true
+	}
+}
@@ -0,0 +1,158 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+)
+
+// Scenario is a named bundle of seeders and parameters reproducing a
+// specific data state, e.g. "churn-risk-customer"
+type Scenario struct {
+	Name        string
+	Description string
+	Seeders     []string          // Seeders to run, in order
+	Params      map[string]string // Default parameters, readable with ScenarioParam
+
+	// Teardown removes the data created by previous runs of the scenario,
+	// identified by the outputs their seeders recorded with RecordOutput
+	Teardown func(ctx context.Context, outputs ScenarioOutputs) error
+}
+
+// RegisterScenario registers a scenario with validation for unique names
+func (sm *SeederManager) RegisterScenario(scenario Scenario) error {
+	if scenario.Name == "" {
+		return fmt.Errorf("scenario name cannot be empty")
+	}
+
+	if _, exists := sm.scenarioMap[scenario.Name]; exists {
+		return fmt.Errorf("scenario with name '%s' already exists", scenario.Name)
+	}
+
+	sm.scenarios = append(sm.scenarios, scenario)
+	sm.scenarioMap[scenario.Name] = scenario
+
+	logInfo(sm.withLogger(context.Background()), "Registered scenario: %s", scenario.Name)
+	return nil
+}
+
+// GetRegisteredScenarios returns a list of all registered scenario names
+func (sm *SeederManager) GetRegisteredScenarios() []string {
+	names := make([]string, len(sm.scenarios))
+	for i, scenario := range sm.scenarios {
+		names[i] = scenario.Name
+	}
+	return names
+}
+
+// GetScenario returns the scenario registered under name
+func (sm *SeederManager) GetScenario(name string) (Scenario, bool) {
+	scenario, exists := sm.scenarioMap[name]
+	return scenario, exists
+}
+
+// RunScenario runs the seeders of a scenario in order. params override the
+// scenario's default parameters for this run.
+func (sm *SeederManager) RunScenario(ctx context.Context, name string, params map[string]string) error {
+	scenario, exists := sm.scenarioMap[name]
+	if !exists {
+		return fmt.Errorf("scenario with name '%s' not found%s", name, didYouMean(name, sm.GetRegisteredScenarios()))
+	}
+
+	for _, seederName := range scenario.Seeders {
+		if !sm.IsSeederRegistered(seederName) {
+			return fmt.Errorf("scenario '%s' references unknown seeder '%s'%s", name, seederName, didYouMean(seederName, sm.GetRegisteredSeeders()))
+		}
+	}
+
+	merged := make(map[string]string, len(scenario.Params)+len(params))
+	for key, value := range scenario.Params {
+		merged[key] = value
+	}
+	for key, value := range params {
+		merged[key] = value
+	}
+	scenario.Params = merged
+
+	logInfo(sm.withLogger(ctx), "Running scenario: %s", name)
+	collector := &outputCollector{outputs: make(ScenarioOutputs)}
+	runCtx := context.WithValue(ContextWithScenario(ctx, scenario), outputsKey, collector)
+	runErr := sm.RunSeedersInOrderContext(runCtx, scenario.Seeders)
+
+	// Keep the outputs of failed runs too, their partial data needs a teardown as well
+	if len(collector.outputs) > 0 {
+		if err := sm.outputStore.Append(ctx, name, collector.outputs); err != nil {
+			return fmt.Errorf("failed to store outputs of scenario '%s': %w", name, err)
+		}
+	}
+
+	if runErr != nil {
+		return fmt.Errorf("scenario '%s' failed: %w", name, runErr)
+	}
+
+	logInfo(sm.withLogger(ctx), "Scenario '%s' completed successfully", name)
+	return nil
+}
+
+// TeardownScenario removes the data created by previous runs of a scenario,
+// clears their recorded outputs and removes its seeders from the history so
+// the scenario runs again
+func (sm *SeederManager) TeardownScenario(ctx context.Context, name string) error {
+	scenario, exists := sm.scenarioMap[name]
+	if !exists {
+		return fmt.Errorf("scenario with name '%s' not found%s", name, didYouMean(name, sm.GetRegisteredScenarios()))
+	}
+	if scenario.Teardown == nil {
+		return fmt.Errorf("scenario '%s' has no teardown", name)
+	}
+
+	outputs, err := sm.outputStore.Load(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to load outputs of scenario '%s': %w", name, err)
+	}
+
+	logInfo(sm.withLogger(ctx), "Tearing down scenario: %s", name)
+	if err := scenario.Teardown(ContextWithScenario(ctx, scenario), outputs); err != nil {
+		return fmt.Errorf("teardown of scenario '%s' failed: %w", name, err)
+	}
+
+	if err := sm.outputStore.Clear(ctx, name); err != nil {
+		return fmt.Errorf("failed to clear outputs of scenario '%s': %w", name, err)
+	}
+	for _, seederName := range scenario.Seeders {
+		if resolved, exists := sm.ResolveSeeder(seederName); exists {
+			seederName = resolved
+		}
+		if err := sm.forgetHistory(ctx, seederName); err != nil {
+			return err
+		}
+	}
+
+	logInfo(sm.withLogger(ctx), "Scenario '%s' torn down successfully", name)
+	return nil
+}
+
+// SetOutputStore sets where scenario outputs are kept between a run and its
+// teardown. The default store is in memory; use a FileOutputStore for
+// teardowns from a later CLI invocation.
+func (sm *SeederManager) SetOutputStore(store OutputStore) {
+	sm.outputStore = store
+}
+
+// ContextWithScenario returns a copy of ctx carrying scenario, which is
+// useful to test scenario-aware seeders in isolation
+func ContextWithScenario(ctx context.Context, scenario Scenario) context.Context {
+	return context.WithValue(ctx, scenarioKey, scenario)
+}
+
+// ScenarioFromContext returns the scenario being run, if any
+func ScenarioFromContext(ctx context.Context) (Scenario, bool) {
+	scenario, ok := ctx.Value(scenarioKey).(Scenario)
+	return scenario, ok
+}
+
+// ScenarioParam returns a parameter of the scenario being run, or "" when
+// the parameter is not set or no scenario is running
+func ScenarioParam(ctx context.Context, key string) string {
+	scenario, _ := ScenarioFromContext(ctx)
+	return scenario.Params[key]
+}
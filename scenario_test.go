@@ -0,0 +1,120 @@
+package goseeder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRegisterScenario tests scenario registration
+func TestRegisterScenario(t *testing.T) {
+	t.Run("Register valid scenario", func(t *testing.T) {
+		manager := NewSeederManager()
+
+		err := manager.RegisterScenario(Scenario{Name: "churn-risk-customer", Seeders: []string{"users"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"churn-risk-customer"}, manager.GetRegisteredScenarios())
+		_, exists := manager.GetScenario("churn-risk-customer")
+		assert.True(t, exists)
+	})
+
+	t.Run("Empty name", func(t *testing.T) {
+		manager := NewSeederManager()
+
+		err := manager.RegisterScenario(Scenario{})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be empty")
+	})
+
+	t.Run("Duplicate name", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterScenario(Scenario{Name: "trial"})
+
+		err := manager.RegisterScenario(Scenario{Name: "trial"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+}
+
+// TestRunScenario tests running scenarios
+func TestRunScenario(t *testing.T) {
+	newManager := func(executed *[]string, params *map[string]string) *SeederManager {
+		manager := NewSeederManager()
+		manager.RegisterSeederContext("customer", func(ctx context.Context) error {
+			*executed = append(*executed, "customer")
+			scenario, _ := ScenarioFromContext(ctx)
+			*params = scenario.Params
+			return nil
+		})
+		manager.RegisterSeeder("invoices", func() error {
+			*executed = append(*executed, "invoices")
+			return nil
+		})
+		manager.RegisterScenario(Scenario{
+			Name:    "churn-risk-customer",
+			Seeders: []string{"invoices", "customer"},
+			Params:  map[string]string{"plan": "pro", "overdue_invoices": "3"},
+		})
+		return manager
+	}
+
+	t.Run("Runs seeders in scenario order with params", func(t *testing.T) {
+		executed := []string{}
+		params := map[string]string{}
+		manager := newManager(&executed, &params)
+
+		err := manager.RunScenario(context.Background(), "churn-risk-customer", map[string]string{"plan": "basic"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"invoices", "customer"}, executed)
+		assert.Equal(t, map[string]string{"plan": "basic", "overdue_invoices": "3"}, params)
+	})
+
+	t.Run("Unknown scenario", func(t *testing.T) {
+		manager := NewSeederManager()
+
+		err := manager.RunScenario(context.Background(), "missing", nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("Unknown seeder is rejected before running", func(t *testing.T) {
+		executed := []string{}
+		params := map[string]string{}
+		manager := newManager(&executed, &params)
+		manager.RegisterScenario(Scenario{Name: "broken", Seeders: []string{"invoices", "missing"}})
+
+		err := manager.RunScenario(context.Background(), "broken", nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown seeder 'missing'")
+		assert.Empty(t, executed)
+	})
+
+	t.Run("CLI scenario command with param override", func(t *testing.T) {
+		executed := []string{}
+		params := map[string]string{}
+		cli := NewCLI(newManager(&executed, &params))
+
+		err := cli.runCommand(context.Background(), []string{"scenario", "churn-risk-customer", "-param", "overdue_invoices=5"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "5", params["overdue_invoices"])
+		assert.Error(t, cli.runCommand(context.Background(), []string{"scenario"}))
+		assert.Error(t, cli.runCommand(context.Background(), []string{"scenario", "churn-risk-customer", "-param", "invalid"}))
+	})
+}
+
+// TestScenarioParam tests the parameter accessor
+func TestScenarioParam(t *testing.T) {
+	ctx := ContextWithScenario(context.Background(), Scenario{Params: map[string]string{"plan": "pro"}})
+
+	assert.Equal(t, "pro", ScenarioParam(ctx, "plan"))
+	assert.Equal(t, "", ScenarioParam(ctx, "missing"))
+	assert.Equal(t, "", ScenarioParam(context.Background(), "plan"))
+}
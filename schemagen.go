@@ -0,0 +1,246 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SchemaGenerator fills tables with random rows valid for their schema,
+// read from the database, with no per-table code, e.g. to fuzz-fill a new
+// schema. Columns the database fills, generated ones and those with a
+// default, are left out; nullable columns are NULL in about one row in
+// ten; foreign key columns take values of the rows they reference, and
+// enum and check-constrained columns one of the values they accept.
+type SchemaGenerator struct {
+	DB          *sql.DB
+	Columns     ColumnLister     // Types, nullability and defaults of columns, e.g. PostgresColumns
+	ForeignKeys ForeignKeyLister // Foreign keys between tables, e.g. PostgresForeignKeys; optional
+	Values      ValueLister      // Values of enum and check-constrained columns, e.g. PostgresValues; optional
+	Seed        int64            // Seeds the random values, so a seed always generates the same rows
+}
+
+// Fill generates counts[table] rows for every table of counts and writes
+// them with writer, e.g. a SQLFixtureWriter, returning the rows written per
+// table. Tables are written after the tables they reference, see
+// OrderTables, so their foreign key columns take values of the rows
+// written before.
+func (g *SchemaGenerator) Fill(ctx context.Context, writer FixtureWriter, counts map[string]int) (map[string]int, error) {
+	tables := make([]string, 0, len(counts))
+	for table := range counts {
+		tables = append(tables, table)
+	}
+	keys, err := g.foreignKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tables, err = OrderTables(tables, keys); err != nil {
+		return nil, err
+	}
+
+	written := make(map[string]int, len(tables))
+	for _, table := range tables {
+		records, err := g.generate(ctx, table, counts[table], keys)
+		if err != nil {
+			return written, err
+		}
+		if err := writer.WriteFixture(ctx, table, records); err != nil {
+			return written, fmt.Errorf("failed to write generated rows of %s: %w", table, err)
+		}
+		written[table] = len(records)
+	}
+	return written, nil
+}
+
+// Generate returns count random rows for table. Foreign key columns take
+// values of the rows already in the referenced tables.
+func (g *SchemaGenerator) Generate(ctx context.Context, table string, count int) ([]Record, error) {
+	keys, err := g.foreignKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return g.generate(ctx, table, count, keys)
+}
+
+// foreignKeys lists the foreign keys, none without a ForeignKeyLister
+func (g *SchemaGenerator) foreignKeys(ctx context.Context) ([]ForeignKey, error) {
+	if g.ForeignKeys == nil {
+		return nil, nil
+	}
+	return g.ForeignKeys.ForeignKeys(ctx)
+}
+
+// generate implements Generate with the listed foreign keys
+func (g *SchemaGenerator) generate(ctx context.Context, table string, count int, keys []ForeignKey) ([]Record, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("count of %s must be at least 1, got %d", table, count)
+	}
+	if g.Columns == nil {
+		return nil, fmt.Errorf("schema generator requires a column lister, e.g. PostgresColumns")
+	}
+	columns, err := g.Columns.Columns(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	references, err := g.references(ctx, table, keys)
+	if err != nil {
+		return nil, err
+	}
+	var pools map[string][]string
+	if g.Values != nil {
+		if pools, err = g.Values.Values(ctx, table); err != nil {
+			return nil, err
+		}
+	}
+
+	r := rand.New(rand.NewSource(g.Seed ^ int64(tableHash(table))))
+	var index int64
+	funcs := generateFuncs(r, &index)
+	records := make([]Record, count)
+	for i := range records {
+		index = int64(i + 1)
+		record := make(Record, len(columns))
+		for _, column := range columns {
+			if column.Generated || column.HasDefault {
+				continue
+			}
+			if column.Nullable && r.Intn(10) == 0 {
+				record[column.Name] = nil
+				continue
+			}
+			if values, ok := references[column.Name]; ok {
+				if len(values) == 0 {
+					if !column.Nullable {
+						return nil, fmt.Errorf("column '%s' of %s references a table without rows", column.Name, table)
+					}
+					record[column.Name] = nil
+					continue
+				}
+				record[column.Name] = values[r.Intn(len(values))]
+				continue
+			}
+			if pool, ok := pools[column.Name]; ok && len(pool) > 0 {
+				record[column.Name] = poolValue(column, pool[r.Intn(len(pool))])
+				continue
+			}
+			value, err := schemaValue(r, funcs, column)
+			if err != nil {
+				return nil, fmt.Errorf("column '%s' of %s: %w", column.Name, table, err)
+			}
+			record[column.Name] = value
+		}
+		records[i] = record
+	}
+	return records, nil
+}
+
+// references reads, for every foreign key column of table, the values of
+// the column it references
+func (g *SchemaGenerator) references(ctx context.Context, table string, keys []ForeignKey) (map[string][]any, error) {
+	references := make(map[string][]any)
+	for _, key := range keys {
+		if key.Table != table {
+			continue
+		}
+		if !identifierPattern.MatchString(key.RefTable) || !identifierPattern.MatchString(key.RefColumn) {
+			return nil, fmt.Errorf("invalid foreign key reference %s.%s", key.RefTable, key.RefColumn)
+		}
+		if g.DB == nil {
+			return nil, fmt.Errorf("column '%s' of %s references %s: reading its rows requires a database", key.Column, table, key.RefTable)
+		}
+		rows, err := g.DB.QueryContext(ctx, fmt.Sprintf("SELECT DISTINCT %s FROM %s", key.RefColumn, key.RefTable))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s.%s: %w", key.RefTable, key.RefColumn, err)
+		}
+		values := make([]any, 0)
+		for rows.Next() {
+			var value any
+			if err := rows.Scan(&value); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if b, ok := value.([]byte); ok {
+				value = string(b)
+			}
+			if value != nil {
+				values = append(values, value)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		sort.Slice(values, func(i, j int) bool { return fmt.Sprint(values[i]) < fmt.Sprint(values[j]) })
+		references[key.Column] = values
+	}
+	return references, nil
+}
+
+// tableHash varies the random values of tables generated with the same seed
+func tableHash(table string) int32 {
+	var hash int32
+	for _, c := range table {
+		hash = hash*31 + c
+	}
+	return hash
+}
+
+// poolValue returns a value listed by a ValueLister for column, as a
+// number for numeric columns
+func poolValue(column ColumnInfo, value string) any {
+	columnType := strings.ToLower(column.Type)
+	numeric := slices.Contains(schemaIntegerTypes, columnType) || columnType == "int2" || columnType == "smallint" ||
+		columnType == "numeric" || columnType == "decimal"
+	if numeric && decimalPattern.MatchString(value) {
+		return json.Number(value)
+	}
+	return value
+}
+
+// schemaIntegerTypes are the integer column types of Postgres and MySQL
+var schemaIntegerTypes = []string{"int", "int4", "int8", "integer", "bigint", "mediumint", "serial", "bigserial"}
+
+// schemaValue returns a random value of the type of column, using the
+// functions of generated rows for names, emails and other strings
+func schemaValue(r *rand.Rand, funcs map[string]any, column ColumnInfo) (any, error) {
+	call := func(name string) string { return funcs[name].(func() string)() }
+	name := strings.ToLower(column.Name)
+	switch columnType := strings.ToLower(column.Type); {
+	case columnType == "bool" || columnType == "boolean":
+		return r.Intn(2) == 1, nil
+	case columnType == "tinyint":
+		return r.Intn(2), nil
+	case columnType == "int2" || columnType == "smallint":
+		return 1 + r.Intn(32767), nil
+	case slices.Contains(schemaIntegerTypes, columnType):
+		return 1 + r.Intn(1000000), nil
+	case columnType == "numeric" || columnType == "decimal":
+		return NewDecimal(r.Int63n(1000000), 2), nil
+	case strings.HasPrefix(columnType, "float") || columnType == "real" || columnType == "double":
+		return float64(r.Intn(100000)) / 100, nil
+	case columnType == "uuid":
+		return call("uuid"), nil
+	case columnType == "date":
+		return call("date"), nil
+	case strings.HasPrefix(columnType, "timestamp") || columnType == "datetime":
+		return call("datetime"), nil
+	case columnType == "json" || columnType == "jsonb":
+		return map[string]any{}, nil
+	case strings.Contains(columnType, "char") || strings.Contains(columnType, "text"):
+		switch {
+		case strings.Contains(name, "email"):
+			return call("email"), nil
+		case name == "name" || strings.HasSuffix(name, "_name"):
+			return call("name"), nil
+		default:
+			return call("word"), nil
+		}
+	default:
+		return nil, fmt.Errorf("type %s is not supported by the schema generator", column.Type)
+	}
+}
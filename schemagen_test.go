@@ -0,0 +1,143 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSchema returns listers of a users table and an orders table
+// referencing it
+func newTestSchema() (ColumnLister, ForeignKeyLister) {
+	columns := ColumnListerFunc(func(ctx context.Context, table string) ([]ColumnInfo, error) {
+		switch table {
+		case "users":
+			return []ColumnInfo{
+				{Name: "id", Type: "int4", HasDefault: true},
+				{Name: "email", Type: "varchar"},
+				{Name: "full_name", Type: "text"},
+				{Name: "active", Type: "bool"},
+				{Name: "created_at", Type: "timestamptz", HasDefault: true},
+			}, nil
+		case "orders":
+			return []ColumnInfo{
+				{Name: "id", Type: "uuid"},
+				{Name: "user_id", Type: "int4"},
+				{Name: "total", Type: "numeric"},
+				{Name: "note", Type: "text", Nullable: true},
+				{Name: "placed_on", Type: "date"},
+			}, nil
+		}
+		return []ColumnInfo{{Name: "location", Type: "point"}}, nil
+	})
+	keys := ForeignKeyListerFunc(func(ctx context.Context) ([]ForeignKey, error) {
+		return []ForeignKey{{Table: "orders", Column: "user_id", RefTable: "users", RefColumn: "id"}}, nil
+	})
+	return columns, keys
+}
+
+// TestSchemaGenerator tests generating rows from column metadata and foreign keys
+func TestSchemaGenerator(t *testing.T) {
+	ctx := context.Background()
+	columns, keys := newTestSchema()
+
+	t.Run("Generate rows of a table", func(t *testing.T) {
+		generator := &SchemaGenerator{Columns: columns, Seed: 3}
+		records, err := generator.Generate(ctx, "users", 50)
+		require.NoError(t, err)
+		require.Len(t, records, 50)
+		emails := make(map[any]bool)
+		for _, record := range records {
+			assert.NotContains(t, record, "id")
+			assert.NotContains(t, record, "created_at")
+			assert.IsType(t, true, record["active"])
+			assert.Regexp(t, `^[A-Z][a-z]+ [A-Z][a-zü]+$`, record["full_name"])
+			assert.Regexp(t, `^[a-z]+\.[a-z]+\d+@example\.com$`, record["email"])
+			emails[record["email"]] = true
+		}
+		assert.Len(t, emails, 50)
+
+		again, err := generator.Generate(ctx, "users", 50)
+		assert.NoError(t, err)
+		assert.Equal(t, records, again)
+	})
+
+	t.Run("Foreign keys take referenced values", func(t *testing.T) {
+		db, fake := newFakeDB()
+		fake.On("SELECT DISTINCT id FROM users", []string{"id"}, []driver.Value{int64(4)}, []driver.Value{int64(9)})
+		generator := &SchemaGenerator{DB: db, Columns: columns, ForeignKeys: keys}
+		records, err := generator.Generate(ctx, "orders", 20)
+		require.NoError(t, err)
+		nulls := 0
+		for _, record := range records {
+			assert.Contains(t, []any{int64(4), int64(9)}, record["user_id"])
+			assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4`, record["id"])
+			assert.IsType(t, Decimal(""), record["total"])
+			assert.Regexp(t, `^\d{4}-\d{2}-\d{2}$`, record["placed_on"])
+			if record["note"] == nil {
+				nulls++
+			}
+		}
+		assert.Less(t, nulls, 20)
+
+		fake.On("SELECT DISTINCT id FROM users", []string{"id"})
+		_, err = generator.Generate(ctx, "orders", 1)
+		assert.EqualError(t, err, "column 'user_id' of orders references a table without rows")
+	})
+
+	t.Run("Fill writes referenced tables first", func(t *testing.T) {
+		db, fake := newFakeDB()
+		fake.On("SELECT DISTINCT id FROM users", []string{"id"}, []driver.Value{int64(1)})
+		var tables []string
+		writer := FixtureWriterFunc(func(ctx context.Context, table string, records []Record) error {
+			tables = append(tables, table)
+			return nil
+		})
+		generator := &SchemaGenerator{DB: db, Columns: columns, ForeignKeys: keys}
+		written, err := generator.Fill(ctx, writer, map[string]int{"orders": 5, "users": 3})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"users", "orders"}, tables)
+		assert.Equal(t, map[string]int{"users": 3, "orders": 5}, written)
+	})
+
+	t.Run("Unsupported types", func(t *testing.T) {
+		generator := &SchemaGenerator{Columns: columns}
+		_, err := generator.Generate(ctx, "places", 1)
+		assert.EqualError(t, err, "column 'location' of places: type point is not supported by the schema generator")
+		_, err = (&SchemaGenerator{}).Generate(ctx, "users", 1)
+		assert.ErrorContains(t, err, "requires a column lister")
+		_, err = generator.Generate(ctx, "users", 0)
+		assert.EqualError(t, err, "count of users must be at least 1, got 0")
+	})
+}
+
+// TestCLIFillCommand tests filling tables from the command line
+func TestCLIFillCommand(t *testing.T) {
+	db, fake := newFakeDB()
+	fake.On("SELECT DISTINCT id FROM users", []string{"id"}, []driver.Value{int64(1)})
+	columns, keys := newTestSchema()
+	cli := NewCLI(NewSeederManager())
+	var stdout bytes.Buffer
+	cli.SetOutput(&stdout)
+	cli.SetDB(db)
+	cli.SetColumnLister(columns)
+	cli.SetForeignKeyLister(keys)
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"fill", "-seed=2", "orders=2", "users=1"}))
+	assert.Equal(t, "Filled orders with 2 rows\nFilled users with 1 rows\n", stdout.String())
+	inserts := 0
+	for _, event := range fake.Events() {
+		if strings.HasPrefix(event, "INSERT INTO") {
+			inserts++
+		}
+	}
+	assert.Equal(t, 2, inserts)
+
+	assert.ErrorIs(t, cli.runCommand(context.Background(), []string{"fill"}), ErrUsage)
+	assert.ErrorIs(t, cli.runCommand(context.Background(), []string{"fill", "users=0"}), ErrUsage)
+}
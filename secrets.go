@@ -0,0 +1,62 @@
+package goseeder
+
+import "context"
+
+// secretMask replaces secret values in run summaries
+const secretMask = "********"
+
+// Secret is a credential a seeder created, e.g. the password of a demo
+// account, listed in the run report
+type Secret struct {
+	Seeder string `json:"seeder"`
+	Name   string `json:"name"` // e.g. "admin@example.com password"
+	Value  string `json:"value"`
+}
+
+// ReportSecret adds a credential the current seeder created to the run
+// report, so demo account passwords can be retrieved after the run. The
+// run summary prints it masked unless ctx carries ContextWithSecretsShown.
+func ReportSecret(ctx context.Context, name, value string) {
+	state, ok := ctx.Value(runKey).(*runState)
+	if !ok {
+		return
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.secrets = append(state.secrets, Secret{Seeder: SeederNameFromContext(ctx), Name: name, Value: value})
+}
+
+// ContextWithSecretsShown returns a copy of ctx whose run summary prints
+// secrets in full, as the CLI's -show-secrets flag does
+func ContextWithSecretsShown(ctx context.Context) context.Context {
+	return context.WithValue(ctx, showSecretsKey, true)
+}
+
+// secretsShown reports whether the run summary prints secrets in full
+func secretsShown(ctx context.Context) bool {
+	shown, _ := ctx.Value(showSecretsKey).(bool)
+	return shown
+}
+
+// MaskSecret returns the value printed in place of a secret
+func MaskSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return secretMask
+}
+
+// maskSecrets returns report with its secret values masked, or report
+// itself when it lists none; report is not modified
+func maskSecrets(report *RunReport) *RunReport {
+	if report == nil || len(report.Secrets) == 0 {
+		return report
+	}
+	masked := *report
+	masked.Secrets = make([]Secret, len(report.Secrets))
+	for i, secret := range report.Secrets {
+		secret.Value = MaskSecret(secret.Value)
+		masked.Secrets[i] = secret
+	}
+	return &masked
+}
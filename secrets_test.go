@@ -0,0 +1,75 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReportSecret tests listing secrets in the report and masking them in
+// the run summary
+func TestReportSecret(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	manager := NewSeederManager()
+	manager.RegisterSeederContext("demo_accounts", func(ctx context.Context) error {
+		ReportSecret(ctx, "admin@example.com password", "s3cret-demo")
+		return nil
+	})
+
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []Secret{{Seeder: "demo_accounts", Name: "admin@example.com password", Value: "s3cret-demo"}}, manager.LastRunReport().Secrets)
+	assert.Contains(t, logs.String(), "Secrets (masked, see -show-secrets):")
+	assert.Contains(t, logs.String(), "demo_accounts: admin@example.com password = ********")
+	assert.NotContains(t, logs.String(), "s3cret-demo")
+
+	logs.Reset()
+	assert.NoError(t, manager.RunAllSeedersContext(ContextWithSecretsShown(context.Background())))
+	assert.Contains(t, logs.String(), "demo_accounts: admin@example.com password = s3cret-demo")
+
+	ReportSecret(context.Background(), "ignored", "outside a run")
+	assert.Equal(t, "", MaskSecret(""))
+}
+
+// TestSecretsNotPersisted tests that report stores and triage bundles never
+// write secret values to disk
+func TestSecretsNotPersisted(t *testing.T) {
+	dir := t.TempDir()
+	manager := NewSeederManager()
+	manager.SetReportStore(NewFileReportStore(filepath.Join(dir, "reports", "last-run.json")))
+	manager.SetTriageBundle(TriageOptions{Dir: filepath.Join(dir, "triage")})
+	manager.RegisterSeederContext("demo_accounts", func(ctx context.Context) error {
+		ReportSecret(ctx, "admin@example.com password", "s3cret-demo")
+		return errors.New("failed after creating the account")
+	})
+
+	assert.Error(t, manager.RunAllSeedersContext(ContextWithSecretsShown(context.Background())))
+	assert.Equal(t, "s3cret-demo", manager.LastRunReport().Secrets[0].Value, "the in-memory report keeps the value")
+
+	written := 0
+	assert.NoError(t, filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		written++
+		assert.NotContains(t, string(data), "s3cret-demo", path)
+		return nil
+	}))
+	assert.Equal(t, 4, written, "the report and the triage bundle's three files")
+
+	last, err := manager.reportStore.LastReport(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, secretMask, last.Secrets[0].Value)
+}
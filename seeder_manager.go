@@ -1,90 +1,316 @@
 package goseeder
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
-	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 )
 
 // SeederItem represents a single seeder with its name and function
 type SeederItem struct {
-	Name     string
-	Function func() error
+	Name            string
+	Function        func() error
+	ContextFunction SeederFunc   // Context-aware alternative to Function, used when set
+	Tags            []string     // Labels used to select seeders, e.g. "core" or "demo"
+	Aliases         []string     // Alternative names accepted wherever the name is, e.g. legacy names
+	Paths           []string     // Fixture files and package directories the seeder is built from, see Affects
+	Phase           Phase        // Deployment stage the seeder runs in, PhaseCore when empty
+	Rollback        func() error // Undoes the seeder's data, e.g. deletes the demo users it created; optional
+	DependsOn       []string     // Seeders ordered before this one that must complete first, see RunAllSeedersParallel
+	Transaction     bool         // Runs the seeder in a transaction rolled back on failure, see WithTransaction
+
+	// Priority orders seeders regardless of when they are registered, e.g.
+	// from init functions of several packages: lower priorities run first,
+	// and seeders of equal priority, 0 by default, keep registration order.
+	// Wherever this package says registration order, it means this
+	// priority order.
+	Priority int
+
+	// ShouldRun, when set, is asked before every run; the seeder is skipped
+	// when it returns false, e.g. WhenFlag("new-checkout")
+	ShouldRun func(ctx context.Context) bool
+
+	// SkipIf, when set, is asked before every run after ShouldRun; the
+	// seeder is skipped when it returns true, and the reason, e.g. "table
+	// not empty", is logged and kept in the run report
+	SkipIf func() (bool, string)
+}
+
+// WithPriority sets the Priority of a seeder registered with RegisterSeeder
+func WithPriority(priority int) SeederOption {
+	return func(si *SeederItem) {
+		si.Priority = priority
+	}
+}
+
+// HasAnyTag reports whether the seeder carries at least one of the given tags
+func (si SeederItem) HasAnyTag(tags ...string) bool {
+	for _, tag := range tags {
+		for _, own := range si.Tags {
+			if own == tag {
+				return true
+			}
+		}
+	}
+	return false
 }
 
+// Manager is the core of a seeder manager the CLI and other front ends
+// need: registering seeders and running them. *SeederManager implements
+// it; wrap it to add decorators such as metrics or logging, or mock it in
+// tests. The CLI runs seeders through the Context methods, so decorators
+// wrap those. CLI commands needing more, such as scenario, rollback or
+// validate, require a manager that also has those methods of
+// *SeederManager.
+type Manager interface {
+	RegisterSeeder(name string, function func() error, options ...SeederOption) error
+	RegisterSeeders(seeders ...SeederItem) error
+	GetRegisteredSeeders() []string
+	IsSeederRegistered(name string) bool
+	GetSeedersByTag(tags ...string) []string
+	RunSeederByName(name string) error
+	RunSeederByNameContext(ctx context.Context, name string) error
+	RunSeedersInOrder(names []string) error
+	RunSeedersInOrderContext(ctx context.Context, names []string) error
+	RunAllSeeders() error
+	RunAllSeedersContext(ctx context.Context) error
+}
+
+// Ensure SeederManager implements Manager
+var _ Manager = (*SeederManager)(nil)
+
 // SeederManager manages all registered seeders
 type SeederManager struct {
-	seeders   []SeederItem
-	seederMap map[string]func() error
+	registryMu        sync.RWMutex // Guards the registry below, replaced as a whole by ReloadFixtures
+	seeders           []SeederItem
+	seederMap         map[string]SeederItem
+	names             []string          // Registered names in order, shared by GetRegisteredSeeders
+	aliases           map[string]string // Alias to seeder name
+	folded            map[string]string // Lowercased name or alias to seeder name, for case-insensitive lookups
+	caseInsensitive   bool
+	reportStore       ReportStore
+	reportMu          sync.Mutex
+	lastReport        *RunReport     // Report of the most recent run
+	triage            *TriageOptions // Bundle written when a run fails, nil when disabled
+	chaos             *chaosInjector // Fault injection for resilience tests, nil when disabled
+	middleware        []Middleware
+	contextValues     []contextValue // Values injected into every run context
+	scenarios         []Scenario
+	scenarioMap       map[string]Scenario
+	outputStore       OutputStore   // Scenario outputs used by teardowns
+	retention         time.Duration // TTL of seeded data, zero when data never expires
+	operator          string        // Recorded in RunInfo, derived from the environment when empty
+	provenanceColumn  string        // Column stamped with the run ID by StampProvenance
+	validators        []namedValidator
+	packExec          SQLExecutor   // Runs the SQL files of loaded packs
+	packFixtures      FixtureWriter // Writes the fixtures of loaded packs
+	schemaVersioner   SchemaVersioner
+	packVersions      map[string]SemVer
+	completionStore   CompletionStore // Cross-service completion markers, nil when disabled
+	completionService string          // Prefix of the markers written by this manager
+	outboxMode        OutboxMode      // How application code treats events raised while seeding
+	outboxPauser      OutboxPauser    // Paused for the duration of every run, nil when disabled
+	fixtureDirs       []*fixtureDir   // Directories registered with RegisterFixtureDir, re-read by ReloadFixtures
+	passwordHasher    PasswordHasher  // Hasher of fixture templates and HashPassword, nil for PBKDF2Hasher
+	featureFlags      FeatureFlags    // Queried by FlagEnabled, nil when all flags are off
+	webhook           *WebhookOptions // Receives every run report, nil when disabled
+	historyStore      HistoryStore    // Applied seeders, skipped by later runs; nil when disabled
+	transactionDB     *sql.DB         // Database of the transactions of WithTransaction seeders
+	replicas          *ReplicaOptions // Read replicas, nil when unaware of them
+	logger            Logger          // Receives log output, nil for the standard log package
+	quiet             bool            // Log errors only, see SetQuiet
+	progressReporter  ProgressReporter
+	quota             *Quota // Row limits of every run, nil when unlimited
+	clock             Clock  // Time of runs, nil for the system clock
 }
 
 // NewSeederManager creates a new seeder manager instance
 func NewSeederManager() *SeederManager {
 	return &SeederManager{
-		seeders:   make([]SeederItem, 0),
-		seederMap: make(map[string]func() error),
+		seeders:     make([]SeederItem, 0),
+		seederMap:   make(map[string]SeederItem),
+		names:       make([]string, 0),
+		aliases:     make(map[string]string),
+		folded:      make(map[string]string),
+		scenarioMap: make(map[string]Scenario),
+		outputStore: NewMemoryOutputStore(),
 	}
 }
 
 // RegisterSeeder registers a new seeder with validation for unique names
-func (sm *SeederManager) RegisterSeeder(name string, function func() error) error {
+func (sm *SeederManager) RegisterSeeder(name string, function func() error, options ...SeederOption) error {
+	item := SeederItem{
+		Name:     name,
+		Function: function,
+	}
+	for _, option := range options {
+		option(&item)
+	}
+	return sm.registerItem(item)
+}
+
+// RegisterSeederContext registers a seeder that receives the run context
+func (sm *SeederManager) RegisterSeederContext(name string, function SeederFunc, options ...SeederOption) error {
+	item := SeederItem{
+		Name:            name,
+		ContextFunction: function,
+	}
+	for _, option := range options {
+		option(&item)
+	}
+	return sm.registerItem(item)
+}
+
+// registerItem validates and stores a seeder
+func (sm *SeederManager) registerItem(seederItem SeederItem) error {
+	sm.registryMu.Lock()
+	defer sm.registryMu.Unlock()
+
+	if err := sm.addItem(seederItem); err != nil {
+		return err
+	}
+	logInfo(sm.withLogger(context.Background()), "Registered seeder: %s", seederItem.Name)
+	return nil
+}
+
+// addItem validates and stores a seeder; the caller holds registryMu
+func (sm *SeederManager) addItem(seederItem SeederItem) error {
 	// Validate name is not empty
-	if name == "" {
+	if seederItem.Name == "" {
 		return fmt.Errorf("seeder name cannot be empty")
 	}
 
-	// Check if name already exists
-	if _, exists := sm.seederMap[name]; exists {
-		return fmt.Errorf("seeder with name '%s' already exists", name)
+	if seederItem.Phase != "" && !slices.Contains(phases, seederItem.Phase) {
+		return fmt.Errorf("seeder '%s' has unknown phase '%s'%s", seederItem.Name, seederItem.Phase, didYouMean(string(seederItem.Phase), phaseNames()))
 	}
 
-	// Add to slice and map
-	seederItem := SeederItem{
-		Name:     name,
-		Function: function,
+	// Check if name or any alias already exists
+	if sm.nameTaken(seederItem.Name) {
+		return fmt.Errorf("seeder with name '%s' already exists", seederItem.Name)
+	}
+	for i, alias := range seederItem.Aliases {
+		if alias == "" {
+			return fmt.Errorf("alias of seeder '%s' cannot be empty", seederItem.Name)
+		}
+		if alias == seederItem.Name || sm.nameTaken(alias) || slices.Contains(seederItem.Aliases[:i], alias) {
+			return fmt.Errorf("alias '%s' of seeder '%s' is already taken", alias, seederItem.Name)
+		}
 	}
-	sm.seeders = append(sm.seeders, seederItem)
-	sm.seederMap[name] = function
 
-	log.Printf("Registered seeder: %s", name)
+	// Add to slice and maps, after the seeders of lower or equal priority.
+	// Insertions copy the slices, whose snapshots registry hands out.
+	position := len(sm.seeders)
+	for position > 0 && sm.seeders[position-1].Priority > seederItem.Priority {
+		position--
+	}
+	if position == len(sm.seeders) {
+		sm.seeders = append(sm.seeders, seederItem)
+		sm.names = append(sm.names, seederItem.Name)
+	} else {
+		sm.seeders = slices.Insert(slices.Clip(sm.seeders), position, seederItem)
+		sm.names = slices.Insert(slices.Clip(sm.names), position, seederItem.Name)
+	}
+	sm.seederMap[seederItem.Name] = seederItem
+	for _, name := range append([]string{seederItem.Name}, seederItem.Aliases...) {
+		if name != seederItem.Name {
+			sm.aliases[name] = seederItem.Name
+		}
+		if _, exists := sm.folded[strings.ToLower(name)]; !exists {
+			sm.folded[strings.ToLower(name)] = seederItem.Name
+		}
+	}
 	return nil
 }
 
 // RegisterSeeders registers multiple seeders at once using variadic function
 func (sm *SeederManager) RegisterSeeders(seeders ...SeederItem) error {
 	for _, seeder := range seeders {
-		if err := sm.RegisterSeeder(seeder.Name, seeder.Function); err != nil {
+		if err := sm.registerItem(seeder); err != nil {
 			return fmt.Errorf("failed to register seeder '%s': %w", seeder.Name, err)
 		}
 	}
 	return nil
 }
 
-// GetRegisteredSeeders returns a list of all registered seeder names
+// GetRegisteredSeeders returns a list of all registered seeder names. The
+// slice is shared between calls without allocating and must not be
+// modified; appending to it is safe.
 func (sm *SeederManager) GetRegisteredSeeders() []string {
-	names := make([]string, len(sm.seeders))
-	for i, seeder := range sm.seeders {
-		names[i] = seeder.Name
+	_, names := sm.registry()
+	return names
+}
+
+// registry returns the registered seeders and their names. Both slices are
+// snapshots that later registrations and reloads leave unchanged.
+func (sm *SeederManager) registry() ([]SeederItem, []string) {
+	sm.registryMu.RLock()
+	defer sm.registryMu.RUnlock()
+	return sm.seeders[:len(sm.seeders):len(sm.seeders)], sm.names[:len(sm.names):len(sm.names)]
+}
+
+// GetSeedersByTag returns, in registration order, the names of the seeders
+// carrying at least one of the given tags
+func (sm *SeederManager) GetSeedersByTag(tags ...string) []string {
+	seeders, _ := sm.registry()
+	names := make([]string, 0)
+	for _, seeder := range seeders {
+		if seeder.HasAnyTag(tags...) {
+			names = append(names, seeder.Name)
+		}
 	}
 	return names
 }
 
 // RunSeederByName runs a specific seeder by name
 func (sm *SeederManager) RunSeederByName(name string) error {
-	if function, exists := sm.seederMap[name]; exists {
-		log.Printf("Running seeder: %s", name)
-		if err := function(); err != nil {
-			return fmt.Errorf("seeder '%s' failed: %w", name, err)
-		}
-		log.Printf("Seeder '%s' completed successfully", name)
-		return nil
+	return sm.RunSeederByNameContext(context.Background(), name)
+}
+
+// RunSeederByNameContext runs a specific seeder by name with the given context
+func (sm *SeederManager) RunSeederByNameContext(ctx context.Context, name string) (err error) {
+	ctx, end, err := sm.beginRun(ctx)
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("seeder with name '%s' not found", name)
+	defer func() { err = end(err) }()
+
+	startProgress(ctx, 1)
+	if seeder, exists := sm.lookupSeeder(name); exists {
+		return sm.executeSeeder(ctx, seeder)
+	}
+	return fmt.Errorf("seeder with name '%s' not found%s", name, didYouMean(name, sm.GetRegisteredSeeders()))
 }
 
 // RunSeedersInOrder runs multiple seeders in the specified order
 func (sm *SeederManager) RunSeedersInOrder(names []string) error {
+	return sm.RunSeedersInOrderContext(context.Background(), names)
+}
+
+// RunSeedersInOrderContext runs multiple seeders in the specified order,
+// stopping before the next seeder once ctx is canceled. A seeder listed
+// before one it depends on fails the run before anything runs; seeders
+// depended on but not listed are taken as seeded already, see
+// ExpandDependencies to run them too.
+func (sm *SeederManager) RunSeedersInOrderContext(ctx context.Context, names []string) (err error) {
+	if err := sm.checkDependencyOrder(names); err != nil {
+		return err
+	}
+	ctx, end, err := sm.beginRun(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
+
+	startProgress(ctx, len(names))
 	for _, name := range names {
-		if err := sm.RunSeederByName(name); err != nil {
+		if err := stopCause(ctx); err != nil {
+			return err
+		}
+		if err := sm.RunSeederByNameContext(ctx, name); err != nil {
 			return err
 		}
 	}
@@ -93,23 +319,157 @@ func (sm *SeederManager) RunSeedersInOrder(names []string) error {
 
 // RunAllSeeders runs all registered seeders in order
 func (sm *SeederManager) RunAllSeeders() error {
-	log.Println("Running all seeders...")
+	return sm.RunAllSeedersContext(context.Background())
+}
+
+// RunAllSeedersContext runs all registered seeders in order, stopping before
+// the next seeder once ctx is canceled
+func (sm *SeederManager) RunAllSeedersContext(ctx context.Context) (err error) {
+	ctx, end, err := sm.beginRun(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
+
+	logInfo(ctx, "Running all seeders...")
 
 	// Run all registered seeders in order
-	for _, seeder := range sm.seeders {
-		log.Printf("Running seeder: %s", seeder.Name)
-		if err := seeder.Function(); err != nil {
-			return fmt.Errorf("seeder '%s' failed: %w", seeder.Name, err)
+	seeders, _ := sm.registry()
+	startProgress(ctx, len(seeders))
+	for _, seeder := range seeders {
+		if err := stopCause(ctx); err != nil {
+			return err
+		}
+		if err := sm.executeSeeder(ctx, seeder); err != nil {
+			return err
 		}
-		log.Printf("Seeder '%s' completed successfully", seeder.Name)
 	}
 
-	log.Println("All seeders completed successfully!")
+	logInfo(ctx, "All seeders completed successfully!")
 	return nil
 }
 
-// IsSeederRegistered checks if a seeder with the given name is registered
+// IsSeederRegistered checks if a seeder with the given name or alias is
+// registered
 func (sm *SeederManager) IsSeederRegistered(name string) bool {
-	_, exists := sm.seederMap[name]
+	_, exists := sm.ResolveSeeder(name)
 	return exists
 }
+
+// HasSeeder is a shorter alias of IsSeederRegistered; neither allocates
+func (sm *SeederManager) HasSeeder(name string) bool {
+	return sm.IsSeederRegistered(name)
+}
+
+// executeSeeder runs a single seeder with logging, progress reporting and
+// error wrapping
+func (sm *SeederManager) executeSeeder(ctx context.Context, seeder SeederItem) (err error) {
+	name := seeder.Name
+	ctx = context.WithValue(sm.withLogger(sm.injectContextValues(ctx)), seederNameKey, name)
+	progressStarted(ctx, name)
+	defer func() {
+		if err == nil {
+			progressDone(ctx)
+		}
+	}()
+	if sm.replicas != nil && sm.replicas.ForcePrimary {
+		ctx = ContextWithPrimaryOnly(ctx)
+	}
+	if seeder.ShouldRun != nil && !seeder.ShouldRun(ctx) {
+		logInfo(ctx, "Skipping seeder: %s", name)
+		recordSkipped(ctx, name, "")
+		return nil
+	}
+	if seeder.SkipIf != nil {
+		if skip, reason := seeder.SkipIf(); skip {
+			logInfo(ctx, "Skipping seeder '%s': %s", name, reason)
+			recordSkipped(ctx, name, reason)
+			return nil
+		}
+	}
+	applied, err := sm.alreadyApplied(ctx, name)
+	if err != nil {
+		return err
+	}
+	if applied {
+		logInfo(ctx, "Skipping seeder '%s': already applied (see ForceRun)", name)
+		recordSkipped(ctx, name, "already applied")
+		return nil
+	}
+	logInfo(ctx, "Running seeder: %s", name)
+
+	run := seeder.ContextFunction
+	if run == nil {
+		function := seeder.Function
+		run = func(ctx context.Context) error {
+			return function()
+		}
+	}
+	if sm.chaos != nil {
+		run = sm.chaos.wrap(name, run)
+	}
+	if seeder.Transaction {
+		run = sm.inTransaction(name, run)
+	}
+
+	start := time.Now()
+	err = sm.chain(run)(ctx)
+	recordSeeder(ctx, name, time.Since(start), err)
+	if err != nil {
+		return fmt.Errorf("seeder '%s' failed: %w", name, err)
+	}
+	if err := sm.recordApplied(ctx, name); err != nil {
+		return err
+	}
+	logInfo(ctx, "Seeder '%s' completed successfully", name)
+	return nil
+}
+
+// SetCaseInsensitive makes seeder names and aliases match regardless of
+// case wherever a name is accepted, e.g. "-type=Users" runs "users". When
+// names differ only in case, the first registered one wins.
+func (sm *SeederManager) SetCaseInsensitive(enabled bool) {
+	sm.caseInsensitive = enabled
+}
+
+// ResolveSeeder returns the registered name of the seeder known as name,
+// which can be its name or an alias
+func (sm *SeederManager) ResolveSeeder(name string) (string, bool) {
+	sm.registryMu.RLock()
+	defer sm.registryMu.RUnlock()
+	return sm.resolveSeeder(name)
+}
+
+// resolveSeeder implements ResolveSeeder; the caller holds registryMu
+func (sm *SeederManager) resolveSeeder(name string) (string, bool) {
+	if _, exists := sm.seederMap[name]; exists {
+		return name, true
+	}
+	if resolved, exists := sm.aliases[name]; exists {
+		return resolved, true
+	}
+	if sm.caseInsensitive {
+		if resolved, exists := sm.folded[strings.ToLower(name)]; exists {
+			return resolved, true
+		}
+	}
+	return "", false
+}
+
+// lookupSeeder finds the seeder known as name
+func (sm *SeederManager) lookupSeeder(name string) (SeederItem, bool) {
+	sm.registryMu.RLock()
+	defer sm.registryMu.RUnlock()
+	resolved, exists := sm.resolveSeeder(name)
+	if !exists {
+		return SeederItem{}, false
+	}
+	return sm.seederMap[resolved], true
+}
+
+// nameTaken reports whether name is already used as a seeder name or alias
+func (sm *SeederManager) nameTaken(name string) bool {
+	_, isName := sm.seederMap[name]
+	_, isAlias := sm.aliases[name]
+	return isName || isAlias
+}
@@ -1,7 +1,9 @@
 package goseeder
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -445,3 +447,224 @@ func TestSeederManagerIntegration(t *testing.T) {
 		assert.Equal(t, []string{"roles", "users", "departments"}, executionLog)
 	})
 }
+
+// TestRegisterSeederContext tests registering context-aware seeders
+func TestRegisterSeederContext(t *testing.T) {
+	t.Run("Context seeder receives seeder name", func(t *testing.T) {
+		manager := NewSeederManager()
+		var name string
+
+		err := manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			name = SeederNameFromContext(ctx)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		err = manager.RunSeederByName("users")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "users", name)
+	})
+
+	t.Run("RegisterSeeders keeps ContextFunction", func(t *testing.T) {
+		manager := NewSeederManager()
+		executed := false
+
+		err := manager.RegisterSeeders(SeederItem{
+			Name: "users",
+			ContextFunction: func(ctx context.Context) error {
+				executed = true
+				return nil
+			},
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, manager.RunAllSeeders())
+		assert.True(t, executed)
+	})
+
+	t.Run("Duplicate context seeder", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+
+		err := manager.RegisterSeederContext("users", func(ctx context.Context) error { return nil })
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+}
+
+// TestGetSeedersByTag tests tag-based selection
+func TestGetSeedersByTag(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeders(
+		SeederItem{Name: "countries", Function: func() error { return nil }, Tags: []string{"core"}},
+		SeederItem{Name: "demo_users", Function: func() error { return nil }, Tags: []string{"demo", "users"}},
+		SeederItem{Name: "untagged", Function: func() error { return nil }},
+	)
+
+	assert.Equal(t, []string{"countries"}, manager.GetSeedersByTag("core"))
+	assert.Equal(t, []string{"countries", "demo_users"}, manager.GetSeedersByTag("users", "core"))
+	assert.Empty(t, manager.GetSeedersByTag("missing"))
+	assert.Empty(t, manager.GetSeedersByTag())
+}
+
+// TestRegistrationLookupsDoNotAllocate tests the lookup hot path
+func TestRegistrationLookupsDoNotAllocate(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("users", func() error { return nil })
+	manager.RegisterSeeder("roles", func() error { return nil })
+
+	allocs := testing.AllocsPerRun(100, func() {
+		manager.GetRegisteredSeeders()
+		manager.HasSeeder("users")
+		manager.IsSeederRegistered("missing")
+	})
+	assert.Equal(t, float64(0), allocs)
+
+	names := manager.GetRegisteredSeeders()
+	extended := append(names, "extra")
+	assert.Equal(t, []string{"users", "roles"}, manager.GetRegisteredSeeders(), "appending does not leak into the manager")
+	assert.Len(t, extended, 3)
+}
+
+// newBenchmarkManager returns a manager with count registered seeders
+func newBenchmarkManager(count int) *SeederManager {
+	manager := NewSeederManager()
+	for i := 0; i < count; i++ {
+		manager.registerItem(SeederItem{Name: fmt.Sprintf("seeder_%03d", i), Function: func() error { return nil }})
+	}
+	return manager
+}
+
+func BenchmarkGetRegisteredSeeders(b *testing.B) {
+	manager := newBenchmarkManager(300)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		manager.GetRegisteredSeeders()
+	}
+}
+
+func BenchmarkHasSeeder(b *testing.B) {
+	manager := newBenchmarkManager(300)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		manager.HasSeeder("seeder_150")
+	}
+}
+
+// TestSeederAliases tests registering and running seeders by alias
+func TestSeederAliases(t *testing.T) {
+	manager := NewSeederManager()
+	runs := 0
+	err := manager.RegisterSeeders(SeederItem{
+		Name:     "users",
+		Aliases:  []string{"usr", "LegacyUsers"},
+		Function: func() error { runs++; return nil },
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, manager.HasSeeder("usr"))
+	assert.NoError(t, manager.RunSeederByName("usr"))
+	assert.NoError(t, manager.RunSeedersInOrder([]string{"LegacyUsers", "users"}))
+	assert.Equal(t, 3, runs)
+	assert.Equal(t, []string{"users"}, manager.GetRegisteredSeeders())
+
+	resolved, ok := manager.ResolveSeeder("usr")
+	assert.True(t, ok)
+	assert.Equal(t, "users", resolved)
+
+	result, err := manager.BenchmarkSeeder("usr", BenchmarkOptions{Iterations: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "users", result.Name)
+
+	t.Run("Conflicts", func(t *testing.T) {
+		noop := func() error { return nil }
+		assert.ErrorContains(t, manager.RegisterSeeders(SeederItem{Name: "usr", Function: noop}), "already exists")
+		assert.ErrorContains(t, manager.RegisterSeeders(SeederItem{Name: "people", Aliases: []string{"users"}, Function: noop}), "alias 'users' of seeder 'people' is already taken")
+		assert.ErrorContains(t, manager.RegisterSeeders(SeederItem{Name: "people", Aliases: []string{"ppl", "ppl"}, Function: noop}), "alias 'ppl'")
+		assert.ErrorContains(t, manager.RegisterSeeders(SeederItem{Name: "people", Aliases: []string{""}, Function: noop}), "cannot be empty")
+		assert.False(t, manager.HasSeeder("people"))
+	})
+}
+
+// TestCaseInsensitiveLookup tests optional case-insensitive name matching
+func TestCaseInsensitiveLookup(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeders(SeederItem{Name: "UserRoles", Aliases: []string{"roles"}, Function: func() error { return nil }})
+
+	assert.False(t, manager.HasSeeder("userroles"))
+	assert.Error(t, manager.RunSeederByName("ROLES"))
+
+	manager.SetCaseInsensitive(true)
+
+	assert.True(t, manager.HasSeeder("userroles"))
+	assert.NoError(t, manager.RunSeederByName("USERROLES"))
+	assert.NoError(t, manager.RunSeederByName("Roles"))
+	resolved, _ := manager.ResolveSeeder("userROLES")
+	assert.Equal(t, "UserRoles", resolved)
+}
+
+// TestSkipIf tests skipping seeders at runtime and reporting the reason
+func TestSkipIf(t *testing.T) {
+	manager := NewSeederManager()
+	ran := []string{}
+	empty := false
+	manager.RegisterSeeders(
+		SeederItem{Name: "users", Function: func() error { ran = append(ran, "users"); return nil }},
+		SeederItem{
+			Name:     "countries",
+			SkipIf:   func() (bool, string) { return !empty, "table not empty" },
+			Function: func() error { ran = append(ran, "countries"); return nil },
+		},
+	)
+
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []string{"users"}, ran)
+	report := manager.LastRunReport()
+	assert.Equal(t, SeederReport{Name: "countries", Skipped: true, Reason: "table not empty"}, report.Seeders[1])
+
+	result, err := manager.Converge(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ConvergeSeeder{Name: "countries", Status: ConvergeSkipped, Reason: "table not empty"}, result.Seeders[1])
+
+	empty = true
+	ran = []string{}
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []string{"users", "countries"}, ran)
+	assert.False(t, manager.LastRunReport().Seeders[1].Skipped)
+}
+
+// TestPriority tests ordering seeders by priority, then registration order
+func TestPriority(t *testing.T) {
+	var executed []string
+	seeder := func(name string, priority int) SeederItem {
+		return SeederItem{Name: name, Priority: priority, Function: func() error {
+			executed = append(executed, name)
+			return nil
+		}}
+	}
+	manager := NewSeederManager()
+	assert.NoError(t, manager.RegisterSeeders(seeder("orders", 10), seeder("users", 0), seeder("settings", 0)))
+	snapshot := manager.GetRegisteredSeeders()
+	assert.NoError(t, manager.RegisterSeeders(seeder("roles", -5), seeder("invoices", 10), seeder("countries", -5)))
+	assert.NoError(t, manager.RegisterSeeder("audit", func() error {
+		executed = append(executed, "audit")
+		return nil
+	}, WithPriority(100)))
+
+	order := []string{"roles", "countries", "users", "settings", "orders", "invoices", "audit"}
+	assert.Equal(t, order, manager.GetRegisteredSeeders())
+	assert.Equal(t, []string{"users", "settings", "orders"}, snapshot, "earlier snapshots are unchanged")
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, order, executed)
+
+	dependent := NewSeederManager()
+	dependent.RegisterSeeders(
+		SeederItem{Name: "users", Priority: 1, Function: func() error { return nil }},
+		SeederItem{Name: "orders", DependsOn: []string{"users"}, Function: func() error { return nil }},
+	)
+	assert.ErrorContains(t, dependent.RunAllSeedersParallel(2), "seeder 'orders' depends on 'users', which must be registered before it or have a lower Priority")
+}
@@ -0,0 +1,31 @@
+package goseeder
+
+import "context"
+
+// IsSeeding reports whether ctx belongs to a seed run, or was marked with
+// ContextWithSeeding. Application code reached through service-layer calls
+// made by seeders uses it to skip side effects such as webhooks, emails or
+// analytics events.
+func IsSeeding(ctx context.Context) bool {
+	seeding, _ := ctx.Value(seedingKey).(bool)
+	return seeding
+}
+
+// ContextWithSeeding marks ctx as part of seeding, for data created outside
+// a manager run, e.g. by a custom import script
+func ContextWithSeeding(ctx context.Context) context.Context {
+	return context.WithValue(ctx, seedingKey, true)
+}
+
+// UnlessSeeding calls fn unless ctx belongs to a seed run, in which case
+// the side effect is skipped and nil is returned:
+//
+//	err := goseeder.UnlessSeeding(ctx, func() error {
+//		return mailer.SendWelcome(ctx, user)
+//	})
+func UnlessSeeding(ctx context.Context, fn func() error) error {
+	if IsSeeding(ctx) {
+		return nil
+	}
+	return fn()
+}
@@ -0,0 +1,44 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsSeeding tests the seeding context flag
+func TestIsSeeding(t *testing.T) {
+	t.Run("Set during runs", func(t *testing.T) {
+		manager := NewSeederManager()
+		var seeding bool
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			seeding = IsSeeding(ctx)
+			return nil
+		})
+
+		assert.NoError(t, manager.RunSeederByName("users"))
+		assert.True(t, seeding)
+		assert.False(t, IsSeeding(context.Background()))
+	})
+
+	t.Run("Marked manually", func(t *testing.T) {
+		assert.True(t, IsSeeding(ContextWithSeeding(context.Background())))
+	})
+}
+
+// TestUnlessSeeding tests skipping side effects while seeding
+func TestUnlessSeeding(t *testing.T) {
+	sent := 0
+	sendEmail := func() error {
+		sent++
+		return errors.New("smtp down")
+	}
+
+	assert.NoError(t, UnlessSeeding(ContextWithSeeding(context.Background()), sendEmail))
+	assert.Equal(t, 0, sent)
+
+	assert.Error(t, UnlessSeeding(context.Background(), sendEmail))
+	assert.Equal(t, 1, sent)
+}
@@ -0,0 +1,168 @@
+package goseeder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// IsGlob reports whether pattern contains glob metacharacters ('*', '?' or '[')
+func IsGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// MatchGlob returns the names matching the glob pattern, e.g. "user_*", in
+// their original order. Patterns use path.Match syntax.
+func MatchGlob(names []string, pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid glob pattern '%s': %w", pattern, err)
+	}
+	return filterNames(names, func(name string) bool {
+		matched, _ := path.Match(pattern, name)
+		return matched
+	}), nil
+}
+
+// MatchRegexp returns the names matching the regular expression expr, e.g.
+// "^billing_", in their original order
+func MatchRegexp(names []string, expr string) ([]string, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s': %w", expr, err)
+	}
+	return filterNames(names, re.MatchString), nil
+}
+
+// GetSeedersByGlob returns, in registration order, the names of the seeders
+// matching the glob pattern. Use ExpandDependencies to run them with the
+// seeders they depend on.
+func (sm *SeederManager) GetSeedersByGlob(pattern string) ([]string, error) {
+	return MatchGlob(sm.GetRegisteredSeeders(), pattern)
+}
+
+// GetSeedersMatching returns, in registration order, the names of the
+// seeders matching the regular expression expr
+func (sm *SeederManager) GetSeedersMatching(expr string) ([]string, error) {
+	return MatchRegexp(sm.GetRegisteredSeeders(), expr)
+}
+
+// ExpandDependencies returns the named seeders, with aliases resolved,
+// together with the seeders they depend on, directly or not. Every seeder
+// comes after its dependencies and otherwise keeps its place, so the
+// result can be run with RunSeedersInOrder. Unknown names, unknown
+// dependencies and dependency cycles are errors.
+func (sm *SeederManager) ExpandDependencies(names []string) ([]string, error) {
+	expanded := make([]string, 0, len(names))
+	added := make(map[string]bool)
+	visiting := make(map[string]bool)
+
+	var visit func(name, dependent string) error
+	visit = func(name, dependent string) error {
+		seeder, exists := sm.lookupSeeder(name)
+		switch {
+		case !exists && dependent == "":
+			return fmt.Errorf("seeder with name '%s' not found%s", name, didYouMean(name, sm.GetRegisteredSeeders()))
+		case !exists:
+			return fmt.Errorf("seeder '%s' depends on unknown seeder '%s'%s", dependent, name, didYouMean(name, sm.GetRegisteredSeeders()))
+		case added[seeder.Name]:
+			return nil
+		case visiting[seeder.Name]:
+			return fmt.Errorf("seeder '%s' depends on itself through '%s'", seeder.Name, dependent)
+		}
+		visiting[seeder.Name] = true
+		for _, dependency := range seeder.DependsOn {
+			if err := visit(dependency, seeder.Name); err != nil {
+				return err
+			}
+		}
+		added[seeder.Name] = true
+		expanded = append(expanded, seeder.Name)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, ""); err != nil {
+			return nil, err
+		}
+	}
+	return expanded, nil
+}
+
+// checkDependencyOrder fails when one of names depends on a seeder listed
+// after it. Dependencies not listed are taken as seeded already.
+func (sm *SeederManager) checkDependencyOrder(names []string) error {
+	position := make(map[string]int, len(names))
+	for i, name := range names {
+		if resolved, exists := sm.ResolveSeeder(name); exists {
+			if _, listed := position[resolved]; !listed {
+				position[resolved] = i
+			}
+		}
+	}
+	for i, name := range names {
+		seeder, exists := sm.lookupSeeder(name)
+		if !exists {
+			continue
+		}
+		for _, dependency := range seeder.DependsOn {
+			resolved, _ := sm.ResolveSeeder(dependency)
+			if at, listed := position[resolved]; listed && at > i {
+				return fmt.Errorf("seeder '%s' is listed before '%s', which it depends on", seeder.Name, resolved)
+			}
+		}
+	}
+	return nil
+}
+
+// ReadSeederList reads a list of seeder names, one per line, e.g. generated
+// by change detection tooling. Blank lines and "#" comments are ignored,
+// and a name may only be listed once.
+func ReadSeederList(r io.Reader) ([]string, error) {
+	var names []string
+	listed := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		name := strings.TrimSpace(text)
+		if name == "" {
+			continue
+		}
+		if first, exists := listed[name]; exists {
+			return nil, fmt.Errorf("line %d: seeder '%s' is already listed on line %d", line, name, first)
+		}
+		listed[name] = line
+		names = append(names, name)
+	}
+	return names, scanner.Err()
+}
+
+// ReadSeederListFile reads a list of seeder names from a file, see
+// ReadSeederList
+func ReadSeederListFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seeder list: %w", err)
+	}
+	defer file.Close()
+
+	names, err := ReadSeederList(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return names, nil
+}
+
+// filterNames returns the names accepted by keep
+func filterNames(names []string, keep func(string) bool) []string {
+	selected := make([]string, 0)
+	for _, name := range names {
+		if keep(name) {
+			selected = append(selected, name)
+		}
+	}
+	return selected
+}
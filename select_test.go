@@ -0,0 +1,199 @@
+package goseeder
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSelectionManager registers seeders of two functional areas, recording runs
+func newSelectionManager(ran *[]string) *SeederManager {
+	manager := NewSeederManager()
+	for _, name := range []string{"user_roles", "billing_plans", "users", "billing_invoices", "user_settings"} {
+		name := name
+		manager.RegisterSeeder(name, func() error {
+			*ran = append(*ran, name)
+			return nil
+		})
+	}
+	return manager
+}
+
+// newDependentManager registers billing seeders depending on seeders of
+// another area, recording runs
+func newDependentManager(ran *[]string) *SeederManager {
+	manager := NewSeederManager()
+	record := func(name string) func() error {
+		return func() error {
+			*ran = append(*ran, name)
+			return nil
+		}
+	}
+	manager.RegisterSeeders(
+		SeederItem{Name: "users", Function: record("users"), Aliases: []string{"accounts"}},
+		SeederItem{Name: "currencies", Function: record("currencies")},
+		SeederItem{Name: "billing_plans", Function: record("billing_plans"), DependsOn: []string{"currencies"}},
+		SeederItem{Name: "billing_invoices", Function: record("billing_invoices"), DependsOn: []string{"accounts", "billing_plans"}},
+	)
+	return manager
+}
+
+// TestExpandDependencies tests adding the dependencies of a selection
+func TestExpandDependencies(t *testing.T) {
+	manager := newDependentManager(new([]string))
+
+	expanded, err := manager.ExpandDependencies([]string{"billing_invoices"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"users", "currencies", "billing_plans", "billing_invoices"}, expanded)
+
+	expanded, err = manager.ExpandDependencies([]string{"billing_plans", "accounts", "currencies"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"currencies", "billing_plans", "users"}, expanded, "listed order kept after dependencies")
+
+	_, err = manager.ExpandDependencies([]string{"invoices"})
+	assert.ErrorContains(t, err, "seeder with name 'invoices' not found")
+
+	manager.RegisterSeeders(SeederItem{Name: "refunds", Function: func() error { return nil }, DependsOn: []string{"payments"}})
+	_, err = manager.ExpandDependencies([]string{"refunds"})
+	assert.EqualError(t, err, "seeder 'refunds' depends on unknown seeder 'payments'")
+}
+
+// TestRunSeedersInOrderDependencies tests rejecting lists ordered against
+// their dependencies
+func TestRunSeedersInOrderDependencies(t *testing.T) {
+	ran := []string{}
+	manager := newDependentManager(&ran)
+
+	err := manager.RunSeedersInOrder([]string{"billing_plans", "currencies"})
+	assert.EqualError(t, err, "seeder 'billing_plans' is listed before 'currencies', which it depends on")
+	assert.Empty(t, ran, "nothing runs")
+
+	assert.NoError(t, manager.RunSeedersInOrder([]string{"billing_plans"}), "unlisted dependencies are taken as seeded")
+	assert.Equal(t, []string{"billing_plans"}, ran)
+}
+
+// TestSeederSelection tests glob and regexp selection
+func TestSeederSelection(t *testing.T) {
+	manager := newSelectionManager(new([]string))
+
+	selected, err := manager.GetSeedersByGlob("user_*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user_roles", "user_settings"}, selected)
+
+	selected, err = manager.GetSeedersByGlob("user?")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"users"}, selected)
+
+	selected, err = manager.GetSeedersMatching("^billing_")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"billing_plans", "billing_invoices"}, selected)
+
+	selected, err = manager.GetSeedersMatching("^none")
+	assert.NoError(t, err)
+	assert.Empty(t, selected)
+
+	_, err = manager.GetSeedersByGlob("user_[")
+	assert.ErrorContains(t, err, "invalid glob pattern")
+	_, err = manager.GetSeedersMatching("(")
+	assert.ErrorContains(t, err, "invalid pattern")
+
+	assert.True(t, IsGlob("user_*"))
+	assert.False(t, IsGlob("user_roles"))
+}
+
+// TestCLIRunSelection tests running selected seeders from the CLI
+func TestCLIRunSelection(t *testing.T) {
+	cases := map[string]struct {
+		glob, expr string
+		expected   []string
+	}{
+		"Glob":           {"user*", "", []string{"user_roles", "users", "user_settings"}},
+		"Regexp":         {"", "^billing_", []string{"billing_plans", "billing_invoices"}},
+		"Both":           {"user*", "s$", []string{"user_roles", "users", "user_settings"}},
+		"All with match": {"all", "invoices$", []string{"billing_invoices"}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ran := []string{}
+			cli := NewCLI(newSelectionManager(&ran))
+
+			assert.NoError(t, cli.runSelection(context.Background(), tc.glob, tc.expr))
+			assert.Equal(t, tc.expected, ran)
+		})
+	}
+
+	t.Run("Includes dependencies", func(t *testing.T) {
+		ran := []string{}
+		cli := NewCLI(newDependentManager(&ran))
+		var out strings.Builder
+		cli.SetOutput(&out)
+
+		assert.NoError(t, cli.runSelection(context.Background(), "", "invoices$"))
+		assert.Equal(t, []string{"users", "currencies", "billing_plans", "billing_invoices"}, ran)
+		assert.Contains(t, out.String(), "Including 3 seeders the selection depends on: users, currencies, billing_plans")
+	})
+
+	t.Run("No match", func(t *testing.T) {
+		ran := []string{}
+		cli := NewCLI(newSelectionManager(&ran))
+
+		assert.ErrorContains(t, cli.runSelection(context.Background(), "orders_*", ""), "no seeders match")
+		assert.Empty(t, ran)
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ran := []string{}
+		cli := NewCLI(newSelectionManager(&ran))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, cli.runSelection(ctx, "user*", ""), context.Canceled)
+		assert.Empty(t, ran)
+	})
+}
+
+// TestReadSeederList tests parsing a list of seeder names
+func TestReadSeederList(t *testing.T) {
+	names, err := ReadSeederList(strings.NewReader("# generated by change detection\nusers\n\n  billing_plans  # changed pricing\nuser_roles\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"users", "billing_plans", "user_roles"}, names)
+
+	_, err = ReadSeederList(strings.NewReader("users\nroles\nusers\n"))
+	assert.EqualError(t, err, "line 3: seeder 'users' is already listed on line 1")
+
+	_, err = ReadSeederListFile("missing.txt")
+	assert.ErrorContains(t, err, "failed to read seeder list")
+}
+
+// TestCLIRunFromFile tests running the seeders listed in a file
+func TestCLIRunFromFile(t *testing.T) {
+	ran := []string{}
+	cli := NewCLI(newSelectionManager(&ran))
+	cli.SetOutput(io.Discard)
+
+	path := writeFixture(t, "seeders.txt", "users\nuser_roles # listed after users on purpose\n")
+	assert.NoError(t, cli.runFromFile(context.Background(), path))
+	assert.Equal(t, []string{"users", "user_roles"}, ran, "the listed order")
+
+	ran = ran[:0]
+	cli.stdin = strings.NewReader("billing_plans\n")
+	assert.NoError(t, cli.runFromFile(context.Background(), "-"))
+	assert.Equal(t, []string{"billing_plans"}, ran)
+
+	ran = ran[:0]
+	err := cli.runFromFile(context.Background(), writeFixture(t, "seeders.txt", "users\nbiling_plans\n"))
+	assert.ErrorIs(t, err, ErrUsage)
+	assert.ErrorContains(t, err, "lists unknown seeder 'biling_plans', did you mean 'billing_plans'?")
+	assert.Empty(t, ran, "nothing runs when a name is unknown")
+
+	assert.ErrorContains(t, cli.runFromFile(context.Background(), writeFixture(t, "seeders.txt", "# nothing changed\n")), "lists no seeders")
+
+	ran = []string{}
+	cli = NewCLI(newDependentManager(&ran))
+	cli.SetOutput(io.Discard)
+	assert.NoError(t, cli.runFromFile(context.Background(), writeFixture(t, "seeders.txt", "billing_plans\nusers\n")))
+	assert.Equal(t, []string{"currencies", "billing_plans", "users"}, ran, "dependencies run first")
+}
@@ -0,0 +1,66 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+	"maps"
+)
+
+// ContextWithAppend returns a copy of ctx whose runs append to the data of
+// the previous run, as the CLI's -append flag does: sequences continue
+// after the last values the previous run report recorded instead of
+// starting at 1, so repeated runs grow the data set rather than colliding
+// with it. Reports come from the ReportStore, see SetReportStore, or the
+// manager's previous run without one.
+func ContextWithAppend(ctx context.Context) context.Context {
+	return context.WithValue(ctx, appendKey, true)
+}
+
+// appendMode reports whether ctx runs continue the previous run's sequences
+func appendMode(ctx context.Context) bool {
+	appending, _ := ctx.Value(appendKey).(bool)
+	return appending
+}
+
+// NextSequence returns the next value of the named sequence of the current
+// run, e.g. the ID or the number in the email of a generated user. See
+// ReserveSequence.
+func NextSequence(ctx context.Context, name string) (int64, error) {
+	return ReserveSequence(ctx, name, 1)
+}
+
+// ReserveSequence reserves n consecutive values of the named sequence of
+// the current run and returns the first, e.g. the IDs of a batch of n
+// generated rows. Sequences start at 1 in every run, or continue from the
+// previous run in append mode, see ContextWithAppend. Their last values
+// are recorded in the run report.
+func ReserveSequence(ctx context.Context, name string, n int64) (int64, error) {
+	state, ok := ctx.Value(runKey).(*runState)
+	if !ok {
+		return 0, fmt.Errorf("sequence '%s' used outside a run", name)
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("sequence '%s': cannot reserve %d values", name, n)
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	first := state.sequences[name] + 1
+	state.sequences[name] += n
+	return first, nil
+}
+
+// continueSequences starts the sequences of state after the values the
+// previous run recorded, for runs in append mode
+func (sm *SeederManager) continueSequences(ctx context.Context, state *runState) error {
+	previous, err := sm.previousReport(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load the previous run report to append to: %w", err)
+	}
+	if previous == nil {
+		logWarn(ctx, "Appending without a previous run report, sequences start at 1")
+		return nil
+	}
+	maps.Copy(state.sequences, previous.Sequences)
+	logInfo(ctx, "Appending to run %s: continuing %d sequences", previous.RunID, len(previous.Sequences))
+	return nil
+}
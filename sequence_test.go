@@ -0,0 +1,117 @@
+package goseeder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSequences tests reserving sequence values within a run
+func TestSequences(t *testing.T) {
+	_, err := NextSequence(context.Background(), "users")
+	assert.EqualError(t, err, "sequence 'users' used outside a run")
+
+	var values []int64
+	manager := NewSeederManager()
+	manager.RegisterSeederContext("users", func(ctx context.Context) error {
+		first, err := ReserveSequence(ctx, "users", 3)
+		assert.NoError(t, err)
+		next, err := NextSequence(ctx, "users")
+		assert.NoError(t, err)
+		other, err := NextSequence(ctx, "orders")
+		assert.NoError(t, err)
+		_, err = ReserveSequence(ctx, "users", 0)
+		assert.EqualError(t, err, "sequence 'users': cannot reserve 0 values")
+		values = append(values, first, next, other)
+		return nil
+	})
+
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []int64{1, 4, 1}, values)
+	assert.Equal(t, map[string]int64{"users": 4, "orders": 1}, manager.LastRunReport().Sequences)
+
+	values = nil
+	assert.NoError(t, manager.RunAllSeeders())
+	assert.Equal(t, []int64{1, 4, 1}, values, "sequences start at 1 without append mode")
+
+	values = nil
+	assert.NoError(t, manager.RunAllSeedersContext(ContextWithAppend(context.Background())))
+	assert.Equal(t, []int64{5, 8, 2}, values)
+	assert.Equal(t, map[string]int64{"users": 8, "orders": 2}, manager.LastRunReport().Sequences)
+}
+
+// TestAppendAcrossProcesses tests continuing sequences from a report store,
+// including with generated fixture rows
+func TestAppendAcrossProcesses(t *testing.T) {
+	dir := t.TempDir()
+	fixture := filepath.Join(dir, "users.json")
+	assert.NoError(t, os.WriteFile(fixture, []byte(`[
+		{"_generate": {"count": 2, "sequence": "users", "template": {"email": "user[[ .Index ]]@example.com"}}}
+	]`), 0o644))
+	store := NewFileReportStore(filepath.Join(dir, "report.json"))
+
+	var emails []any
+	run := func(ctx context.Context) {
+		manager := NewSeederManager()
+		manager.SetReportStore(store)
+		manager.RegisterSeederContext("users", func(ctx context.Context) error {
+			records, err := ReadFixtureFileContext(ctx, fixture)
+			for _, record := range records {
+				emails = append(emails, record["email"])
+			}
+			return err
+		})
+		assert.NoError(t, manager.RunAllSeedersContext(ctx))
+	}
+
+	run(context.Background())
+	run(ContextWithAppend(context.Background()))
+	run(ContextWithAppend(context.Background()))
+	assert.Equal(t, []any{
+		"user1@example.com", "user2@example.com",
+		"user3@example.com", "user4@example.com",
+		"user5@example.com", "user6@example.com",
+	}, emails)
+
+	records, err := ReadFixtureFile(fixture)
+	assert.NoError(t, err)
+	assert.Equal(t, "user1@example.com", records[0]["email"], "outside a run indexes start at 1")
+}
+
+// TestAppendWithoutReport tests append runs failing or starting fresh
+func TestAppendWithoutReport(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeders(SeederItem{Name: "users", Function: func() error { return nil }})
+	manager.SetReportStore(failingReportStore{})
+	err := manager.RunAllSeedersContext(ContextWithAppend(context.Background()))
+	assert.EqualError(t, err, "failed to load the previous run report to append to: store unavailable")
+
+	logger := &recordingLogger{}
+	fresh := NewSeederManager().WithLogger(logger)
+	fresh.RegisterSeeders(SeederItem{Name: "users", Function: func() error { return nil }})
+	assert.NoError(t, fresh.RunAllSeedersContext(ContextWithAppend(context.Background())))
+	assert.Contains(t, logger.lines, "warn: Appending without a previous run report, sequences start at 1 run_id="+fresh.LastRunReport().RunID)
+}
+
+// TestCLIAppend tests the -append flag
+func TestCLIAppend(t *testing.T) {
+	var values []int64
+	manager := NewSeederManager()
+	manager.RegisterSeederContext("users", func(ctx context.Context) error {
+		value, err := NextSequence(ctx, "users")
+		values = append(values, value)
+		return err
+	})
+	cli := NewCLI(manager)
+
+	args := os.Args
+	defer func() { os.Args = args }()
+	for _, arguments := range [][]string{{"seeder", "-type=users"}, {"seeder", "-append", "-type=users"}} {
+		os.Args = arguments
+		assert.NoError(t, cli.Run())
+	}
+	assert.Equal(t, []int64{1, 2}, values)
+}
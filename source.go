@@ -0,0 +1,214 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Record is a single row or object read from a Source
+type Record map[string]any
+
+// Source is a read-only connection seeders derive data from, such as
+// another database or a REST API
+type Source interface {
+	// Read calls fn for every record, stopping at the first error
+	Read(ctx context.Context, fn func(Record) error) error
+}
+
+// SourceFunc adapts a function to the Source interface
+type SourceFunc func(ctx context.Context, fn func(Record) error) error
+
+// Read calls f(ctx, fn)
+func (f SourceFunc) Read(ctx context.Context, fn func(Record) error) error {
+	return f(ctx, fn)
+}
+
+// Sink receives the transformed records of a Transfer in batches
+type Sink interface {
+	Write(ctx context.Context, records []Record) error
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(ctx context.Context, records []Record) error
+
+// Write calls f(ctx, records)
+func (f SinkFunc) Write(ctx context.Context, records []Record) error {
+	return f(ctx, records)
+}
+
+// sourceKey is the context key of a named source
+type sourceKey string
+
+// WithSource declares a named read-only source available to every seeder
+// through SourceFromContext. It returns the manager to allow chaining.
+func (sm *SeederManager) WithSource(name string, source Source) *SeederManager {
+	return sm.WithContextValue(sourceKey(name), source)
+}
+
+// SourceFromContext returns the source declared with WithSource under name
+func SourceFromContext(ctx context.Context, name string) (Source, bool) {
+	source, ok := ctx.Value(sourceKey(name)).(Source)
+	return source, ok
+}
+
+// TransferOptions configures a Transfer
+type TransferOptions struct {
+	// Transform maps a source record to the record written to the sink.
+	// Returning false skips the record. Records are passed through when nil.
+	Transform func(Record) (Record, bool, error)
+	BatchSize int // Records per Sink.Write call (defaults to 100)
+}
+
+// Transfer streams records from source through the transform into sink and
+// returns the number of records written
+func Transfer(ctx context.Context, source Source, sink Sink, options TransferOptions) (int64, error) {
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	var written int64
+	batch := make([]Record, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := sink.Write(ctx, batch); err != nil {
+			return fmt.Errorf("transfer write failed: %w", err)
+		}
+		written += int64(len(batch))
+		batch = make([]Record, 0, batchSize)
+		return nil
+	}
+
+	err := source.Read(ctx, func(record Record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if options.Transform != nil {
+			transformed, keep, err := options.Transform(record)
+			if err != nil {
+				return fmt.Errorf("transfer transform failed: %w", err)
+			}
+			if !keep {
+				return nil
+			}
+			record = transformed
+		}
+		batch = append(batch, record)
+		if len(batch) >= batchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return written, err
+	}
+	return written, flush()
+}
+
+// SQLSource reads the rows of a query inside a read-only transaction
+type SQLSource struct {
+	db    *sql.DB
+	query string
+	args  []any
+}
+
+// NewSQLSource creates a source streaming the rows returned by query
+func NewSQLSource(db *sql.DB, query string, args ...any) *SQLSource {
+	return &SQLSource{db: db, query: query, args: args}
+}
+
+// Read implements Source. Columns become record keys; []byte values are
+// converted to strings.
+func (s *SQLSource) Read(ctx context.Context, fn func(Record) error) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to open read-only transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, s.query, s.args...)
+	if err != nil {
+		return fmt.Errorf("source query failed: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return fmt.Errorf("source scan failed: %w", err)
+		}
+		record := make(Record, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				record[column] = string(b)
+			} else {
+				record[column] = values[i]
+			}
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// JSONSource reads a JSON array of objects from an HTTP endpoint
+type JSONSource struct {
+	client *http.Client
+	url    string
+}
+
+// NewJSONSource creates a source streaming the objects of the JSON array
+// returned by a GET request to url. A nil client uses http.DefaultClient.
+func NewJSONSource(client *http.Client, url string) *JSONSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &JSONSource{client: client, url: url}
+}
+
+// Read implements Source, decoding the array one object at a time
+func (s *JSONSource) Read(ctx context.Context, fn func(Record) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("source request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("source request failed: %s", resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return fmt.Errorf("source response is not a JSON array")
+	}
+	for decoder.More() {
+		var record Record
+		if err := decoder.Decode(&record); err != nil {
+			return fmt.Errorf("source decode failed: %w", err)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
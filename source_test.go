@@ -0,0 +1,114 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// numbersSource returns a source emitting records {"n": 1} .. {"n": count}
+func numbersSource(count int) Source {
+	return SourceFunc(func(ctx context.Context, fn func(Record) error) error {
+		for i := 1; i <= count; i++ {
+			if err := fn(Record{"n": i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// TestTransfer tests streaming records from a source into a sink
+func TestTransfer(t *testing.T) {
+	t.Run("Batches and transforms", func(t *testing.T) {
+		batches := [][]Record{}
+		sink := SinkFunc(func(ctx context.Context, records []Record) error {
+			batches = append(batches, records)
+			return nil
+		})
+
+		written, err := Transfer(context.Background(), numbersSource(7), sink, TransferOptions{
+			BatchSize: 2,
+			Transform: func(r Record) (Record, bool, error) {
+				if r["n"].(int)%2 == 0 {
+					return nil, false, nil
+				}
+				return Record{"label": fmt.Sprintf("item-%d", r["n"])}, true, nil
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), written)
+		assert.Len(t, batches, 2)
+		assert.Equal(t, Record{"label": "item-7"}, batches[1][1])
+	})
+
+	t.Run("Stops on errors", func(t *testing.T) {
+		sink := SinkFunc(func(ctx context.Context, records []Record) error {
+			return errors.New("disk full")
+		})
+		_, err := Transfer(context.Background(), numbersSource(3), sink, TransferOptions{})
+		assert.ErrorContains(t, err, "disk full")
+
+		_, err = Transfer(context.Background(), numbersSource(3), SinkFunc(func(context.Context, []Record) error { return nil }), TransferOptions{
+			Transform: func(Record) (Record, bool, error) { return nil, false, errors.New("bad row") },
+		})
+		assert.ErrorContains(t, err, "bad row")
+	})
+
+	t.Run("Stops when canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		written, err := Transfer(ctx, numbersSource(3), SinkFunc(func(context.Context, []Record) error { return nil }), TransferOptions{})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int64(0), written)
+	})
+}
+
+// TestWithSource tests declaring sources for seeders
+func TestWithSource(t *testing.T) {
+	manager := NewSeederManager()
+	manager.WithSource("prod-replica", numbersSource(2))
+	var found, missing bool
+
+	manager.RegisterSeederContext("users", func(ctx context.Context) error {
+		_, found = SourceFromContext(ctx, "prod-replica")
+		_, missing = SourceFromContext(ctx, "crm")
+		return nil
+	})
+
+	assert.NoError(t, manager.RunSeederByName("users"))
+	assert.True(t, found)
+	assert.False(t, missing)
+}
+
+// TestJSONSource tests reading records from an HTTP endpoint
+func TestJSONSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `[{"name": "Alice"}, {"name": "Bob"}]`)
+	}))
+	defer server.Close()
+
+	records := []Record{}
+	err := NewJSONSource(nil, server.URL+"/users").Read(context.Background(), func(r Record) error {
+		records = append(records, r)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{{"name": "Alice"}, {"name": "Bob"}}, records)
+
+	err = NewJSONSource(nil, server.URL+"/broken").Read(context.Background(), func(Record) error { return nil })
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+// ErrStopped is wrapped by the error of a run that stopped early because it
+// was asked to, see WithGracefulStop
+var ErrStopped = errors.New("run stopped")
+
+// StopError is the cause of a run stopped by a signal
+type StopError struct {
+	Signal os.Signal
+	Forced bool // The current seeder was canceled instead of finished
+}
+
+func (se *StopError) Error() string {
+	if se.Forced {
+		return "run canceled by a second " + se.Signal.String()
+	}
+	return "run stopped by " + se.Signal.String() + " after the current seeder"
+}
+
+func (se *StopError) Unwrap() error { return ErrStopped }
+
+// WithGracefulStop returns a context whose runs stop before starting their
+// next seeder once stop is called, letting the current seeder finish. The
+// run fails with the cause given to stop, or ErrStopped if it is nil. Cancel
+// the context itself to also cancel the current seeder.
+func WithGracefulStop(ctx context.Context) (context.Context, func(cause error)) {
+	stopCtx, cancel := context.WithCancelCause(context.Background())
+	stop := func(cause error) {
+		if cause == nil {
+			cause = ErrStopped
+		}
+		cancel(cause)
+	}
+	return context.WithValue(ctx, stopKey, stopCtx), stop
+}
+
+// stopCause returns why a run must not start another seeder: the cause of
+// ctx being done or of a graceful stop, or nil to keep going
+func stopCause(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	if stop, ok := ctx.Value(stopKey).(context.Context); ok && stop.Err() != nil {
+		return context.Cause(stop)
+	}
+	return nil
+}
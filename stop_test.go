@@ -0,0 +1,104 @@
+package goseeder
+
+import (
+	"context"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForStop blocks until the run of ctx is asked to stop
+func waitForStop(ctx context.Context) {
+	deadline := time.Now().Add(time.Second)
+	for stopCause(ctx) == nil && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestWithGracefulStop tests finishing the current seeder before stopping
+func TestWithGracefulStop(t *testing.T) {
+	manager := NewSeederManager()
+	ran := []string{}
+	ctx, stop := WithGracefulStop(context.Background())
+	manager.RegisterSeeders(
+		SeederItem{Name: "users", ContextFunction: func(ctx context.Context) error {
+			stop(nil)
+			ran = append(ran, "users")
+			return ctx.Err()
+		}},
+		SeederItem{Name: "orders", Function: func() error {
+			ran = append(ran, "orders")
+			return nil
+		}},
+	)
+
+	err := manager.RunAllSeedersContext(ctx)
+
+	assert.ErrorIs(t, err, ErrStopped)
+	assert.Equal(t, []string{"users"}, ran, "the current seeder finishes, the next one does not start")
+}
+
+// TestCLISignals tests the graceful and forced stops of the signal handler
+func TestCLISignals(t *testing.T) {
+	run := func(send []os.Signal, waitForCancel bool) (*SeederManager, []string, error) {
+		manager := NewSeederManager()
+		cli := NewCLI(manager)
+		cli.SetOutput(io.Discard)
+		signals := make(chan os.Signal, len(send))
+		ctx, release := cli.watchSignals(context.Background(), signals)
+		defer release()
+
+		ran := []string{}
+		manager.RegisterSeeders(
+			SeederItem{Name: "users", ContextFunction: func(ctx context.Context) error {
+				for _, sig := range send {
+					signals <- sig
+				}
+				if waitForCancel {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				waitForStop(ctx)
+				ran = append(ran, "users")
+				return nil
+			}},
+			SeederItem{Name: "orders", Function: func() error {
+				ran = append(ran, "orders")
+				return nil
+			}},
+		)
+		err := manager.RunAllSeedersContext(ctx)
+		return manager, ran, err
+	}
+
+	t.Run("First interrupt finishes the current seeder", func(t *testing.T) {
+		manager, ran, err := run([]os.Signal{os.Interrupt}, false)
+
+		assert.ErrorIs(t, err, ErrStopped)
+		assert.Equal(t, []string{"users"}, ran)
+		assert.Equal(t, "interrupt", manager.LastRunReport().Stopped)
+	})
+
+	t.Run("Second interrupt cancels the current seeder", func(t *testing.T) {
+		manager, ran, err := run([]os.Signal{os.Interrupt, os.Interrupt}, true)
+
+		var stop *StopError
+		assert.ErrorAs(t, err, &stop)
+		assert.True(t, stop.Forced)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, ran)
+		assert.Equal(t, "interrupt (forced)", manager.LastRunReport().Stopped)
+	})
+
+	t.Run("SIGTERM stops gracefully", func(t *testing.T) {
+		manager, ran, err := run([]os.Signal{syscall.SIGTERM, syscall.SIGTERM}, false)
+
+		assert.ErrorIs(t, err, ErrStopped)
+		assert.Equal(t, []string{"users"}, ran)
+		assert.Equal(t, "terminated", manager.LastRunReport().Stopped)
+	})
+}
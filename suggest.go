@@ -0,0 +1,101 @@
+package goseeder
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the number of names suggested for an unknown name
+const maxSuggestions = 3
+
+// Suggest returns up to three candidates closest to name, best match first.
+// Case and the separators '-', '_', '.' and ' ' are ignored, so
+// "User-Roles" matches "user_roles" exactly; other names are suggested
+// when they are within a few typos of name.
+func Suggest(name string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+		index    int
+	}
+
+	normalized := normalizeName(name)
+	limit := max(1, len([]rune(normalized))/4)
+	var matches []match
+	for i, candidate := range candidates {
+		distance := editDistance(normalized, normalizeName(candidate))
+		if distance <= limit {
+			matches = append(matches, match{candidate, distance, i})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].index < matches[j].index
+	})
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+
+	suggestions := make([]string, len(matches))
+	for i, m := range matches {
+		suggestions[i] = m.name
+	}
+	return suggestions
+}
+
+// didYouMean returns a ", did you mean ...?" suffix for a not-found error,
+// or an empty string when no candidate is close to name
+func didYouMean(name string, candidates []string) string {
+	suggestions := Suggest(name, candidates)
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return ", did you mean '" + suggestions[0] + "'?"
+	default:
+		return ", did you mean one of '" + strings.Join(suggestions, "', '") + "'?"
+	}
+}
+
+// normalizeName lowercases name and unifies word separators
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '.', ' ':
+			return '_'
+		}
+		return r
+	}, strings.ToLower(name))
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the Levenshtein distance, with swapped adjacent characters counting as
+// a single edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
@@ -0,0 +1,51 @@
+package goseeder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSuggest tests closest-match suggestions
+func TestSuggest(t *testing.T) {
+	candidates := []string{"users", "user_roles", "departments", "roles", "countries"}
+
+	cases := map[string][]string{
+		"user-roles":  {"user_roles"},
+		"User Roles":  {"user_roles"},
+		"usr_roles":   {"user_roles"},
+		"user":        {"users"},
+		"role":        {"roles"},
+		"departmnts":  {"departments"},
+		"usres":       {"users"},
+		"invoices":    {},
+		"":            {},
+		"userroles":   {"user_roles"},
+		"countries_x": {"countries"},
+	}
+	for name, expected := range cases {
+		assert.Equal(t, expected, Suggest(name, candidates), name)
+	}
+
+	assert.Equal(t, []string{"seed_a", "seed_b", "seed_c"}, Suggest("seed_x", []string{"seed_a", "seed_b", "seed_c", "seed_d"}), "at most three, in order")
+	assert.Equal(t, []string{"user_roles", "user_role"}, Suggest("user-roles", []string{"user_role", "user_roles"}), "best match first")
+}
+
+// TestNotFoundSuggestions tests suggestions in not-found errors
+func TestNotFoundSuggestions(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("user_roles", func() error { return nil })
+	manager.RegisterScenario(Scenario{Name: "checkout_flow", Seeders: []string{"user_roles"}})
+
+	assert.EqualError(t, manager.RunSeederByName("user-roles"), "seeder with name 'user-roles' not found, did you mean 'user_roles'?")
+	assert.EqualError(t, manager.RunSeederByName("invoices"), "seeder with name 'invoices' not found")
+
+	_, err := manager.BenchmarkSeeder("userroles", BenchmarkOptions{})
+	assert.ErrorContains(t, err, "did you mean 'user_roles'?")
+	assert.ErrorContains(t, manager.RunScenario(context.Background(), "checkout-flow", nil), "did you mean 'checkout_flow'?")
+
+	cli := NewCLI(manager)
+	assert.EqualError(t, cli.runCommand(context.Background(), []string{"bootsrap"}), "unknown command: bootsrap, did you mean 'bootstrap'?")
+	assert.ErrorContains(t, NewBootstrap(nil, manager).RunProfile(context.Background(), "Preview"), "did you mean 'preview'?")
+}
@@ -6,6 +6,8 @@ import (
 )
 
 // TestOutputCapture provides utilities for capturing and testing output
+//
+// Deprecated: use goseedertest.OutputCapture.
 type TestOutputCapture struct {
 	originalStdout *os.File
 	originalStderr *os.File
@@ -88,6 +90,8 @@ func (toc *TestOutputCapture) Stop() (stdout, stderr string, err error) {
 }
 
 // CaptureOutput captures output from a function execution
+//
+// Deprecated: use goseedertest.CaptureOutput.
 func CaptureOutput(fn func()) (stdout, stderr string, err error) {
 	capture := NewTestOutputCapture()
 
@@ -101,6 +105,8 @@ func CaptureOutput(fn func()) (stdout, stderr string, err error) {
 }
 
 // TestSeederFunction is a helper type for creating test seeder functions
+//
+// Deprecated: use goseedertest.SeederConfig.
 type TestSeederFunction struct {
 	Name         string
 	ShouldError  bool
@@ -109,6 +115,8 @@ type TestSeederFunction struct {
 }
 
 // CreateTestSeeder creates a test seeder function
+//
+// Deprecated: use goseedertest.NewSeeder.
 func CreateTestSeeder(config TestSeederFunction) func() error {
 	return func() error {
 		if config.ExecutionLog != nil {
@@ -136,6 +144,8 @@ func (te *TestError) Error() string {
 }
 
 // MockSeederManagerInterface defines the interface for mocking SeederManager
+//
+// Deprecated: use Manager.
 type MockSeederManagerInterface interface {
 	RegisterSeeder(name string, function func() error) error
 	RegisterSeeders(seeders ...SeederItem) error
@@ -147,6 +157,8 @@ type MockSeederManagerInterface interface {
 }
 
 // TestSeederManager is a test implementation of SeederManager
+//
+// Deprecated: use goseedertest.FakeManager.
 type TestSeederManager struct {
 	seeders     []SeederItem
 	seederMap   map[string]func() error
@@ -293,6 +305,8 @@ func (tcli *TestCLI) Run() error {
 }
 
 // TestDataBuilder provides a fluent interface for building test data
+//
+// Deprecated: use goseedertest.Builder.
 type TestDataBuilder struct {
 	seeders []SeederItem
 }
@@ -0,0 +1,75 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SeederOption configures a seeder registered with RegisterSeeder or
+// RegisterSeederContext
+type SeederOption func(*SeederItem)
+
+// WithTransaction runs the seeder inside a transaction of the manager's
+// transaction database (see SetTransactionDB), committed when the seeder
+// succeeds and rolled back when it fails or panics, so a seeder failing
+// halfway leaves no partial data. The seeder reaches the transaction with
+// TxFromContext, so register it with RegisterSeederContext.
+func WithTransaction() SeederOption {
+	return func(si *SeederItem) {
+		si.Transaction = true
+	}
+}
+
+// SetTransactionDB sets the database seeders registered WithTransaction
+// (or with SeederItem.Transaction) run their transaction in
+func (sm *SeederManager) SetTransactionDB(db *sql.DB) {
+	sm.transactionDB = db
+}
+
+// TxFromContext returns the transaction of a seeder registered
+// WithTransaction
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey).(*sql.Tx)
+	return tx, ok
+}
+
+// inTransaction wraps run in a transaction of the transaction database
+func (sm *SeederManager) inTransaction(name string, run SeederFunc) SeederFunc {
+	return func(ctx context.Context) (err error) {
+		if _, ok := TxFromContext(ctx); ok {
+			return run(ctx) // Joins the transaction of an atomic run
+		}
+		if sm.transactionDB == nil {
+			return fmt.Errorf("seeder '%s' runs in a transaction but no database is set, see SetTransactionDB", name)
+		}
+		tx, err := sm.transactionDB.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer func() {
+			if err == nil {
+				return
+			}
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				logError(ctx, "Failed to roll back the transaction of seeder '%s': %v", name, rollbackErr)
+				return
+			}
+			logInfo(ctx, "Rolled back the transaction of seeder '%s'", name)
+		}()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				tx.Rollback()
+				panic(recovered)
+			}
+		}()
+
+		if err := run(context.WithValue(ctx, txKey, tx)); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithTransaction tests committing and rolling back seeder transactions
+func TestWithTransaction(t *testing.T) {
+	db, fake := newFakeDB()
+	manager := NewSeederManager()
+	manager.SetTransactionDB(db)
+
+	insert := func(ctx context.Context) error {
+		tx, ok := TxFromContext(ctx)
+		if !ok {
+			return errors.New("no transaction")
+		}
+		_, err := tx.ExecContext(ctx, "INSERT INTO users (id) VALUES (1)")
+		return err
+	}
+	manager.RegisterSeederContext("users", insert, WithTransaction())
+	manager.RegisterSeederContext("orders", func(ctx context.Context) error {
+		if err := insert(ctx); err != nil {
+			return err
+		}
+		return errors.New("duplicate key")
+	}, WithTransaction())
+	manager.RegisterSeederContext("plain", func(ctx context.Context) error {
+		_, ok := TxFromContext(ctx)
+		assert.False(t, ok, "only transaction seeders get one")
+		return nil
+	})
+
+	assert.NoError(t, manager.RunSeederByName("users"))
+	assert.ErrorContains(t, manager.RunSeederByName("orders"), "seeder 'orders' failed: duplicate key")
+	assert.NoError(t, manager.RunSeederByName("plain"))
+	assert.Equal(t, []string{
+		"BEGIN", "INSERT INTO users (id) VALUES (1)", "COMMIT",
+		"BEGIN", "INSERT INTO users (id) VALUES (1)", "ROLLBACK",
+	}, fake.Events())
+}
+
+// TestWithTransactionPanic tests that a panicking seeder is rolled back
+func TestWithTransactionPanic(t *testing.T) {
+	db, fake := newFakeDB()
+	manager := NewSeederManager()
+	manager.SetTransactionDB(db)
+	manager.RegisterSeederContext("users", func(ctx context.Context) error { panic("boom") }, WithTransaction())
+
+	assert.PanicsWithValue(t, "boom", func() { manager.RunSeederByName("users") })
+	assert.Equal(t, []string{"BEGIN", "ROLLBACK"}, fake.Events())
+}
+
+// TestWithTransactionWithoutDB tests the error when no database is set
+func TestWithTransactionWithoutDB(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("users", func() error { return nil }, WithTransaction())
+
+	assert.ErrorContains(t, manager.RunSeederByName("users"), "seeder 'users' runs in a transaction but no database is set, see SetTransactionDB")
+	assert.ErrorContains(t, manager.Validate(), "seeder 'users' runs in a transaction but no database is set")
+}
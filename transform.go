@@ -0,0 +1,52 @@
+package goseeder
+
+import (
+	"context"
+	"fmt"
+)
+
+// ColumnTransform rewrites a column value before it is inserted, e.g. to
+// envelope-encrypt an SSN with the application's KMS client so seeded rows
+// meet the same at-rest encryption invariants as rows the application
+// writes
+type ColumnTransform func(ctx context.Context, value any) (any, error)
+
+// transformRecords applies the writer's Transforms to records of table.
+// Transforms are keyed by "table.column", or by "column" to apply to every
+// table; a table-qualified one wins. NULLs are left untouched, and records
+// are copied only when they change.
+func (w *SQLFixtureWriter) transformRecords(ctx context.Context, table string, records []Record) ([]Record, error) {
+	if len(w.Transforms) == 0 {
+		return records, nil
+	}
+	result := make([]Record, len(records))
+	for i, record := range records {
+		result[i] = record
+		copied := false
+		for column, value := range record {
+			if value == nil {
+				continue
+			}
+			transform, ok := w.Transforms[table+"."+column]
+			if !ok {
+				transform, ok = w.Transforms[column]
+			}
+			if !ok {
+				continue
+			}
+			transformed, err := transform(ctx, value)
+			if err != nil {
+				return nil, fmt.Errorf("record %d: column '%s': %w", i+1, column, err)
+			}
+			if !copied {
+				result[i] = make(Record, len(record))
+				for c, v := range record {
+					result[i][c] = v
+				}
+				copied = true
+			}
+			result[i][column] = transformed
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encrypt is a ColumnTransform standing in for envelope encryption
+func encrypt(prefix string) ColumnTransform {
+	return func(ctx context.Context, value any) (any, error) {
+		return fmt.Sprintf("%s(%v)", prefix, value), nil
+	}
+}
+
+// TestSQLFixtureWriterTransforms tests rewriting column values before insert
+func TestSQLFixtureWriterTransforms(t *testing.T) {
+	db, fake := newFakeDB()
+	writer := NewSQLFixtureWriter(db, nil)
+	writer.Transforms = map[string]ColumnTransform{
+		"ssn":          encrypt("kms"),
+		"patients.ssn": encrypt("patients-key"),
+	}
+	records := []Record{
+		{"name": "Ana", "ssn": "123-45-6789"},
+		{"name": "Bob", "ssn": nil},
+	}
+
+	assert.NoError(t, writer.WriteFixture(context.Background(), "users", records))
+	assert.NoError(t, writer.WriteFixture(context.Background(), "patients", records[:1]))
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO users (name, ssn) VALUES ($1, $2), ($3, $4) [Ana kms(123-45-6789) Bob <nil>]",
+		"COMMIT",
+		"BEGIN",
+		"INSERT INTO patients (name, ssn) VALUES ($1, $2) [Ana patients-key(123-45-6789)]",
+		"COMMIT",
+	}, fake.Events(), "a table-qualified transform wins and NULLs are left untouched")
+	assert.Equal(t, "123-45-6789", records[0]["ssn"], "records of the caller are not modified")
+
+	writer.Transforms["ssn"] = func(ctx context.Context, value any) (any, error) {
+		return nil, errors.New("kms unavailable")
+	}
+	err := writer.WriteFixture(context.Background(), "users", records)
+	assert.EqualError(t, err, "failed to transform records of users: record 1: column 'ssn': kms unavailable")
+}
+
+// TestCLISetColumnTransforms tests that the insert command applies transforms
+func TestCLISetColumnTransforms(t *testing.T) {
+	db, fake := newFakeDB()
+	fake.On("SELECT * FROM users WHERE 1 = 0", []string{"name", "ssn"})
+	cli := NewCLI(NewSeederManager())
+	cli.SetDB(db)
+	cli.SetColumnTransforms(map[string]ColumnTransform{"users.ssn": encrypt("kms")})
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"insert", "users", "-set", "name=Ana", "-set", "ssn=123-45-6789"}))
+	assert.Contains(t, fake.Events(), "INSERT INTO users (name, ssn) VALUES ($1, $2) [Ana kms(123-45-6789)]")
+}
@@ -0,0 +1,206 @@
+package goseeder
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// defaultTriageStatements is the number of statements kept when
+// TriageOptions.Statements is zero
+const defaultTriageStatements = 50
+
+// TriageOptions configures the bundle written when a run fails
+type TriageOptions struct {
+	Dir        string // Directory bundles are written to
+	Zip        bool   // Write "<Dir>/triage-<run ID>.zip" instead of a directory
+	Statements int    // Number of last SQL statements kept, see LogStatement; default 50
+}
+
+// TriageBundle is the summary written to triage.json in a bundle. The bundle
+// also contains report.json, the run report, and statements.log, the last
+// statements logged with LogStatement.
+type TriageBundle struct {
+	RunID       string            `json:"run_id"`
+	Seeder      string            `json:"seeder,omitempty"` // The failed seeder, empty if the run failed outside a seeder
+	Error       string            `json:"error"`
+	FailedAt    time.Time         `json:"failed_at"`
+	Config      map[string]string `json:"config"`
+	Environment map[string]string `json:"environment"`
+}
+
+// SetTriageBundle makes every failed run write a triage bundle, with the
+// failed seeder, the error, the last SQL statements, the manager's
+// configuration and environment info, for attaching to tickets
+func (sm *SeederManager) SetTriageBundle(options TriageOptions) {
+	if options.Statements <= 0 {
+		options.Statements = defaultTriageStatements
+	}
+	sm.triage = &options
+}
+
+// LogStatement records a SQL statement in the run's statement log, which is
+// included in triage bundles. It does nothing unless a triage bundle is
+// configured, see SeederManager.SetTriageBundle.
+func LogStatement(ctx context.Context, query string, args ...any) {
+	state, ok := ctx.Value(runKey).(*runState)
+	if !ok || state.statementLimit == 0 {
+		return
+	}
+
+	entry := time.Now().Format(time.RFC3339Nano) + " " + strings.TrimSpace(query)
+	if len(args) > 0 {
+		entry += fmt.Sprintf(" %v", args)
+	}
+	if seeder := SeederNameFromContext(ctx); seeder != "" {
+		entry = "[" + seeder + "] " + entry
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.statements = append(state.statements, entry)
+	if len(state.statements) > state.statementLimit {
+		state.statements = state.statements[len(state.statements)-state.statementLimit:]
+	}
+}
+
+// LoggingExecutor returns an SQLExecutor recording every statement with
+// LogStatement before executing it with exec
+func LoggingExecutor(exec SQLExecutor) SQLExecutor {
+	return loggingExecutor{exec}
+}
+
+type loggingExecutor struct {
+	exec SQLExecutor
+}
+
+func (le loggingExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	LogStatement(ctx, query, args...)
+	return le.exec.ExecContext(ctx, query, args...)
+}
+
+// writeTriage writes the triage bundle of a failed run and returns its path
+func (sm *SeederManager) writeTriage(state *runState, runErr error) (string, error) {
+	bundle := TriageBundle{
+		RunID:       state.info.ID,
+		Error:       runErr.Error(),
+		FailedAt:    sm.now(),
+		Config:      sm.triageConfig(),
+		Environment: triageEnvironment(state.info),
+	}
+
+	state.mu.Lock()
+	statements := strings.Join(state.statements, "\n")
+	for _, seeder := range state.seeders {
+		if seeder.Error != "" {
+			bundle.Seeder = seeder.Name
+		}
+	}
+	state.mu.Unlock()
+
+	summary, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	// Bundles are attached to tickets, so secrets are always masked
+	report, err := json.MarshalIndent(maskSecrets(sm.LastRunReport()), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"triage.json", summary},
+		{"report.json", report},
+		{"statements.log", []byte(statements)},
+	}
+
+	if err := os.MkdirAll(sm.triage.Dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(sm.triage.Dir, "triage-"+state.info.ID)
+
+	if !sm.triage.Zip {
+		if err := os.Mkdir(path, 0o755); err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			if err := os.WriteFile(filepath.Join(path, file.name), file.data, 0o644); err != nil {
+				return "", err
+			}
+		}
+		return path, nil
+	}
+
+	path += ".zip"
+	out, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+	archive := zip.NewWriter(out)
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return "", err
+		}
+		if _, err := w.Write(file.data); err != nil {
+			return "", err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return "", err
+	}
+	return path, out.Close()
+}
+
+// triageConfig describes the manager's configuration relevant to a failure
+func (sm *SeederManager) triageConfig() map[string]string {
+	return map[string]string{
+		"seeders":           strings.Join(sm.GetRegisteredSeeders(), ","),
+		"scenarios":         strings.Join(sm.GetRegisteredScenarios(), ","),
+		"provenance_column": sm.provenanceColumn,
+		"retention":         sm.retention.String(),
+		"outbox_mode":       sm.outboxMode.String(),
+		"completion_store":  fmt.Sprint(sm.completionStore != nil),
+		"case_insensitive":  fmt.Sprint(sm.caseInsensitive),
+	}
+}
+
+// triageEnvironment describes where the run happened. Environment variables
+// and command line arguments are left out since they commonly hold
+// credentials.
+func triageEnvironment(info RunInfo) map[string]string {
+	hostname, _ := os.Hostname()
+	workdir, _ := os.Getwd()
+	return map[string]string{
+		"operator":   info.Operator,
+		"started_at": info.StartedAt.Format(time.RFC3339),
+		"hostname":   hostname,
+		"workdir":    workdir,
+		"go_version": runtime.Version(),
+		"os_arch":    runtime.GOOS + "/" + runtime.GOARCH,
+		"pid":        fmt.Sprint(os.Getpid()),
+	}
+}
+
+// finishTriage writes the triage bundle if the run failed and one is configured
+func (sm *SeederManager) finishTriage(ctx context.Context, state *runState, runErr error) {
+	if sm.triage == nil || runErr == nil {
+		return
+	}
+	path, err := sm.writeTriage(state, runErr)
+	if err != nil {
+		logError(ctx, "Failed to write triage bundle: %v", err)
+		return
+	}
+	logInfo(ctx, "Wrote triage bundle to %s", path)
+}
@@ -0,0 +1,94 @@
+package goseeder
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFailingManager returns a manager whose "orders" seeder logs statements and fails
+func newFailingManager() *SeederManager {
+	manager := NewSeederManager()
+	manager.RegisterSeederContext("users", func(ctx context.Context) error {
+		LogStatement(ctx, "INSERT INTO users (name) VALUES ($1)", "Alice")
+		return nil
+	})
+	manager.RegisterSeederContext("orders", func(ctx context.Context) error {
+		exec := LoggingExecutor(&recordingExecutor{})
+		exec.ExecContext(ctx, "INSERT INTO orders (id) VALUES (1)")
+		exec.ExecContext(ctx, "INSERT INTO orders (id) VALUES (2)")
+		return errors.New("duplicate key")
+	})
+	return manager
+}
+
+// TestTriageBundle tests the bundle written when a run fails
+func TestTriageBundle(t *testing.T) {
+	t.Run("Directory", func(t *testing.T) {
+		dir := t.TempDir()
+		manager := newFailingManager()
+		manager.SetTriageBundle(TriageOptions{Dir: dir, Statements: 2})
+
+		assert.Error(t, manager.RunAllSeeders())
+
+		path := filepath.Join(dir, "triage-"+manager.LastRunReport().RunID)
+		data, err := os.ReadFile(filepath.Join(path, "triage.json"))
+		assert.NoError(t, err)
+		var bundle TriageBundle
+		assert.NoError(t, json.Unmarshal(data, &bundle))
+		assert.Equal(t, "orders", bundle.Seeder)
+		assert.Equal(t, "seeder 'orders' failed: duplicate key", bundle.Error)
+		assert.Equal(t, "users,orders", bundle.Config["seeders"])
+		assert.NotEmpty(t, bundle.Environment["go_version"])
+
+		statements, err := os.ReadFile(filepath.Join(path, "statements.log"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(statements), "INSERT INTO users", "only the last 2 statements are kept")
+		assert.Contains(t, string(statements), "[orders]")
+		assert.Contains(t, string(statements), "VALUES (2)")
+
+		assert.FileExists(t, filepath.Join(path, "report.json"))
+	})
+
+	t.Run("Zip", func(t *testing.T) {
+		dir := t.TempDir()
+		manager := newFailingManager()
+		manager.SetTriageBundle(TriageOptions{Dir: dir, Zip: true})
+
+		assert.Error(t, manager.RunAllSeeders())
+
+		archive, err := zip.OpenReader(filepath.Join(dir, "triage-"+manager.LastRunReport().RunID+".zip"))
+		assert.NoError(t, err)
+		defer archive.Close()
+		names := []string{}
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+			if file.Name == "statements.log" {
+				r, _ := file.Open()
+				data, _ := io.ReadAll(r)
+				r.Close()
+				assert.Contains(t, string(data), "INSERT INTO users (name) VALUES ($1) [Alice]")
+			}
+		}
+		assert.ElementsMatch(t, []string{"triage.json", "report.json", "statements.log"}, names)
+	})
+
+	t.Run("Successful runs write nothing", func(t *testing.T) {
+		dir := t.TempDir()
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+		manager.SetTriageBundle(TriageOptions{Dir: dir})
+
+		assert.NoError(t, manager.RunAllSeeders())
+
+		entries, _ := os.ReadDir(dir)
+		assert.Empty(t, entries)
+	})
+}
@@ -0,0 +1,164 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationIssue is a single problem found while validating the seed setup
+type ValidationIssue struct {
+	Check   string // Built-in check ("seeders", "scenarios", ...) or validator name
+	Message string
+}
+
+// ValidationError lists every issue found by a validation, so a CI run
+// reports all problems at once instead of the first one
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+// Error implements the error interface
+func (ve *ValidationError) Error() string {
+	lines := make([]string, len(ve.Issues))
+	for i, issue := range ve.Issues {
+		lines[i] = fmt.Sprintf("[%s] %s", issue.Check, issue.Message)
+	}
+	return fmt.Sprintf("validation failed with %d issue(s): %s", len(ve.Issues), strings.Join(lines, "; "))
+}
+
+// add records an issue
+func (ve *ValidationError) add(check, format string, args ...any) {
+	ve.Issues = append(ve.Issues, ValidationIssue{Check: check, Message: fmt.Sprintf(format, args...)})
+}
+
+// addError records err as issues of check, flattening a *ValidationError
+func (ve *ValidationError) addError(check string, err error) {
+	var nested *ValidationError
+	if errors.As(err, &nested) {
+		ve.Issues = append(ve.Issues, nested.Issues...)
+		return
+	}
+	ve.add(check, "%v", err)
+}
+
+// err returns ve, or nil when no issue was found
+func (ve *ValidationError) err() error {
+	if len(ve.Issues) == 0 {
+		return nil
+	}
+	return ve
+}
+
+// Validator checks part of the seed setup without touching data, e.g. that
+// fixture files match the schema
+type Validator func(ctx context.Context) error
+
+// namedValidator is a validator registered with AddValidator or AddStaticValidator
+type namedValidator struct {
+	name      string
+	validator Validator
+	static    bool // Needs no database or network, run by Validate too
+}
+
+// AddValidator registers a check run by ValidateContext, e.g. one comparing
+// fixtures with the live schema. Returning a *ValidationError reports
+// several issues at once.
+func (sm *SeederManager) AddValidator(name string, validator Validator) {
+	sm.validators = append(sm.validators, namedValidator{name: name, validator: validator})
+}
+
+// AddStaticValidator registers a check that needs no database or network,
+// e.g. parsing fixture files. Static checks run in both Validate and
+// ValidateContext.
+func (sm *SeederManager) AddStaticValidator(name string, validator Validator) {
+	sm.validators = append(sm.validators, namedValidator{name: name, validator: validator, static: true})
+}
+
+// Validate runs only the static checks: the built-in ones and the
+// validators added with AddStaticValidator. It is fast enough for a
+// pre-commit hook and needs no database.
+func (sm *SeederManager) Validate() error {
+	return sm.validate(context.Background(), true)
+}
+
+// ValidateContext checks the seed setup without running any seeder: every
+// seeder has a function, seeders tagged TagReversible have a rollback,
+// names are unambiguous, scenarios only reference
+// registered seeders, and every registered validator passes. Validators
+// run once the replicas set with SetReplicas have caught up.
+// It returns a *ValidationError listing all issues found.
+func (sm *SeederManager) ValidateContext(ctx context.Context) error {
+	return sm.validate(ctx, false)
+}
+
+// validate runs the built-in checks and the validators, only static ones
+// when staticOnly is set
+func (sm *SeederManager) validate(ctx context.Context, staticOnly bool) error {
+	result := &ValidationError{}
+	sm.validateSeeders(result)
+	sm.validateScenarios(result)
+
+	if !staticOnly {
+		if err := sm.waitForReplicas(ctx); err != nil {
+			return fmt.Errorf("replicas did not catch up: %w", err)
+		}
+	}
+	for _, v := range sm.validators {
+		if staticOnly && !v.static {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := v.validator(ctx); err != nil {
+			result.addError(v.name, err)
+		}
+	}
+	return result.err()
+}
+
+// validateSeeders checks seeder functions, rollbacks, names and dependencies
+func (sm *SeederManager) validateSeeders(result *ValidationError) {
+	seeders, _ := sm.registry()
+	folded := make(map[string]string, len(seeders))
+	for _, seeder := range seeders {
+		if seeder.Function == nil && seeder.ContextFunction == nil {
+			result.add("seeders", "seeder '%s' has no function", seeder.Name)
+		}
+		if seeder.Rollback == nil && seeder.HasAnyTag(TagReversible) {
+			result.add("seeders", "seeder '%s' is tagged %s but has no rollback", seeder.Name, TagReversible)
+		}
+		if seeder.Transaction && sm.transactionDB == nil {
+			result.add("seeders", "seeder '%s' runs in a transaction but no database is set, see SetTransactionDB", seeder.Name)
+		}
+		if strings.TrimSpace(seeder.Name) != seeder.Name {
+			result.add("seeders", "seeder '%s' has leading or trailing whitespace", seeder.Name)
+		}
+
+		key := strings.ToLower(seeder.Name)
+		if other, exists := folded[key]; exists {
+			result.add("seeders", "seeder names '%s' and '%s' differ only in case", other, seeder.Name)
+			continue
+		}
+		folded[key] = seeder.Name
+	}
+	for _, err := range sm.dependencyErrors(seeders) {
+		result.add("seeders", "%v", err)
+	}
+}
+
+// validateScenarios checks that scenarios only reference registered seeders
+func (sm *SeederManager) validateScenarios(result *ValidationError) {
+	for _, scenario := range sm.scenarios {
+		if len(scenario.Seeders) == 0 {
+			result.add("scenarios", "scenario '%s' has no seeders", scenario.Name)
+		}
+		for _, name := range scenario.Seeders {
+			if !sm.IsSeederRegistered(name) {
+				result.add("scenarios", "scenario '%s' references unknown seeder '%s'%s", scenario.Name, name, didYouMean(name, sm.GetRegisteredSeeders()))
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package goseeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidateContext tests the built-in checks and custom validators
+func TestValidateContext(t *testing.T) {
+	t.Run("Valid setup", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+		manager.RegisterScenario(Scenario{Name: "trial", Seeders: []string{"users"}})
+		manager.AddValidator("fixtures", func(ctx context.Context) error { return nil })
+
+		assert.NoError(t, manager.ValidateContext(context.Background()))
+	})
+
+	t.Run("Reports every issue", func(t *testing.T) {
+		manager := NewSeederManager()
+		manager.RegisterSeeder("users", func() error { return nil })
+		manager.RegisterSeeder("Users", func() error { return nil })
+		manager.RegisterSeeders(SeederItem{Name: "empty"})
+		manager.RegisterSeeders(SeederItem{Name: "demo_users", Function: func() error { return nil }, Tags: []string{TagDemo, TagReversible}})
+		manager.RegisterSeeders(SeederItem{Name: "demo_orders", Function: func() error { return nil }, Tags: []string{TagReversible},
+			Rollback: func() error { return nil }})
+		manager.RegisterScenario(Scenario{Name: "trial", Seeders: []string{"users", "missing"}})
+		manager.AddValidator("fixtures", func(ctx context.Context) error {
+			return errors.New("users.yaml: unknown column 'nickname'")
+		})
+		manager.AddValidator("config", func(ctx context.Context) error {
+			return &ValidationError{Issues: []ValidationIssue{
+				{Check: "config", Message: "a"},
+				{Check: "config", Message: "b"},
+			}}
+		})
+
+		err := manager.ValidateContext(context.Background())
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []ValidationIssue{
+			{Check: "seeders", Message: "seeder names 'users' and 'Users' differ only in case"},
+			{Check: "seeders", Message: "seeder 'empty' has no function"},
+			{Check: "seeders", Message: "seeder 'demo_users' is tagged reversible but has no rollback"},
+			{Check: "scenarios", Message: "scenario 'trial' references unknown seeder 'missing'"},
+			{Check: "fixtures", Message: "users.yaml: unknown column 'nickname'"},
+			{Check: "config", Message: "a"},
+			{Check: "config", Message: "b"},
+		}, validationErr.Issues)
+		assert.Contains(t, err.Error(), "7 issue(s)")
+	})
+
+	t.Run("Does not run seeders", func(t *testing.T) {
+		manager := NewSeederManager()
+		ran := false
+		manager.RegisterSeeder("users", func() error {
+			ran = true
+			return nil
+		})
+
+		assert.NoError(t, manager.ValidateContext(context.Background()))
+		assert.False(t, ran)
+	})
+}
+
+// TestValidate tests that static validation skips non-static validators
+func TestValidate(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("users", func() error { return nil })
+	calls := []string{}
+	manager.AddStaticValidator("fixtures", func(ctx context.Context) error {
+		calls = append(calls, "fixtures")
+		return nil
+	})
+	manager.AddValidator("schema", func(ctx context.Context) error {
+		calls = append(calls, "schema")
+		return errors.New("column users.nickname does not exist")
+	})
+
+	assert.NoError(t, manager.Validate())
+	assert.Equal(t, []string{"fixtures"}, calls)
+
+	assert.ErrorContains(t, manager.ValidateContext(context.Background()), "users.nickname")
+	assert.Equal(t, []string{"fixtures", "fixtures", "schema"}, calls)
+}
+
+// TestCLIValidateCommand tests the validate command
+func TestCLIValidateCommand(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("users", func() error { return nil })
+	cli := NewCLI(manager)
+
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"validate"}))
+	assert.Error(t, cli.runCommand(context.Background(), []string{"validate", "extra"}))
+
+	manager.AddValidator("schema", func(ctx context.Context) error { return errors.New("no database") })
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"validate", "-fast"}))
+	assert.Error(t, cli.runCommand(context.Background(), []string{"validate"}))
+
+	cli.AddBootstrapProfile(BootstrapProfile{Name: "qa", Seeders: []string{"qa_accounts"}})
+	err := cli.runCommand(context.Background(), []string{"validate", "-fast"})
+
+	assert.ErrorContains(t, err, "bootstrap profile 'qa' references unknown seeder 'qa_accounts'")
+}
+
+// TestCLIValidateConfig tests checking the config file's command flags
+func TestCLIValidateConfig(t *testing.T) {
+	manager := NewSeederManager()
+	manager.RegisterSeeder("users", func() error { return nil })
+	cli := NewCLI(manager)
+
+	assert.NoError(t, cli.SetConfigFile(writeFixture(t, "seeder.yaml", "validate:\n  fast: true\nfmt:\n  key: id\n")))
+	assert.NoError(t, cli.runCommand(context.Background(), []string{"validate"}))
+
+	assert.NoError(t, cli.SetConfigFile(writeFixture(t, "seeder.yaml",
+		"fmt:\n  chek: true\nprofile:\n  tables: users\nbench:\n  n: many\nteardown:\n  force: true\nfmtt:\n  key: id\n")))
+	err := cli.runCommand(context.Background(), []string{"validate", "-fast"})
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []ValidationIssue{
+		{Check: "config", Message: `invalid value "many" of config bench.n: parse error`},
+		{Check: "config", Message: `config file sets unknown flag "teardown.force"`},
+		{Check: "config", Message: `config file sets unknown flag "fmt.chek", did you mean 'check'?`},
+		{Check: "config", Message: `config file sets flags of unknown command "fmtt", did you mean 'fmt'?`},
+	}, validationErr.Issues)
+}
@@ -0,0 +1,106 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrWaitTimeout is returned by WaitForHTTP and WaitForQuery when the
+// condition is not met in time
+var ErrWaitTimeout = errors.New("timed out waiting for condition")
+
+// waitPollInterval is how often WaitForHTTP and WaitForQuery check their
+// condition
+var waitPollInterval = time.Second
+
+// WaitForHTTP blocks until a GET of url answers with a 2xx status or timeout
+// elapses, e.g. as the first post-app seeder so the others only start once
+// the application reports healthy
+func WaitForHTTP(url string, timeout time.Duration) error {
+	return WaitForHTTPContext(context.Background(), url, timeout)
+}
+
+// WaitForHTTPContext is WaitForHTTP returning early when ctx is done
+func WaitForHTTPContext(ctx context.Context, url string, timeout time.Duration) error {
+	return waitUntil(ctx, url, timeout, func(ctx context.Context) error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			return err
+		}
+		response.Body.Close()
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return fmt.Errorf("status %s", response.Status)
+		}
+		return nil
+	})
+}
+
+// WaitForQuery blocks until query returns expected as its first column or
+// timeout elapses, e.g. until a migration run by the application created a
+// row. Values are compared by their printed form, so int64(1) matches 1.
+func WaitForQuery(db *sql.DB, query string, expected any, timeout time.Duration) error {
+	return WaitForQueryContext(context.Background(), db, query, expected, timeout)
+}
+
+// WaitForQueryContext is WaitForQuery returning early when ctx is done
+func WaitForQueryContext(ctx context.Context, db *sql.DB, query string, expected any, timeout time.Duration) error {
+	return waitUntil(ctx, query, timeout, func(ctx context.Context) error {
+		var value any
+		if err := db.QueryRowContext(ctx, query).Scan(&value); err != nil {
+			return err
+		}
+		if raw, ok := value.([]byte); ok {
+			value = string(raw)
+		}
+		if fmt.Sprint(value) != fmt.Sprint(expected) {
+			return fmt.Errorf("got %v, want %v", value, expected)
+		}
+		return nil
+	})
+}
+
+// waitUntil polls check until it succeeds, timeout elapses or ctx is done.
+// Failed checks are retried, as the dependency may not be up yet; the last
+// failure before the deadline is part of the timeout error.
+func waitUntil(ctx context.Context, target string, timeout time.Duration, check func(ctx context.Context) error) error {
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	var last error
+	for {
+		err := check(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() == nil {
+			if last == nil {
+				logInfo(ctx, "Waiting for %s (timeout %s): %v", target, timeout, err)
+			}
+			last = err
+		}
+
+		select {
+		case <-ctx.Done():
+			// A deadline of the caller's context is not our timeout
+			if err := parent.Err(); err != nil {
+				return err
+			}
+			if last == nil {
+				return fmt.Errorf("%w: %s after %s", ErrWaitTimeout, target, timeout)
+			}
+			return fmt.Errorf("%w: %s after %s: %v", ErrWaitTimeout, target, timeout, last)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package goseeder
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fastWaitPolling shortens the poll interval for a test
+func fastWaitPolling(t *testing.T) {
+	previous := waitPollInterval
+	waitPollInterval = time.Millisecond
+	t.Cleanup(func() { waitPollInterval = previous })
+}
+
+// TestWaitForHTTP tests waiting for a health endpoint
+func TestWaitForHTTP(t *testing.T) {
+	fastWaitPolling(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	assert.NoError(t, WaitForHTTP(server.URL, time.Second))
+	assert.Equal(t, int32(3), calls.Load(), "retried until healthy")
+
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer unhealthy.Close()
+
+	err := WaitForHTTP(unhealthy.URL, 20*time.Millisecond)
+	assert.ErrorIs(t, err, ErrWaitTimeout)
+	assert.ErrorContains(t, err, "status 500 Internal Server Error")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, WaitForHTTPContext(ctx, unhealthy.URL, time.Second), context.Canceled)
+}
+
+// TestWaitForQuery tests waiting for a query to return the expected value
+func TestWaitForQuery(t *testing.T) {
+	fastWaitPolling(t)
+	db, fake := newFakeDB()
+	query := "SELECT status FROM app_migrations WHERE version = 42"
+
+	fake.Fail(query, errors.New("relation \"app_migrations\" does not exist"))
+	err := WaitForQuery(db, query, "done", 20*time.Millisecond)
+	assert.ErrorIs(t, err, ErrWaitTimeout)
+	assert.ErrorContains(t, err, "does not exist")
+
+	fake.Fail(query, nil)
+	fake.On(query, []string{"status"}, []driver.Value{[]byte("running")})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		fake.On(query, []string{"status"}, []driver.Value{[]byte("done")})
+	}()
+	assert.NoError(t, WaitForQuery(db, query, "done", time.Second))
+
+	fake.On("SELECT COUNT(*) FROM plans", []string{"count"}, []driver.Value{int64(3)})
+	assert.NoError(t, WaitForQuery(db, "SELECT COUNT(*) FROM plans", 3, time.Second), "compared by printed form")
+	err = WaitForQuery(db, "SELECT COUNT(*) FROM plans", 4, 20*time.Millisecond)
+	assert.ErrorContains(t, err, "got 3, want 4")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = WaitForQueryContext(ctx, db, "SELECT COUNT(*) FROM plans", 4, time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the caller's deadline is not a timeout")
+	assert.NotErrorIs(t, err, ErrWaitTimeout)
+}
@@ -0,0 +1,150 @@
+package goseeder
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WebhookSignatureHeader carries the HMAC-SHA256 of the request body,
+// as "sha256=<hex>"
+const WebhookSignatureHeader = "X-Goseeder-Signature"
+
+// WebhookRunIDHeader carries the ID of the reported run
+const WebhookRunIDHeader = "X-Goseeder-Run-ID"
+
+// Defaults of WebhookOptions
+const (
+	defaultWebhookRetries = 3
+	defaultWebhookBackoff = time.Second
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// WebhookOptions configures the webhook posted after every run
+type WebhookOptions struct {
+	URL     string        // Endpoint the run report is posted to as JSON
+	Secret  string        // Key of the signature header, see VerifyWebhookSignature; unsigned when empty
+	Retries int           // Attempts after the first one on network errors, 429 and 5xx responses; default 3
+	Backoff time.Duration // Wait before the first retry, doubled for every further retry; default 1s
+	Timeout time.Duration // Limit of every attempt, so a hanging endpoint cannot hold up the run; default 10s
+	Client  *http.Client  // Client posting the report, http.DefaultClient when nil
+}
+
+// SetWebhook makes every run post its report to options.URL when it
+// finishes, so test orchestrators and environment managers can react to
+// seeding without polling. Secrets are masked unless the run shows them,
+// see ContextWithSecretsShown. A failed delivery is logged and does not
+// fail the run.
+func (sm *SeederManager) SetWebhook(options WebhookOptions) {
+	if options.Retries <= 0 {
+		options.Retries = defaultWebhookRetries
+	}
+	if options.Backoff <= 0 {
+		options.Backoff = defaultWebhookBackoff
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultWebhookTimeout
+	}
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+	sm.webhook = &options
+}
+
+// SignWebhook returns the signature header value of body signed with secret
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether signature, the value of the
+// WebhookSignatureHeader, is the signature of body with secret
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, body)), []byte(signature))
+}
+
+// sendWebhook posts report to the configured webhook, if any. It is sent
+// even when the run was canceled, as the receiver waits for the outcome.
+func (sm *SeederManager) sendWebhook(ctx context.Context, report *RunReport) {
+	if sm.webhook == nil {
+		return
+	}
+	if !secretsShown(ctx) {
+		report = maskSecrets(report)
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		logError(ctx, "Failed to encode the run report for the webhook: %v", err)
+		return
+	}
+	if err := postWebhook(context.WithoutCancel(ctx), sm.webhook, report.RunID, body); err != nil {
+		logError(ctx, "Failed to send the run report webhook: %v", err)
+	}
+}
+
+// postWebhook posts body to the webhook, retrying with exponential backoff
+func postWebhook(ctx context.Context, options *WebhookOptions, runID string, body []byte) error {
+	backoff := options.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := postWebhookOnce(ctx, options, runID, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == options.Retries {
+			return fmt.Errorf("%s: %w", options.URL, err)
+		}
+
+		logWarn(ctx, "Webhook %s failed, retrying in %s: %v", options.URL, backoff, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// postWebhookOnce makes one delivery attempt, limited to options.Timeout,
+// and reports whether a failure is worth retrying
+func postWebhookOnce(ctx context.Context, options *WebhookOptions, runID string, body []byte) (retry bool, err error) {
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, options.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookRunIDHeader, runID)
+	if options.Secret != "" {
+		request.Header.Set(WebhookSignatureHeader, SignWebhook(options.Secret, body))
+	}
+
+	response, err := options.Client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 200 && response.StatusCode <= 299 {
+		return false, nil
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+	err = fmt.Errorf("status %s", response.Status)
+	if text := strings.TrimSpace(string(message)); text != "" {
+		err = fmt.Errorf("status %s: %s", response.Status, text)
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500, err
+}
@@ -0,0 +1,131 @@
+package goseeder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// webhookReceiver records the deliveries of a test webhook, failing the
+// first attempts with the given statuses
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	attempts int
+	body     []byte
+	header   http.Header
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts++
+	if len(r.statuses) > 0 {
+		w.WriteHeader(r.statuses[0])
+		r.statuses = r.statuses[1:]
+		return
+	}
+	r.body, _ = io.ReadAll(req.Body)
+	r.header = req.Header.Clone()
+}
+
+// TestSetWebhook tests that the run report is posted signed, with secrets
+// masked, after retrying failed deliveries
+func TestSetWebhook(t *testing.T) {
+	receiver := &webhookReceiver{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	manager := NewSeederManager()
+	manager.SetWebhook(WebhookOptions{URL: server.URL, Secret: "hook-key", Backoff: time.Millisecond})
+	manager.RegisterSeeders(SeederItem{Name: "users", ContextFunction: func(ctx context.Context) error {
+		ReportSecret(ctx, "admin password", "s3cret")
+		return nil
+	}})
+	assert.NoError(t, manager.RunAllSeeders())
+
+	assert.Equal(t, 3, receiver.attempts, "retried the 502 and the 429")
+	assert.Equal(t, "application/json", receiver.header.Get("Content-Type"))
+	assert.True(t, VerifyWebhookSignature("hook-key", receiver.body, receiver.header.Get(WebhookSignatureHeader)))
+	assert.False(t, VerifyWebhookSignature("other-key", receiver.body, receiver.header.Get(WebhookSignatureHeader)))
+
+	var report RunReport
+	assert.NoError(t, json.Unmarshal(receiver.body, &report))
+	assert.Equal(t, report.RunID, receiver.header.Get(WebhookRunIDHeader))
+	assert.Equal(t, "users", report.Seeders[0].Name)
+	assert.Equal(t, secretMask, report.Secrets[0].Value)
+	assert.Equal(t, "s3cret", manager.LastRunReport().Secrets[0].Value, "only the webhook copy is masked")
+}
+
+// TestPostWebhook tests which failures are retried
+func TestPostWebhook(t *testing.T) {
+	t.Run("Client errors are not retried", func(t *testing.T) {
+		receiver := &webhookReceiver{statuses: []int{http.StatusUnauthorized}}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		options := &WebhookOptions{URL: server.URL, Retries: 3, Backoff: time.Millisecond, Client: http.DefaultClient}
+		err := postWebhook(context.Background(), options, "run-1", []byte("{}"))
+		assert.ErrorContains(t, err, "status 401 Unauthorized")
+		assert.Equal(t, 1, receiver.attempts)
+	})
+
+	t.Run("Gives up after the retries", func(t *testing.T) {
+		receiver := &webhookReceiver{statuses: []int{500, 500, 500}}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		options := &WebhookOptions{URL: server.URL, Retries: 2, Backoff: time.Millisecond, Client: http.DefaultClient}
+		err := postWebhook(context.Background(), options, "run-1", []byte("{}"))
+		assert.ErrorContains(t, err, "status 500 Internal Server Error")
+		assert.Equal(t, 3, receiver.attempts)
+	})
+
+	t.Run("Attempts time out", func(t *testing.T) {
+		hanging := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-hanging:
+			}
+		}))
+		defer server.Close()
+		defer close(hanging)
+
+		manager := NewSeederManager()
+		manager.SetWebhook(WebhookOptions{URL: server.URL, Retries: 1, Backoff: time.Millisecond, Timeout: 20 * time.Millisecond})
+		manager.RegisterSeeder("users", func() error { return nil })
+		done := make(chan error, 1)
+		go func() { done <- manager.RunAllSeeders() }()
+		select {
+		case err := <-done:
+			assert.NoError(t, err, "a failed delivery does not fail the run")
+		case <-time.After(5 * time.Second):
+			t.Fatal("run blocked on a webhook endpoint that never answers")
+		}
+	})
+
+	t.Run("Sent for failed runs", func(t *testing.T) {
+		receiver := &webhookReceiver{}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		manager := NewSeederManager()
+		manager.SetWebhook(WebhookOptions{URL: server.URL})
+		manager.RegisterSeeder("broken", func() error { return errors.New("boom") })
+		assert.Error(t, manager.RunAllSeeders())
+
+		var report RunReport
+		assert.NoError(t, json.Unmarshal(receiver.body, &report))
+		assert.Contains(t, report.Error, "boom")
+		assert.Empty(t, receiver.header.Get(WebhookSignatureHeader), "unsigned without a secret")
+	})
+}